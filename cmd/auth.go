@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/auth/users/create"
+	"github.com/supabase/cli/internal/auth/users/delete"
+	"github.com/supabase/cli/internal/auth/users/invite"
+	"github.com/supabase/cli/internal/auth/users/list"
+	"github.com/supabase/cli/internal/auth/users/update"
+)
+
+var (
+	authCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "auth",
+		Short:   "Manage Supabase Auth",
+	}
+
+	authLinked bool
+
+	authUsersCmd = &cobra.Command{
+		Use:   "users",
+		Short: "Manage auth users of the local stack or linked project",
+	}
+
+	usersFilter  string
+	usersPage    uint
+	usersPerPage uint
+
+	authUsersListCmd = &cobra.Command{
+		Use:     "list",
+		Short:   "List or search auth users",
+		Example: "  supabase auth users list --filter @example.com --linked",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fsys := afero.NewOsFs()
+			projectRef, err := authProjectRef(cmd, fsys)
+			if err != nil {
+				return err
+			}
+			return list.Run(cmd.Context(), projectRef, usersFilter, usersPage, usersPerPage, fsys)
+		},
+	}
+
+	createParams auth.CreateUserParams
+	userMetadata string
+	appMetadata  string
+
+	authUsersCreateCmd = &cobra.Command{
+		Use:     "create",
+		Short:   "Create a confirmed test user",
+		Example: "  supabase auth users create --email test@example.com --password secret",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fsys := afero.NewOsFs()
+			projectRef, err := authProjectRef(cmd, fsys)
+			if err != nil {
+				return err
+			}
+			return create.Run(cmd.Context(), projectRef, createParams, userMetadata, fsys)
+		},
+	}
+
+	magicLink bool
+
+	authUsersInviteCmd = &cobra.Command{
+		Use:   "invite <email>",
+		Short: "Send an invite or magic link email",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fsys := afero.NewOsFs()
+			projectRef, err := authProjectRef(cmd, fsys)
+			if err != nil {
+				return err
+			}
+			return invite.Run(cmd.Context(), projectRef, args[0], magicLink, fsys)
+		},
+	}
+
+	authUsersUpdateCmd = &cobra.Command{
+		Use:     "update <user-id>",
+		Short:   "Update metadata of an auth user",
+		Example: `  supabase auth users update 5b4a... --user-metadata '{"name":"Alice"}'`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fsys := afero.NewOsFs()
+			projectRef, err := authProjectRef(cmd, fsys)
+			if err != nil {
+				return err
+			}
+			return update.Run(cmd.Context(), projectRef, args[0], userMetadata, appMetadata, fsys)
+		},
+	}
+
+	authUsersDeleteCmd = &cobra.Command{
+		Use:   "delete <user-id>...",
+		Short: "Delete auth users",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fsys := afero.NewOsFs()
+			projectRef, err := authProjectRef(cmd, fsys)
+			if err != nil {
+				return err
+			}
+			return delete.Run(cmd.Context(), projectRef, args, fsys)
+		},
+	}
+)
+
+func authProjectRef(cmd *cobra.Command, fsys afero.Fs) (string, error) {
+	if !authLinked {
+		return "", nil
+	}
+	return loadLinkedRef(cmd.Context(), fsys)
+}
+
+func init() {
+	persistentFlags := authUsersCmd.PersistentFlags()
+	persistentFlags.BoolVar(&authLinked, "linked", false, "Manages users of the linked project instead of the local stack.")
+	persistentFlags.Bool("local", true, "Manages users of the local stack.")
+	authUsersCmd.MarkFlagsMutuallyExclusive("local", "linked")
+	listFlags := authUsersListCmd.Flags()
+	listFlags.StringVar(&usersFilter, "filter", "", "Search term matched against user email.")
+	listFlags.UintVar(&usersPage, "page", 1, "Page number to list.")
+	listFlags.UintVar(&usersPerPage, "per-page", 50, "Number of users per page.")
+	authUsersCmd.AddCommand(authUsersListCmd)
+	createFlags := authUsersCreateCmd.Flags()
+	createFlags.StringVar(&createParams.Email, "email", "", "Email address of the user.")
+	createFlags.StringVar(&createParams.Phone, "phone", "", "Phone number of the user.")
+	createFlags.StringVar(&createParams.Password, "password", "", "Password of the user.")
+	createFlags.StringVar(&userMetadata, "user-metadata", "", "User metadata as a JSON object.")
+	authUsersCmd.AddCommand(authUsersCreateCmd)
+	authUsersInviteCmd.Flags().BoolVar(&magicLink, "magic-link", false, "Sends a magic link to sign in instead of an invite.")
+	authUsersCmd.AddCommand(authUsersInviteCmd)
+	updateFlags := authUsersUpdateCmd.Flags()
+	updateFlags.StringVar(&userMetadata, "user-metadata", "", "User metadata as a JSON object to merge.")
+	updateFlags.StringVar(&appMetadata, "app-metadata", "", "App metadata as a JSON object to merge.")
+	authUsersCmd.AddCommand(authUsersUpdateCmd)
+	authUsersCmd.AddCommand(authUsersDeleteCmd)
+	authCmd.AddCommand(authUsersCmd)
+	rootCmd.AddCommand(authCmd)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/backups/download"
+	"github.com/supabase/cli/internal/backups/list"
+	"github.com/supabase/cli/internal/backups/restore"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+var (
+	backupsCmd = &cobra.Command{
+		GroupID: groupManagementAPI,
+		Use:     "backups",
+		Short:   "Manage Supabase database backups",
+	}
+
+	backupsListCmd = &cobra.Command{
+		Use:   "list [ref]",
+		Short: "List all database backups",
+		Long:  "List all database backups of a project, including the restorable window for point-in-time recovery.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			// Positional ref is resolved before project-ref flag is parsed
+			if len(args) > 0 {
+				flags.ProjectRef = args[0]
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), flags.ProjectRef, render.Format.Value)
+		},
+	}
+
+	backupFile string
+
+	backupsDownloadCmd = &cobra.Command{
+		Use:   "download <backup-id>",
+		Short: "Download a database backup",
+		Long:  "Download a database backup to a local file, verifying its checksum when available.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return download.Run(cmd.Context(), flags.ProjectRef, args[0], backupFile, afero.NewOsFs())
+		},
+	}
+
+	restoreTimestamp string
+	restoreConfirm   string
+
+	backupsRestoreCmd = &cobra.Command{
+		Use:     "restore",
+		Short:   "Restore a project to a point in time",
+		Long:    "Restore the database of a project to a point in time within its PITR window. All changes after the target time are lost.",
+		Example: `supabase backups restore --timestamp 2024-01-01T00:00:00Z`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := restore.ParseTimestamp(restoreTimestamp)
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			if err := restore.PreRun(ctx, flags.ProjectRef, target, restoreConfirm); err != nil {
+				return err
+			}
+			return restore.Run(ctx, flags.ProjectRef, target)
+		},
+	}
+)
+
+func init() {
+	backupsCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	backupsDownloadCmd.Flags().StringVarP(&backupFile, "file", "f", "", "Path to save the downloaded backup, defaults to <backup-id>.backup")
+	restoreFlags := backupsRestoreCmd.Flags()
+	restoreFlags.StringVar(&restoreTimestamp, "timestamp", "", "Point in time to restore to, in RFC3339 or unix seconds.")
+	restoreFlags.StringVar(&restoreConfirm, "confirm", "", "Skip typing the project ref by passing it here.")
+	cobra.CheckErr(backupsRestoreCmd.MarkFlagRequired("timestamp"))
+	backupsCmd.AddCommand(backupsListCmd)
+	backupsCmd.AddCommand(backupsDownloadCmd)
+	backupsCmd.AddCommand(backupsRestoreCmd)
+	rootCmd.AddCommand(backupsCmd)
+}
@@ -5,13 +5,16 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/supabase/cli/internal/branches/create"
 	"github.com/supabase/cli/internal/branches/delete"
 	"github.com/supabase/cli/internal/branches/disable"
 	"github.com/supabase/cli/internal/branches/get"
 	"github.com/supabase/cli/internal/branches/list"
+	"github.com/supabase/cli/internal/branches/merge"
 	"github.com/supabase/cli/internal/branches/update"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/api"
 )
 
@@ -26,13 +29,19 @@ var (
 		Allowed: make([]string, len(utils.FlyRegions)),
 	}
 
+	branchFromGit bool
+
 	branchCreateCmd = &cobra.Command{
-		Use:   "create <name>",
+		Use:   "create [name]",
 		Short: "Create a preview branch",
 		Long:  "Create a preview branch for the linked project.",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return create.Run(cmd.Context(), args[0], branchRegion.Value, afero.NewOsFs())
+			var name string
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return create.Run(cmd.Context(), name, branchRegion.Value, branchFromGit, afero.NewOsFs())
 		},
 	}
 
@@ -45,13 +54,15 @@ var (
 		},
 	}
 
+	branchVersion bool
+
 	branchGetCmd = &cobra.Command{
 		Use:   "get <branch-id>",
 		Short: "Retrieve details of a preview branch",
 		Long:  "Retrieve details of the specified preview branch.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return get.Run(cmd.Context(), args[0])
+			return get.Run(cmd.Context(), args[0], branchVersion)
 		},
 	}
 
@@ -85,6 +96,16 @@ var (
 		},
 	}
 
+	branchMergeCmd = &cobra.Command{
+		Use:   "merge <branch-id>",
+		Short: "Merge a preview branch",
+		Long:  "Merge schema changes on a preview branch back to its parent project.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return merge.Run(cmd.Context(), args[0], dryRun, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
 	branchDisableCmd = &cobra.Command{
 		Use:   "disable",
 		Short: "Disable preview branching",
@@ -106,13 +127,25 @@ func init() {
 	sort.Strings(branchRegion.Allowed)
 	createFlags := branchCreateCmd.Flags()
 	createFlags.Var(&branchRegion, "region", "Select a region to deploy the branch database.")
+	createFlags.BoolVar(&branchFromGit, "from-git", false, "Name the preview branch after the current git branch.")
 	branchesCmd.AddCommand(branchListCmd)
+	branchGetCmd.Flags().BoolVar(&branchVersion, "migration-version", false, "Connect to the branch database to show its latest migration version.")
 	branchesCmd.AddCommand(branchGetCmd)
 	updateFlags := branchUpdateCmd.Flags()
 	updateFlags.StringVar(&branchName, "name", "", "Rename the preview branch.")
 	updateFlags.StringVar(&gitBranch, "git-branch", "", "Change the associated git branch.")
 	branchesCmd.AddCommand(branchUpdateCmd)
 	branchesCmd.AddCommand(branchDeleteCmd)
+	mergeFlags := branchMergeCmd.Flags()
+	mergeFlags.BoolVar(&dryRun, "dry-run", false, "Print the schema changes that would be applied to the parent project.")
+	mergeFlags.String("db-url", "", "Merges into the parent database specified by the connection string (must be percent-encoded).")
+	mergeFlags.Bool("linked", true, "Merges into the linked project.")
+	mergeFlags.Bool("local", false, "Merges into the local database.")
+	branchMergeCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	mergeFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", mergeFlags.Lookup("password")))
+	branchMergeCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	branchesCmd.AddCommand(branchMergeCmd)
 	branchesCmd.AddCommand(branchDisableCmd)
 	rootCmd.AddCommand(branchesCmd)
 }
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/completion"
+	"github.com/supabase/cli/internal/storage"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+func completeProjectRefs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	refs := completion.ProjectRefs(cmd.Context(), afero.NewOsFs())
+	return completion.Filter(refs, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeFunctionSlugs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	slugs := completion.Filter(completion.FunctionSlugs(afero.NewOsFs()), toComplete)
+	// Skip slugs that are already specified
+	var result []string
+	for _, s := range slugs {
+		if !utils.SliceContains(args, s) {
+			result = append(result, s)
+		}
+	}
+	return result, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeFunctionSlug(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeFunctionSlugs(cmd, args, toComplete)
+}
+
+func completeMigrationVersions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	versions := completion.MigrationVersions(afero.NewOsFs())
+	return completion.Filter(versions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// Completes bucket names of storage paths, falling back to local files otherwise.
+func completeStoragePath(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := storage.STORAGE_SCHEME + ":///"
+	if !strings.HasPrefix(prefix, toComplete) && !strings.HasPrefix(toComplete, prefix) {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	// Object paths within a bucket are not completed
+	if strings.Contains(strings.TrimPrefix(toComplete, prefix), "/") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	fsys := afero.NewOsFs()
+	projectRef := flags.ProjectRef
+	if len(projectRef) == 0 {
+		var err error
+		if projectRef, err = utils.LoadProjectRef(fsys); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	var paths []string
+	for _, b := range completion.Buckets(cmd.Context(), projectRef, fsys) {
+		paths = append(paths, prefix+b+"/")
+	}
+	return completion.Filter(paths, toComplete), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
+
+// Registers dynamic completions once all commands are added to the tree.
+func registerCompletions(root *cobra.Command) {
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		// Persistent flags are shared with subcommands, so they only need to be registered once
+		if cmd.LocalFlags().Lookup("project-ref") != nil {
+			if _, ok := cmd.GetFlagCompletionFunc("project-ref"); !ok {
+				cobra.CheckErr(cmd.RegisterFlagCompletionFunc("project-ref", completeProjectRefs))
+			}
+		}
+		for _, c := range cmd.Commands() {
+			walk(c)
+		}
+	}
+	walk(root)
+	functionsDeployCmd.ValidArgsFunction = completeFunctionSlugs
+	for _, cmd := range []*cobra.Command{functionsDeleteCmd, functionsDownloadCmd, functionsLogsCmd} {
+		cmd.ValidArgsFunction = completeFunctionSlug
+	}
+	migrationRepairCmd.ValidArgsFunction = completeMigrationVersions
+	cobra.CheckErr(migrationSquashCmd.RegisterFlagCompletionFunc("version", completeMigrationVersions))
+	cobra.CheckErr(migrationSquashCmd.RegisterFlagCompletionFunc("from", completeMigrationVersions))
+	cobra.CheckErr(dbResetCmd.RegisterFlagCompletionFunc("version", completeMigrationVersions))
+	for _, cmd := range []*cobra.Command{lsCmd, cpCmd, mvCmd, rmCmd, syncCmd} {
+		cmd.ValidArgsFunction = completeStoragePath
+	}
+}
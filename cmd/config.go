@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/config/diff"
+	"github.com/supabase/cli/internal/config/export"
+	"github.com/supabase/cli/internal/config/push"
+	"github.com/supabase/cli/internal/config/upgrade"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	configCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "config",
+		Short:   "Manage local config.toml",
+	}
+
+	configUpgradeCmd = &cobra.Command{
+		Use:   "upgrade",
+		Short: "Migrate deprecated keys in config.toml",
+		Long:  "Rename deprecated keys in supabase/config.toml to their current names, preserving comments and ordering.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return upgrade.Run(cmd.Context(), afero.NewOsFs())
+		},
+	}
+
+	configSections []string
+	configKeys     []string
+
+	configDiffCmd = &cobra.Command{
+		Use:   "diff",
+		Short: "Show differences between local config and the linked project",
+		Long:  "Compare api, auth and storage settings in supabase/config.toml against the linked project.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fsys := afero.NewOsFs()
+			if _, err := loadLinkedRef(cmd.Context(), fsys); err != nil {
+				return err
+			}
+			return diff.Run(cmd.Context(), flags.ProjectRef, configSections, configKeys, fsys)
+		},
+	}
+
+	configPushCmd = &cobra.Command{
+		Use:   "push",
+		Short: "Push local config to the linked project",
+		Long:  "Update api, auth and storage settings of the linked project to match supabase/config.toml. Each section is confirmed separately.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fsys := afero.NewOsFs()
+			if _, err := loadLinkedRef(cmd.Context(), fsys); err != nil {
+				return err
+			}
+			return push.Run(cmd.Context(), flags.ProjectRef, configSections, configKeys, fsys)
+		},
+	}
+
+	exportFormat = utils.EnumFlag{
+		Allowed: export.Formats,
+		Value:   export.FormatTerraform,
+	}
+
+	configExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export configuration of the linked project as code",
+		Long:  "Print settings, functions, secret names, buckets and custom domain of the linked project as Terraform configuration with import blocks.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := loadLinkedRef(cmd.Context(), afero.NewOsFs()); err != nil {
+				return err
+			}
+			return export.Run(cmd.Context(), flags.ProjectRef, exportFormat.Value)
+		},
+	}
+)
+
+func init() {
+	configCmd.AddCommand(configUpgradeCmd)
+	for _, cmd := range []*cobra.Command{configDiffCmd, configPushCmd} {
+		cmd.Flags().StringSliceVar(&configSections, "section", diff.Sections, "Comma separated list of config sections to compare.")
+		cmd.Flags().StringSliceVar(&configKeys, "key", []string{}, "Comma separated list of config keys to compare, ie. auth.site_url.")
+		cmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+		configCmd.AddCommand(cmd)
+	}
+	exportFlags := configExportCmd.Flags()
+	exportFlags.Var(&exportFormat, "format", "Output format of the exported configuration.")
+	exportFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	configCmd.AddCommand(configExportCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 
+	"github.com/jackc/pgconn"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -12,17 +14,27 @@ import (
 	"github.com/supabase/cli/internal/db/branch/delete"
 	"github.com/supabase/cli/internal/db/branch/list"
 	"github.com/supabase/cli/internal/db/branch/switch_"
+	"github.com/supabase/cli/internal/db/credentials"
 	"github.com/supabase/cli/internal/db/diff"
 	"github.com/supabase/cli/internal/db/dump"
 	"github.com/supabase/cli/internal/db/lint"
 	"github.com/supabase/cli/internal/db/pull"
 	"github.com/supabase/cli/internal/db/push"
+	"github.com/supabase/cli/internal/db/push/check"
 	"github.com/supabase/cli/internal/db/remote/changes"
 	"github.com/supabase/cli/internal/db/remote/commit"
+	"github.com/supabase/cli/internal/db/replicate/drop"
+	"github.com/supabase/cli/internal/db/replicate/setup"
+	replicateStatus "github.com/supabase/cli/internal/db/replicate/status"
 	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/db/shell"
+	snapshotCreate "github.com/supabase/cli/internal/db/snapshot/create"
+	snapshotList "github.com/supabase/cli/internal/db/snapshot/list"
+	snapshotRestore "github.com/supabase/cli/internal/db/snapshot/restore"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/db/test"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/fanout"
 	"github.com/supabase/cli/internal/utils/flags"
 )
 
@@ -84,15 +96,61 @@ var (
 		},
 	}
 
-	useMigra   bool
-	usePgAdmin bool
-	schema     []string
-	file       string
+	dbSnapshotCmd = &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage local database snapshots",
+		Long:  "Capture and restore the state of the local database. Snapshots are stored in pg_dump custom format under " + utils.SnapshotsDir + ".",
+	}
+
+	dbSnapshotCreateCmd = &cobra.Command{
+		Use:   "create [name]",
+		Short: "Create a snapshot of the local database",
+		Long:  "Create a snapshot of the local database. Defaults to a timestamp name if not specified. Oldest snapshots are pruned according to db.snapshots.max_count in config.toml.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return snapshotCreate.Run(cmd.Context(), name, afero.NewOsFs())
+		},
+	}
+
+	dbSnapshotRestoreCmd = &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Restore the local database from a snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshotRestore.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
+	dbSnapshotListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List local database snapshots",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshotList.Run(afero.NewOsFs())
+		},
+	}
+
+	useMigra    bool
+	usePgAdmin  bool
+	useNative   bool
+	shadowDbUrl string
+	schemaDir   string
+	schema      []string
+	file        string
 
 	dbDiffCmd = &cobra.Command{
 		Use:   "diff",
 		Short: "Diffs the local database for schema changes",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(schemaDir) > 0 {
+				return diff.RunDeclarative(cmd.Context(), schema, file, schemaDir, afero.NewOsFs())
+			}
+			if useNative {
+				return diff.RunNative(cmd.Context(), schema, file, shadowDbUrl, flags.DbConfig, afero.NewOsFs())
+			}
 			if usePgAdmin {
 				return diff.Run(cmd.Context(), schema, file, flags.DbConfig, afero.NewOsFs())
 			}
@@ -104,12 +162,14 @@ var (
 	useCopy      bool
 	roleOnly     bool
 	keepComments bool
+	includeTable []string
+	excludeTable []string
 
 	dbDumpCmd = &cobra.Command{
 		Use:   "dump",
 		Short: "Dumps data or schemas from the remote database",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return dump.Run(cmd.Context(), file, flags.DbConfig, schema, dataOnly, roleOnly, keepComments, useCopy, dryRun, afero.NewOsFs())
+			return dump.Run(cmd.Context(), file, flags.DbConfig, schema, includeTable, excludeTable, dataOnly, roleOnly, keepComments, useCopy, dryRun, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
 			if len(file) > 0 {
@@ -123,11 +183,48 @@ var (
 	includeRoles bool
 	includeSeed  bool
 
+	pushCheck        bool
+	allowDestructive bool
+
 	dbPushCmd = &cobra.Command{
 		Use:   "push",
 		Short: "Push new migrations to the remote database",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return push.Run(cmd.Context(), dryRun, includeAll, includeRoles, includeSeed, flags.DbConfig, afero.NewOsFs())
+			fsys := afero.NewOsFs()
+			if len(flags.ProjectRefs) == 0 {
+				if pushCheck {
+					if err := check.Run(cmd.Context(), includeAll, allowDestructive, flags.DbConfig, fsys); err != nil {
+						return err
+					}
+				}
+				return push.Run(cmd.Context(), dryRun, includeAll, includeRoles, includeSeed, flags.DbConfig, fsys)
+			}
+			// Resolve passwords upfront since prompts can't be answered concurrently
+			configs := map[string]pgconn.Config{}
+			for _, ref := range flags.ProjectRefs {
+				config, err := flags.NewLinkedConfig(ref)
+				if err != nil {
+					return err
+				}
+				configs[ref] = config
+			}
+			// Migrations are the same for all projects, so they are replayed on the shadow database once
+			var shadow check.ShadowCheck
+			return fanout.Run(cmd.Context(), flags.ProjectRefs, func(ctx context.Context, projectRef string) error {
+				if pushCheck {
+					pending, err := check.Analyze(ctx, includeAll, allowDestructive, configs[projectRef], fsys)
+					if err != nil {
+						return err
+					}
+					// Result is shared by all projects, so it runs with the command context
+					if len(pending) > 0 {
+						if err := shadow.Run(cmd.Context(), fsys); err != nil {
+							return err
+						}
+					}
+				}
+				return push.Run(ctx, dryRun, includeAll, includeRoles, includeSeed, configs[projectRef], fsys)
+			})
 		},
 	}
 
@@ -171,6 +268,57 @@ var (
 		},
 	}
 
+	dbReplicateCmd = &cobra.Command{
+		Use:   "replicate",
+		Short: "Manage logical replication to external targets",
+	}
+
+	replicatePlugin = utils.EnumFlag{
+		Allowed: setup.Plugins,
+		Value:   setup.PluginPgoutput,
+	}
+	replicateFormat = utils.EnumFlag{
+		Allowed: setup.Formats,
+		Value:   setup.FormatSql,
+	}
+	replicateSetupOptions = setup.Options{}
+	// Drop has different defaults so it must not share flag variables with setup
+	replicateDropOptions = struct {
+		Slot        string
+		Publication string
+	}{}
+
+	dbReplicateSetupCmd = &cobra.Command{
+		Use:   "setup",
+		Short: "Configure a publication and replication slot",
+		Long:  "Configure a publication and logical replication slot on the database, then print the subscription to run on an external Postgres or the consumer config for an ETL pipeline.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			replicateSetupOptions.Plugin = replicatePlugin.Value
+			replicateSetupOptions.Format = replicateFormat.Value
+			return setup.Run(cmd.Context(), replicateSetupOptions, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbReplicateStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show replication lag of logical replication slots",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slot, _ := cmd.Flags().GetString("slot")
+			return replicateStatus.Run(cmd.Context(), slot, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	dbReplicateDropCmd = &cobra.Command{
+		Use:   "drop",
+		Short: "Drop a replication slot and its publication",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return drop.Run(cmd.Context(), replicateDropOptions.Slot, replicateDropOptions.Publication, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
 	dbResetCmd = &cobra.Command{
 		Use:   "reset",
 		Short: "Resets the local database to current migrations",
@@ -184,11 +332,66 @@ var (
 		Value:   lint.AllowedLevels[0],
 	}
 
+	lintFormat = utils.EnumFlag{
+		Allowed: lint.AllowedFormats,
+		Value:   lint.FormatJson,
+	}
+
 	dbLintCmd = &cobra.Command{
 		Use:   "lint",
 		Short: "Checks local database for typing error",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return lint.Run(cmd.Context(), schema, level.Value, flags.DbConfig, afero.NewOsFs())
+			return lint.Run(cmd.Context(), schema, level.Value, lintFormat.Value, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	credentialsMode = utils.EnumFlag{
+		Allowed: credentials.Modes,
+		Value:   credentials.ModeDirect,
+	}
+	credentialsFormat = utils.EnumFlag{
+		Allowed: credentials.Formats,
+		Value:   credentials.FormatUri,
+	}
+	credentialsRotate bool
+
+	dbCredentialsCmd = &cobra.Command{
+		Use:   "credentials",
+		Short: "Print connection info of the database",
+		Long:  "Print ready to use connection info of the linked project or local database, optionally rotating the password of the linked project.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			fsys := afero.NewOsFs()
+			if local, err := cmd.Flags().GetBool("local"); err != nil || local {
+				return credentials.Run(ctx, "", credentialsMode.Value, credentialsFormat.Value, credentialsRotate, fsys)
+			}
+			// Direct connections only require the project ref and password
+			if credentialsRotate || credentialsMode.Value != credentials.ModeDirect {
+				if err := PromptLogin(ctx, fsys); err != nil {
+					return err
+				}
+			}
+			if err := flags.ParseProjectRef(fsys); err != nil {
+				return err
+			}
+			return credentials.Run(ctx, flags.ProjectRef, credentialsMode.Value, credentialsFormat.Value, credentialsRotate, fsys)
+		},
+	}
+
+	shellFile string
+
+	dbShellCmd = &cobra.Command{
+		Use:   "shell",
+		Short: "Open an interactive SQL shell",
+		Long:  "Connect to the local or linked database with an interactive SQL shell. Statements are read from --file or piped stdin when specified.",
+		Args:  cobra.NoArgs,
+		// Skip the interrupt handler of db commands so that Ctrl+C only cancels the running query
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return shell.Run(cmd.Context(), shellFile, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 
@@ -205,7 +408,7 @@ var (
 		Use:    "test",
 		Short:  "Tests local database with pgTAP",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return test.Run(cmd.Context(), afero.NewOsFs())
+			return test.Run(cmd.Context(), testOptions, afero.NewOsFs())
 		},
 	}
 )
@@ -221,11 +424,17 @@ func init() {
 	diffFlags := dbDiffCmd.Flags()
 	diffFlags.BoolVar(&useMigra, "use-migra", true, "Use migra to generate schema diff.")
 	diffFlags.BoolVar(&usePgAdmin, "use-pgadmin", false, "Use pgAdmin to generate schema diff.")
-	dbDiffCmd.MarkFlagsMutuallyExclusive("use-migra", "use-pgadmin")
+	diffFlags.BoolVar(&useNative, "use-native", false, "Use built-in schema introspection to generate diff without Docker.")
+	dbDiffCmd.MarkFlagsMutuallyExclusive("use-migra", "use-pgadmin", "use-native")
+	diffFlags.StringVar(&shadowDbUrl, "shadow-db-url", "", "Connection string of a scratch database or preview branch to apply local migrations to (required with --use-native).")
 	diffFlags.String("db-url", "", "Diffs against the database specified by the connection string (must be percent-encoded).")
 	diffFlags.Bool("linked", false, "Diffs local migration files against the linked project.")
 	diffFlags.Bool("local", true, "Diffs local migration files against the local database.")
 	dbDiffCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	diffFlags.StringVar(&schemaDir, "schema-dir", "", "Diffs declarative schema files in the specified directory, ie. "+utils.SchemasDir+", against local migrations.")
+	dbDiffCmd.MarkFlagsMutuallyExclusive("schema-dir", "use-pgadmin", "use-native")
+	dbDiffCmd.MarkFlagsMutuallyExclusive("schema-dir", "db-url")
+	dbDiffCmd.MarkFlagsMutuallyExclusive("schema-dir", "linked")
 	diffFlags.StringVarP(&file, "file", "f", "", "Saves schema diff to a new migration file.")
 	diffFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
 	dbCmd.AddCommand(dbDiffCmd)
@@ -245,6 +454,8 @@ func init() {
 	dumpFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
 	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", dumpFlags.Lookup("password")))
 	dumpFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	dumpFlags.StringSliceVar(&includeTable, "include-table", []string{}, "Comma separated list of tables to include, ie. schema.table with optional wildcards.")
+	dumpFlags.StringSliceVar(&excludeTable, "exclude-table", []string{}, "Comma separated list of tables to exclude, ie. schema.table with optional wildcards.")
 	dbCmd.AddCommand(dbDumpCmd)
 	// Build push command
 	pushFlags := dbPushCmd.Flags()
@@ -252,10 +463,15 @@ func init() {
 	pushFlags.BoolVar(&includeRoles, "include-roles", false, "Include custom roles from "+utils.CustomRolesPath+".")
 	pushFlags.BoolVar(&includeSeed, "include-seed", false, "Include seed data from "+utils.SeedDataPath+".")
 	pushFlags.BoolVar(&dryRun, "dry-run", false, "Print the migrations that would be applied, but don't actually apply them.")
+	pushFlags.BoolVar(&pushCheck, "check", false, "Check pending migrations for locking and destructive statements on a shadow database before pushing.")
+	pushFlags.BoolVar(&allowDestructive, "allow-destructive", false, "Allow pushing destructive statements found by --check.")
 	pushFlags.String("db-url", "", "Pushes to the database specified by the connection string (must be percent-encoded).")
 	pushFlags.Bool("linked", true, "Pushes to the linked project.")
 	pushFlags.Bool("local", false, "Pushes to the local database.")
 	dbPushCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	pushFlags.StringSliceVar(&flags.ProjectRefs, "project-ref", []string{}, "Comma separated list of project refs or groups to push to.")
+	dbPushCmd.MarkFlagsMutuallyExclusive("db-url", "project-ref")
+	dbPushCmd.MarkFlagsMutuallyExclusive("local", "project-ref")
 	pushFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
 	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", pushFlags.Lookup("password")))
 	dbCmd.AddCommand(dbPushCmd)
@@ -278,6 +494,28 @@ func init() {
 	dbRemoteCmd.AddCommand(dbRemoteChangesCmd)
 	dbRemoteCmd.AddCommand(dbRemoteCommitCmd)
 	dbCmd.AddCommand(dbRemoteCmd)
+	// Build replicate command
+	replicateFlags := dbReplicateCmd.PersistentFlags()
+	replicateFlags.String("db-url", "", "Replicates from the database specified by the connection string (must be percent-encoded).")
+	replicateFlags.Bool("linked", true, "Replicates from the linked project.")
+	replicateFlags.Bool("local", false, "Replicates from the local database.")
+	dbReplicateCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	replicateFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", replicateFlags.Lookup("password")))
+	setupFlags := dbReplicateSetupCmd.Flags()
+	setupFlags.StringVar(&replicateSetupOptions.Publication, "publication", "supabase_etl", "Name of the publication to create.")
+	setupFlags.StringVar(&replicateSetupOptions.Slot, "slot", "supabase_etl", "Name of the replication slot to create.")
+	setupFlags.StringSliceVar(&replicateSetupOptions.Tables, "table", []string{}, "Comma separated list of tables to replicate, ie. public.orders. Defaults to all tables in user schemas.")
+	setupFlags.Var(&replicatePlugin, "plugin", "Logical decoding output plugin.")
+	setupFlags.Var(&replicateFormat, "format", "Output format of the subscription setup.")
+	dbReplicateCmd.AddCommand(dbReplicateSetupCmd)
+	dbReplicateStatusCmd.Flags().String("slot", "", "Only show the specified replication slot.")
+	dbReplicateCmd.AddCommand(dbReplicateStatusCmd)
+	dropFlags := dbReplicateDropCmd.Flags()
+	dropFlags.StringVar(&replicateDropOptions.Slot, "slot", "supabase_etl", "Name of the replication slot to drop.")
+	dropFlags.StringVar(&replicateDropOptions.Publication, "publication", "", "Name of the publication to drop.")
+	dbReplicateCmd.AddCommand(dbReplicateDropCmd)
+	dbCmd.AddCommand(dbReplicateCmd)
 	// Build reset command
 	resetFlags := dbResetCmd.Flags()
 	resetFlags.String("db-url", "", "Resets the database specified by the connection string (must be percent-encoded).")
@@ -294,10 +532,38 @@ func init() {
 	dbLintCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	lintFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
 	lintFlags.Var(&level, "level", "Error level to emit.")
+	lintFlags.Var(&lintFormat, "format", "Output format of lint results.")
 	dbCmd.AddCommand(dbLintCmd)
+	// Build snapshot command
+	dbSnapshotCmd.AddCommand(dbSnapshotCreateCmd)
+	dbSnapshotCmd.AddCommand(dbSnapshotRestoreCmd)
+	dbSnapshotCmd.AddCommand(dbSnapshotListCmd)
+	dbCmd.AddCommand(dbSnapshotCmd)
+	// Build credentials command
+	credentialsFlags := dbCredentialsCmd.Flags()
+	credentialsFlags.Var(&credentialsMode, "mode", "Connection mode of the database.")
+	credentialsFlags.Var(&credentialsFormat, "format", "Output format of the connection info.")
+	credentialsFlags.BoolVar(&credentialsRotate, "rotate", false, "Rotate the database password of the linked project.")
+	credentialsFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	credentialsFlags.Bool("local", false, "Prints connection info of the local database.")
+	dbCredentialsCmd.MarkFlagsMutuallyExclusive("project-ref", "local")
+	dbCredentialsCmd.MarkFlagsMutuallyExclusive("rotate", "local")
+	dbCmd.AddCommand(dbCredentialsCmd)
+	// Build shell command
+	shellFlags := dbShellCmd.Flags()
+	shellFlags.StringVarP(&shellFile, "file", "f", "", "Execute statements from the file and exit.")
+	shellFlags.String("db-url", "", "Connects to the database specified by the connection string (must be percent-encoded).")
+	shellFlags.Bool("linked", false, "Connects to the linked project.")
+	shellFlags.Bool("local", true, "Connects to the local database.")
+	dbShellCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	shellFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", shellFlags.Lookup("password")))
+	dbShellCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	dbCmd.AddCommand(dbShellCmd)
 	// Build start command
 	dbCmd.AddCommand(dbStartCmd)
 	// Build test command
+	addTestFlags(dbTestCmd.Flags())
 	dbCmd.AddCommand(dbTestCmd)
 	rootCmd.AddCommand(dbCmd)
 }
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/doctor/auth"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	doctorCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "doctor",
+		Short:   "Diagnose common setup issues",
+	}
+
+	migrateTokenTo = utils.EnumFlag{
+		Allowed: utils.TokenStores,
+	}
+
+	doctorAuthCmd = &cobra.Command{
+		Use:   "auth",
+		Short: "Diagnose where the access token is stored",
+		Long:  "Report which store holds the access token of the current profile, validate it against the Management API, and optionally move it to another store.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return auth.Run(cmd.Context(), migrateTokenTo.Value, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	doctorAuthCmd.Flags().Var(&migrateTokenTo, "migrate-to", "Move the access token to the specified store and pin it for subsequent commands.")
+	doctorCmd.AddCommand(doctorAuthCmd)
+	rootCmd.AddCommand(doctorCmd)
+}
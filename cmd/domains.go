@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/hostnames/activate"
@@ -24,6 +26,8 @@ Use of custom domains and vanity subdomains is mutually exclusive.
 
 	rawOutput      bool
 	customHostname string
+	waitVerified   bool
+	waitTimeout    time.Duration
 
 	customHostnamesCreateCmd = &cobra.Command{
 		Use:   "create",
@@ -32,7 +36,7 @@ Use of custom domains and vanity subdomains is mutually exclusive.
 
 Expects your custom hostname to have a CNAME record to your Supabase project's subdomain.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return create.Run(cmd.Context(), flags.ProjectRef, customHostname, rawOutput, afero.NewOsFs())
+			return create.Run(cmd.Context(), flags.ProjectRef, customHostname, rawOutput, getWaitTimeout(), afero.NewOsFs())
 		},
 	}
 
@@ -46,10 +50,11 @@ Expects your custom hostname to have a CNAME record to your Supabase project's s
 	}
 
 	customHostnamesReverifyCmd = &cobra.Command{
-		Use:   "reverify",
-		Short: "Re-verify the custom hostname config for your project",
+		Use:     "reverify",
+		Aliases: []string{"verify"},
+		Short:   "Re-verify the custom hostname config for your project",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return reverify.Run(cmd.Context(), flags.ProjectRef, rawOutput, afero.NewOsFs())
+			return reverify.Run(cmd.Context(), flags.ProjectRef, rawOutput, getWaitTimeout(), afero.NewOsFs())
 		},
 	}
 
@@ -74,11 +79,22 @@ After the custom hostname is activated, your project's auth services will no lon
 	}
 )
 
+func getWaitTimeout() time.Duration {
+	if !waitVerified {
+		return 0
+	}
+	return waitTimeout
+}
+
 func init() {
 	persistentFlags := customHostnamesCmd.PersistentFlags()
 	persistentFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	persistentFlags.BoolVar(&rawOutput, "include-raw-output", false, "Include raw output (useful for debugging).")
 	customHostnamesCreateCmd.Flags().StringVar(&customHostname, "custom-hostname", "", "The custom hostname to use for your Supabase project.")
+	for _, cmd := range []*cobra.Command{customHostnamesCreateCmd, customHostnamesReverifyCmd} {
+		cmd.Flags().BoolVar(&waitVerified, "wait", false, "Polls for verification until the custom hostname is ready for activation.")
+		cmd.Flags().DurationVar(&waitTimeout, "timeout", 10*time.Minute, "Maximum duration to wait for verification.")
+	}
 	customHostnamesCmd.AddCommand(customHostnamesGetCmd)
 	customHostnamesCmd.AddCommand(customHostnamesCreateCmd)
 	customHostnamesCmd.AddCommand(customHostnamesReverifyCmd)
@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"os"
+	"os/signal"
+	"time"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/functions/delete"
 	"github.com/supabase/cli/internal/functions/deploy"
 	"github.com/supabase/cli/internal/functions/download"
 	"github.com/supabase/cli/internal/functions/list"
+	"github.com/supabase/cli/internal/functions/logs"
 	new_ "github.com/supabase/cli/internal/functions/new"
 	"github.com/supabase/cli/internal/functions/serve"
+	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 )
 
@@ -48,20 +54,36 @@ var (
 		},
 	}
 
+	functionLogsOptions logs.Options
+
+	functionsLogsCmd = &cobra.Command{
+		Use:   "logs <Function name>",
+		Short: "Print execution logs of a Function",
+		Long:  "Print invocations and console logs of a Function deployed to the linked Supabase project. Memory used is only reported once the worker serving an invocation has shut down.",
+		Example: `  supabase functions logs hello-world --status 5xx --since 30m
+  supabase functions logs hello-world --follow -o json | jq 'select(.cold_start)'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return logs.Run(ctx, args[0], flags.ProjectRef, functionLogsOptions)
+		},
+	}
+
 	noVerifyJWT     = new(bool)
 	useLegacyBundle bool
 	importMapPath   string
+	deployJobs      uint
 
 	functionsDeployCmd = &cobra.Command{
 		Use:   "deploy <Function name>",
 		Short: "Deploy a Function to Supabase",
-		Long:  "Deploy a Function to the linked Supabase project.",
+		Long:  "Deploy a Function to the linked Supabase project, or all Functions found in " + utils.FunctionsDir + " if no name is given.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Fallback to config if user did not set the flag.
 			if !cmd.Flags().Changed("no-verify-jwt") {
 				noVerifyJWT = nil
 			}
-			return deploy.Run(cmd.Context(), args, flags.ProjectRef, noVerifyJWT, importMapPath, afero.NewOsFs())
+			return deploy.RunMany(cmd.Context(), args, flags.GetProjectRefs(), noVerifyJWT, importMapPath, deployJobs, afero.NewOsFs())
 		},
 	}
 
@@ -74,11 +96,24 @@ var (
 			return cmd.Root().PersistentPreRunE(cmd, args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return new_.Run(cmd.Context(), args[0], afero.NewOsFs())
+			return new_.Run(cmd.Context(), args[0], dbTrigger, afero.NewOsFs())
 		},
 	}
 
-	envFilePath string
+	dbTrigger string
+
+	envFilePath  string
+	watchFiles   bool
+	inspectServe bool
+	inspectPort  uint16
+	inspectMode  = utils.EnumFlag{
+		Allowed: []string{
+			string(serve.InspectModeRun),
+			string(serve.InspectModeBrk),
+			string(serve.InspectModeWait),
+		},
+		Value: string(serve.InspectModeRun),
+	}
 
 	functionsServeCmd = &cobra.Command{
 		Use:   "serve",
@@ -92,7 +127,12 @@ var (
 			if !cmd.Flags().Changed("no-verify-jwt") {
 				noVerifyJWT = nil
 			}
-			return serve.Run(cmd.Context(), envFilePath, noVerifyJWT, importMapPath, afero.NewOsFs())
+			runtimeOption := serve.RuntimeOption{Watch: watchFiles, InspectPort: inspectPort}
+			if inspectServe || cmd.Flags().Changed("inspect-mode") {
+				mode := serve.InspectMode(inspectMode.Value)
+				runtimeOption.InspectMode = &mode
+			}
+			return serve.Run(cmd.Context(), envFilePath, noVerifyJWT, importMapPath, runtimeOption, afero.NewOsFs())
 		},
 	}
 )
@@ -101,17 +141,32 @@ func init() {
 	functionsListCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	functionsDeleteCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	functionsDeployCmd.Flags().BoolVar(noVerifyJWT, "no-verify-jwt", false, "Disable JWT verification for the Function.")
-	functionsDeployCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	functionsDeployCmd.Flags().StringSliceVar(&flags.ProjectRefs, "project-ref", []string{}, "Comma separated list of project refs or groups to deploy to.")
 	functionsDeployCmd.Flags().BoolVar(&useLegacyBundle, "legacy-bundle", false, "Use legacy bundling mechanism.")
 	functionsDeployCmd.Flags().StringVar(&importMapPath, "import-map", "", "Path to import map file.")
+	functionsDeployCmd.Flags().UintVarP(&deployJobs, "jobs", "j", 1, "Maximum number of Functions to bundle and deploy in parallel.")
 	cobra.CheckErr(functionsDeployCmd.Flags().MarkHidden("legacy-bundle"))
+	functionsNewCmd.Flags().StringVar(&dbTrigger, "with-db-trigger", "", "Create a migration that calls the Function on table events, ie. todos:INSERT,UPDATE.")
 	functionsServeCmd.Flags().BoolVar(noVerifyJWT, "no-verify-jwt", false, "Disable JWT verification for the Function.")
 	functionsServeCmd.Flags().StringVar(&envFilePath, "env-file", "", "Path to an env file to be populated to the Function environment.")
 	functionsServeCmd.Flags().StringVar(&importMapPath, "import-map", "", "Path to import map file.")
+	functionsServeCmd.Flags().BoolVar(&watchFiles, "watch", false, "Restart the Functions runtime when files in "+utils.FunctionsDir+" change.")
+	functionsServeCmd.Flags().BoolVar(&inspectServe, "inspect", false, "Alias of --inspect-mode run.")
+	functionsServeCmd.Flags().Var(&inspectMode, "inspect-mode", "Activate inspector capability for debugging.")
+	functionsServeCmd.Flags().Uint16Var(&inspectPort, "inspect-port", 8083, "Local port to expose the inspector on.")
 	functionsServeCmd.Flags().Bool("all", true, "Serve all Functions")
 	cobra.CheckErr(functionsServeCmd.Flags().MarkHidden("all"))
 	functionsDownloadCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	logsFlags := functionsLogsCmd.Flags()
+	logsFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	logsFlags.StringSliceVar(&functionLogsOptions.Status, "status", []string{}, "Comma separated list of response status codes to include, ie. 500,4xx.")
+	logsFlags.DurationVar(&functionLogsOptions.Since, "since", time.Hour, "Only print events newer than this duration.")
+	logsFlags.DurationVar(&functionLogsOptions.Until, "until", 0, "Only print events older than this duration.")
+	logsFlags.UintVar(&functionLogsOptions.Limit, "limit", 100, "Maximum number of events to fetch per query.")
+	logsFlags.BoolVarP(&functionLogsOptions.Follow, "follow", "f", false, "Keeps polling for new events until interrupted.")
+	functionsLogsCmd.MarkFlagsMutuallyExclusive("follow", "until")
 	functionsCmd.AddCommand(functionsListCmd)
+	functionsCmd.AddCommand(functionsLogsCmd)
 	functionsCmd.AddCommand(functionsDeleteCmd)
 	functionsCmd.AddCommand(functionsDeployCmd)
 	functionsCmd.AddCommand(functionsNewCmd)
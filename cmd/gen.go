@@ -9,9 +9,11 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/gen/keys"
+	"github.com/supabase/cli/internal/gen/types"
 	"github.com/supabase/cli/internal/gen/types/typescript"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/utils/render"
 )
 
 var (
@@ -47,20 +49,45 @@ var (
 			return cmd.Root().PersistentPreRunE(cmd, args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Local flag shadows the global --output, so errors are rendered in the same format
+			if keyOutput.Value != utils.OutputEnv {
+				render.Format.Value = keyOutput.Value
+			}
 			return keys.Run(cmd.Context(), flags.ProjectRef, keyOutput.Value, keyNames, afero.NewOsFs())
 		},
 	}
 
-	genTypesCmd = &cobra.Command{
-		Use:   "types",
-		Short: "Generate types from Postgres schema",
-	}
-
 	local     bool
 	linked    bool
 	projectId string
 	dbUrl     string
 	schemas   []string
+	typesLang = utils.EnumFlag{
+		Allowed: types.Languages(),
+		Value:   types.LangTypescript,
+	}
+
+	genTypesCmd = &cobra.Command{
+		Use:   "types",
+		Short: "Generate types from Postgres schema",
+		Long:  "Generate types from Postgres schema. Must specify one of --local, --linked, --project-id, or --db-url",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !local && !linked && projectId == "" && dbUrl == "" {
+				return errors.New("Must specify one of --local, --linked, --project-id, or --db-url")
+			}
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			if typesLang.Value == types.LangTypescript {
+				return typescript.Run(ctx, local, linked, projectId, dbUrl, schemas, afero.NewOsFs())
+			}
+			if projectId != "" {
+				return errors.New("--project-id is only supported for TypeScript. Use --linked or --db-url instead.")
+			}
+			return types.Run(ctx, typesLang.Value, schemas, flags.DbConfig)
+		},
+		Example: `  supabase gen types --lang go --local
+  supabase gen types --lang python --linked --schema public
+  supabase gen types --lang swift --db-url 'postgresql://...'`,
+	}
 
 	genTypesTypescriptCmd = &cobra.Command{
 		Use:   "typescript",
@@ -89,6 +116,14 @@ func init() {
 	genFlags.StringVar(&dbUrl, "db-url", "", "Generate types from a database url.")
 	genFlags.StringArrayVar(&schemas, "schema", []string{}, "Schemas to generate types for.")
 	genTypesTypescriptCmd.MarkFlagsMutuallyExclusive("local", "linked", "project-id", "db-url")
+	typesFlags := genTypesCmd.Flags()
+	typesFlags.Var(&typesLang, "lang", "Output language of the generated types.")
+	typesFlags.BoolVar(&local, "local", false, "Generate types from the local dev database.")
+	typesFlags.BoolVar(&linked, "linked", false, "Generate types from the linked project.")
+	typesFlags.StringVar(&projectId, "project-id", "", "Generate types from a project ID.")
+	typesFlags.StringVar(&dbUrl, "db-url", "", "Generate types from a database url.")
+	typesFlags.StringArrayVar(&schemas, "schema", []string{}, "Schemas to generate types for.")
+	genTypesCmd.MarkFlagsMutuallyExclusive("local", "linked", "project-id", "db-url")
 	genTypesCmd.AddCommand(genTypesTypescriptCmd)
 	genCmd.AddCommand(genTypesCmd)
 	keyFlags := genKeysCmd.Flags()
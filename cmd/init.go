@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -13,6 +14,7 @@ import (
 var (
 	createVscodeWorkspace = new(bool)
 	useOrioleDB           bool
+	initTemplate          string
 
 	initCmd = &cobra.Command{
 		GroupID: groupLocalDev,
@@ -33,7 +35,7 @@ var (
 			if !cmd.Flags().Changed("with-vscode-workspace") {
 				createVscodeWorkspace = nil
 			}
-			if err := _init.Run(fsys, createVscodeWorkspace, useOrioleDB); err != nil {
+			if err := _init.Run(cmd.Context(), fsys, createVscodeWorkspace, useOrioleDB, initTemplate); err != nil {
 				return err
 			}
 
@@ -47,5 +49,6 @@ func init() {
 	flags := initCmd.Flags()
 	flags.BoolVar(createVscodeWorkspace, "with-vscode-workspace", false, "Generate VS Code workspace.")
 	flags.BoolVar(&useOrioleDB, "use-orioledb", false, "Use OrioleDB storage engine for Postgres")
+	flags.StringVar(&initTemplate, "template", "", "Starter template to initialize from, either one of ["+strings.Join(_init.TemplateNames(), "|")+"] or a git URL.")
 	rootCmd.AddCommand(initCmd)
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/supabase/cli/internal/inspect/long_running_queries"
 	"github.com/supabase/cli/internal/inspect/outliers"
 	"github.com/supabase/cli/internal/inspect/replication_slots"
+	"github.com/supabase/cli/internal/inspect/report"
 	"github.com/supabase/cli/internal/inspect/role_connections"
 	"github.com/supabase/cli/internal/inspect/seq_scans"
 	"github.com/supabase/cli/internal/inspect/table_index_sizes"
@@ -197,6 +198,19 @@ var (
 			return role_connections.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
 		},
 	}
+
+	reportFile string
+
+	inspectReportCmd = &cobra.Command{
+		Use:   "report",
+		Short: "Generate a performance report combining multiple inspect queries",
+		Long:  "Collects slowest queries, cache hit ratios, bloat, unused indexes, locks and table sizes into a single report. Pass a file ending in .html or .json to save the report.",
+		Example: `  supabase inspect db report --local
+  supabase inspect db report --file report.html`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return report.Run(cmd.Context(), reportFile, flags.DbConfig, afero.NewOsFs())
+		},
+	}
 )
 
 func init() {
@@ -225,5 +239,7 @@ func init() {
 	inspectDBCmd.AddCommand(inspectBloatCmd)
 	inspectDBCmd.AddCommand(inspectVacuumStatsCmd)
 	inspectDBCmd.AddCommand(inspectRoleConnectionsCmd)
+	inspectReportCmd.Flags().StringVarP(&reportFile, "file", "f", "", "File path to save the report as HTML or JSON.")
+	inspectDBCmd.AddCommand(inspectReportCmd)
 	rootCmd.AddCommand(inspectCmd)
 }
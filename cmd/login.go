@@ -38,6 +38,12 @@ func generateTokenName() (string, error) {
 }
 
 var (
+	// Either a credentials backend, or a plaintext file which bypasses credentials stores entirely
+	loginStore = utils.EnumFlag{
+		Allowed: append(append([]string{}, utils.CredentialStores...), utils.TokenStoreFile),
+		Value:   utils.CredentialStoreKeyring,
+	}
+
 	loginCmd = &cobra.Command{
 		GroupID: groupLocalDev,
 		Use:     "login",
@@ -46,6 +52,11 @@ var (
 			params := login.RunParams{
 				Fsys: afero.NewOsFs(),
 			}
+			if cmd.Flags().Changed("store") {
+				if err := setLoginStore(loginStore.Value, params.Fsys); err != nil {
+					return err
+				}
+			}
 
 			if !term.IsTerminal(int(os.Stdin.Fd())) {
 				var buf bytes.Buffer
@@ -65,9 +76,15 @@ var (
 				params.Token = token
 			}
 
+			// Device authorization does not require a TTY as login is approved on another device.
+			device, err := cmd.Flags().GetBool("device")
+			if err != nil {
+				return fmt.Errorf("cannot parse 'device' flag: %w", err)
+			}
+			params.DeviceFlow = params.Token == "" && device
 			// Login encryption and Session ID are only required for end-to-end communication.
 			// We can skip it if token is already provided by user.
-			if params.Token == "" {
+			if params.Token == "" && !params.DeviceFlow {
 				enc, err := login.NewLoginEncryption()
 				if err != nil {
 					return err
@@ -76,7 +93,7 @@ var (
 				params.SessionId = uuid.New().String()
 			}
 
-			if !term.IsTerminal(int(os.Stdin.Fd())) && params.Token == "" {
+			if !term.IsTerminal(int(os.Stdin.Fd())) && params.Token == "" && !params.DeviceFlow {
 				return ErrMissingToken
 			}
 
@@ -112,5 +129,19 @@ func init() {
 	loginFlags.String("token", "", "Use provided token instead of automatic login flow")
 	loginFlags.String("name", "", "Name that will be used to store token in your settings, defaults to built-in token name generator")
 	loginFlags.Bool("no-browser", false, "Do not open browser automatically")
+	loginFlags.Bool("device", false, "Use device authorization flow with a one-time code")
+	loginFlags.Var(&loginStore, "store", "Always keep the access token in this credentials backend or a plaintext file, instead of falling back from one to the other")
 	rootCmd.AddCommand(loginCmd)
 }
+
+// Pins the token store of the current profile, also selecting the credentials backend unless
+// the token is kept in a plaintext file.
+func setLoginStore(store string, fsys afero.Fs) error {
+	if store == utils.TokenStoreFile {
+		return utils.SetTokenStore(utils.TokenStoreFile, fsys)
+	}
+	if err := utils.SetCredentialStore(store, fsys); err != nil {
+		return err
+	}
+	return utils.SetTokenStore(utils.TokenStoreKeyring, fsys)
+}
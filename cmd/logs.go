@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/logs/tail"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	logsCmd = &cobra.Command{
+		GroupID: groupManagementAPI,
+		Use:     "logs",
+		Short:   "Query logs of Supabase projects",
+	}
+
+	logsService = utils.EnumFlag{
+		Allowed: tail.SourceNames(),
+		Value:   "api",
+	}
+	logsOptions tail.Options
+
+	logsTailCmd = &cobra.Command{
+		Use:   "tail",
+		Short: "Print recent log events of a service",
+		Long:  "Print recent log events of a service on the linked project. Events are filtered with Logflare Query Language (LQL), or replaced by a custom query in Logflare SQL.",
+		Example: `  supabase logs tail --service db --severity error,warning
+  supabase logs tail --service functions --follow -o json | jq .event_message
+  supabase logs tail --service api --query "m.response.status_code:>=500 -health"
+  supabase logs tail --query "select id, timestamp, event_message from edge_logs limit 10"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			logsOptions.Service = logsService.Value
+			return tail.Run(ctx, flags.ProjectRef, logsOptions)
+		},
+	}
+)
+
+func init() {
+	logsCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	tailFlags := logsTailCmd.Flags()
+	tailFlags.Var(&logsService, "service", "Service to query logs from, one of: "+strings.Join(logsService.Allowed, ", ")+".")
+	tailFlags.StringSliceVar(&logsOptions.Severity, "severity", []string{}, "Comma separated list of severity levels to include, ie. error,warning.")
+	tailFlags.StringVar(&logsOptions.Query, "query", "", "LQL filter for the service query, or a custom Logflare SQL query to run in its place.")
+	tailFlags.DurationVar(&logsOptions.Since, "since", time.Hour, "Only print events newer than this duration.")
+	tailFlags.UintVar(&logsOptions.Limit, "limit", 100, "Maximum number of events to fetch per query.")
+	tailFlags.BoolVarP(&logsOptions.Follow, "follow", "f", false, "Keeps polling for new events until interrupted.")
+	logsCmd.AddCommand(logsTailCmd)
+	rootCmd.AddCommand(logsCmd)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/migration/doctor"
+	"github.com/supabase/cli/internal/migration/fetch"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/new"
 	"github.com/supabase/cli/internal/migration/repair"
@@ -55,20 +57,38 @@ var (
 	}
 
 	migrationRepairCmd = &cobra.Command{
-		Use:   "repair <version>",
+		Use:   "repair <version>...",
 		Short: "Repair the migration history table",
+		Args:  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return repair.Run(cmd.Context(), flags.DbConfig, args[0], targetStatus.Value, afero.NewOsFs())
+			return repair.Run(cmd.Context(), flags.DbConfig, args, targetStatus.Value, afero.NewOsFs())
+		},
+	}
+
+	migrationFetchCmd = &cobra.Command{
+		Use:   "fetch",
+		Short: "Fetch remote-only migrations to local files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fetch.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	migrationDoctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose divergence between local and remote migration history",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doctor.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
 		},
 	}
 
 	migrationVersion string
+	squashFrom       string
 
 	migrationSquashCmd = &cobra.Command{
 		Use:   "squash",
 		Short: "Squash migrations to a single file",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return squash.Run(cmd.Context(), migrationVersion, flags.DbConfig, afero.NewOsFs())
+			return squash.Run(cmd.Context(), migrationVersion, squashFrom, flags.DbConfig, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
 			fmt.Println("Finished " + utils.Aqua("supabase migration squash") + ".")
@@ -110,9 +130,30 @@ func init() {
 	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", repairFlags.Lookup("password")))
 	migrationRepairCmd.MarkFlagsMutuallyExclusive("db-url", "password")
 	migrationCmd.AddCommand(migrationRepairCmd)
+	// Build fetch command
+	fetchFlags := migrationFetchCmd.Flags()
+	fetchFlags.String("db-url", "", "Fetches migrations from the database specified by the connection string (must be percent-encoded).")
+	fetchFlags.Bool("linked", true, "Fetches the migration history of the linked project.")
+	fetchFlags.Bool("local", false, "Fetches the migration history of the local database.")
+	migrationFetchCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	fetchFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", fetchFlags.Lookup("password")))
+	migrationFetchCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	migrationCmd.AddCommand(migrationFetchCmd)
+	// Build doctor command
+	doctorFlags := migrationDoctorCmd.Flags()
+	doctorFlags.String("db-url", "", "Diagnoses migrations of the database specified by the connection string (must be percent-encoded).")
+	doctorFlags.Bool("linked", true, "Diagnoses the migration history of the linked project.")
+	doctorFlags.Bool("local", false, "Diagnoses the migration history of the local database.")
+	migrationDoctorCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	doctorFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", doctorFlags.Lookup("password")))
+	migrationDoctorCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	migrationCmd.AddCommand(migrationDoctorCmd)
 	// Build squash command
 	squashFlags := migrationSquashCmd.Flags()
 	squashFlags.StringVar(&migrationVersion, "version", "", "Squash up to the specified version.")
+	squashFlags.StringVar(&squashFrom, "from", "", "Squash starting from the specified version, keeping earlier migrations.")
 	squashFlags.String("db-url", "", "Squashes migrations of the database specified by the connection string (must be percent-encoded).")
 	squashFlags.Bool("linked", false, "Squashes the migration history of the linked project.")
 	squashFlags.Bool("local", true, "Squashes the migration history of the local database.")
@@ -4,6 +4,9 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/orgs/create"
 	"github.com/supabase/cli/internal/orgs/list"
+	"github.com/supabase/cli/internal/orgs/members/invite"
+	memberList "github.com/supabase/cli/internal/orgs/members/list"
+	"github.com/supabase/cli/internal/orgs/members/remove"
 )
 
 var (
@@ -31,10 +34,59 @@ var (
 			return create.Run(cmd.Context(), args[0])
 		},
 	}
+
+	orgsMembersCmd = &cobra.Command{
+		Use:   "members",
+		Short: "Manage members of an organization",
+	}
+
+	memberOrgId   string
+	memberRole    string
+	memberConfirm string
+
+	orgsMembersListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all members of an organization",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return memberList.Run(cmd.Context(), memberOrgId)
+		},
+	}
+
+	orgsMembersInviteCmd = &cobra.Command{
+		Use:     "invite <email>",
+		Short:   "Invite a user to an organization",
+		Args:    cobra.ExactArgs(1),
+		Example: `supabase orgs members invite jane@example.com --org-id cool-green-pqdr0qc --role Developer`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return invite.Run(cmd.Context(), memberOrgId, args[0], memberRole)
+		},
+	}
+
+	orgsMembersRemoveCmd = &cobra.Command{
+		Use:   "remove <user id>",
+		Short: "Remove a member from an organization",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return remove.PreRun(memberOrgId, args[0], memberConfirm)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return remove.Run(cmd.Context(), memberOrgId, args[0])
+		},
+	}
 )
 
 func init() {
+	membersFlags := orgsMembersCmd.PersistentFlags()
+	membersFlags.StringVar(&memberOrgId, "org-id", "", "Organization ID to manage members of.")
+	cobra.CheckErr(orgsMembersCmd.MarkPersistentFlagRequired("org-id"))
+	orgsMembersInviteCmd.Flags().StringVar(&memberRole, "role", "", "Role to assign to the invited user.")
+	orgsMembersRemoveCmd.Flags().StringVar(&memberConfirm, "confirm", "", "Skip the confirmation prompt by passing the user ID.")
+	orgsMembersCmd.AddCommand(orgsMembersListCmd)
+	orgsMembersCmd.AddCommand(orgsMembersInviteCmd)
+	orgsMembersCmd.AddCommand(orgsMembersRemoveCmd)
+
 	orgsCmd.AddCommand(orgsListCmd)
 	orgsCmd.AddCommand(orgsCreateCmd)
+	orgsCmd.AddCommand(orgsMembersCmd)
 	rootCmd.AddCommand(orgsCmd)
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/profiles/list"
+	"github.com/supabase/cli/internal/profiles/use"
+)
+
+var (
+	profilesCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "profiles",
+		Short:   "Manage named profiles for access tokens",
+	}
+
+	profilesListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all logged in profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), afero.NewOsFs())
+		},
+	}
+
+	profilesSwitchCmd = &cobra.Command{
+		Use:     "switch <name>",
+		Aliases: []string{"use"},
+		Short:   "Switch the active profile",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return use.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	profilesCmd.AddCommand(profilesListCmd)
+	profilesCmd.AddCommand(profilesSwitchCmd)
+	rootCmd.AddCommand(profilesCmd)
+}
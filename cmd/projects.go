@@ -15,6 +15,9 @@ import (
 	"github.com/supabase/cli/internal/projects/create"
 	"github.com/supabase/cli/internal/projects/delete"
 	"github.com/supabase/cli/internal/projects/list"
+	"github.com/supabase/cli/internal/projects/pause"
+	"github.com/supabase/cli/internal/projects/restore"
+	"github.com/supabase/cli/internal/projects/transfer"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/api"
@@ -30,6 +33,8 @@ var (
 	interactive bool
 	orgId       string
 	dbPassword  string
+	confirm     string
+	targetOrg   string
 
 	region = utils.EnumFlag{
 		Allowed: make([]string, len(utils.RegionMap)),
@@ -89,12 +94,46 @@ var (
 		Short: "Delete a Supabase project",
 		Args:  cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return delete.PreRun(args[0])
+			return delete.PreRun(args[0], confirm)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return delete.Run(cmd.Context(), args[0], afero.NewOsFs())
 		},
 	}
+
+	projectsPauseCmd = &cobra.Command{
+		Use:   "pause <ref>",
+		Short: "Pause a Supabase project",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return pause.PreRun(args[0], confirm)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pause.Run(cmd.Context(), args[0])
+		},
+	}
+
+	projectsRestoreCmd = &cobra.Command{
+		Use:   "restore <ref>",
+		Short: "Restore a paused Supabase project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restore.Run(cmd.Context(), args[0])
+		},
+	}
+
+	projectsTransferCmd = &cobra.Command{
+		Use:     "transfer <ref>",
+		Short:   "Transfer a Supabase project to another organization",
+		Args:    cobra.ExactArgs(1),
+		Example: `supabase projects transfer abcdefghijklmnopqrst --target-org cool-green-pqdr0qc`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return transfer.PreRun(args[0], targetOrg, confirm)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transfer.Run(cmd.Context(), args[0], targetOrg)
+		},
+	}
 )
 
 func init() {
@@ -117,9 +156,20 @@ func init() {
 	apiKeysFlags := projectsApiKeysCmd.Flags()
 	apiKeysFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 
+	confirmUsage := "Skip the confirmation prompt by passing the project ref."
+	projectsDeleteCmd.Flags().StringVar(&confirm, "confirm", "", confirmUsage)
+	projectsPauseCmd.Flags().StringVar(&confirm, "confirm", "", confirmUsage)
+	transferFlags := projectsTransferCmd.Flags()
+	transferFlags.StringVar(&targetOrg, "target-org", "", "Organization ID to transfer the project to.")
+	transferFlags.StringVar(&confirm, "confirm", "", confirmUsage)
+	cobra.CheckErr(projectsTransferCmd.MarkFlagRequired("target-org"))
+
 	// Add commands to root
 	projectsCmd.AddCommand(projectsCreateCmd)
 	projectsCmd.AddCommand(projectsDeleteCmd)
+	projectsCmd.AddCommand(projectsPauseCmd)
+	projectsCmd.AddCommand(projectsRestoreCmd)
+	projectsCmd.AddCommand(projectsTransferCmd)
 	projectsCmd.AddCommand(projectsListCmd)
 	projectsCmd.AddCommand(projectsApiKeysCmd)
 	rootCmd.AddCommand(projectsCmd)
@@ -175,7 +225,10 @@ func PromptCreateFlags(cmd *cobra.Command) error {
 	}
 	fmt.Fprintln(os.Stderr, printKeyValue("Selected plan", plan.Value))
 	if dbPassword == "" {
-		dbPassword = link.PromptPassword(os.Stdin)
+		var err error
+		if dbPassword, err = link.PromptPassword(os.Stdin); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/realtime/listen"
+)
+
+var (
+	realtimeCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "realtime",
+		Short:   "Debug Supabase Realtime channels",
+	}
+
+	realtimeLinked  bool
+	realtimeOptions listen.Options
+
+	realtimeListenCmd = &cobra.Command{
+		Use:   "listen",
+		Short: "Print events received on Realtime channels",
+		Long:  "Subscribes to postgres changes, broadcast and presence events on the given channels of local or linked Realtime.",
+		Example: `  supabase realtime listen --channel room:lobby
+  supabase realtime listen --channel changes --event postgres_changes --table todos --linked
+  supabase realtime listen --channel room:lobby --event broadcast -o json | jq .payload`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			fsys := afero.NewOsFs()
+			projectRef := ""
+			if realtimeLinked {
+				ref, err := loadLinkedRef(ctx, fsys)
+				if err != nil {
+					return err
+				}
+				projectRef = ref
+			}
+			return listen.Run(ctx, projectRef, realtimeOptions, fsys)
+		},
+	}
+)
+
+func init() {
+	listenFlags := realtimeListenCmd.Flags()
+	listenFlags.StringSliceVarP(&realtimeOptions.Channels, "channel", "c", []string{}, "Comma separated list of channels to subscribe to.")
+	listenFlags.StringSliceVar(&realtimeOptions.Events, "event", listen.AllEvents, "Comma separated list of event kinds to print.")
+	listenFlags.StringVar(&realtimeOptions.Schema, "schema", "public", "Schema to stream postgres changes from.")
+	listenFlags.StringVar(&realtimeOptions.Table, "table", "", "Table to stream postgres changes from, defaults to all tables in schema.")
+	listenFlags.StringVar(&realtimeOptions.Filter, "filter", "", "Row filter for postgres changes, ie. id=eq.1.")
+	listenFlags.BoolVar(&realtimeLinked, "linked", false, "Listens on the linked project instead of the local stack.")
+	listenFlags.Bool("local", true, "Listens on the local stack.")
+	realtimeListenCmd.MarkFlagsMutuallyExclusive("local", "linked")
+	cobra.CheckErr(realtimeListenCmd.MarkFlagRequired("channel"))
+	realtimeCmd.AddCommand(realtimeListenCmd)
+	rootCmd.AddCommand(realtimeCmd)
+}
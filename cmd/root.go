@@ -14,6 +14,8 @@ import (
 	"github.com/spf13/viper"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/utils/log"
+	"github.com/supabase/cli/internal/utils/render"
 )
 
 const (
@@ -37,13 +39,28 @@ func IsManagementAPI(cmd *cobra.Command) bool {
 
 func PromptLogin(ctx context.Context, fsys afero.Fs) error {
 	if _, err := utils.LoadAccessTokenFS(fsys); err == utils.ErrMissingToken {
-		utils.CmdSuggestion = fmt.Sprintf("Run %s first.", utils.Aqua("supabase login"))
+		command := "supabase login"
+		if profile := utils.GetCurrentProfile(fsys); profile != utils.DefaultProfile {
+			command += " --profile " + profile
+		}
+		utils.CmdSuggestion = fmt.Sprintf("Run %s first.", utils.Aqua(command))
 		return errors.New("You need to be logged-in in order to use Management API commands.")
 	} else {
 		return err
 	}
 }
 
+// Returns the linked project ref for commands that target either the local stack or the linked project.
+func loadLinkedRef(ctx context.Context, fsys afero.Fs) (string, error) {
+	if err := PromptLogin(ctx, fsys); err != nil {
+		return "", err
+	}
+	if err := flags.ParseProjectRef(fsys); err != nil {
+		return "", err
+	}
+	return flags.ProjectRef, nil
+}
+
 var experimental = []*cobra.Command{
 	bansCmd,
 	restrictionsCmd,
@@ -79,6 +96,14 @@ var (
 			if err := changeWorkDir(fsys); err != nil {
 				return err
 			}
+			if profile := viper.GetString("PROFILE"); len(profile) > 0 {
+				if err := utils.ValidateProfileName(profile); err != nil {
+					return err
+				}
+			}
+			if err := utils.InitCredentialStore(fsys); err != nil {
+				return err
+			}
 			// Add common flags
 			ctx := cmd.Context()
 			if IsManagementAPI(cmd) {
@@ -96,6 +121,9 @@ var (
 				return err
 			}
 			// Prepare context
+			if err := setLogLevel(); err != nil {
+				return err
+			}
 			if viper.GetBool("DEBUG") {
 				ctx = utils.WithTraceContext(ctx)
 				fmt.Fprintln(os.Stderr, cmd.Root().Short)
@@ -110,7 +138,10 @@ var (
 )
 
 func Execute() {
+	registerCompletions(rootCmd)
 	if err := rootCmd.Execute(); err != nil {
+		// Machine readable envelope goes to stdout so that pipelines can parse failures
+		render.Error(os.Stdout, err, utils.CmdSuggestion)
 		fmt.Fprintln(os.Stderr, utils.Red(err.Error()))
 		if len(utils.CmdSuggestion) > 0 {
 			fmt.Fprintln(os.Stderr, utils.CmdSuggestion)
@@ -141,9 +172,14 @@ func init() {
 
 	flags := rootCmd.PersistentFlags()
 	flags.Bool("debug", false, "output debug logs to stderr")
+	flags.Var(&logLevel, "log-level", "minimum level of logs written to stderr")
+	flags.Uint("api-retries", 3, "maximum retries of failed management API requests")
 	flags.String("workdir", "", "path to a Supabase project directory")
 	flags.Bool("experimental", false, "enable experimental features")
+	flags.String("profile", "", "use access token from the named profile")
 	flags.Var(&utils.DNSResolver, "dns-resolver", "lookup domain names using the specified resolver")
+	flags.VarP(&render.Format, "output", "o", "output format of command results")
+	flags.Bool("yes", false, "answer yes to all prompts and fail fast on prompts that require input")
 	cobra.CheckErr(viper.BindPFlags(flags))
 
 	rootCmd.SetVersionTemplate("{{.Version}}\n")
@@ -151,6 +187,25 @@ func init() {
 	rootCmd.AddGroup(&cobra.Group{ID: groupManagementAPI, Title: "Management APIs:"})
 }
 
+var logLevel = utils.EnumFlag{
+	Allowed: log.Levels(),
+	Value:   log.LevelInfo.String(),
+}
+
+// Debug flag takes precedence over log level, which can also be set via SUPABASE_LOG_LEVEL.
+func setLogLevel() error {
+	if viper.GetBool("DEBUG") {
+		log.SetLevel(log.LevelDebug)
+		return nil
+	}
+	level, err := log.ParseLevel(viper.GetString("LOG-LEVEL"))
+	if err != nil {
+		return err
+	}
+	log.SetLevel(level)
+	return nil
+}
+
 // instantiate new rootCmd is a bit tricky with cobra, but it can be done later with the following
 // approach for example: https://github.com/portworx/pxc/tree/master/cmd
 func GetRootCmd() *cobra.Command {
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/secrets/diff"
 	"github.com/supabase/cli/internal/secrets/list"
 	"github.com/supabase/cli/internal/secrets/set"
+	"github.com/supabase/cli/internal/secrets/sync"
 	"github.com/supabase/cli/internal/secrets/unset"
+	"github.com/supabase/cli/internal/utils/fanout"
 	"github.com/supabase/cli/internal/utils/flags"
 )
 
@@ -34,7 +39,9 @@ var (
 			if err != nil {
 				return err
 			}
-			return set.Run(cmd.Context(), flags.ProjectRef, envFilePath, args, afero.NewOsFs())
+			return fanout.Run(cmd.Context(), flags.GetProjectRefs(), func(ctx context.Context, projectRef string) error {
+				return set.Run(ctx, projectRef, envFilePath, args, afero.NewOsFs())
+			})
 		},
 	}
 
@@ -46,13 +53,43 @@ var (
 			return unset.Run(cmd.Context(), flags.ProjectRef, args, afero.NewOsFs())
 		},
 	}
+
+	secretsEnvFile string
+	secretsPrune   bool
+
+	secretsDiffCmd = &cobra.Command{
+		Use:   "diff",
+		Short: "Diff secrets on Supabase against a .env file",
+		Long:  "Show secrets that would be added, changed or removed to match the .env file. Values are never printed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diff.Run(cmd.Context(), flags.ProjectRef, secretsEnvFile, afero.NewOsFs())
+		},
+	}
+
+	secretsSyncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Sync secrets on Supabase with a .env file",
+		Long:  "Apply only the secrets that differ from the .env file to the linked Supabase project.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sync.Run(cmd.Context(), flags.ProjectRef, secretsEnvFile, secretsPrune, afero.NewOsFs())
+		},
+	}
 )
 
 func init() {
 	secretsCmd.PersistentFlags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	secretsSetCmd.Flags().String("env-file", "", "Read secrets from a .env file.")
+	// Shadows the single project flag so that secrets can be set on multiple projects
+	secretsSetCmd.Flags().StringSliceVar(&flags.ProjectRefs, "project-ref", []string{}, "Comma separated list of project refs or groups to set secrets on.")
 	secretsCmd.AddCommand(secretsListCmd)
 	secretsCmd.AddCommand(secretsSetCmd)
 	secretsCmd.AddCommand(secretsUnsetCmd)
+	secretsDiffCmd.Flags().StringVar(&secretsEnvFile, "env-file", "", "Read secrets from a .env file.")
+	cobra.CheckErr(secretsDiffCmd.MarkFlagRequired("env-file"))
+	secretsCmd.AddCommand(secretsDiffCmd)
+	secretsSyncCmd.Flags().StringVar(&secretsEnvFile, "env-file", "", "Read secrets from a .env file.")
+	secretsSyncCmd.Flags().BoolVar(&secretsPrune, "prune", false, "Remove secrets that are not found in the .env file.")
+	cobra.CheckErr(secretsSyncCmd.MarkFlagRequired("env-file"))
+	secretsCmd.AddCommand(secretsSyncCmd)
 	rootCmd.AddCommand(secretsCmd)
 }
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/seed/run"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	seedCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "seed",
+		Short:   "Manage seed data scripts",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			cmd.SetContext(ctx)
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+	}
+
+	seedEnv string
+
+	seedRunCmd = &cobra.Command{
+		Use:   "run",
+		Short: "Apply seed files to the database",
+		Long:  "Apply seed files under " + utils.SeedsDir + ", followed by those under the environment subdirectory. Files that have not changed since they were last applied are skipped.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run.Run(cmd.Context(), seedEnv, dryRun, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	runFlags := seedRunCmd.Flags()
+	runFlags.StringVar(&seedEnv, "env", "", "Environment subdirectory of seed files to apply.")
+	runFlags.BoolVar(&dryRun, "dry-run", false, "Print the seed statements that would be executed.")
+	runFlags.String("db-url", "", "Seeds the database specified by the connection string (must be percent-encoded).")
+	runFlags.Bool("linked", false, "Seeds the linked project.")
+	runFlags.Bool("local", true, "Seeds the local database.")
+	seedRunCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	runFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", runFlags.Lookup("password")))
+	seedRunCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	seedCmd.AddCommand(seedRunCmd)
+	rootCmd.AddCommand(seedCmd)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/db/native"
 	"github.com/supabase/cli/internal/start"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
@@ -15,6 +16,8 @@ var (
 	excludedContainers []string
 	ignoreHealthCheck  bool
 	preview            bool
+	dbOnly             bool
+	startNative        bool
 
 	startCmd = &cobra.Command{
 		GroupID: groupLocalDev,
@@ -22,6 +25,12 @@ var (
 		Short:   "Start containers for Supabase local development",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fsys := afero.NewOsFs()
+			if startNative {
+				return native.Run(cmd.Context(), fsys)
+			}
+			if dbOnly {
+				excludedContainers = allowedContainers
+			}
 			if preview {
 				projectRef, err := utils.LoadProjectRef(fsys)
 				if err != nil {
@@ -37,9 +46,13 @@ var (
 func init() {
 	flags := startCmd.Flags()
 	names := strings.Join(allowedContainers, ",")
-	flags.StringSliceVarP(&excludedContainers, "exclude", "x", []string{}, "Names of containers to not start. ["+names+"]")
+	flags.StringSliceVarP(&excludedContainers, "exclude", "x", []string{}, "Names of containers to not start, including any services that depend on them. ["+names+"]")
 	flags.BoolVar(&ignoreHealthCheck, "ignore-health-check", false, "Ignore unhealthy services and exit 0")
 	flags.BoolVar(&preview, "preview", false, "Connect to feature preview branch")
 	cobra.CheckErr(flags.MarkHidden("preview"))
+	flags.BoolVar(&dbOnly, "db-only", false, "Start only the Postgres database.")
+	flags.BoolVar(&startNative, "native", false, "Experimental: run a pinned Postgres build without Docker.")
+	startCmd.MarkFlagsMutuallyExclusive("native", "exclude")
+	startCmd.MarkFlagsMutuallyExclusive("native", "preview")
 	rootCmd.AddCommand(startCmd)
 }
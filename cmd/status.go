@@ -3,12 +3,14 @@ package cmd
 import (
 	"os"
 	"os/signal"
+	"time"
 
 	env "github.com/Netflix/go-env"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/status"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
 )
 
 var (
@@ -18,6 +20,9 @@ var (
 		Allowed: append([]string{utils.OutputEnv}, utils.OutputDefaultAllowed...),
 		Value:   utils.OutputPretty,
 	}
+	watchStatus   bool
+	watchInterval time.Duration
+
 	statusCmd = &cobra.Command{
 		GroupID: groupLocalDev,
 		Use:     "status",
@@ -31,10 +36,18 @@ var (
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			if watchStatus {
+				return status.Watch(ctx, watchInterval, afero.NewOsFs())
+			}
+			// Local flag shadows the global --output, so errors are rendered in the same format
+			if output.Value != utils.OutputEnv {
+				render.Format.Value = output.Value
+			}
 			return status.Run(ctx, names, output.Value, afero.NewOsFs())
 		},
 		Example: `  supabase status -o env --override-name api.url=NEXT_PUBLIC_SUPABASE_URL
-  supabase status -o json`,
+  supabase status -o json
+  supabase status --watch --interval 5s`,
 	}
 )
 
@@ -42,5 +55,9 @@ func init() {
 	flags := statusCmd.Flags()
 	flags.VarP(&output, "output", "o", "Output format of status variables.")
 	flags.StringSliceVar(&override, "override-name", []string{}, "Override specific variable names.")
+	flags.BoolVar(&watchStatus, "watch", false, "Continuously show health, resource usage and database activity of local containers.")
+	flags.DurationVar(&watchInterval, "interval", 2*time.Second, "Refresh interval of the --watch dashboard.")
+	statusCmd.MarkFlagsMutuallyExclusive("watch", "output")
+	statusCmd.MarkFlagsMutuallyExclusive("watch", "override-name")
 	rootCmd.AddCommand(statusCmd)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/supabase/cli/internal/storage/ls"
 	"github.com/supabase/cli/internal/storage/mv"
 	"github.com/supabase/cli/internal/storage/rm"
+	"github.com/supabase/cli/internal/storage/storagesync"
 )
 
 var (
@@ -18,6 +19,7 @@ var (
 	}
 
 	recursive bool
+	noResume  bool
 
 	lsCmd = &cobra.Command{
 		Use:     "ls [path]",
@@ -42,7 +44,7 @@ cp -r ss:///bucket/docs .
 		Short: "Copy objects from src to dst path",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return cp.Run(cmd.Context(), args[0], args[1], recursive, afero.NewOsFs())
+			return cp.Run(cmd.Context(), args[0], args[1], recursive, !noResume, afero.NewOsFs())
 		},
 	}
 
@@ -67,16 +69,37 @@ rm ss:///bucket/docs/example.md ss:///bucket/readme.md
 			return rm.Run(cmd.Context(), args, recursive, afero.NewOsFs())
 		},
 	}
+
+	syncOptions storagesync.SyncOptions
+
+	syncCmd = &cobra.Command{
+		Use:   "sync <src> <dst>",
+		Short: "Sync objects between a local directory and storage path",
+		Long:  "Copy only new or changed files from src to dst. Files are compared by size and content hash.",
+		Example: `sync ./public ss:///assets
+sync --delete --dry-run ss:///assets/images ./images
+`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return storagesync.Run(cmd.Context(), args[0], args[1], syncOptions, afero.NewOsFs())
+		},
+	}
 )
 
 func init() {
 	lsCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively list a directory.")
 	storageCmd.AddCommand(lsCmd)
 	cpCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively copy a directory.")
+	cpCmd.Flags().BoolVar(&noResume, "no-resume", false, "Disable resumable transfers for large objects.")
 	storageCmd.AddCommand(cpCmd)
 	rmCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively move a directory.")
 	storageCmd.AddCommand(rmCmd)
 	mvCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively remove a directory.")
 	storageCmd.AddCommand(mvCmd)
+	syncFlags := syncCmd.Flags()
+	syncFlags.BoolVar(&syncOptions.Delete, "delete", false, "Delete files in dst that do not exist in src.")
+	syncFlags.BoolVar(&syncOptions.DryRun, "dry-run", false, "Print the changes that would be made without applying them.")
+	syncFlags.UintVarP(&syncOptions.Jobs, "jobs", "j", 4, "Maximum number of files to transfer in parallel.")
+	storageCmd.AddCommand(syncCmd)
 	rootCmd.AddCommand(storageCmd)
 }
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/upgrade"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	upgradeChannel = utils.EnumFlag{
+		Allowed: upgrade.Channels,
+		Value:   upgrade.ChannelStable,
+	}
+	upgradeCheck bool
+
+	upgradeCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "upgrade",
+		Short:   "Upgrade Supabase CLI to the latest version",
+		Long:    "Upgrade Supabase CLI to the latest release. Set SUPABASE_DISABLE_UPGRADE=true to disable self-update for installs managed by a package manager.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return upgrade.Run(cmd.Context(), upgradeChannel.Value, upgradeCheck, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	upgradeFlags := upgradeCmd.Flags()
+	upgradeFlags.Var(&upgradeChannel, "channel", "Release channel to upgrade from, one of: "+strings.Join(upgradeChannel.Allowed, ", ")+".")
+	upgradeFlags.BoolVar(&upgradeCheck, "check", false, "Only check if a new version is available.")
+	rootCmd.AddCommand(upgradeCmd)
+}
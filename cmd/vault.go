@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/vault/get"
+	"github.com/supabase/cli/internal/vault/list"
+	"github.com/supabase/cli/internal/vault/set"
+)
+
+var (
+	vaultCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "vault",
+		Short:   "Manage secrets in Supabase Vault",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			cmd.SetContext(ctx)
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+	}
+
+	vaultDescription string
+	vaultMigration   bool
+
+	vaultSetCmd = &cobra.Command{
+		Use:   "set <name> [value]",
+		Short: "Create or update a secret in Vault",
+		Long:  "Create or update an encrypted secret. The value is read from stdin when not specified as an argument.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var value string
+			if len(args) > 1 {
+				value = args[1]
+			} else {
+				fmt.Fprintf(os.Stderr, "Enter the secret value: ")
+				value = strings.TrimSpace(credentials.PromptMasked(os.Stdin))
+			}
+			return set.Run(cmd.Context(), args[0], value, vaultDescription, vaultMigration, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	vaultGetCmd = &cobra.Command{
+		Use:   "get <name>",
+		Short: "Print the decrypted value of a secret",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return get.Run(cmd.Context(), args[0], flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	vaultListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List secrets in Vault without their values",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), flags.DbConfig, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	vaultFlags := vaultCmd.PersistentFlags()
+	vaultFlags.String("db-url", "", "Connects to the database specified by the connection string (must be percent-encoded).")
+	vaultFlags.Bool("linked", false, "Connects to the linked project.")
+	vaultFlags.Bool("local", true, "Connects to the local database.")
+	vaultCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	vaultFlags.StringVarP(&dbPassword, "password", "p", "", "Password to your remote Postgres database.")
+	cobra.CheckErr(viper.BindPFlag("DB_PASSWORD", vaultFlags.Lookup("password")))
+	vaultCmd.MarkFlagsMutuallyExclusive("db-url", "password")
+	setFlags := vaultSetCmd.Flags()
+	setFlags.StringVar(&vaultDescription, "description", "", "Description of the secret.")
+	setFlags.BoolVar(&vaultMigration, "migration", false, "Create a migration that references the secret by name without its value.")
+	vaultCmd.AddCommand(vaultSetCmd)
+	vaultCmd.AddCommand(vaultGetCmd)
+	vaultCmd.AddCommand(vaultListCmd)
+	rootCmd.AddCommand(vaultCmd)
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+)
+
+// Client calls the GoTrue admin API with a service role key.
+type Client struct {
+	BaseUrl    string
+	ServiceKey string
+}
+
+type User struct {
+	Id               string         `json:"id"`
+	Email            string         `json:"email,omitempty"`
+	Phone            string         `json:"phone,omitempty"`
+	Role             string         `json:"role,omitempty"`
+	EmailConfirmedAt string         `json:"email_confirmed_at,omitempty"`
+	LastSignInAt     string         `json:"last_sign_in_at,omitempty"`
+	CreatedAt        string         `json:"created_at,omitempty"`
+	UpdatedAt        string         `json:"updated_at,omitempty"`
+	UserMetadata     map[string]any `json:"user_metadata,omitempty"`
+	AppMetadata      map[string]any `json:"app_metadata,omitempty"`
+}
+
+type UserList struct {
+	Users []User `json:"users"`
+}
+
+type CreateUserParams struct {
+	Email        string         `json:"email,omitempty"`
+	Phone        string         `json:"phone,omitempty"`
+	Password     string         `json:"password,omitempty"`
+	EmailConfirm bool           `json:"email_confirm,omitempty"`
+	PhoneConfirm bool           `json:"phone_confirm,omitempty"`
+	UserMetadata map[string]any `json:"user_metadata,omitempty"`
+}
+
+type UpdateUserParams struct {
+	UserMetadata map[string]any `json:"user_metadata,omitempty"`
+	AppMetadata  map[string]any `json:"app_metadata,omitempty"`
+}
+
+type emailParams struct {
+	Email string `json:"email"`
+}
+
+// Connects to the linked project when projectRef is set, otherwise to the local GoTrue instance.
+func NewClient(ctx context.Context, projectRef string, fsys afero.Fs) (Client, error) {
+	if len(projectRef) > 0 {
+		keys, err := tenant.GetApiKeys(ctx, projectRef)
+		if err != nil {
+			return Client{}, err
+		}
+		return Client{
+			BaseUrl:    fmt.Sprintf("https://%s/auth/v1", utils.GetSupabaseHost(projectRef)),
+			ServiceKey: keys.ServiceRole,
+		}, nil
+	}
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return Client{}, err
+	}
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		return Client{}, err
+	}
+	return Client{
+		BaseUrl:    fmt.Sprintf("http://127.0.0.1:%d/auth/v1", utils.Config.Api.Port),
+		ServiceKey: utils.Config.Auth.ServiceRoleKey,
+	}, nil
+}
+
+func send[T any](ctx context.Context, c Client, method, path string, body any) (*T, error) {
+	return utils.JsonResponse[T](ctx, method, c.BaseUrl+path, body, func(ctx context.Context, req *http.Request) error {
+		req.Header.Add("apikey", c.ServiceKey)
+		req.Header.Add("Authorization", "Bearer "+c.ServiceKey)
+		return nil
+	})
+}
+
+// Lists users by page, optionally filtered by a search term matched against email.
+func (c Client) ListUsers(ctx context.Context, filter string, page, perPage uint) ([]User, error) {
+	query := url.Values{
+		"page":     {strconv.FormatUint(uint64(page), 10)},
+		"per_page": {strconv.FormatUint(uint64(perPage), 10)},
+	}
+	if len(filter) > 0 {
+		query.Set("filter", filter)
+	}
+	data, err := send[UserList](ctx, c, http.MethodGet, "/admin/users?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return data.Users, nil
+}
+
+func (c Client) CreateUser(ctx context.Context, params CreateUserParams) (*User, error) {
+	return send[User](ctx, c, http.MethodPost, "/admin/users", params)
+}
+
+func (c Client) UpdateUser(ctx context.Context, id string, params UpdateUserParams) (*User, error) {
+	return send[User](ctx, c, http.MethodPut, "/admin/users/"+url.PathEscape(id), params)
+}
+
+func (c Client) DeleteUser(ctx context.Context, id string) error {
+	_, err := send[map[string]any](ctx, c, http.MethodDelete, "/admin/users/"+url.PathEscape(id), nil)
+	return err
+}
+
+func (c Client) InviteUser(ctx context.Context, email string) (*User, error) {
+	return send[User](ctx, c, http.MethodPost, "/invite", emailParams{Email: email})
+}
+
+func (c Client) SendMagicLink(ctx context.Context, email string) error {
+	_, err := send[map[string]any](ctx, c, http.MethodPost, "/magiclink", emailParams{Email: email})
+	return err
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestUsersClient(t *testing.T) {
+	client := Client{BaseUrl: "http://127.0.0.1:54321/auth/v1", ServiceKey: "service-key"}
+
+	t.Run("lists users with filter", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(client.BaseUrl).
+			Get("/admin/users").
+			MatchHeader("Authorization", "Bearer service-key").
+			MatchHeader("apikey", "service-key").
+			MatchParam("filter", "alice").
+			MatchParam("page", "2").
+			MatchParam("per_page", "10").
+			Reply(http.StatusOK).
+			JSON(UserList{Users: []User{{Id: "user-id", Email: "alice@example.com"}}})
+		// Run test
+		users, err := client.ListUsers(context.Background(), "alice", 2, 10)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []User{{Id: "user-id", Email: "alice@example.com"}}, users)
+		assert.Empty(t, gock.Pending())
+	})
+
+	t.Run("creates confirmed user", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(client.BaseUrl).
+			Post("/admin/users").
+			JSON(CreateUserParams{Email: "alice@example.com", Password: "secret", EmailConfirm: true}).
+			Reply(http.StatusOK).
+			JSON(User{Id: "user-id"})
+		// Run test
+		user, err := client.CreateUser(context.Background(), CreateUserParams{
+			Email:        "alice@example.com",
+			Password:     "secret",
+			EmailConfirm: true,
+		})
+		// Check error
+		require.NoError(t, err)
+		assert.Equal(t, "user-id", user.Id)
+		assert.Empty(t, gock.Pending())
+	})
+
+	t.Run("updates metadata", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(client.BaseUrl).
+			Put("/admin/users/user-id").
+			JSON(UpdateUserParams{UserMetadata: map[string]any{"name": "Alice"}}).
+			Reply(http.StatusOK).
+			JSON(User{Id: "user-id"})
+		// Run test
+		_, err := client.UpdateUser(context.Background(), "user-id", UpdateUserParams{
+			UserMetadata: map[string]any{"name": "Alice"},
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, gock.Pending())
+	})
+
+	t.Run("sends invite and magic link", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(client.BaseUrl).
+			Post("/invite").
+			JSON(emailParams{Email: "bob@example.com"}).
+			Reply(http.StatusOK).
+			JSON(User{Id: "user-id"})
+		gock.New(client.BaseUrl).
+			Post("/magiclink").
+			JSON(emailParams{Email: "bob@example.com"}).
+			Reply(http.StatusOK).
+			JSON(map[string]any{})
+		// Run test
+		_, err := client.InviteUser(context.Background(), "bob@example.com")
+		assert.NoError(t, err)
+		err = client.SendMagicLink(context.Background(), "bob@example.com")
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, gock.Pending())
+	})
+
+	t.Run("throws error on delete failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(client.BaseUrl).
+			Delete("/admin/users/user-id").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := client.DeleteUser(context.Background(), "user-id")
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, gock.Pending())
+	})
+}
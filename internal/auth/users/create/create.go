@@ -0,0 +1,43 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+var ErrMissingIdentity = errors.New("Must specify either " + utils.Aqua("--email") + " or " + utils.Aqua("--phone"))
+
+// Creates a confirmed user that can sign in immediately, which is handy for testing.
+func Run(ctx context.Context, projectRef string, params auth.CreateUserParams, metadata string, fsys afero.Fs) error {
+	if len(params.Email) == 0 && len(params.Phone) == 0 {
+		return ErrMissingIdentity
+	}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal([]byte(metadata), &params.UserMetadata); err != nil {
+			return fmt.Errorf("failed to parse user metadata: %w", err)
+		}
+	}
+	params.EmailConfirm = len(params.Email) > 0
+	params.PhoneConfirm = len(params.Phone) > 0
+	client, err := auth.NewClient(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	user, err := client.CreateUser(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	if !render.IsPretty() {
+		return render.Encode(user)
+	}
+	fmt.Fprintln(os.Stderr, "Created user:", utils.Aqua(user.Id))
+	return nil
+}
@@ -0,0 +1,58 @@
+package create
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateUser(t *testing.T) {
+	projectRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("creates confirmed user with metadata", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://" + utils.GetSupabaseHost(projectRef)).
+			Post("/auth/v1/admin/users").
+			JSON(auth.CreateUserParams{
+				Email:        "alice@example.com",
+				Password:     "secret",
+				EmailConfirm: true,
+				UserMetadata: map[string]any{"name": "Alice"},
+			}).
+			Reply(http.StatusOK).
+			JSON(auth.User{Id: "user-id"})
+		// Run test
+		params := auth.CreateUserParams{Email: "alice@example.com", Password: "secret"}
+		err := Run(context.Background(), projectRef, params, `{"name":"Alice"}`, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing identity", func(t *testing.T) {
+		err := Run(context.Background(), projectRef, auth.CreateUserParams{}, "", afero.NewMemMapFs())
+		assert.ErrorIs(t, err, ErrMissingIdentity)
+	})
+
+	t.Run("throws error on invalid metadata", func(t *testing.T) {
+		params := auth.CreateUserParams{Phone: "+6591234567"}
+		err := Run(context.Background(), projectRef, params, "{", afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "failed to parse user metadata")
+	})
+}
@@ -0,0 +1,30 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, projectRef string, ids []string, fsys afero.Fs) error {
+	client, err := auth.NewClient(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	if !utils.PromptYesNo("Do you want to delete users "+utils.Aqua(strings.Join(ids, ", "))+"? This action is irreversible.", false, os.Stdin) {
+		return errors.New("Not deleting users.")
+	}
+	for _, id := range ids {
+		if err := client.DeleteUser(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete user %s: %w", id, err)
+		}
+		fmt.Fprintln(os.Stderr, "Deleted user:", utils.Aqua(id))
+	}
+	return nil
+}
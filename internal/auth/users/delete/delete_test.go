@@ -0,0 +1,82 @@
+package delete
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestDeleteUsers(t *testing.T) {
+	projectRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("deletes users of linked project", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		authUrl := "https://" + utils.GetSupabaseHost(projectRef) + "/auth/v1"
+		gock.New(authUrl).
+			Delete("/admin/users/user-1").
+			MatchHeader("Authorization", "Bearer service-key").
+			Reply(http.StatusOK).
+			JSON(map[string]any{})
+		gock.New(authUrl).
+			Delete("/admin/users/user-2").
+			Reply(http.StatusOK).
+			JSON(map[string]any{})
+		// Run test
+		err := Run(context.Background(), projectRef, []string{"user-1", "user-2"}, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("declines deletion by default", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		// Run test
+		err := Run(context.Background(), projectRef, []string{"user-1"}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Not deleting users.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing user", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://" + utils.GetSupabaseHost(projectRef)).
+			Delete("/auth/v1/admin/users/user-1").
+			Reply(http.StatusNotFound).
+			JSON(map[string]string{"msg": "User not found"})
+		// Run test
+		err := Run(context.Background(), projectRef, []string{"user-1"}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to delete user user-1")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,36 @@
+package invite
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+// Sends an invite email to a new user, or a magic link to sign in an existing user.
+func Run(ctx context.Context, projectRef, email string, magicLink bool, fsys afero.Fs) error {
+	client, err := auth.NewClient(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	if magicLink {
+		if err := client.SendMagicLink(ctx, email); err != nil {
+			return fmt.Errorf("failed to send magic link: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, "Sent magic link to:", utils.Aqua(email))
+		return nil
+	}
+	user, err := client.InviteUser(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to invite user: %w", err)
+	}
+	if !render.IsPretty() {
+		return render.Encode(user)
+	}
+	fmt.Fprintln(os.Stderr, "Invited user:", utils.Aqua(email))
+	return nil
+}
@@ -0,0 +1,60 @@
+package invite
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestInviteUser(t *testing.T) {
+	projectRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("invites new user", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://" + utils.GetSupabaseHost(projectRef)).
+			Post("/auth/v1/invite").
+			JSON(map[string]string{"email": "alice@example.com"}).
+			Reply(http.StatusOK).
+			JSON(auth.User{Id: "user-id", Email: "alice@example.com"})
+		// Run test
+		err := Run(context.Background(), projectRef, "alice@example.com", false, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("sends magic link", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://" + utils.GetSupabaseHost(projectRef)).
+			Post("/auth/v1/magiclink").
+			JSON(map[string]string{"email": "alice@example.com"}).
+			Reply(http.StatusOK).
+			JSON(map[string]any{})
+		// Run test
+		err := Run(context.Background(), projectRef, "alice@example.com", true, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,48 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+func Run(ctx context.Context, projectRef, filter string, page, perPage uint, fsys afero.Fs) error {
+	client, err := auth.NewClient(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	users, err := client.ListUsers(ctx, filter, page, perPage)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+	if !render.IsPretty() {
+		return render.Encode(users)
+	}
+	table := `|ID|EMAIL|PHONE|CREATED AT (UTC)|LAST SIGN IN (UTC)|
+|-|-|-|-|-|
+`
+	for _, user := range users {
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|`%s`|`%s`|`%s`|\n",
+			user.Id,
+			strings.ReplaceAll(user.Email, "|", "\\|"),
+			strings.ReplaceAll(user.Phone, "|", "\\|"),
+			formatTimestamp(user.CreatedAt),
+			formatTimestamp(user.LastSignInAt),
+		)
+	}
+	return list.RenderTable(table)
+}
+
+// GoTrue returns RFC3339 timestamps with fractional seconds, which are truncated for display.
+func formatTimestamp(ts string) string {
+	if i := strings.IndexByte(ts, '.'); i > 0 {
+		ts = ts[:i]
+	}
+	return strings.Replace(ts, "T", " ", 1)
+}
@@ -0,0 +1,68 @@
+package list
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestListUsers(t *testing.T) {
+	projectRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("lists users of linked project", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://"+utils.GetSupabaseHost(projectRef)).
+			Get("/auth/v1/admin/users").
+			MatchParam("filter", "alice").
+			MatchParam("page", "1").
+			MatchParam("per_page", "50").
+			Reply(http.StatusOK).
+			JSON(auth.UserList{Users: []auth.User{{
+				Id:        "user-id",
+				Email:     "alice@example.com",
+				CreatedAt: "2024-01-02T03:04:05.123456Z",
+			}}})
+		// Run test
+		err := Run(context.Background(), projectRef, "alice", 1, 50, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://" + utils.GetSupabaseHost(projectRef)).
+			Get("/auth/v1/admin/users").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), projectRef, "", 1, 50, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to list users")
+	})
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	assert.Equal(t, "2024-01-02 03:04:05", formatTimestamp("2024-01-02T03:04:05.123456Z"))
+	assert.Equal(t, "", formatTimestamp(""))
+}
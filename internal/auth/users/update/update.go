@@ -0,0 +1,47 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+var ErrNoChanges = errors.New("Must specify either " + utils.Aqua("--user-metadata") + " or " + utils.Aqua("--app-metadata"))
+
+// Metadata is passed as JSON objects which GoTrue merges into the existing metadata.
+func Run(ctx context.Context, projectRef, id, userMetadata, appMetadata string, fsys afero.Fs) error {
+	var params auth.UpdateUserParams
+	if len(userMetadata) == 0 && len(appMetadata) == 0 {
+		return ErrNoChanges
+	}
+	if len(userMetadata) > 0 {
+		if err := json.Unmarshal([]byte(userMetadata), &params.UserMetadata); err != nil {
+			return fmt.Errorf("failed to parse user metadata: %w", err)
+		}
+	}
+	if len(appMetadata) > 0 {
+		if err := json.Unmarshal([]byte(appMetadata), &params.AppMetadata); err != nil {
+			return fmt.Errorf("failed to parse app metadata: %w", err)
+		}
+	}
+	client, err := auth.NewClient(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	user, err := client.UpdateUser(ctx, id, params)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	if !render.IsPretty() {
+		return render.Encode(user)
+	}
+	fmt.Fprintln(os.Stderr, "Updated user:", utils.Aqua(user.Id))
+	return nil
+}
@@ -0,0 +1,51 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/auth"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestUpdateUser(t *testing.T) {
+	projectRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("updates app metadata", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "service_role", ApiKey: "service-key"}})
+		gock.New("https://" + utils.GetSupabaseHost(projectRef)).
+			Put("/auth/v1/admin/users/user-id").
+			JSON(auth.UpdateUserParams{AppMetadata: map[string]any{"role": "admin"}}).
+			Reply(http.StatusOK).
+			JSON(auth.User{Id: "user-id"})
+		// Run test
+		err := Run(context.Background(), projectRef, "user-id", "", `{"role":"admin"}`, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on no changes", func(t *testing.T) {
+		err := Run(context.Background(), projectRef, "user-id", "", "", afero.NewMemMapFs())
+		assert.ErrorIs(t, err, ErrNoChanges)
+	})
+
+	t.Run("throws error on invalid metadata", func(t *testing.T) {
+		err := Run(context.Background(), projectRef, "user-id", "[", "", afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "failed to parse user metadata")
+	})
+}
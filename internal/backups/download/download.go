@@ -0,0 +1,120 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef, backupId, output string, fsys afero.Fs) error {
+	link, err := getDownloadLink(ctx, projectRef, backupId)
+	if err != nil {
+		return err
+	}
+	if len(output) == 0 {
+		output = backupId + ".backup"
+	}
+	if err := utils.RunProgram(ctx, func(p utils.Program, ctx context.Context) error {
+		p.Send(utils.StatusMsg("Downloading backup " + backupId + "..."))
+		return downloadBackup(ctx, *link, output, p, fsys)
+	}); err != nil {
+		return err
+	}
+	fmt.Println("Downloaded backup to " + utils.Bold(output) + ".")
+	return nil
+}
+
+func getDownloadLink(ctx context.Context, projectRef, backupId string) (*api.V1BackupDownloadResponse, error) {
+	resp, err := utils.GetSupabase().V1DownloadBackupWithResponse(ctx, projectRef, backupId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return resp.JSON200, nil
+	case http.StatusPaymentRequired, http.StatusForbidden:
+		// Plan restrictions are surfaced to the user as is
+		return nil, errors.New(string(resp.Body))
+	case http.StatusNotFound:
+		return nil, errors.New("Backup " + utils.Aqua(backupId) + " does not exist or is not available for download.")
+	default:
+		return nil, errors.New("Unexpected error retrieving backup download link: " + string(resp.Body))
+	}
+}
+
+func downloadBackup(ctx context.Context, link api.V1BackupDownloadResponse, output string, p utils.Program, fsys afero.Fs) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.Url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("Error status %d: %s", resp.StatusCode, body)
+	}
+	// Streams to file while computing checksum
+	f, err := fsys.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	total := resp.ContentLength
+	if total <= 0 && link.SizeBytes != nil {
+		total = *link.SizeBytes
+	}
+	hash := sha256.New()
+	progress := &progressWriter{p: p, total: total}
+	if _, err := io.Copy(io.MultiWriter(f, hash, progress), resp.Body); err != nil {
+		return err
+	}
+	if link.ChecksumSha256 == nil || len(*link.ChecksumSha256) == 0 {
+		return nil
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, *link.ChecksumSha256) {
+		// Remove corrupted download so that it's not mistaken for a valid backup
+		if err := fsys.Remove(output); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return fmt.Errorf("Checksum mismatch: expected %s but got %s", *link.ChecksumSha256, actual)
+	}
+	return nil
+}
+
+type progressWriter struct {
+	p       utils.Program
+	total   int64
+	written int64
+	percent int64
+}
+
+func (w *progressWriter) Write(b []byte) (int, error) {
+	w.written += int64(len(b))
+	if w.total <= 0 {
+		return len(b), nil
+	}
+	// Only report progress on whole percentage increments
+	if percent := w.written * 100 / w.total; percent > w.percent {
+		w.percent = percent
+		value := float64(w.written) / float64(w.total)
+		w.p.Send(utils.ProgressMsg(&value))
+	}
+	return len(b), nil
+}
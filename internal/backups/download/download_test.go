@@ -0,0 +1,113 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestBackupsDownloadCommand(t *testing.T) {
+	const backupId = "test-backup"
+	const downloadHost = "https://storage.example.com"
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	// Setup backup content
+	content := []byte("-- PostgreSQL database dump")
+	digest := sha256.Sum256(content)
+	checksum := hex.EncodeToString(digest[:])
+
+	t.Run("downloads backup to file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups/" + backupId + "/download").
+			Reply(http.StatusOK).
+			JSON(api.V1BackupDownloadResponse{
+				Url:            downloadHost + "/backup.gz",
+				ChecksumSha256: &checksum,
+			})
+		gock.New(downloadHost).
+			Get("/backup.gz").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(content))
+		// Run test
+		assert.NoError(t, Run(context.Background(), project, backupId, "out.backup", fsys))
+		// Validate output
+		data, err := afero.ReadFile(fsys, "out.backup")
+		assert.NoError(t, err)
+		assert.Equal(t, content, data)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on checksum mismatch", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups/" + backupId + "/download").
+			Reply(http.StatusOK).
+			JSON(api.V1BackupDownloadResponse{
+				Url:            downloadHost + "/backup.gz",
+				ChecksumSha256: &checksum,
+			})
+		gock.New(downloadHost).
+			Get("/backup.gz").
+			Reply(http.StatusOK).
+			BodyString("corrupted")
+		// Run test
+		err := Run(context.Background(), project, backupId, "", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Checksum mismatch")
+		exists, err := afero.Exists(fsys, backupId+".backup")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("passes through plan errors", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups/" + backupId + "/download").
+			Reply(http.StatusPaymentRequired).
+			BodyString(`{"message":"Upgrade your plan to download backups"}`)
+		// Run test
+		err := Run(context.Background(), project, backupId, "", fsys)
+		// Check error
+		assert.EqualError(t, err, `{"message":"Upgrade your plan to download backups"}`)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing backup", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups/" + backupId + "/download").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), project, backupId, "", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "is not available for download")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
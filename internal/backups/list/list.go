@@ -0,0 +1,79 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, projectRef, format string) error {
+	resp, err := utils.GetSupabase().V1ListAllBackupsWithResponse(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+
+	if resp.JSON200 == nil {
+		// Plan restrictions are surfaced to the user as is
+		if resp.StatusCode() == http.StatusPaymentRequired || resp.StatusCode() == http.StatusForbidden {
+			return errors.New(string(resp.Body))
+		}
+		return errors.New("Unexpected error listing backups: " + string(resp.Body))
+	}
+
+	if format != utils.OutputPretty {
+		return utils.EncodeOutput(format, os.Stdout, resp.JSON200)
+	}
+
+	table := `|ID|TYPE|STATUS|SIZE|CREATED AT (UTC)|
+|-|-|-|-|-|
+`
+	for _, backup := range resp.JSON200.Backups {
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|`%s`|`%s`|`%s`|\n",
+			backup.Id,
+			formatType(backup),
+			backup.Status,
+			formatSize(backup.SizeBytes),
+			utils.FormatTimestamp(backup.InsertedAt),
+		)
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+
+	if resp.JSON200.PitrEnabled {
+		data := resp.JSON200.PhysicalBackupData
+		fmt.Println("PITR earliest restorable time (UTC):", formatUnix(data.EarliestPhysicalBackupDateUnix))
+		fmt.Println("PITR latest restorable time (UTC):  ", formatUnix(data.LatestPhysicalBackupDateUnix))
+	}
+	return nil
+}
+
+func formatType(backup api.V1BackupResponse) string {
+	if backup.IsPhysicalBackup {
+		return "physical"
+	}
+	return "logical"
+}
+
+func formatSize(size *int64) string {
+	if size == nil {
+		return "-"
+	}
+	return units.BytesSize(float64(*size))
+}
+
+func formatUnix(timestamp *int64) string {
+	if timestamp == nil {
+		return "-"
+	}
+	return time.Unix(*timestamp, 0).UTC().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,103 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestBackupsListCommand(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("lists all backups", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups").
+			Reply(http.StatusOK).
+			JSON(api.V1BackupsResponse{
+				Region:      "ap-southeast-1",
+				WalgEnabled: true,
+				PitrEnabled: true,
+				Backups: []api.V1BackupResponse{{
+					Id:               "1",
+					IsPhysicalBackup: true,
+					Status:           "COMPLETED",
+					SizeBytes:        utils.Ptr(int64(1024)),
+					InsertedAt:       "2023-12-01T00:00:00Z",
+				}},
+				PhysicalBackupData: api.V1PhysicalBackupData{
+					EarliestPhysicalBackupDateUnix: utils.Ptr(int64(1701388800)),
+					LatestPhysicalBackupDateUnix:   utils.Ptr(int64(1701475200)),
+				},
+			})
+		// Run test
+		assert.NoError(t, Run(context.Background(), project, utils.OutputPretty))
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("encodes json output", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups").
+			Reply(http.StatusOK).
+			JSON(api.V1BackupsResponse{Backups: []api.V1BackupResponse{}})
+		// Run test
+		assert.NoError(t, Run(context.Background(), project, utils.OutputJson))
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("passes through plan errors", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups").
+			Reply(http.StatusPaymentRequired).
+			BodyString(`{"message":"PITR is not enabled for this project"}`)
+		// Run test
+		err := Run(context.Background(), project, utils.OutputPretty)
+		// Check error
+		assert.EqualError(t, err, `{"message":"PITR is not enabled for this project"}`)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := Run(context.Background(), project, utils.OutputPretty)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), project, utils.OutputPretty)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error listing backups:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,88 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Parses a recovery target given as RFC3339 or unix seconds.
+func ParseTimestamp(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	target, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("Invalid timestamp " + utils.Aqua(value) + ", must be RFC3339 or unix seconds.")
+	}
+	return target, nil
+}
+
+func PreRun(ctx context.Context, projectRef string, target time.Time, confirm string) error {
+	if err := assertRestorable(ctx, projectRef, target); err != nil {
+		return err
+	}
+	// Both prompts must be answered; --yes alone does not skip typing the project ref
+	if !utils.PromptYesNo("Do you want to restore project "+utils.Aqua(projectRef)+" to "+formatTime(target)+"? All changes after this time will be lost.", false, os.Stdin) {
+		return errors.New("Not restoring project: " + utils.Aqua(projectRef))
+	}
+	return utils.PromptConfirmToken("The database will be unavailable while restoring.", projectRef, confirm, os.Stdin)
+}
+
+func Run(ctx context.Context, projectRef string, target time.Time) error {
+	resp, err := utils.GetSupabase().V1RestorePitrWithResponse(ctx, projectRef, api.V1RestorePitrJSONRequestBody{
+		RecoveryTimeTargetUnix: target.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusCreated:
+		break
+	case http.StatusPaymentRequired, http.StatusForbidden:
+		// Plan restrictions are surfaced to the user as is
+		return errors.New(string(resp.Body))
+	default:
+		return errors.New("Failed to restore project " + utils.Aqua(projectRef) + ": " + string(resp.Body))
+	}
+
+	fmt.Println("Restoring project " + utils.Aqua(projectRef) + " to " + formatTime(target) + ". Run " + utils.Aqua("supabase status") + " to check progress.")
+	return nil
+}
+
+// Checks that PITR is enabled and the target falls within the restorable window.
+func assertRestorable(ctx context.Context, projectRef string, target time.Time) error {
+	resp, err := utils.GetSupabase().V1ListAllBackupsWithResponse(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+
+	if resp.JSON200 == nil {
+		if resp.StatusCode() == http.StatusPaymentRequired || resp.StatusCode() == http.StatusForbidden {
+			return errors.New(string(resp.Body))
+		}
+		return errors.New("Unexpected error listing backups: " + string(resp.Body))
+	}
+
+	if !resp.JSON200.PitrEnabled {
+		return errors.New("Point-in-time recovery is not enabled for project " + utils.Aqua(projectRef) + ".")
+	}
+	data := resp.JSON200.PhysicalBackupData
+	if data.EarliestPhysicalBackupDateUnix != nil && target.Unix() < *data.EarliestPhysicalBackupDateUnix ||
+		data.LatestPhysicalBackupDateUnix != nil && target.Unix() > *data.LatestPhysicalBackupDateUnix {
+		return fmt.Errorf("Timestamp %s is outside the restorable window. Run %s to view the earliest and latest restorable times.", formatTime(target), utils.Aqua("supabase backups list"))
+	}
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	return utils.Aqua(t.UTC().Format("2006-01-02 15:04:05") + " UTC")
+}
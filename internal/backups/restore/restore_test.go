@@ -0,0 +1,119 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	t.Run("parses unix seconds", func(t *testing.T) {
+		target, err := ParseTimestamp("1701432000")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1701432000), target.Unix())
+	})
+
+	t.Run("parses rfc3339", func(t *testing.T) {
+		target, err := ParseTimestamp("2023-12-01T12:00:00Z")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1701432000), target.Unix())
+	})
+
+	t.Run("throws error on invalid timestamp", func(t *testing.T) {
+		_, err := ParseTimestamp("yesterday")
+		assert.ErrorContains(t, err, "Invalid timestamp")
+	})
+}
+
+func TestRestoreCommand(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	target := time.Unix(1701432000, 0)
+
+	t.Run("restores to point in time", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/backups/restore-pitr").
+			JSON(api.V1RestorePitrBody{RecoveryTimeTargetUnix: 1701432000}).
+			Reply(http.StatusCreated)
+		// Run test
+		assert.NoError(t, Run(context.Background(), project, target))
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("sends exact target seconds", func(t *testing.T) {
+		target, err := ParseTimestamp("2026-10-15T08:00:30Z")
+		require.NoError(t, err)
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/backups/restore-pitr").
+			BodyString(`{"recovery_time_target_unix":1792051230}`).
+			Reply(http.StatusCreated)
+		// Run test
+		assert.NoError(t, Run(context.Background(), project, target))
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/backups/restore-pitr").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := Run(context.Background(), project, target)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+	})
+
+	t.Run("throws error when pitr is disabled", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups").
+			Reply(http.StatusOK).
+			JSON(api.V1BackupsResponse{Backups: []api.V1BackupResponse{}})
+		// Run test
+		err := PreRun(context.Background(), project, target, project)
+		// Check error
+		assert.ErrorContains(t, err, "Point-in-time recovery is not enabled")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error outside restorable window", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/database/backups").
+			Reply(http.StatusOK).
+			JSON(api.V1BackupsResponse{
+				PitrEnabled: true,
+				Backups:     []api.V1BackupResponse{},
+				PhysicalBackupData: api.V1PhysicalBackupData{
+					EarliestPhysicalBackupDateUnix: utils.Ptr(int64(1701475200)),
+					LatestPhysicalBackupDateUnix:   utils.Ptr(int64(1701561600)),
+				},
+			})
+		// Run test
+		err := PreRun(context.Background(), project, target, project)
+		// Check error
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "outside the restorable window")
+	})
+}
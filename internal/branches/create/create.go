@@ -11,12 +11,23 @@ import (
 	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, name, region string, fsys afero.Fs) error {
+var ErrMissingName = errors.New("Either a branch name or --from-git flag must be specified.")
+
+func Run(ctx context.Context, name, region string, fromGit bool, fsys afero.Fs) error {
 	ref, err := utils.LoadProjectRef(fsys)
 	if err != nil {
 		return err
 	}
 	gitBranch := keys.GetGitBranchOrDefault("", fsys)
+	if fromGit && len(name) == 0 {
+		if len(gitBranch) == 0 {
+			return errors.New("Failed to detect the current git branch.")
+		}
+		name = gitBranch
+	}
+	if len(name) == 0 {
+		return ErrMissingName
+	}
 	resp, err := utils.GetSupabase().CreateBranchWithResponse(ctx, ref, api.CreateBranchJSONRequestBody{
 		BranchName: name,
 		GitBranch:  &gitBranch,
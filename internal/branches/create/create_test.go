@@ -0,0 +1,84 @@
+package create
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateBranch(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	projectRef := apitest.RandomProjectRef()
+
+	t.Run("names branch after git branch", func(t *testing.T) {
+		t.Setenv("GITHUB_HEAD_REF", "feat/login")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(projectRef), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + projectRef + "/branches").
+			JSON(api.CreateBranchJSONRequestBody{
+				BranchName: "feat/login",
+				GitBranch:  utils.Ptr("feat/login"),
+				Region:     utils.Ptr("sin"),
+			}).
+			Reply(http.StatusCreated).
+			JSON(api.BranchResponse{Id: "test-branch"})
+		// Run test
+		err := Run(context.Background(), "", "sin", true, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("prefers explicit name over git branch", func(t *testing.T) {
+		t.Setenv("GITHUB_HEAD_REF", "feat/login")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(projectRef), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + projectRef + "/branches").
+			JSON(api.CreateBranchJSONRequestBody{
+				BranchName: "staging",
+				GitBranch:  utils.Ptr("feat/login"),
+				Region:     utils.Ptr("sin"),
+			}).
+			Reply(http.StatusCreated).
+			JSON(api.BranchResponse{Id: "test-branch"})
+		// Run test
+		err := Run(context.Background(), "staging", "sin", true, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing name", func(t *testing.T) {
+		t.Setenv("GITHUB_HEAD_REF", "feat/login")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(projectRef), 0644))
+		// Run test
+		err := Run(context.Background(), "", "sin", false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingName)
+	})
+
+	t.Run("throws error on unlinked project", func(t *testing.T) {
+		err := Run(context.Background(), "staging", "sin", false, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, utils.ErrNotLinked)
+	})
+}
@@ -4,12 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, branchId string) error {
+var errMissingCredentials = errors.New("Database credentials are not available for this preview branch.")
+
+// The branch database is only connected to when showVersion is set, since it may be paused.
+func Run(ctx context.Context, branchId string, showVersion bool, options ...func(*pgx.ConnConfig)) error {
 	resp, err := utils.GetSupabase().GetBranchDetailsWithResponse(ctx, branchId)
 	if err != nil {
 		return err
@@ -18,6 +25,15 @@ func Run(ctx context.Context, branchId string) error {
 		return errors.New("Unexpected error retrieving preview branch: " + string(resp.Body))
 	}
 
+	version := "-"
+	if config, err := ToPostgresConfig(*resp.JSON200); err == nil && showVersion {
+		if v, err := LoadMigrationVersion(ctx, config, options...); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to load migration version:", err)
+		} else if len(v) > 0 {
+			version = v
+		}
+	}
+
 	masked := "******"
 	if resp.JSON200.DbUser == nil {
 		resp.JSON200.DbUser = &masked
@@ -29,17 +45,57 @@ func Run(ctx context.Context, branchId string) error {
 		resp.JSON200.JwtSecret = &masked
 	}
 
-	table := `|HOST|PORT|USER|PASSWORD|JWT SECRET|POSTGRES VERSION|STATUS|
-|-|-|-|-|-|-|-|
+	table := `|HOST|PORT|USER|PASSWORD|JWT SECRET|POSTGRES VERSION|MIGRATION VERSION|STATUS|
+|-|-|-|-|-|-|-|-|
 ` + fmt.Sprintf(
-		"|`%s`|`%d`|`%s`|`%s`|`%s`|`%s`|`%s`|\n",
+		"|`%s`|`%d`|`%s`|`%s`|`%s`|`%s`|`%s`|`%s`|\n",
 		resp.JSON200.DbHost,
 		resp.JSON200.DbPort,
 		*resp.JSON200.DbUser,
 		*resp.JSON200.DbPass,
 		*resp.JSON200.JwtSecret,
 		resp.JSON200.PostgresVersion,
+		version,
 		resp.JSON200.Status,
 	)
 	return list.RenderTable(table)
 }
+
+// Resolves connection parameters of a preview branch database from its details.
+func GetBranchConfig(ctx context.Context, branchId string) (pgconn.Config, error) {
+	resp, err := utils.GetSupabase().GetBranchDetailsWithResponse(ctx, branchId)
+	if err != nil {
+		return pgconn.Config{}, err
+	}
+	if resp.JSON200 == nil {
+		return pgconn.Config{}, errors.New("Unexpected error retrieving preview branch: " + string(resp.Body))
+	}
+	return ToPostgresConfig(*resp.JSON200)
+}
+
+func ToPostgresConfig(branch api.BranchDetailResponse) (pgconn.Config, error) {
+	if branch.DbUser == nil || branch.DbPass == nil {
+		return pgconn.Config{}, errMissingCredentials
+	}
+	return pgconn.Config{
+		Host:     branch.DbHost,
+		Port:     uint16(branch.DbPort),
+		User:     *branch.DbUser,
+		Password: *branch.DbPass,
+		Database: "postgres",
+	}, nil
+}
+
+// Returns the latest migration version applied to a branch database, or empty if there are none.
+func LoadMigrationVersion(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) (string, error) {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close(context.Background())
+	versions, err := list.LoadRemoteMigrations(ctx, conn)
+	if err != nil || len(versions) == 0 {
+		return "", err
+	}
+	return versions[len(versions)-1], nil
+}
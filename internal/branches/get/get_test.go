@@ -0,0 +1,87 @@
+package get
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestGetBranch(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	branch := api.BranchDetailResponse{
+		DbHost:          "127.0.0.1",
+		DbPort:          5432,
+		DbUser:          utils.Ptr("postgres"),
+		DbPass:          utils.Ptr("password"),
+		PostgresVersion: "15.1.0.117",
+		Ref:             apitest.RandomProjectRef(),
+		Status:          "ACTIVE_HEALTHY",
+	}
+
+	t.Run("shows branch details without connecting", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/branches/test-branch").
+			Reply(http.StatusOK).
+			JSON(branch)
+		// Run test
+		err := Run(context.Background(), "test-branch", false, func(cc *pgx.ConnConfig) {
+			t.Error("unexpected connection to branch database")
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("shows migration version when requested", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/branches/test-branch").
+			Reply(http.StatusOK).
+			JSON(branch)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"20240101000000"})
+		// Run test
+		err := Run(context.Background(), "test-branch", true, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing branch", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/branches/test-branch").
+			Reply(http.StatusNotFound).
+			JSON(map[string]string{"message": "Branch not found"})
+		// Run test
+		err := Run(context.Background(), "test-branch", false)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving preview branch:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestBranchConfig(t *testing.T) {
+	t.Run("throws error on missing credentials", func(t *testing.T) {
+		_, err := ToPostgresConfig(api.BranchDetailResponse{DbHost: "127.0.0.1"})
+		assert.ErrorIs(t, err, errMissingCredentials)
+	})
+}
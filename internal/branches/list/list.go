@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
 )
 
 func Run(ctx context.Context, fsys afero.Fs) error {
@@ -25,6 +26,10 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 		return errors.New("Unexpected error listing preview branches: " + string(resp.Body))
 	}
 
+	if !render.IsPretty() {
+		return render.Encode(resp.JSON200)
+	}
+
 	table := `|ID|NAME|DEFAULT|GIT BRANCH|CREATED AT (UTC)|UPDATED AT (UTC)|
 |-|-|-|-|-|-|
 `
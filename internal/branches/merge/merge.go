@@ -0,0 +1,106 @@
+package merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/branches/get"
+	"github.com/supabase/cli/internal/db/diff"
+	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/migration/new"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Merges schema changes on a preview branch back to its parent database. The changes are
+// saved as a new local migration and applied to the parent unless dryRun is set.
+func Run(ctx context.Context, branchId string, dryRun bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	branchConfig, err := get.GetBranchConfig(ctx, branchId)
+	if err != nil {
+		return err
+	}
+	branch, err := utils.ConnectByConfig(ctx, branchConfig, options...)
+	if err != nil {
+		return err
+	}
+	defer branch.Close(context.Background())
+	parent, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer parent.Close(context.Background())
+	if err := printVersions(ctx, branch, parent); err != nil {
+		return err
+	}
+	out, err := DiffBranch(ctx, branch, parent)
+	if err != nil {
+		return err
+	}
+	if len(out) == 0 {
+		fmt.Fprintln(os.Stderr, "No schema changes found on preview branch.")
+		return nil
+	}
+	if dryRun {
+		fmt.Fprintln(os.Stderr, "DRY RUN: the following changes will not be applied to the parent database.")
+		fmt.Println(out)
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, out)
+	if !utils.PromptYesNo("Do you want to apply these changes to the parent database?", false, os.Stdin) {
+		return errors.New("Merge cancelled by user.")
+	}
+	path := new.GetMigrationPath(utils.GetCurrentTimestamp(), "merge_"+branchId)
+	return applyMerge(ctx, path, out, parent, fsys)
+}
+
+// Saves the diff as a local migration before applying it, so that it is tracked in version control.
+func applyMerge(ctx context.Context, path, out string, parent *pgx.Conn, fsys afero.Fs) error {
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fsys, path, []byte(out), 0644); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Created new migration at "+utils.Bold(path))
+	if err := apply.MigrateUp(ctx, parent, []string{filepath.Base(path)}, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Merged preview branch into parent database.")
+	return nil
+}
+
+// Generates DDL that brings the parent database up to date with the branch.
+func DiffBranch(ctx context.Context, branch, parent *pgx.Conn) (string, error) {
+	schema, err := diff.LoadUserSchemas(ctx, branch)
+	if err != nil {
+		return "", err
+	}
+	return diff.DiffNative(ctx, branch, parent, schema)
+}
+
+func printVersions(ctx context.Context, branch, parent *pgx.Conn) error {
+	branchVersions, err := list.LoadRemoteMigrations(ctx, branch)
+	if err != nil {
+		return err
+	}
+	parentVersions, err := list.LoadRemoteMigrations(ctx, parent)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Preview branch is at migration version:", latestVersion(branchVersions))
+	fmt.Fprintln(os.Stderr, "Parent project is at migration version:", latestVersion(parentVersions))
+	return nil
+}
+
+func latestVersion(versions []string) string {
+	if len(versions) == 0 {
+		return "none"
+	}
+	return utils.Bold(versions[len(versions)-1])
+}
@@ -0,0 +1,91 @@
+package merge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestApplyMerge(t *testing.T) {
+	sql := "create table test()"
+	path := filepath.Join(utils.MigrationsDir, "0_merge_test-branch.sql")
+
+	t.Run("saves and applies migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(repair.CREATE_VERSION_SCHEMA).
+			Reply("CREATE SCHEMA").
+			Query(repair.CREATE_VERSION_TABLE).
+			Reply("CREATE TABLE").
+			Query(repair.ADD_STATEMENTS_COLUMN).
+			Reply("ALTER TABLE").
+			Query(repair.ADD_NAME_COLUMN).
+			Reply("ALTER TABLE").
+			Query(sql).
+			Reply("CREATE TABLE").
+			Query(repair.INSERT_MIGRATION_VERSION, "0", "merge_test-branch", fmt.Sprintf("{%s}", sql)).
+			Reply("INSERT 1")
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		err = applyMerge(ctx, path, sql, mock, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, path)
+		assert.NoError(t, err)
+		assert.Equal(t, sql, string(contents))
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
+		// Run test
+		err := applyMerge(context.Background(), path, sql, nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "operation not permitted")
+	})
+}
+
+func TestMergeBranch(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("throws error on missing credentials", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/branches/test-branch").
+			Reply(http.StatusOK).
+			JSON(api.BranchDetailResponse{DbHost: "127.0.0.1", DbPort: 5432})
+		// Run test
+		err := Run(context.Background(), "test-branch", false, pgconn.Config{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Database credentials are not available")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestLatestVersion(t *testing.T) {
+	assert.Equal(t, "none", latestVersion(nil))
+	assert.Equal(t, utils.Bold("2"), latestVersion([]string{"1", "2"}))
+}
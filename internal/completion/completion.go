@@ -0,0 +1,154 @@
+package completion
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const defaultTTL = 5 * time.Minute
+
+var (
+	// Remote resources are refetched after this duration
+	cacheTTL = defaultTTL
+	// Completion should not block the shell for long when offline
+	fetchTimeout = 3 * time.Second
+)
+
+type cacheEntry struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Items     []string  `json:"items"`
+}
+
+func getCachePath(key string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".supabase", "cache", "completion", key+".json"), nil
+}
+
+// Returns cached items if fresh, otherwise refetches them. Stale items are returned when fetching fails.
+func cached(ctx context.Context, key string, fsys afero.Fs, fetch func(context.Context) ([]string, error)) []string {
+	path, err := getCachePath(key)
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if data, err := afero.ReadFile(fsys, path); err == nil {
+		_ = json.Unmarshal(data, &entry)
+	}
+	if time.Since(entry.UpdatedAt) < cacheTTL {
+		return entry.Items
+	}
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	items, err := fetch(ctx)
+	if err != nil {
+		return entry.Items
+	}
+	entry = cacheEntry{UpdatedAt: time.Now(), Items: items}
+	if data, err := json.Marshal(entry); err == nil {
+		if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err == nil {
+			_ = afero.WriteFile(fsys, path, data, 0600)
+		}
+	}
+	return items
+}
+
+// Management API requests exit the process without an access token, so check for one upfront.
+func isLoggedIn(fsys afero.Fs) bool {
+	_, err := utils.LoadAccessTokenFS(fsys)
+	return err == nil
+}
+
+// Returns project refs described by their names, ie. ref\tname.
+func ProjectRefs(ctx context.Context, fsys afero.Fs) []string {
+	key := "projects-" + utils.GetCurrentProfile(fsys)
+	return cached(ctx, key, fsys, func(ctx context.Context) ([]string, error) {
+		if !isLoggedIn(fsys) {
+			return nil, utils.ErrMissingToken
+		}
+		resp, err := utils.GetSupabase().GetProjectsWithResponse(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if resp.JSON200 == nil {
+			return nil, utils.ErrMissingToken
+		}
+		var refs []string
+		for _, p := range *resp.JSON200 {
+			refs = append(refs, p.Id+"\t"+p.Name)
+		}
+		return refs, nil
+	})
+}
+
+// Returns bucket names of the project.
+func Buckets(ctx context.Context, projectRef string, fsys afero.Fs) []string {
+	return cached(ctx, "buckets-"+projectRef, fsys, func(ctx context.Context) ([]string, error) {
+		if !isLoggedIn(fsys) {
+			return nil, utils.ErrMissingToken
+		}
+		buckets, err := client.ListStorageBuckets(ctx, projectRef)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, b := range buckets {
+			names = append(names, b.Name)
+		}
+		return names, nil
+	})
+}
+
+// Returns slugs of Functions in the local functions directory.
+func FunctionSlugs(fsys afero.Fs) []string {
+	entries, err := afero.ReadDir(fsys, utils.FunctionsDir)
+	if err != nil {
+		return nil
+	}
+	var slugs []string
+	for _, e := range entries {
+		if e.IsDir() && utils.FuncSlugPattern.MatchString(e.Name()) {
+			slugs = append(slugs, e.Name())
+		}
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// Returns versions of local migrations described by their names, ie. version\tname.
+func MigrationVersions(fsys afero.Fs) []string {
+	// Reads the directory directly because loading migrations prints warnings to stderr
+	entries, err := afero.ReadDir(fsys, utils.MigrationsDir)
+	if err != nil {
+		return nil
+	}
+	var versions []string
+	for _, e := range entries {
+		if matches := utils.MigrateFilePattern.FindStringSubmatch(e.Name()); len(matches) > 2 {
+			versions = append(versions, matches[1]+"\t"+matches[2])
+		}
+	}
+	return versions
+}
+
+// Filters items, which may contain a tab separated description, by prefix.
+func Filter(items []string, prefix string) []string {
+	var result []string
+	for _, item := range items {
+		if value, _, _ := strings.Cut(item, "\t"); strings.HasPrefix(value, prefix) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
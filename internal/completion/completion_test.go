@@ -0,0 +1,78 @@
+package completion
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestCached(t *testing.T) {
+	fetch := func(items ...string) func(context.Context) ([]string, error) {
+		return func(context.Context) ([]string, error) {
+			return items, nil
+		}
+	}
+
+	t.Run("reuses fresh cache", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.Equal(t, []string{"a"}, cached(context.Background(), "test", fsys, fetch("a")))
+		assert.Equal(t, []string{"a"}, cached(context.Background(), "test", fsys, fetch("b")))
+	})
+
+	t.Run("refetches stale cache", func(t *testing.T) {
+		cacheTTL = 0
+		defer func() { cacheTTL = defaultTTL }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.Equal(t, []string{"a"}, cached(context.Background(), "test", fsys, fetch("a")))
+		assert.Equal(t, []string{"b"}, cached(context.Background(), "test", fsys, fetch("b")))
+	})
+
+	t.Run("falls back to stale cache when offline", func(t *testing.T) {
+		cacheTTL = 0
+		defer func() { cacheTTL = defaultTTL }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.Equal(t, []string{"a"}, cached(context.Background(), "test", fsys, fetch("a")))
+		items := cached(context.Background(), "test", fsys, func(context.Context) ([]string, error) {
+			return nil, errors.New("network error")
+		})
+		assert.Equal(t, []string{"a"}, items)
+	})
+}
+
+func TestLocalResources(t *testing.T) {
+	t.Run("lists function slugs", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		for _, dir := range []string{"world", "hello", "_shared"} {
+			require.NoError(t, fsys.MkdirAll(filepath.Join(utils.FunctionsDir, dir), 0755))
+		}
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "import_map.json"), []byte("{}"), 0644))
+		// Run test
+		assert.Equal(t, []string{"hello", "world"}, FunctionSlugs(fsys))
+	})
+
+	t.Run("lists migration versions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		for _, name := range []string{"20230101000000_init.sql", "20230102000000_add_table.sql", "README.md"} {
+			require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, name), []byte(""), 0644))
+		}
+		// Run test
+		versions := MigrationVersions(fsys)
+		// Check result
+		assert.Equal(t, []string{"20230101000000\tinit", "20230102000000\tadd_table"}, versions)
+		assert.Equal(t, []string{"20230102000000\tadd_table"}, Filter(versions, "202301020"))
+	})
+}
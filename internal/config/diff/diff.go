@@ -0,0 +1,226 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+const (
+	SectionApi     = "api"
+	SectionAuth    = "auth"
+	SectionStorage = "storage"
+)
+
+var Sections = []string{SectionApi, SectionAuth, SectionStorage}
+
+// A setting whose value in config.toml differs from the hosted project.
+type Change struct {
+	Section string `json:"section"`
+	// Field name used by the management API
+	Key    string `json:"key"`
+	Local  any    `json:"local"`
+	Remote any    `json:"remote"`
+}
+
+func Run(ctx context.Context, projectRef string, sections, keys []string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	changes, err := Compare(ctx, projectRef, sections)
+	if err != nil {
+		return err
+	}
+	if changes, err = FilterKeys(changes, keys); err != nil {
+		return err
+	}
+	if !render.IsPretty() {
+		return render.Encode(changes)
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "Remote config is up to date.")
+		return nil
+	}
+	PrintChanges(changes, os.Stdout)
+	return nil
+}
+
+// Compares local config against the linked project, returning changes sorted by section and key.
+func Compare(ctx context.Context, projectRef string, sections []string) ([]Change, error) {
+	var changes []Change
+	for _, name := range sections {
+		remote, err := GetRemote(ctx, projectRef, name)
+		if err != nil {
+			return nil, err
+		}
+		local, err := normalise(Local(name))
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(local))
+		for k := range local {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			// Skip settings that are not exposed by the management API
+			r, ok := remote[k]
+			if !ok || equal(local[k], r) {
+				continue
+			}
+			changes = append(changes, Change{Section: name, Key: k, Local: local[k], Remote: r})
+		}
+	}
+	return changes, nil
+}
+
+// Keeps only changes to the given keys, each qualified by section such as auth.site_url.
+// All changes are kept if no keys are given.
+func FilterKeys(changes []Change, keys []string) ([]Change, error) {
+	if len(keys) == 0 {
+		return changes, nil
+	}
+	selected := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		section, _, found := strings.Cut(k, ".")
+		if !found || !utils.SliceContains(Sections, section) {
+			return nil, fmt.Errorf("Invalid config key %s, must be prefixed by one of: %s", utils.Aqua(k), strings.Join(Sections, ", "))
+		}
+		selected[k] = true
+	}
+	var result []Change
+	for _, c := range changes {
+		if selected[c.Section+"."+c.Key] {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func PrintChanges(changes []Change, w io.Writer) {
+	section := ""
+	for _, c := range changes {
+		if c.Section != section {
+			section = c.Section
+			fmt.Fprintln(w, utils.Bold("["+section+"]"))
+		}
+		fmt.Fprintln(w, utils.Red(fmt.Sprintf("- %s = %s", c.Key, format(c.Remote))))
+		fmt.Fprintln(w, utils.Aqua(fmt.Sprintf("+ %s = %s", c.Key, format(c.Local))))
+	}
+}
+
+// Maps local config to the request body accepted by the management API.
+func Local(section string) map[string]any {
+	switch section {
+	case SectionApi:
+		return map[string]any{
+			"db_schema":            strings.Join(utils.Config.Api.Schemas, ","),
+			"db_extra_search_path": strings.Join(utils.Config.Api.ExtraSearchPath, ","),
+			"max_rows":             utils.Config.Api.MaxRows,
+		}
+	case SectionAuth:
+		auth := utils.Config.Auth
+		body := map[string]any{
+			"site_url":                              auth.SiteUrl,
+			"uri_allow_list":                        strings.Join(auth.AdditionalRedirectUrls, ","),
+			"jwt_exp":                               auth.JwtExpiry,
+			"disable_signup":                        !auth.EnableSignup,
+			"refresh_token_rotation_enabled":        auth.EnableRefreshTokenRotation,
+			"security_refresh_token_reuse_interval": auth.RefreshTokenReuseInterval,
+			"external_email_enabled":                auth.Email.EnableSignup,
+			"mailer_secure_email_change_enabled":    auth.Email.DoubleConfirmChanges,
+			"mailer_autoconfirm":                    !auth.Email.EnableConfirmations,
+			"external_phone_enabled":                auth.Sms.EnableSignup,
+			"sms_autoconfirm":                       !auth.Sms.EnableConfirmations,
+		}
+		// Local stack sends emails to Inbucket unless a custom SMTP server is configured
+		if smtp := auth.Email.Smtp; smtp.Enabled {
+			body["smtp_host"] = smtp.Host
+			body["smtp_port"] = fmt.Sprintf("%d", smtp.Port)
+			body["smtp_user"] = smtp.User
+			// Password is excluded since the API never returns it, and diffs are printed in clear text
+			body["smtp_admin_email"] = smtp.AdminEmail
+			body["smtp_sender_name"] = smtp.SenderName
+		}
+		return body
+	case SectionStorage:
+		return map[string]any{
+			"fileSizeLimit": int64(utils.Config.Storage.FileSizeLimit),
+		}
+	}
+	return nil
+}
+
+// Fetches settings of a config section from the management API as decoded json.
+func GetRemote(ctx context.Context, projectRef, section string) (map[string]any, error) {
+	var body []byte
+	var status int
+	switch section {
+	case SectionApi:
+		resp, err := utils.GetSupabase().GetPostgRESTConfigWithResponse(ctx, projectRef)
+		if err != nil {
+			return nil, err
+		}
+		body, status = resp.Body, resp.StatusCode()
+	case SectionAuth:
+		resp, err := utils.GetSupabase().GetV1AuthConfigWithResponse(ctx, projectRef)
+		if err != nil {
+			return nil, err
+		}
+		body, status = resp.Body, resp.StatusCode()
+	case SectionStorage:
+		resp, err := utils.GetSupabase().GetStorageConfigWithResponse(ctx, projectRef)
+		if err != nil {
+			return nil, err
+		}
+		body, status = resp.Body, resp.StatusCode()
+	default:
+		return nil, fmt.Errorf("Unknown config section %s, must be one of: %s", utils.Aqua(section), strings.Join(Sections, ", "))
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to get remote %s config: %s", section, string(body))
+	}
+	var remote map[string]any
+	if err := json.Unmarshal(body, &remote); err != nil {
+		return nil, fmt.Errorf("failed to parse remote %s config: %w", section, err)
+	}
+	return remote, nil
+}
+
+// Round trips through json so that local values compare equal to decoded remote values.
+func normalise(value map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func equal(local, remote any) bool {
+	// Remote returns null for unset strings
+	if remote == nil {
+		return local == nil || local == ""
+	}
+	return format(local) == format(remote)
+}
+
+func format(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
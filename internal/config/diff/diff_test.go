@@ -0,0 +1,116 @@
+package diff
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestConfigDiff(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	projectRef := apitest.RandomProjectRef()
+
+	t.Run("reports changed settings only", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.LoadConfigFS(fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/postgrest").
+			Reply(http.StatusOK).
+			JSON(map[string]any{
+				"db_schema":            "public,storage,graphql_public",
+				"db_extra_search_path": "public,extensions",
+				"max_rows":             1000,
+			})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/auth").
+			Reply(http.StatusOK).
+			JSON(map[string]any{
+				"site_url":       "https://example.com",
+				"jwt_exp":        3600,
+				"disable_signup": false,
+				"uri_allow_list": "https://127.0.0.1:3000",
+			})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/storage").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"fileSizeLimit": 52428800})
+		// Run test
+		changes, err := Compare(context.Background(), projectRef, Sections)
+		// Check error
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []Change{{
+			Section: SectionAuth,
+			Key:     "site_url",
+			Local:   utils.Config.Auth.SiteUrl,
+			Remote:  "https://example.com",
+		}}, changes)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("excludes smtp password", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.LoadConfigFS(fsys))
+		utils.Config.Auth.Email.Smtp.Enabled = true
+		utils.Config.Auth.Email.Smtp.Pass = "hunter2"
+		// Run test
+		body := Local(SectionAuth)
+		// Check output
+		assert.Contains(t, body, "smtp_user")
+		assert.NotContains(t, body, "smtp_pass")
+	})
+
+	t.Run("filters changes by key", func(t *testing.T) {
+		changes := []Change{
+			{Section: SectionAuth, Key: "site_url"},
+			{Section: SectionAuth, Key: "jwt_exp"},
+			{Section: SectionApi, Key: "max_rows"},
+		}
+		// Run test
+		filtered, err := FilterKeys(changes, []string{"auth.jwt_exp", "api.max_rows"})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{changes[1], changes[2]}, filtered)
+	})
+
+	t.Run("throws error on unqualified key", func(t *testing.T) {
+		// Run test
+		_, err := FilterKeys(nil, []string{"site_url"})
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config key")
+	})
+
+	t.Run("throws error on unknown section", func(t *testing.T) {
+		// Run test
+		_, err := Compare(context.Background(), projectRef, []string{"invalid"})
+		// Check error
+		assert.ErrorContains(t, err, "Unknown config section")
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/storage").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := Compare(context.Background(), projectRef, []string{SectionStorage})
+		// Check error
+		assert.ErrorContains(t, err, "failed to get remote storage config:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
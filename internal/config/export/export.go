@@ -0,0 +1,185 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/supabase/cli/internal/config/diff"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const FormatTerraform = "terraform"
+
+var (
+	Formats = []string{FormatTerraform}
+
+	// Settings ending with these suffixes hold credentials that must not be written to source control
+	sensitiveSuffixes = []string{"_secret", "_secrets", "_pass", "_password", "_token", "_key"}
+)
+
+// Settings and resources of a hosted project that can be managed as code.
+type Project struct {
+	Ref      string
+	Settings map[string]map[string]any
+	// Resources not yet supported by the Terraform provider
+	Functions    []string
+	SecretNames  []string
+	Buckets      []client.BucketResponse
+	CustomDomain string
+}
+
+func Run(ctx context.Context, projectRef, format string) error {
+	if format != FormatTerraform {
+		return fmt.Errorf("Unknown format %s, must be one of: %s", utils.Aqua(format), strings.Join(Formats, ", "))
+	}
+	project, err := Introspect(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	return WriteTerraform(project, os.Stdout)
+}
+
+func Introspect(ctx context.Context, projectRef string) (Project, error) {
+	project := Project{Ref: projectRef, Settings: map[string]map[string]any{}}
+	for _, name := range diff.Sections {
+		remote, err := diff.GetRemote(ctx, projectRef, name)
+		if err != nil {
+			return Project{}, err
+		}
+		project.Settings[name] = remote
+	}
+	functions, err := utils.GetSupabase().GetFunctionsWithResponse(ctx, projectRef)
+	if err != nil {
+		return Project{}, err
+	}
+	if functions.JSON200 == nil {
+		return Project{}, errors.New("Unexpected error retrieving functions: " + string(functions.Body))
+	}
+	for _, f := range *functions.JSON200 {
+		project.Functions = append(project.Functions, f.Slug)
+	}
+	secrets, err := utils.GetSupabase().GetSecretsWithResponse(ctx, projectRef)
+	if err != nil {
+		return Project{}, err
+	}
+	if secrets.JSON200 == nil {
+		return Project{}, errors.New("Unexpected error retrieving project secrets: " + string(secrets.Body))
+	}
+	for _, s := range *secrets.JSON200 {
+		project.SecretNames = append(project.SecretNames, s.Name)
+	}
+	sort.Strings(project.SecretNames)
+	if project.Buckets, err = client.ListStorageBuckets(ctx, projectRef); err != nil {
+		return Project{}, err
+	}
+	// Custom domains are a paid add-on, so an error response means none is configured
+	hostname, err := utils.GetSupabase().GetCustomHostnameConfigWithResponse(ctx, projectRef)
+	if err != nil {
+		return Project{}, err
+	}
+	if hostname.JSON200 != nil {
+		project.CustomDomain = hostname.JSON200.CustomHostname
+	}
+	return project, nil
+}
+
+// Writes a Terraform configuration that imports the project settings into the supabase provider.
+func WriteTerraform(project Project, w io.Writer) error {
+	// Ref is always lowercase letters, hence a valid resource name
+	name := project.Ref
+	var b strings.Builder
+	b.WriteString(`terraform {
+  required_providers {
+    supabase = {
+      source  = "supabase/supabase"
+      version = "~> 1.0"
+    }
+  }
+}
+
+`)
+	fmt.Fprintf(&b, "import {\n  to = supabase_settings.%s\n  id = %s\n}\n\n", name, literal(project.Ref))
+	fmt.Fprintf(&b, "resource \"supabase_settings\" %s {\n  project_ref = %s\n", literal(name), literal(project.Ref))
+	for _, section := range diff.Sections {
+		settings := project.Settings[section]
+		if len(settings) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n  %s = jsonencode({\n", section)
+		writeObject(&b, settings, "    ")
+		b.WriteString("  })\n")
+	}
+	b.WriteString("}\n")
+	// Resources without provider support are kept as locals so they can be reviewed alongside settings
+	b.WriteString("\n# The following resources are not yet managed by the Terraform provider.\nlocals {\n")
+	fmt.Fprintf(&b, "  functions = %s\n", list(project.Functions))
+	fmt.Fprintf(&b, "  # Secret values are not exported\n  secret_names = %s\n", list(project.SecretNames))
+	b.WriteString("  buckets = {\n")
+	for _, bucket := range project.Buckets {
+		fmt.Fprintf(&b, "    %s = {\n      public = %t\n", literal(bucket.Name), bucket.Public)
+		if bucket.FileSizeLimit != nil {
+			fmt.Fprintf(&b, "      file_size_limit = %d\n", *bucket.FileSizeLimit)
+		}
+		if len(bucket.AllowedMimeTypes) > 0 {
+			fmt.Fprintf(&b, "      allowed_mime_types = %s\n", list(bucket.AllowedMimeTypes))
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("  }\n")
+	if len(project.CustomDomain) > 0 {
+		fmt.Fprintf(&b, "  custom_domain = %s\n", literal(project.CustomDomain))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeObject(b *strings.Builder, settings map[string]any, indent string) {
+	keys := make([]string, 0, len(settings))
+	for k, v := range settings {
+		// Unset values are left to the provider defaults
+		if v != nil && !isSensitive(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s%s = %s\n", indent, k, literal(settings[k]))
+	}
+}
+
+func isSensitive(key string) bool {
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Encodes a json value as an HCL literal, which shares json syntax apart from template sequences.
+func literal(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return literal(fmt.Sprint(value))
+	}
+	return escape(string(data))
+}
+
+func list(values []string) string {
+	if len(values) == 0 {
+		return "[]"
+	}
+	return literal(values)
+}
+
+func escape(value string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(value)
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestWriteTerraform(t *testing.T) {
+	t.Run("writes settings and import block", func(t *testing.T) {
+		limit := 1024
+		project := Project{
+			Ref: "abcdefghijklmnopqrst",
+			Settings: map[string]map[string]any{
+				"auth": {
+					"site_url":                  "http://${host}",
+					"jwt_exp":                   3600.0,
+					"smtp_pass":                 "hunter2",
+					"disable_signup":            false,
+					"external_github_client_id": nil,
+				},
+			},
+			Functions:   []string{"hello"},
+			SecretNames: []string{"STRIPE_KEY"},
+			Buckets: []client.BucketResponse{{
+				Name:          "avatars",
+				Public:        true,
+				FileSizeLimit: &limit,
+			}},
+			CustomDomain: "api.example.com",
+		}
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, WriteTerraform(project, &out))
+		// Check output
+		assert.Contains(t, out.String(), "import {\n  to = supabase_settings.abcdefghijklmnopqrst\n  id = \"abcdefghijklmnopqrst\"\n}")
+		assert.Contains(t, out.String(), `  auth = jsonencode({
+    disable_signup = false
+    jwt_exp = 3600
+    site_url = "http://$${host}"
+  })`)
+		assert.NotContains(t, out.String(), "hunter2")
+		assert.NotContains(t, out.String(), "external_github_client_id")
+		assert.Contains(t, out.String(), `functions = ["hello"]`)
+		assert.Contains(t, out.String(), `secret_names = ["STRIPE_KEY"]`)
+		assert.Contains(t, out.String(), "\"avatars\" = {\n      public = true\n      file_size_limit = 1024\n    }")
+		assert.Contains(t, out.String(), `custom_domain = "api.example.com"`)
+	})
+}
+
+func TestIntrospect(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/postgrest").
+			ReplyError(errors.New("network error"))
+		// Run test
+		_, err := Introspect(context.Background(), project)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on functions unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		for _, path := range []string{"/postgrest", "/config/auth", "/config/storage"} {
+			gock.New(utils.DefaultApiHost).
+				Get("/v1/projects/" + project + path).
+				Reply(http.StatusOK).
+				JSON(map[string]any{})
+		}
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := Introspect(context.Background(), project)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving functions:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,96 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/config/diff"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Updates the linked project with local settings, prompting for confirmation of each section.
+// Setting keys restricts the update to those settings, such as auth.site_url.
+func Run(ctx context.Context, projectRef string, sections, keys []string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	changes, err := diff.Compare(ctx, projectRef, sections)
+	if err != nil {
+		return err
+	}
+	if changes, err = diff.FilterKeys(changes, keys); err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "Remote config is up to date.")
+		return nil
+	}
+	for _, name := range sections {
+		var pending []diff.Change
+		for _, c := range changes {
+			if c.Section == name {
+				pending = append(pending, c)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+		diff.PrintChanges(pending, os.Stderr)
+		if !utils.PromptYesNo("Do you want to update "+utils.Aqua(name)+" config of project "+utils.Aqua(projectRef)+"?", false, os.Stdin) {
+			fmt.Fprintln(os.Stderr, "Skipped updating", name, "config.")
+			continue
+		}
+		// Only send changed keys so that settings managed elsewhere are left intact
+		body := map[string]any{}
+		for _, c := range pending {
+			body[c.Key] = c.Local
+		}
+		if err := update(ctx, projectRef, name, body); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Updated", name, "config.")
+	}
+	return nil
+}
+
+func update(ctx context.Context, projectRef, section string, body map[string]any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	const contentType = "application/json"
+	var respBody []byte
+	var status int
+	switch section {
+	case diff.SectionApi:
+		resp, err := utils.GetSupabase().UpdatePostgRESTConfigWithBodyWithResponse(ctx, projectRef, contentType, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		respBody, status = resp.Body, resp.StatusCode()
+	case diff.SectionAuth:
+		resp, err := utils.GetSupabase().UpdateV1AuthConfigWithBodyWithResponse(ctx, projectRef, contentType, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		respBody, status = resp.Body, resp.StatusCode()
+	case diff.SectionStorage:
+		resp, err := utils.GetSupabase().UpdateStorageConfigWithBodyWithResponse(ctx, projectRef, contentType, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		respBody, status = resp.Body, resp.StatusCode()
+	default:
+		return errors.New("Unknown config section: " + section)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("failed to update %s config: %s", section, string(respBody))
+	}
+	return nil
+}
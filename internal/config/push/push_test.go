@@ -0,0 +1,130 @@
+package push
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/config/diff"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestConfigPush(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	projectRef := apitest.RandomProjectRef()
+
+	t.Run("updates changed keys only", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/storage").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"fileSizeLimit": 1024})
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + projectRef + "/config/storage").
+			JSON(map[string]any{"fileSizeLimit": 52428800}).
+			Reply(http.StatusOK).
+			JSON(map[string]any{"fileSizeLimit": 52428800})
+		// Run test
+		err := Run(context.Background(), projectRef, []string{diff.SectionStorage}, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips update when up to date", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/storage").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"fileSizeLimit": 52428800})
+		// Run test
+		err := Run(context.Background(), projectRef, []string{diff.SectionStorage}, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("updates selected keys only", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/auth").
+			Reply(http.StatusOK).
+			JSON(map[string]any{
+				"site_url": "https://example.com",
+				"jwt_exp":  60,
+			})
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + projectRef + "/config/auth").
+			JSON(map[string]any{"jwt_exp": 3600}).
+			Reply(http.StatusOK).
+			JSON(map[string]any{"jwt_exp": 3600})
+		// Run test
+		err := Run(context.Background(), projectRef, []string{diff.SectionAuth}, []string{"auth.jwt_exp"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips update by default", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/storage").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"fileSizeLimit": 1024})
+		// Run test
+		err := Run(context.Background(), projectRef, []string{diff.SectionStorage}, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on update failure", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/storage").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"fileSizeLimit": 1024})
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + projectRef + "/config/storage").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), projectRef, []string{diff.SectionStorage}, nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to update storage config:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,48 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	if err := utils.AssertSupabaseCliIsSetUpFS(fsys); err != nil {
+		return err
+	}
+	data, err := afero.ReadFile(fsys, utils.ConfigPath)
+	if err != nil {
+		return err
+	}
+	upgraded, changes, err := utils.UpgradeConfig(string(data))
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, utils.Bold(utils.ConfigPath), "is already up to date.")
+		return nil
+	}
+	if err := afero.WriteFile(fsys, utils.ConfigPath, []byte(upgraded), 0644); err != nil {
+		return err
+	}
+	printSummary(os.Stdout, changes)
+	fmt.Fprintln(os.Stderr, "Finished upgrading", utils.Bold(utils.ConfigPath)+".")
+	return nil
+}
+
+func printSummary(w io.Writer, changes []utils.ConfigChange) {
+	for _, c := range changes {
+		switch {
+		case c.Conflict:
+			fmt.Fprintf(w, "Removed %s because %s is already set\n", utils.Aqua(c.OldPath), utils.Aqua(c.NewPath))
+		case c.OldPath == c.NewPath:
+			fmt.Fprintf(w, "Updated %s: %s => %s\n", utils.Aqua(c.OldPath), c.OldValue, c.NewValue)
+		default:
+			fmt.Fprintf(w, "Renamed %s => %s\n", utils.Aqua(c.OldPath), utils.Aqua(c.NewPath))
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package upgrade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestUpgradeCommand(t *testing.T) {
+	t.Run("rewrites deprecated keys", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+
+[db]
+port = 54322
+pooler_enabled = true
+`), 0644))
+		// Run test
+		assert.NoError(t, Run(context.Background(), fsys))
+		// Check output
+		data, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `project_id = "test"
+
+[db]
+port = 54322
+
+[db.pooler]
+enabled = true
+`, string(data))
+	})
+
+	t.Run("skips up to date config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		config := []byte("[db]\nport = 54322\n")
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, config, 0644))
+		// Run test
+		assert.NoError(t, Run(context.Background(), fsys))
+		// Check output
+		data, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, config, data)
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Cannot find supabase/config.toml")
+	})
+}
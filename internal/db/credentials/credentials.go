@@ -0,0 +1,193 @@
+package credentials
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/utils/tenant"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const (
+	ModeDirect      = "direct"
+	ModeSession     = "session"
+	ModeTransaction = "transaction"
+
+	FormatUri    = "uri"
+	FormatEnv    = "env"
+	FormatPgpass = "pgpass"
+
+	passwordLength = 32
+	passwordChars  = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+var (
+	Modes   = []string{ModeDirect, ModeSession, ModeTransaction}
+	Formats = []string{FormatUri, FormatEnv, FormatPgpass}
+)
+
+// Prints connection info of the local database, or the linked project when projectRef is set.
+func Run(ctx context.Context, projectRef, mode, format string, rotate bool, fsys afero.Fs) error {
+	var config pgconn.Config
+	var err error
+	if len(projectRef) == 0 {
+		if rotate {
+			return errors.New("Rotating the password of the local database is not supported.")
+		}
+		config, err = GetLocalConfig(mode, fsys)
+	} else {
+		config, err = GetLinkedConfig(ctx, projectRef, mode, rotate)
+	}
+	if err != nil {
+		return err
+	}
+	return Print(config, format, os.Stdout)
+}
+
+func GetLocalConfig(mode string, fsys afero.Fs) (pgconn.Config, error) {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return pgconn.Config{}, err
+	}
+	config := pgconn.Config{
+		Host:     "127.0.0.1",
+		Port:     uint16(utils.Config.Db.Port),
+		User:     "postgres",
+		Password: utils.Config.Db.Password,
+		Database: "postgres",
+	}
+	if mode == ModeDirect {
+		return config, nil
+	}
+	pooler := utils.Config.Db.Pooler
+	if !pooler.Enabled {
+		return pgconn.Config{}, errors.New("Local pooler is not enabled. Set " + utils.Aqua("db.pooler.enabled") + " in " + utils.Bold(utils.ConfigPath) + " or use " + utils.Aqua("--mode direct") + ".")
+	}
+	if err := assertPoolMode(mode, string(pooler.PoolMode)); err != nil {
+		return pgconn.Config{}, err
+	}
+	config.Port = pooler.Port
+	return config, nil
+}
+
+func GetLinkedConfig(ctx context.Context, projectRef, mode string, rotate bool) (pgconn.Config, error) {
+	config := pgconn.Config{
+		Host:     utils.GetSupabaseDbHost(projectRef),
+		Port:     5432,
+		User:     "postgres",
+		Database: "postgres",
+	}
+	// Port 6543 is served by the project's pooler in its configured pool mode
+	if mode != ModeDirect {
+		resp, err := utils.GetSupabase().V1GetPgbouncerConfigWithResponse(ctx, projectRef)
+		if err != nil {
+			return pgconn.Config{}, err
+		}
+		if resp.JSON200 == nil {
+			return pgconn.Config{}, fmt.Errorf("%w: %s", tenant.ErrAuthToken, string(resp.Body))
+		}
+		if resp.JSON200.PoolMode != nil {
+			if err := assertPoolMode(mode, string(*resp.JSON200.PoolMode)); err != nil {
+				return pgconn.Config{}, err
+			}
+		}
+		config.Port = 6543
+	}
+	var err error
+	if rotate {
+		config.Password, err = rotatePassword(ctx, projectRef)
+	} else {
+		config.Password, err = flags.GetPassword(projectRef)
+	}
+	return config, err
+}
+
+func assertPoolMode(mode, poolMode string) error {
+	if mode == poolMode {
+		return nil
+	}
+	return fmt.Errorf("Pooler is configured in %s mode. Change the pool mode or use %s instead.", utils.Aqua(poolMode), utils.Aqua("--mode "+poolMode))
+}
+
+// Sets a random database password and saves it to the credential store for subsequent commands.
+func rotatePassword(ctx context.Context, projectRef string) (string, error) {
+	if !utils.PromptYesNo("Do you want to rotate the database password of project "+utils.Aqua(projectRef)+"? Clients using the current password will fail to connect.", false, os.Stdin) {
+		return "", errors.New("Not rotating database password.")
+	}
+	password, err := generatePassword()
+	if err != nil {
+		return "", err
+	}
+	resp, err := utils.GetSupabase().V1UpdateDatabasePasswordWithResponse(ctx, projectRef, api.V1UpdateDatabasePasswordJSONRequestBody{
+		Password: password,
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.JSON200 == nil {
+		return "", errors.New("Failed to update database password: " + string(resp.Body))
+	}
+	if err := credentials.Set(projectRef, password); err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to save database password:", err)
+	}
+	fmt.Fprintln(os.Stderr, "Rotated database password of project:", utils.Aqua(projectRef))
+	return password, nil
+}
+
+func generatePassword() (string, error) {
+	max := big.NewInt(int64(len(passwordChars)))
+	password := make([]byte, passwordLength)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordChars[n.Int64()]
+	}
+	return string(password), nil
+}
+
+func ToUri(config pgconn.Config) string {
+	return fmt.Sprintf(
+		"postgresql://%s@%s:%d/%s",
+		url.UserPassword(config.User, config.Password),
+		config.Host,
+		config.Port,
+		url.PathEscape(config.Database),
+	)
+}
+
+func Print(config pgconn.Config, format string, w io.Writer) error {
+	switch format {
+	case FormatUri:
+		_, err := fmt.Fprintln(w, ToUri(config))
+		return err
+	case FormatEnv:
+		return utils.EncodeOutput(utils.OutputEnv, w, map[string]string{
+			"PGHOST":       config.Host,
+			"PGPORT":       strconv.FormatUint(uint64(config.Port), 10),
+			"PGUSER":       config.User,
+			"PGPASSWORD":   config.Password,
+			"PGDATABASE":   config.Database,
+			"DATABASE_URL": ToUri(config),
+		})
+	case FormatPgpass:
+		// Ref: https://www.postgresql.org/docs/current/libpq-pgpass.html
+		escape := strings.NewReplacer(`\`, `\\`, `:`, `\:`).Replace
+		_, err := fmt.Fprintf(w, "%s:%d:%s:%s:%s\n", escape(config.Host), config.Port, escape(config.Database), escape(config.User), escape(config.Password))
+		return err
+	}
+	return fmt.Errorf("Unknown format %s, must be one of: %s", utils.Aqua(format), strings.Join(Formats, ", "))
+}
@@ -0,0 +1,276 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Diff returns DDL statements that migrate target to match source. Statements are ordered so that
+// dependencies are created before their dependents and dropped after them.
+func Diff(source, target Catalog) []string {
+	var creates, alters, drops []string
+	// Schemas
+	for _, s := range source.Schemas {
+		if !contains(target.Schemas, s) {
+			creates = append(creates, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;", pgx.Identifier{s}.Sanitize()))
+		}
+	}
+	// Enums
+	for _, name := range sortedKeys(source.Enums) {
+		labels := source.Enums[name]
+		existing, ok := target.Enums[name]
+		if !ok {
+			creates = append(creates, fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);", name, quoteLiterals(labels)))
+			continue
+		}
+		for _, l := range labels {
+			if !contains(existing, l) {
+				creates = append(creates, fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s;", name, quoteLiteral(l)))
+			}
+		}
+	}
+	for _, name := range sortedKeys(target.Enums) {
+		if _, ok := source.Enums[name]; !ok {
+			drops = append(drops, fmt.Sprintf("DROP TYPE IF EXISTS %s;", name))
+		}
+	}
+	// Tables and columns
+	var fkeys []string
+	for _, name := range sortedKeys(source.Tables) {
+		table := source.Tables[name]
+		existing, ok := target.Tables[name]
+		if !ok {
+			creates = append(creates, createTable(name, table))
+			existing = &Table{RLS: false, Constraints: map[string]Constraint{}}
+		} else {
+			alters = append(alters, alterColumns(name, table, existing)...)
+		}
+		if table.RLS != existing.RLS {
+			action := "ENABLE"
+			if !table.RLS {
+				action = "DISABLE"
+			}
+			alters = append(alters, fmt.Sprintf("ALTER TABLE %s %s ROW LEVEL SECURITY;", name, action))
+		}
+		for _, c := range sortedKeys(table.Constraints) {
+			constraint := table.Constraints[c]
+			if old, ok := existing.Constraints[c]; ok && old == constraint {
+				continue
+			} else if ok {
+				drops = append(drops, dropConstraint(name, c))
+			}
+			stmt := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;", name, pgx.Identifier{c}.Sanitize(), constraint.Definition)
+			// Foreign keys may reference tables created later
+			if constraint.Type == "f" {
+				fkeys = append(fkeys, stmt)
+			} else {
+				alters = append(alters, stmt)
+			}
+		}
+		for _, c := range sortedKeys(existing.Constraints) {
+			if _, ok := table.Constraints[c]; !ok {
+				drops = append(drops, dropConstraint(name, c))
+			}
+		}
+	}
+	alters = append(alters, fkeys...)
+	for _, name := range sortedKeys(target.Tables) {
+		if _, ok := source.Tables[name]; !ok {
+			drops = append(drops, fmt.Sprintf("DROP TABLE IF EXISTS %s;", name))
+		}
+	}
+	// Indexes
+	for _, name := range sortedKeys(source.Indexes) {
+		def := source.Indexes[name]
+		if old, ok := target.Indexes[name]; ok && old == def {
+			continue
+		} else if ok {
+			drops = append(drops, fmt.Sprintf("DROP INDEX IF EXISTS %s;", name))
+		}
+		alters = append(alters, def+";")
+	}
+	for _, name := range sortedKeys(target.Indexes) {
+		if _, ok := source.Indexes[name]; !ok {
+			drops = append(drops, fmt.Sprintf("DROP INDEX IF EXISTS %s;", name))
+		}
+	}
+	// Functions are created before views because views may call them
+	for _, name := range sortedKeys(source.Functions) {
+		def := source.Functions[name]
+		if old, ok := target.Functions[name]; !ok || old != def {
+			alters = append(alters, strings.TrimSuffix(def, ";")+";")
+		}
+	}
+	for _, name := range sortedKeys(target.Functions) {
+		if _, ok := source.Functions[name]; !ok {
+			drops = append(drops, fmt.Sprintf("DROP FUNCTION IF EXISTS %s;", name))
+		}
+	}
+	// Views
+	for _, name := range sortedKeys(source.Views) {
+		def := source.Views[name]
+		if old, ok := target.Views[name]; !ok || old != def {
+			alters = append(alters, fmt.Sprintf("CREATE OR REPLACE VIEW %s AS\n%s", name, strings.TrimSuffix(def, ";")+";"))
+		}
+	}
+	for _, name := range sortedKeys(target.Views) {
+		if _, ok := source.Views[name]; !ok {
+			drops = append(drops, fmt.Sprintf("DROP VIEW IF EXISTS %s;", name))
+		}
+	}
+	// Policies are recreated on change since not all attributes can be altered
+	for _, name := range sortedKeys(source.Policies) {
+		policy := source.Policies[name]
+		if old, ok := target.Policies[name]; ok && equalPolicy(old, policy) {
+			continue
+		} else if ok {
+			drops = append(drops, dropPolicy(old))
+		}
+		alters = append(alters, createPolicy(policy))
+	}
+	for _, name := range sortedKeys(target.Policies) {
+		if _, ok := source.Policies[name]; !ok {
+			drops = append(drops, dropPolicy(target.Policies[name]))
+		}
+	}
+	// Drops run first in reverse order so that dependents are removed before their dependencies
+	var result []string
+	for i := len(drops) - 1; i >= 0; i-- {
+		result = append(result, drops[i])
+	}
+	result = append(result, creates...)
+	return append(result, alters...)
+}
+
+func createTable(name string, table *Table) string {
+	var columns []string
+	for _, c := range table.Columns {
+		columns = append(columns, "    "+columnDefinition(c))
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n);", name, strings.Join(columns, ",\n"))
+}
+
+func columnDefinition(c Column) string {
+	def := pgx.Identifier{c.Name}.Sanitize() + " " + c.Type
+	if len(c.Default) > 0 {
+		def += " DEFAULT " + c.Default
+	}
+	if c.NotNull {
+		def += " NOT NULL"
+	}
+	return def
+}
+
+func alterColumns(name string, source, target *Table) []string {
+	var result []string
+	existing := map[string]Column{}
+	for _, c := range target.Columns {
+		existing[c.Name] = c
+	}
+	for _, c := range source.Columns {
+		old, ok := existing[c.Name]
+		if !ok {
+			result = append(result, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", name, columnDefinition(c)))
+			continue
+		}
+		delete(existing, c.Name)
+		column := pgx.Identifier{c.Name}.Sanitize()
+		if old.Type != c.Type {
+			result = append(result, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;", name, column, c.Type, column, c.Type))
+		}
+		if old.Default != c.Default {
+			if len(c.Default) > 0 {
+				result = append(result, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", name, column, c.Default))
+			} else {
+				result = append(result, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", name, column))
+			}
+		}
+		if old.NotNull != c.NotNull {
+			action := "DROP"
+			if c.NotNull {
+				action = "SET"
+			}
+			result = append(result, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s NOT NULL;", name, column, action))
+		}
+	}
+	for _, c := range target.Columns {
+		if _, ok := existing[c.Name]; ok {
+			result = append(result, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", name, pgx.Identifier{c.Name}.Sanitize()))
+		}
+	}
+	return result
+}
+
+func dropConstraint(table, name string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", table, pgx.Identifier{name}.Sanitize())
+}
+
+func createPolicy(p Policy) string {
+	var roles []string
+	for _, r := range p.Roles {
+		if r == "public" {
+			roles = append(roles, r)
+		} else {
+			roles = append(roles, pgx.Identifier{r}.Sanitize())
+		}
+	}
+	stmt := fmt.Sprintf("CREATE POLICY %s ON %s AS %s FOR %s TO %s",
+		pgx.Identifier{p.Name}.Sanitize(),
+		QualifiedName(p.Schema, p.Table),
+		p.Permissive,
+		p.Command,
+		strings.Join(roles, ", "),
+	)
+	if len(p.Using) > 0 {
+		stmt += " USING (" + p.Using + ")"
+	}
+	if len(p.WithCheck) > 0 {
+		stmt += " WITH CHECK (" + p.WithCheck + ")"
+	}
+	return stmt + ";"
+}
+
+func dropPolicy(p Policy) string {
+	return fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s;", pgx.Identifier{p.Name}.Sanitize(), QualifiedName(p.Schema, p.Table))
+}
+
+func equalPolicy(a, b Policy) bool {
+	return a.Permissive == b.Permissive &&
+		a.Command == b.Command &&
+		a.Using == b.Using &&
+		a.WithCheck == b.WithCheck &&
+		strings.Join(a.Roles, ",") == strings.Join(b.Roles, ",")
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func quoteLiterals(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quoteLiteral(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
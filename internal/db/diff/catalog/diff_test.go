@@ -0,0 +1,109 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCatalog(t *testing.T) {
+	t.Run("creates missing objects", func(t *testing.T) {
+		source := Catalog{
+			Schemas: []string{"public", "private"},
+			Enums:   map[string][]string{`"public"."status"`: {"active", "it's done"}},
+			Tables: map[string]*Table{
+				`"private"."todos"`: {
+					Schema: "private",
+					Name:   "todos",
+					RLS:    true,
+					Columns: []Column{
+						{Name: "id", Type: "bigint", NotNull: true},
+						{Name: "owner", Type: "uuid", Default: "auth.uid()"},
+					},
+					Constraints: map[string]Constraint{
+						"todos_pkey":     {Type: "p", Definition: "PRIMARY KEY (id)"},
+						"todos_owner_fk": {Type: "f", Definition: "FOREIGN KEY (owner) REFERENCES auth.users(id)"},
+					},
+				},
+			},
+			Policies: map[string]Policy{
+				`"private"."todos"."owner"`: {
+					Schema:     "private",
+					Table:      "todos",
+					Name:       "owner",
+					Permissive: "PERMISSIVE",
+					Roles:      []string{"authenticated"},
+					Command:    "SELECT",
+					Using:      "(auth.uid() = owner)",
+				},
+			},
+		}
+		target := Catalog{Schemas: []string{"public"}}
+		// Run test
+		stmts := Diff(source, target)
+		// Check output
+		assert.Equal(t, []string{
+			`CREATE SCHEMA IF NOT EXISTS "private";`,
+			`CREATE TYPE "public"."status" AS ENUM ('active', 'it''s done');`,
+			"CREATE TABLE \"private\".\"todos\" (\n    \"id\" bigint NOT NULL,\n    \"owner\" uuid DEFAULT auth.uid()\n);",
+			`ALTER TABLE "private"."todos" ENABLE ROW LEVEL SECURITY;`,
+			`ALTER TABLE "private"."todos" ADD CONSTRAINT "todos_pkey" PRIMARY KEY (id);`,
+			`ALTER TABLE "private"."todos" ADD CONSTRAINT "todos_owner_fk" FOREIGN KEY (owner) REFERENCES auth.users(id);`,
+			`CREATE POLICY "owner" ON "private"."todos" AS PERMISSIVE FOR SELECT TO "authenticated" USING ((auth.uid() = owner));`,
+		}, stmts)
+	})
+
+	t.Run("alters changed objects", func(t *testing.T) {
+		source := Catalog{
+			Schemas: []string{"public"},
+			Enums:   map[string][]string{`"public"."status"`: {"active", "archived"}},
+			Tables: map[string]*Table{
+				`"public"."todos"`: {
+					Columns:     []Column{{Name: "id", Type: "bigint", NotNull: true}, {Name: "done", Type: "boolean", Default: "false"}},
+					Constraints: map[string]Constraint{},
+				},
+			},
+			Indexes:   map[string]string{`"public"."todos_done_idx"`: "CREATE INDEX todos_done_idx ON public.todos USING btree (done)"},
+			Functions: map[string]string{`"public"."noop"()`: "CREATE OR REPLACE FUNCTION public.noop()\n RETURNS void\n LANGUAGE sql\nAS $function$select 2$function$"},
+		}
+		target := Catalog{
+			Schemas: []string{"public"},
+			Enums:   map[string][]string{`"public"."status"`: {"active"}},
+			Tables: map[string]*Table{
+				`"public"."todos"`: {
+					Columns:     []Column{{Name: "id", Type: "integer"}, {Name: "title", Type: "text"}},
+					Constraints: map[string]Constraint{"todos_title_check": {Type: "c", Definition: "CHECK (length(title) > 0)"}},
+				},
+				`"public"."legacy"`: {Constraints: map[string]Constraint{}},
+			},
+			Views: map[string]string{`"public"."stale"`: "SELECT 1;"},
+			Functions: map[string]string{
+				`"public"."noop"()`: "CREATE OR REPLACE FUNCTION public.noop()\n RETURNS void\n LANGUAGE sql\nAS $function$select 1$function$",
+			},
+		}
+		// Run test
+		stmts := Diff(source, target)
+		// Check output
+		assert.Equal(t, []string{
+			`DROP VIEW IF EXISTS "public"."stale";`,
+			`DROP TABLE IF EXISTS "public"."legacy";`,
+			`ALTER TABLE "public"."todos" DROP CONSTRAINT IF EXISTS "todos_title_check";`,
+			`ALTER TYPE "public"."status" ADD VALUE IF NOT EXISTS 'archived';`,
+			`ALTER TABLE "public"."todos" ALTER COLUMN "id" TYPE bigint USING "id"::bigint;`,
+			`ALTER TABLE "public"."todos" ALTER COLUMN "id" SET NOT NULL;`,
+			`ALTER TABLE "public"."todos" ADD COLUMN "done" boolean DEFAULT false;`,
+			`ALTER TABLE "public"."todos" DROP COLUMN "title";`,
+			`CREATE INDEX todos_done_idx ON public.todos USING btree (done);`,
+			"CREATE OR REPLACE FUNCTION public.noop()\n RETURNS void\n LANGUAGE sql\nAS $function$select 2$function$;",
+		}, stmts)
+	})
+
+	t.Run("returns empty on identical catalogs", func(t *testing.T) {
+		c := Catalog{
+			Schemas:  []string{"public"},
+			Tables:   map[string]*Table{`"public"."todos"`: {Columns: []Column{{Name: "id", Type: "bigint"}}}},
+			Policies: map[string]Policy{`"public"."todos"."p"`: {Name: "p", Roles: []string{"public"}, Command: "ALL"}},
+		}
+		assert.Empty(t, Diff(c, c))
+	})
+}
@@ -0,0 +1,201 @@
+// Package catalog introspects Postgres system catalogs and generates DDL to reconcile two databases
+// without relying on external diff tools.
+package catalog
+
+import (
+	"context"
+	_ "embed"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type Column struct {
+	Name    string
+	Type    string
+	NotNull bool
+	Default string
+}
+
+type Table struct {
+	Schema      string
+	Name        string
+	RLS         bool
+	Columns     []Column
+	Constraints map[string]Constraint
+}
+
+type Constraint struct {
+	// One of c (check), f (foreign key), p (primary key), u (unique), x (exclusion)
+	Type       string
+	Definition string
+}
+
+type Policy struct {
+	Schema     string
+	Table      string
+	Name       string
+	Permissive string
+	Roles      []string
+	Command    string
+	Using      string
+	WithCheck  string
+}
+
+// Catalog is a snapshot of user defined objects in the inspected schemas. Maps are keyed by the
+// sanitized qualified name of each object.
+type Catalog struct {
+	Schemas   []string
+	Enums     map[string][]string
+	Tables    map[string]*Table
+	Indexes   map[string]string
+	Views     map[string]string
+	Functions map[string]string
+	Policies  map[string]Policy
+}
+
+var (
+	//go:embed queries/schemas.sql
+	SchemasQuery string
+	//go:embed queries/tables.sql
+	TablesQuery string
+	//go:embed queries/columns.sql
+	ColumnsQuery string
+	//go:embed queries/constraints.sql
+	ConstraintsQuery string
+	//go:embed queries/indexes.sql
+	IndexesQuery string
+	//go:embed queries/views.sql
+	ViewsQuery string
+	//go:embed queries/functions.sql
+	FunctionsQuery string
+	//go:embed queries/enums.sql
+	EnumsQuery string
+	//go:embed queries/policies.sql
+	PoliciesQuery string
+)
+
+func Inspect(ctx context.Context, conn *pgx.Conn, schemas []string) (Catalog, error) {
+	result := Catalog{
+		Enums:     map[string][]string{},
+		Tables:    map[string]*Table{},
+		Indexes:   map[string]string{},
+		Views:     map[string]string{},
+		Functions: map[string]string{},
+		Policies:  map[string]Policy{},
+	}
+	if err := queryRows(ctx, conn, SchemasQuery, schemas, func(rows pgx.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		result.Schemas = append(result.Schemas, name)
+		return nil
+	}); err != nil {
+		return result, err
+	}
+	if err := queryRows(ctx, conn, EnumsQuery, schemas, func(rows pgx.Rows) error {
+		var schema, name string
+		var labels []string
+		if err := rows.Scan(&schema, &name, &labels); err != nil {
+			return err
+		}
+		result.Enums[QualifiedName(schema, name)] = labels
+		return nil
+	}); err != nil {
+		return result, err
+	}
+	if err := queryRows(ctx, conn, TablesQuery, schemas, func(rows pgx.Rows) error {
+		table := Table{Constraints: map[string]Constraint{}}
+		if err := rows.Scan(&table.Schema, &table.Name, &table.RLS); err != nil {
+			return err
+		}
+		result.Tables[QualifiedName(table.Schema, table.Name)] = &table
+		return nil
+	}); err != nil {
+		return result, err
+	}
+	if err := queryRows(ctx, conn, ColumnsQuery, schemas, func(rows pgx.Rows) error {
+		var schema, table string
+		var column Column
+		if err := rows.Scan(&schema, &table, &column.Name, &column.Type, &column.NotNull, &column.Default); err != nil {
+			return err
+		}
+		if t, ok := result.Tables[QualifiedName(schema, table)]; ok {
+			t.Columns = append(t.Columns, column)
+		}
+		return nil
+	}); err != nil {
+		return result, err
+	}
+	if err := queryRows(ctx, conn, ConstraintsQuery, schemas, func(rows pgx.Rows) error {
+		var schema, table, name string
+		var constraint Constraint
+		if err := rows.Scan(&schema, &table, &name, &constraint.Type, &constraint.Definition); err != nil {
+			return err
+		}
+		if t, ok := result.Tables[QualifiedName(schema, table)]; ok {
+			t.Constraints[name] = constraint
+		}
+		return nil
+	}); err != nil {
+		return result, err
+	}
+	if err := queryRows(ctx, conn, IndexesQuery, schemas, func(rows pgx.Rows) error {
+		var schema, name, definition string
+		if err := rows.Scan(&schema, &name, &definition); err != nil {
+			return err
+		}
+		result.Indexes[QualifiedName(schema, name)] = definition
+		return nil
+	}); err != nil {
+		return result, err
+	}
+	if err := queryRows(ctx, conn, ViewsQuery, schemas, func(rows pgx.Rows) error {
+		var schema, name, definition string
+		if err := rows.Scan(&schema, &name, &definition); err != nil {
+			return err
+		}
+		result.Views[QualifiedName(schema, name)] = strings.TrimSpace(definition)
+		return nil
+	}); err != nil {
+		return result, err
+	}
+	if err := queryRows(ctx, conn, FunctionsQuery, schemas, func(rows pgx.Rows) error {
+		var schema, name, args, definition string
+		if err := rows.Scan(&schema, &name, &args, &definition); err != nil {
+			return err
+		}
+		result.Functions[QualifiedName(schema, name)+"("+args+")"] = strings.TrimSpace(definition)
+		return nil
+	}); err != nil {
+		return result, err
+	}
+	err := queryRows(ctx, conn, PoliciesQuery, schemas, func(rows pgx.Rows) error {
+		var policy Policy
+		if err := rows.Scan(&policy.Schema, &policy.Table, &policy.Name, &policy.Permissive, &policy.Roles, &policy.Command, &policy.Using, &policy.WithCheck); err != nil {
+			return err
+		}
+		result.Policies[QualifiedName(policy.Schema, policy.Table)+"."+pgx.Identifier{policy.Name}.Sanitize()] = policy
+		return nil
+	})
+	return result, err
+}
+
+func queryRows(ctx context.Context, conn *pgx.Conn, sql string, schemas []string, scan func(pgx.Rows) error) error {
+	rows, err := conn.Query(ctx, sql, schemas)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func QualifiedName(schema, name string) string {
+	return pgx.Identifier{schema, name}.Sanitize()
+}
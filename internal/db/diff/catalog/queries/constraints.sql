@@ -0,0 +1,7 @@
+SELECT n.nspname, c.relname, con.conname, con.contype::text, pg_get_constraintdef(con.oid)
+FROM pg_constraint con
+JOIN pg_class c ON c.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE con.contype IN ('c', 'f', 'p', 'u', 'x')
+  AND n.nspname = ANY($1)
+ORDER BY 1, 2, 3
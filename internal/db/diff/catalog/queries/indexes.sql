@@ -0,0 +1,8 @@
+SELECT n.nspname, i.relname, pg_get_indexdef(i.oid)
+FROM pg_index x
+JOIN pg_class i ON i.oid = x.indexrelid
+JOIN pg_namespace n ON n.oid = i.relnamespace
+WHERE n.nspname = ANY($1)
+  AND NOT EXISTS (SELECT 1 FROM pg_constraint con WHERE con.conindid = x.indexrelid)
+  AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.objid = x.indrelid AND d.deptype = 'e')
+ORDER BY 1, 2
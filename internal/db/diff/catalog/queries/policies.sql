@@ -0,0 +1,4 @@
+SELECT schemaname::text, tablename::text, policyname::text, permissive, roles::text[], cmd, coalesce(qual, ''), coalesce(with_check, '')
+FROM pg_policies
+WHERE schemaname = ANY($1)
+ORDER BY 1, 2, 3
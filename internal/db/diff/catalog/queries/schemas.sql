@@ -0,0 +1,4 @@
+SELECT nspname
+FROM pg_namespace
+WHERE nspname = ANY($1)
+ORDER BY 1
@@ -0,0 +1,85 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestLoadSchemaFiles(t *testing.T) {
+	t.Run("loads nested sql files in order", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		for _, name := range []string{"b.sql", "a/c.sql", "a.sql", "README.md"} {
+			path := filepath.Join(utils.SchemasDir, name)
+			require.NoError(t, afero.WriteFile(fsys, path, []byte(""), 0644))
+		}
+		// Run test
+		files, err := LoadSchemaFiles(utils.SchemasDir, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(utils.SchemasDir, "a", "c.sql"),
+			filepath.Join(utils.SchemasDir, "a.sql"),
+			filepath.Join(utils.SchemasDir, "b.sql"),
+		}, files)
+	})
+
+	t.Run("throws error on missing directory", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		_, err := LoadSchemaFiles(utils.SchemasDir, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("throws error on empty directory", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, fsys.MkdirAll(utils.SchemasDir, 0755))
+		// Run test
+		_, err := LoadSchemaFiles(utils.SchemasDir, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "No schema files found in")
+	})
+}
+
+func TestRunDeclarative(t *testing.T) {
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := RunDeclarative(context.Background(), []string{"public"}, "", utils.SchemasDir, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("throws error on failure to create shadow", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		path := filepath.Join(utils.SchemasDir, "public.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create table test();"), 0644))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Pg15Image) + "/json").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := RunDeclarative(context.Background(), []string{"public"}, "file", utils.SchemasDir, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 	"github.com/spf13/afero"
@@ -358,3 +359,48 @@ func TestUserSchema(t *testing.T) {
 	assert.NoError(t, err)
 	assert.ElementsMatch(t, []string{"test"}, schemas)
 }
+
+func TestEphemeralDatabase(t *testing.T) {
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.Db.Image)
+
+	t.Run("reads port assigned by docker", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, "test-ephemeral-db")
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/test-ephemeral-db/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{NetworkSettings: &types.NetworkSettings{
+				NetworkSettingsBase: types.NetworkSettingsBase{
+					Ports: nat.PortMap{"5432/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "49153"}}},
+				},
+			}})
+		// Run test
+		container, port, err := createEphemeralDatabase(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "test-ephemeral-db", container)
+		assert.Equal(t, uint(49153), port)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing port binding", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, "test-ephemeral-db")
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/test-ephemeral-db/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New(utils.Docker.DaemonHost()).
+			Delete("/v" + utils.Docker.ClientVersion() + "/containers/test-ephemeral-db").
+			Reply(http.StatusOK)
+		// Run test
+		_, _, err := createEphemeralDatabase(context.Background())
+		// Check error
+		assert.ErrorContains(t, err, "failed to find the host port of shadow database")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
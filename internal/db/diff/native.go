@@ -0,0 +1,82 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/diff/catalog"
+	"github.com/supabase/cli/internal/db/native"
+	"github.com/supabase/cli/internal/gen/keys"
+	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/migration/up"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Diffs schema without Docker by introspecting system catalogs of both databases. The shadow database,
+// such as a preview branch, is brought up to date with local migrations before diffing.
+func RunNative(ctx context.Context, schema []string, file string, shadowUrl string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if len(shadowUrl) == 0 && utils.IsNativeDbRunning() {
+		var err error
+		if shadowUrl, err = native.CreateShadowDatabase(ctx, options...); err != nil {
+			return err
+		}
+	} else if len(shadowUrl) == 0 {
+		return fmt.Errorf("Missing required flag: %s", utils.Aqua("--shadow-db-url"))
+	}
+	shadow, err := utils.ConnectByUrl(ctx, shadowUrl, options...)
+	if err != nil {
+		return err
+	}
+	defer shadow.Close(context.Background())
+	pending, err := up.GetPendingMigrations(ctx, true, shadow, fsys)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Applying", len(pending), "pending migrations to shadow database...")
+	if err := apply.MigrateUp(ctx, shadow, pending, fsys); err != nil {
+		return err
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if len(schema) == 0 {
+		if schema, err = LoadUserSchemas(ctx, shadow); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Diffing schemas:", strings.Join(schema, ","))
+	out, err := DiffNative(ctx, conn, shadow, schema)
+	if err != nil {
+		return err
+	}
+	branch := keys.GetGitBranch(fsys)
+	fmt.Fprintln(os.Stderr, "Finished "+utils.Aqua("supabase db diff")+" on branch "+utils.Aqua(branch)+".\n")
+	return SaveDiff(out, file, fsys)
+}
+
+// Generates DDL that migrates target database to match source database.
+func DiffNative(ctx context.Context, source, target *pgx.Conn, schema []string) (string, error) {
+	expected, err := catalog.Inspect(ctx, source, schema)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect source database: %w", err)
+	}
+	actual, err := catalog.Inspect(ctx, target, schema)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect target database: %w", err)
+	}
+	stmts := catalog.Diff(expected, actual)
+	if len(stmts) == 0 {
+		return "", nil
+	}
+	return strings.Join(stmts, "\n\n") + "\n", nil
+}
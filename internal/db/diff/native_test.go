@@ -0,0 +1,67 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/diff/catalog"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Remote connections use simple protocol so query args are interpolated by pgx.
+func mockCatalog(conn *pgtest.MockConn, columns ...[]interface{}) {
+	query := func(sql string) string {
+		return strings.ReplaceAll(sql, "$1", "'{public}'")
+	}
+	conn.Query(query(catalog.SchemasQuery)).
+		Reply("SELECT 1", []interface{}{"public"}).
+		Query(query(catalog.EnumsQuery)).
+		Reply("SELECT 0").
+		Query(query(catalog.TablesQuery)).
+		Reply("SELECT 1", []interface{}{"public", "todos", false}).
+		Query(query(catalog.ColumnsQuery)).
+		Reply(fmt.Sprintf("SELECT %d", len(columns)), columns...).
+		Query(query(catalog.ConstraintsQuery)).
+		Reply("SELECT 0").
+		Query(query(catalog.IndexesQuery)).
+		Reply("SELECT 0").
+		Query(query(catalog.ViewsQuery)).
+		Reply("SELECT 0").
+		Query(query(catalog.FunctionsQuery)).
+		Reply("SELECT 0").
+		Query(query(catalog.PoliciesQuery)).
+		Reply("SELECT 0")
+}
+
+func TestDiffNative(t *testing.T) {
+	schema := []string{"public"}
+	id := []interface{}{"public", "todos", "id", "bigint", true, ""}
+	done := []interface{}{"public", "todos", "done", "boolean", false, ""}
+
+	t.Run("adds local changes to shadow", func(t *testing.T) {
+		// Setup mock postgres
+		local := pgtest.NewConn()
+		defer local.Close(t)
+		mockCatalog(local, id, done)
+		shadow := pgtest.NewConn()
+		defer shadow.Close(t)
+		mockCatalog(shadow, id)
+		localConn, err := utils.ConnectByConfig(context.Background(), dbConfig, local.Intercept)
+		require.NoError(t, err)
+		defer localConn.Close(context.Background())
+		shadowConn, err := utils.ConnectByConfig(context.Background(), dbConfig, shadow.Intercept)
+		require.NoError(t, err)
+		defer shadowConn.Close(context.Background())
+		// Run test
+		out, err := DiffNative(context.Background(), localConn, shadowConn, schema)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out, `ADD COLUMN "done" boolean`)
+		assert.NotContains(t, out, "DROP COLUMN")
+	})
+}
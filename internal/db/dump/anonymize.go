@@ -0,0 +1,246 @@
+package dump
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/parser"
+)
+
+// Replaces a SQL literal, as printed by pg_dump, with a masked literal.
+type Masker func(literal string) string
+
+var (
+	Maskers = map[string]Masker{
+		"email": maskEmail,
+		"hash":  maskHash,
+		"null":  func(string) string { return "NULL" },
+	}
+
+	ErrAnonymizeCopy = errors.New("Data anonymization is not supported with " + utils.Aqua("--use-copy"))
+
+	insertPattern = regexp.MustCompile(`(?m)^INSERT INTO `)
+)
+
+// Maps qualified table name to column maskers, ie. "schema"."table" -> column -> masker.
+type anonymizeRules map[string]map[string]Masker
+
+func NewAnonymizeRules(config map[string]string) (anonymizeRules, error) {
+	rules := anonymizeRules{}
+	// Sort keys for deterministic error messages
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, column := range keys {
+		masker, ok := Maskers[config[column]]
+		if !ok {
+			return nil, fmt.Errorf("Unknown masker for column %s: %s", utils.Aqua(column), config[column])
+		}
+		parts := strings.Split(column, ".")
+		if len(parts) != 3 {
+			return nil, errors.New("Column must be fully qualified as schema.table.column: " + utils.Aqua(column))
+		}
+		table := quoteIdent(parts[0]) + "." + quoteIdent(parts[1])
+		if _, ok := rules[table]; !ok {
+			rules[table] = map[string]Masker{}
+		}
+		rules[table][parts[2]] = masker
+	}
+	return rules, nil
+}
+
+// Copies a data dump from r to w, masking the configured columns of every insert statement.
+func anonymize(r io.Reader, w io.Writer, rules anonymizeRules) error {
+	scanner := parser.NewScanner(r)
+	for scanner.Scan() {
+		stat, err := rules.apply(scanner.Text())
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, stat); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Masks the configured columns of an insert statement. Inserts that cannot be parsed are rejected
+// instead of being copied verbatim, so that unmasked rows never end up in the dump.
+func (rules anonymizeRules) apply(stat string) (string, error) {
+	loc := insertPattern.FindStringIndex(stat)
+	if loc == nil {
+		return stat, nil
+	}
+	// Statement looks like: INSERT INTO "schema"."table" ("a", "b") VALUES (1, 'x'), (2, 'y');
+	schema, rest, ok := scanIdent(stat[loc[1]:])
+	table := quoteIdent(schema)
+	if ok && strings.HasPrefix(rest, ".") {
+		var name string
+		name, rest, ok = scanIdent(rest[1:])
+		table += "." + quoteIdent(name)
+	}
+	if !ok {
+		return "", errors.New("failed to anonymize data: cannot parse table name of insert statement")
+	}
+	columns, ok := rules[table]
+	if !ok {
+		return stat, nil
+	}
+	errParse := fmt.Errorf("failed to anonymize data: cannot parse insert statement for table %s", utils.Aqua(table))
+	if !strings.HasPrefix(rest, " (") {
+		return "", errParse
+	}
+	names, rest, ok := scanIdentList(rest[2:])
+	if !ok || !strings.HasPrefix(rest, " VALUES") {
+		return "", errParse
+	}
+	maskers := make([]Masker, len(names))
+	for i, name := range names {
+		maskers[i] = columns[name]
+	}
+	var out strings.Builder
+	out.WriteString(stat[:len(stat)-len(rest)])
+	// Multi-row inserts are separated by commas and whitespace, which are copied verbatim
+	for sep := " VALUES"; strings.HasPrefix(rest, sep); {
+		trimmed := strings.TrimLeft(rest[len(sep):], " \t\r\n")
+		out.WriteString(rest[:len(rest)-len(trimmed)])
+		rest = trimmed
+		if !strings.HasPrefix(rest, "(") {
+			return "", errParse
+		}
+		values, tail, ok := scanValueList(rest[1:])
+		if !ok || len(values) != len(maskers) {
+			return "", errParse
+		}
+		for i, v := range values {
+			if maskers[i] != nil {
+				values[i] = maskers[i](v)
+			}
+		}
+		out.WriteString("(" + strings.Join(values, ", ") + ")")
+		rest, sep = tail, ","
+	}
+	// Anything other than the statement terminator may contain more rows
+	if strings.TrimSpace(rest) != ";" {
+		return "", errParse
+	}
+	out.WriteString(rest)
+	return out.String(), nil
+}
+
+// Scans a comma separated list of quoted identifiers terminated by a closing parenthesis.
+func scanIdentList(s string) ([]string, string, bool) {
+	var names []string
+	for {
+		name, rest, ok := scanIdent(s)
+		if !ok {
+			return nil, s, false
+		}
+		names = append(names, name)
+		s = rest
+		if strings.HasPrefix(s, ")") {
+			return names, s[1:], true
+		}
+		if !strings.HasPrefix(s, ", ") {
+			return nil, s, false
+		}
+		s = s[2:]
+	}
+}
+
+// Scans a quoted identifier, where quotes are escaped by doubling.
+func scanIdent(s string) (string, string, bool) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", s, false
+	}
+	var name strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != '"' {
+			name.WriteByte(s[i])
+		} else if i+1 < len(s) && s[i+1] == '"' {
+			name.WriteByte('"')
+			i++
+		} else {
+			return name.String(), s[i+1:], true
+		}
+	}
+	return "", s, false
+}
+
+// Scans a comma separated list of literals terminated by a closing parenthesis.
+func scanValueList(s string) ([]string, string, bool) {
+	var values []string
+	start, depth := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'':
+			// Skip over quoted literal, where quotes are escaped by doubling
+			for i++; i < len(s); i++ {
+				if s[i] == '\'' {
+					if i+1 < len(s) && s[i+1] == '\'' {
+						i++
+					} else {
+						break
+					}
+				}
+			}
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			values = append(values, strings.TrimSpace(s[start:i]))
+			return values, s[i+1:], true
+		case ',':
+			if depth == 0 {
+				values = append(values, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return nil, s, false
+}
+
+func unquote(literal string) (string, bool) {
+	if len(literal) < 2 || literal[0] != '\'' || literal[len(literal)-1] != '\'' {
+		return literal, literal != "NULL"
+	}
+	return strings.ReplaceAll(literal[1:len(literal)-1], "''", "'"), true
+}
+
+func digest(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// Replaces with a deterministic fake email so that unique constraints are preserved.
+func maskEmail(literal string) string {
+	value, ok := unquote(literal)
+	if !ok {
+		return literal
+	}
+	return "'user_" + digest(value)[:16] + "@example.com'"
+}
+
+func maskHash(literal string) string {
+	value, ok := unquote(literal)
+	if !ok {
+		return literal
+	}
+	return "'" + digest(value) + "'"
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,93 @@
+package dump
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnonymize(t *testing.T) {
+	rules, err := NewAnonymizeRules(map[string]string{
+		"auth.users.email":  "email",
+		"auth.users.phone":  "null",
+		"public.notes.body": "hash",
+	})
+	require.NoError(t, err)
+
+	t.Run("masks configured columns", func(t *testing.T) {
+		input := `SET statement_timeout = 0;
+
+--
+-- Data for Name: users; Type: TABLE DATA; Schema: auth; Owner: supabase_auth_admin
+--
+
+INSERT INTO "auth"."users" ("id", "email", "phone") VALUES (1, 'alice@supabase.io', '+6512345678');
+INSERT INTO "auth"."users" ("id", "email", "phone") VALUES (2, NULL, 'it''s; (private)');
+INSERT INTO "public"."notes" ("id", "body") VALUES
+	(1, 'hello'),
+	(2, 'world');
+INSERT INTO "public"."other" ("id", "body") VALUES (1, 'hello');
+RESET ALL;
+`
+		var out bytes.Buffer
+		// Run test
+		err := anonymize(strings.NewReader(input), &out, rules)
+		// Check error
+		assert.NoError(t, err)
+		expected := `SET statement_timeout = 0;
+
+--
+-- Data for Name: users; Type: TABLE DATA; Schema: auth; Owner: supabase_auth_admin
+--
+
+INSERT INTO "auth"."users" ("id", "email", "phone") VALUES (1, ` + maskEmail("'alice@supabase.io'") + `, NULL);
+INSERT INTO "auth"."users" ("id", "email", "phone") VALUES (2, NULL, NULL);
+INSERT INTO "public"."notes" ("id", "body") VALUES
+	(1, ` + maskHash("'hello'") + `),
+	(2, ` + maskHash("'world'") + `);
+INSERT INTO "public"."other" ("id", "body") VALUES (1, 'hello');
+RESET ALL;
+`
+		assert.Equal(t, expected, out.String())
+	})
+
+	t.Run("masks deterministically", func(t *testing.T) {
+		assert.Equal(t, maskEmail("'a@b.c'"), maskEmail("'a@b.c'"))
+		assert.NotEqual(t, maskEmail("'a@b.c'"), maskEmail("'d@b.c'"))
+		assert.Regexp(t, `^'user_[0-9a-f]{16}@example\.com'$`, maskEmail("'a@b.c'"))
+		assert.Equal(t, maskHash("'it''s'"), "'"+digest("it's")+"'")
+	})
+
+	t.Run("throws error on unparsable insert", func(t *testing.T) {
+		input := `INSERT INTO "auth"."users" VALUES (1, 'alice@supabase.io', NULL);`
+		var out bytes.Buffer
+		// Run test
+		err := anonymize(strings.NewReader(input), &out, rules)
+		// Check error
+		assert.ErrorContains(t, err, "cannot parse insert statement for table")
+		assert.NotContains(t, out.String(), "alice@supabase.io")
+	})
+
+	t.Run("throws error on mismatched values", func(t *testing.T) {
+		input := `INSERT INTO "auth"."users" ("id", "email") VALUES (1, 'alice@supabase.io', NULL);`
+		var out bytes.Buffer
+		// Run test
+		err := anonymize(strings.NewReader(input), &out, rules)
+		// Check error
+		assert.ErrorContains(t, err, "cannot parse insert statement for table")
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("throws error on unknown masker", func(t *testing.T) {
+		_, err := NewAnonymizeRules(map[string]string{"auth.users.email": "redact"})
+		assert.ErrorContains(t, err, "Unknown masker for column")
+	})
+
+	t.Run("throws error on unqualified column", func(t *testing.T) {
+		_, err := NewAnonymizeRules(map[string]string{"users.email": "null"})
+		assert.ErrorContains(t, err, "Column must be fully qualified")
+	})
+}
@@ -3,6 +3,7 @@ package dump
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -24,7 +25,7 @@ var (
 	dumpRoleScript string
 )
 
-func Run(ctx context.Context, path string, config pgconn.Config, schema []string, dataOnly, roleOnly, keepComments, useCopy, dryRun bool, fsys afero.Fs) error {
+func Run(ctx context.Context, path string, config pgconn.Config, schema, includeTables, excludeTables []string, dataOnly, roleOnly, keepComments, useCopy, dryRun bool, fsys afero.Fs) error {
 	// Initialize output stream
 	var outStream afero.File
 	if len(path) > 0 {
@@ -43,27 +44,44 @@ func Run(ctx context.Context, path string, config pgconn.Config, schema []string
 	}
 	if dataOnly {
 		fmt.Fprintln(os.Stderr, "Dumping data from remote database...")
-		return dumpData(ctx, config, schema, useCopy, dryRun, outStream)
+		return dumpData(ctx, config, schema, tableEnv(includeTables, excludeTables), useCopy, dryRun, outStream, fsys)
 	} else if roleOnly {
 		fmt.Fprintln(os.Stderr, "Dumping roles from remote database...")
 		return dumpRole(ctx, config, keepComments, dryRun, outStream)
 	}
 	fmt.Fprintln(os.Stderr, "Dumping schemas from remote database...")
-	return DumpSchema(ctx, config, schema, keepComments, dryRun, outStream)
+	return dumpSchema(ctx, config, schema, tableEnv(includeTables, excludeTables), keepComments, dryRun, outStream)
 }
 
 func DumpSchema(ctx context.Context, config pgconn.Config, schema []string, keepComments, dryRun bool, stdout io.Writer) error {
+	return dumpSchema(ctx, config, schema, nil, keepComments, dryRun, stdout)
+}
+
+// Table patterns follow pg_dump syntax, ie. schema.table with optional wildcards.
+func tableEnv(includeTables, excludeTables []string) []string {
+	var env []string
+	if len(includeTables) > 0 {
+		env = append(env, "INCLUDED_TABLES="+strings.Join(includeTables, "\n"))
+	}
+	if len(excludeTables) > 0 {
+		env = append(env, "EXCLUDED_TABLES="+strings.Join(excludeTables, "\n"))
+	}
+	return env
+}
+
+func dumpSchema(ctx context.Context, config pgconn.Config, schema, tables []string, keepComments, dryRun bool, stdout io.Writer) error {
 	env := []string{"EXCLUDED_SCHEMAS=" + strings.Join(utils.InternalSchemas, "|")}
 	if len(schema) > 0 {
 		env[0] = "INCLUDED_SCHEMAS=" + strings.Join(schema, "|")
 	}
+	env = append(env, tables...)
 	if !keepComments {
 		env = append(env, "DELETE_COMMENTS=1")
 	}
 	return dump(ctx, config, dumpSchemaScript, env, dryRun, stdout)
 }
 
-func dumpData(ctx context.Context, config pgconn.Config, schema []string, useCopy, dryRun bool, stdout io.Writer) error {
+func dumpData(ctx context.Context, config pgconn.Config, schema, tables []string, useCopy, dryRun bool, stdout io.Writer, fsys afero.Fs) error {
 	// We want to dump user data in auth, storage, etc. for migrating to new project
 	excludedSchemas := []string{
 		"information_schema",
@@ -98,10 +116,43 @@ func dumpData(ctx context.Context, config pgconn.Config, schema []string, useCop
 	if len(schema) > 0 {
 		env[0] = "INCLUDED_SCHEMAS=" + strings.Join(schema, "|")
 	}
+	env = append(env, tables...)
 	if !useCopy {
 		env = append(env, "COLUMN_INSERTS=1")
 	}
-	return dump(ctx, config, dumpDataScript, env, dryRun, stdout)
+	// Anonymization rules are optional because remote dumps may run outside a project directory,
+	// but an existing config that fails to load must not silently skip masking.
+	rules := anonymizeRules{}
+	if err := utils.LoadConfigFS(fsys); err == nil {
+		parsed, err := NewAnonymizeRules(utils.Config.Db.Dump.Anonymize)
+		if err != nil {
+			return err
+		}
+		rules = parsed
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(rules) == 0 || dryRun {
+		return dump(ctx, config, dumpDataScript, env, dryRun, stdout)
+	} else if useCopy {
+		return ErrAnonymizeCopy
+	}
+	// Dump one row per statement to keep the streaming buffer small
+	env = append(env, "ROWS_PER_INSERT=1")
+	r, w := io.Pipe()
+	errCh := make(chan error, 1)
+	go func() {
+		err := anonymize(r, stdout, rules)
+		// Unblock the writer if anonymization fails midway
+		r.CloseWithError(err)
+		errCh <- err
+	}()
+	err := dump(ctx, config, dumpDataScript, env, dryRun, w)
+	w.CloseWithError(err)
+	if aerr := <-errCh; err == nil {
+		err = aerr
+	}
+	return err
 }
 
 func dumpRole(ctx context.Context, config pgconn.Config, keepComments, dryRun bool, stdout io.Writer) error {
@@ -1,6 +1,7 @@
 package dump
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"testing"
@@ -35,7 +36,7 @@ func TestPullCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world"))
 		// Run test
-		err := Run(context.Background(), "schema.sql", dbConfig, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, nil, false, false, false, false, false, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -54,7 +55,7 @@ func TestPullCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world"))
 		// Run test
-		err := Run(context.Background(), "", dbConfig, []string{"public"}, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "", dbConfig, []string{"public"}, nil, nil, false, false, false, false, false, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -70,7 +71,7 @@ func TestPullCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/images").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := Run(context.Background(), "", dbConfig, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "", dbConfig, nil, nil, nil, false, false, false, false, false, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "request returned Service Unavailable for API route and version")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -85,9 +86,33 @@ func TestPullCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "hello world"))
 		// Run test
-		err := Run(context.Background(), "schema.sql", dbConfig, nil, false, false, false, false, false, fsys)
+		err := Run(context.Background(), "schema.sql", dbConfig, nil, nil, nil, false, false, false, false, false, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+
+	t.Run("throws error on anonymize with copy", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		data, err := afero.ReadFile(fsys, utils.ConfigPath)
+		require.NoError(t, err)
+		data = bytes.Replace(data, []byte(`# "auth.users.email" = "email"`), []byte(`"auth.users.email" = "email"`), 1)
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, data, 0644))
+		// Run test
+		err = Run(context.Background(), "", dbConfig, nil, nil, nil, true, false, false, true, false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrAnonymizeCopy)
+	})
+
+	t.Run("throws error on invalid config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte("malformed"), 0644))
+		// Run test
+		err := Run(context.Background(), "", dbConfig, nil, nil, nil, true, false, false, false, false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "cannot read config")
+	})
 }
@@ -7,18 +7,30 @@ set -euo pipefail
 #   --exclude-table  omit data from migration history tables as they are managed by platform
 #   --column-inserts only column insert syntax is supported, ie. no copy from stdin
 #   --schema '*'     include all other schemas by default
+#   --table          only dump data from the matched tables, overriding --schema
 #
 # Never delete SQL comments because multiline records may begin with them.
+
+# Table patterns are passed in as newline separated lists
+table_args=()
+while IFS= read -r pattern; do
+    if [ -n "$pattern" ]; then table_args+=(--table "$pattern"); fi
+done <<< "${INCLUDED_TABLES:-}"
+while IFS= read -r pattern; do
+    if [ -n "$pattern" ]; then table_args+=(--exclude-table "$pattern"); fi
+done <<< "${EXCLUDED_TABLES:-}"
+
 pg_dump \
     --data-only \
     --quote-all-identifier \
     ${COLUMN_INSERTS:+--column-inserts} \
-    ${COLUMN_INSERTS:+--rows-per-insert 100000} \
+    ${COLUMN_INSERTS:+--rows-per-insert "${ROWS_PER_INSERT:-100000}"} \
     --exclude-schema "${EXCLUDED_SCHEMAS:-}" \
     --exclude-table "auth.schema_migrations" \
     --exclude-table "storage.migrations" \
     --exclude-table "supabase_functions.migrations" \
     --schema "${INCLUDED_SCHEMAS:-*}" \
+    ${table_args[@]+"${table_args[@]}"} \
     --dbname "$DB_URL"
 
 # Reset session config generated by pg_dump
@@ -13,11 +13,22 @@ set -euo pipefail
 #   - do not alter superuser role "supabase_admin"
 #   - do not include ACL changes on internal schemas
 #   - do not include RLS policies on cron extension schema
+
+# Table patterns are passed in as newline separated lists
+table_args=()
+while IFS= read -r pattern; do
+    if [ -n "$pattern" ]; then table_args+=(--table "$pattern"); fi
+done <<< "${INCLUDED_TABLES:-}"
+while IFS= read -r pattern; do
+    if [ -n "$pattern" ]; then table_args+=(--exclude-table "$pattern"); fi
+done <<< "${EXCLUDED_TABLES:-}"
+
 pg_dump \
     --schema-only \
     --quote-all-identifier \
     ${INCLUDED_SCHEMAS:+--schema "$INCLUDED_SCHEMAS"} \
     --exclude-schema "${EXCLUDED_SCHEMAS:-}" \
+    ${table_args[@]+"${table_args[@]}"} \
     --extension '*' \
     --no-comments \
     --dbname "$DB_URL" \
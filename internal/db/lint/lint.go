@@ -38,18 +38,41 @@ func toEnum(level string) LintLevel {
 	return -1
 }
 
-func Run(ctx context.Context, schema []string, level string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+const (
+	FormatJson  = "json"
+	FormatSarif = "sarif"
+)
+
+var AllowedFormats = []string{FormatJson, FormatSarif}
+
+func Run(ctx context.Context, schema []string, level, format string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	// Sanity checks.
+	// Rules can be configured in config.toml, but linting a remote database should work without one.
+	_ = utils.LoadConfigFS(fsys)
 	conn, err := utils.ConnectByConfig(ctx, config, options...)
 	if err != nil {
 		return err
 	}
 	defer conn.Close(context.Background())
+	if len(schema) == 0 {
+		schema, err = diff.LoadUserSchemas(ctx, conn, utils.InternalSchemas...)
+		if err != nil {
+			return err
+		}
+	}
 	// Run lint script
 	result, err := LintDatabase(ctx, conn, schema)
 	if err != nil {
 		return err
 	}
+	found, err := LintRules(ctx, conn, schema, fsys)
+	if err != nil {
+		return err
+	}
+	result = append(result, found...)
+	if format == FormatSarif {
+		return printResultSarif(result, toEnum(level), os.Stdout)
+	}
 	if len(result) == 0 {
 		fmt.Fprintln(os.Stderr, "\nNo schema errors found")
 		return nil
@@ -59,7 +82,8 @@ func Run(ctx context.Context, schema []string, level string, config pgconn.Confi
 
 func filterResult(result []Result, minLevel LintLevel) (filtered []Result) {
 	for _, r := range result {
-		out := Result{Function: r.Function}
+		out := r
+		out.Issues = nil
 		for _, issue := range r.Issues {
 			if toEnum(issue.Level) >= minLevel {
 				out.Issues = append(out.Issues, issue)
@@ -151,6 +175,7 @@ type Statement struct {
 }
 
 type Issue struct {
+	Rule      string     `json:"rule,omitempty"`
 	Level     string     `json:"level"`
 	Message   string     `json:"message"`
 	Statement *Statement `json:"statement,omitempty"`
@@ -162,6 +187,10 @@ type Issue struct {
 }
 
 type Result struct {
-	Function string  `json:"function"`
-	Issues   []Issue `json:"issues"`
+	Function string `json:"function,omitempty"`
+	// Table or other database object reported by lint rules
+	Object string `json:"object,omitempty"`
+	// Migration file reported by lint rules
+	File   string  `json:"file,omitempty"`
+	Issues []Issue `json:"issues"`
 }
@@ -64,7 +64,7 @@ func TestLintCommand(t *testing.T) {
 		Reply("SELECT 1", []interface{}{"f1", string(data)}).
 		Query("rollback").Reply("ROLLBACK")
 	// Run test
-	assert.NoError(t, Run(context.Background(), []string{"public"}, "warning", dbConfig, fsys, conn.Intercept))
+	assert.NoError(t, Run(context.Background(), []string{"public"}, "warning", FormatJson, dbConfig, fsys, conn.Intercept))
 	// Validate api
 	assert.Empty(t, apitest.ListUnmatchedRequests())
 }
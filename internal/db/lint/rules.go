@@ -0,0 +1,181 @@
+package lint
+
+import (
+	"context"
+	_ "embed"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/parser"
+)
+
+// Rule checks the database or local migrations for a class of problems. Rules are opt-in and
+// enabled in config.toml under [db.lint.rules].
+type Rule struct {
+	Name        string
+	Description string
+	Level       string
+	Check       func(ctx context.Context, conn *pgx.Conn, schema []string, fsys afero.Fs) ([]Result, error)
+}
+
+var (
+	//go:embed rules/no_primary_key.sql
+	noPrimaryKeyQuery string
+	//go:embed rules/rls_disabled.sql
+	rlsDisabledQuery string
+	//go:embed rules/unindexed_foreign_key.sql
+	unindexedForeignKeyQuery string
+
+	Rules = []Rule{{
+		Name:        "no_primary_key",
+		Description: "Tables without a primary key cannot be replicated or updated efficiently.",
+		Level:       AllowedLevels[0],
+		Check:       queryRule(noPrimaryKeyQuery),
+	}, {
+		Name:        "rls_disabled",
+		Description: "Tables without row level security are fully accessible with the anon key.",
+		Level:       AllowedLevels[1],
+		Check:       queryRule(rlsDisabledQuery),
+	}, {
+		Name:        "unindexed_foreign_key",
+		Description: "Foreign keys without a covering index slow down joins and cascading deletes.",
+		Level:       AllowedLevels[0],
+		Check:       queryRule(unindexedForeignKeyQuery),
+	}, {
+		Name:        "unsafe_migration",
+		Description: "Migrations that lock or fail on tables with existing rows.",
+		Level:       AllowedLevels[0],
+		Check:       checkMigrations,
+	}}
+)
+
+func IsRuleEnabled(name string) bool {
+	return utils.Config.Db.Lint.Rules[name]
+}
+
+// LintRules runs all enabled rules, tagging each issue with the rule that reported it.
+func LintRules(ctx context.Context, conn *pgx.Conn, schema []string, fsys afero.Fs) ([]Result, error) {
+	var result []Result
+	for _, rule := range Rules {
+		if !IsRuleEnabled(rule.Name) {
+			continue
+		}
+		found, err := rule.Check(ctx, conn, schema, fsys)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range found {
+			for i := range r.Issues {
+				r.Issues[i].Rule = rule.Name
+				if len(r.Issues[i].Level) == 0 {
+					r.Issues[i].Level = rule.Level
+				}
+			}
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// Each row returned by query is an object name followed by a message.
+func queryRule(query string) func(context.Context, *pgx.Conn, []string, afero.Fs) ([]Result, error) {
+	return func(ctx context.Context, conn *pgx.Conn, schema []string, _ afero.Fs) ([]Result, error) {
+		rows, err := conn.Query(ctx, query, schema)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		var result []Result
+		for rows.Next() {
+			var object, message string
+			if err := rows.Scan(&object, &message); err != nil {
+				return nil, err
+			}
+			result = append(result, Result{
+				Object: object,
+				Issues: []Issue{{Message: message}},
+			})
+		}
+		return result, rows.Err()
+	}
+}
+
+// Tables estimated to have at least this many rows are considered large.
+const largeTableRows = 10000
+
+const ESTIMATE_ROWS = "SELECT coalesce((SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass($1)), 0)"
+
+var (
+	alterTablePattern  = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?([^\s]+)\s`)
+	addNotNullPattern  = regexp.MustCompile(`(?is)\bADD\s+(?:COLUMN\s+)?[^,]*\bNOT\s+NULL\b`)
+	hasDefaultPattern  = regexp.MustCompile(`(?is)\bDEFAULT\b`)
+	setNotNullPattern  = regexp.MustCompile(`(?is)\bALTER\s+(?:COLUMN\s+)?[^\s]+\s+SET\s+NOT\s+NULL\b`)
+	unsafeAddNotNull   = "Adding a NOT NULL column without a default fails on tables with existing rows."
+	unsafeSetNotNull   = "Setting NOT NULL scans the whole table while holding an exclusive lock."
+	suggestSafeDefault = "Add a default value, or backfill the column before adding the constraint."
+	suggestCheckFirst  = "Add a CHECK (column IS NOT NULL) NOT VALID constraint and validate it first."
+)
+
+func checkMigrations(ctx context.Context, conn *pgx.Conn, _ []string, fsys afero.Fs) ([]Result, error) {
+	migrations, err := list.LoadLocalMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	var result []Result
+	for _, filename := range migrations {
+		path := filepath.Join(utils.MigrationsDir, filename)
+		contents, err := afero.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		lines, err := parser.SplitAndTrim(strings.NewReader(string(contents)))
+		if err != nil {
+			return nil, err
+		}
+		r := Result{File: filepath.ToSlash(path)}
+		for _, stat := range lines {
+			matches := alterTablePattern.FindStringSubmatch(stat)
+			if len(matches) < 2 {
+				continue
+			}
+			var issue Issue
+			if addNotNullPattern.MatchString(stat) && !hasDefaultPattern.MatchString(stat) {
+				issue = Issue{Message: unsafeAddNotNull, Hint: suggestSafeDefault}
+			} else if setNotNullPattern.MatchString(stat) {
+				issue = Issue{Message: unsafeSetNotNull, Hint: suggestCheckFirst}
+			} else {
+				continue
+			}
+			// Escalate to error if the table is already large
+			var rows int64
+			if err := conn.QueryRow(ctx, ESTIMATE_ROWS, matches[1]).Scan(&rows); err == nil && rows >= largeTableRows {
+				issue.Level = AllowedLevels[1]
+			}
+			issue.Statement = &Statement{
+				LineNumber: lineNumber(string(contents), stat),
+				Text:       stat,
+			}
+			r.Issues = append(r.Issues, issue)
+		}
+		if len(r.Issues) > 0 {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// Finds the 1-based line where a trimmed statement starts in the file.
+func lineNumber(contents, stat string) string {
+	first, _, _ := strings.Cut(stat, "\n")
+	i := strings.Index(contents, strings.TrimSpace(first))
+	if i < 0 {
+		return ""
+	}
+	return strconv.Itoa(strings.Count(contents[:i], "\n") + 1)
+}
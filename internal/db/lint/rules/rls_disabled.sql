@@ -0,0 +1,8 @@
+SELECT n.nspname || '.' || c.relname, 'Row level security is disabled.'
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'p')
+  AND NOT c.relispartition
+  AND NOT c.relrowsecurity
+  AND n.nspname = ANY($1)
+ORDER BY 1
@@ -0,0 +1,12 @@
+SELECT n.nspname || '.' || c.relname, format('Foreign key %I has no covering index.', con.conname)
+FROM pg_constraint con
+JOIN pg_class c ON c.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE con.contype = 'f'
+  AND n.nspname = ANY($1)
+  AND NOT EXISTS (
+    SELECT 1 FROM pg_index i
+    WHERE i.indrelid = con.conrelid
+      AND con.conkey <@ (i.indkey::int2[])[0:cardinality(con.conkey) - 1]
+  )
+ORDER BY 1, con.conname
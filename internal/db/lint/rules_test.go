@@ -0,0 +1,135 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestLintRules(t *testing.T) {
+	remote := pgconn.Config{
+		Host:     "db.supabase.co",
+		Port:     5432,
+		User:     "admin",
+		Password: "password",
+		Database: "postgres",
+	}
+	withSchema := func(query string) string {
+		return strings.ReplaceAll(query, "$1", "'{public}'")
+	}
+
+	t.Run("reports schema and migration issues", func(t *testing.T) {
+		utils.Config.Db.Lint.Rules = map[string]bool{
+			"no_primary_key":        true,
+			"rls_disabled":          true,
+			"unindexed_foreign_key": true,
+			"unsafe_migration":      true,
+		}
+		t.Cleanup(func() { utils.Config.Db.Lint.Rules = nil })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "20220727064247_add_status.sql")
+		sql := "create table todos();\n\nalter table public.todos add column status text not null;\n"
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(withSchema(noPrimaryKeyQuery)).
+			Reply("SELECT 1", []interface{}{"public.todos", "Table has no primary key."}).
+			Query(withSchema(rlsDisabledQuery)).
+			Reply("SELECT 0").
+			Query(withSchema(unindexedForeignKeyQuery)).
+			Reply("SELECT 0").
+			Query(strings.ReplaceAll(ESTIMATE_ROWS, "$1", "'public.todos'")).
+			Reply("SELECT 1", []interface{}{int64(20000)})
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectRemotePostgres(ctx, remote, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		result, err := LintRules(ctx, mock, []string{"public"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Result{{
+			Object: "public.todos",
+			Issues: []Issue{{
+				Rule:    "no_primary_key",
+				Level:   "warning",
+				Message: "Table has no primary key.",
+			}},
+		}, {
+			File: filepath.ToSlash(path),
+			Issues: []Issue{{
+				Rule:    "unsafe_migration",
+				Level:   "error",
+				Message: unsafeAddNotNull,
+				Hint:    suggestSafeDefault,
+				Statement: &Statement{
+					LineNumber: "3",
+					Text:       "alter table public.todos add column status text not null",
+				},
+			}},
+		}}, result)
+	})
+
+	t.Run("skips rules by default", func(t *testing.T) {
+		utils.Config.Db.Lint.Rules = nil
+		// Run test
+		result, err := LintRules(context.Background(), nil, []string{"public"}, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestPrintSarif(t *testing.T) {
+	result := []Result{{
+		Function: "public.f1",
+		Issues:   []Issue{{Level: "error", Message: "test 1"}},
+	}, {
+		File: "supabase/migrations/0_test.sql",
+		Issues: []Issue{{
+			Rule:      "unsafe_migration",
+			Level:     "warning",
+			Message:   "test 2",
+			Statement: &Statement{LineNumber: "3"},
+		}},
+	}}
+	// Run test
+	var out bytes.Buffer
+	assert.NoError(t, printResultSarif(result, toEnum("warning"), &out))
+	// Validate output
+	var actual sarifLog
+	require.NoError(t, json.Unmarshal(out.Bytes(), &actual))
+	assert.Equal(t, "2.1.0", actual.Version)
+	require.Len(t, actual.Runs, 1)
+	assert.Len(t, actual.Runs[0].Tool.Driver.Rules, len(Rules)+1)
+	assert.Equal(t, []sarifResult{{
+		RuleId:  plpgsqlCheckRule,
+		Level:   "error",
+		Message: sarifMessage{Text: "test 1"},
+		Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+			FullyQualifiedName: "public.f1",
+			Kind:               "function",
+		}}}},
+	}, {
+		RuleId:  "unsafe_migration",
+		Level:   "warning",
+		Message: sarifMessage{Text: "test 2"},
+		Locations: []sarifLocation{{PhysicalLocation: &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifact{Uri: "supabase/migrations/0_test.sql"},
+			Region:           &sarifRegion{StartLine: 3},
+		}}},
+	}}, actual.Runs[0].Results)
+}
@@ -0,0 +1,144 @@
+package lint
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Minimal subset of SARIF 2.1.0 accepted by GitHub code scanning.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion  `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	Uri string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// Issues reported by plpgsql_check are not tagged with a rule.
+const plpgsqlCheckRule = "plpgsql_check"
+
+func printResultSarif(result []Result, minLevel LintLevel, stdout io.Writer) error {
+	driver := sarifDriver{
+		Name:           "supabase-db-lint",
+		Version:        utils.Version,
+		InformationUri: "https://supabase.com/docs/reference/cli/supabase-db-lint",
+		Rules: []sarifRule{{
+			Id:               plpgsqlCheckRule,
+			ShortDescription: sarifMessage{Text: "Type errors in PL/pgSQL functions."},
+		}},
+	}
+	for _, rule := range Rules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			Id:               rule.Name,
+			ShortDescription: sarifMessage{Text: rule.Description},
+		})
+	}
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, r := range filterResult(result, minLevel) {
+		for _, issue := range r.Issues {
+			ruleId := issue.Rule
+			if len(ruleId) == 0 {
+				ruleId = plpgsqlCheckRule
+			}
+			level := "warning"
+			if toEnum(issue.Level) == toEnum(AllowedLevels[1]) {
+				level = "error"
+			}
+			message := issue.Message
+			if len(issue.Hint) > 0 {
+				message += " " + issue.Hint
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleId:    ruleId,
+				Level:     level,
+				Message:   sarifMessage{Text: message},
+				Locations: []sarifLocation{toSarifLocation(r, issue)},
+			})
+		}
+	}
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
+
+func toSarifLocation(r Result, issue Issue) sarifLocation {
+	if len(r.File) > 0 {
+		loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifact{Uri: r.File}}
+		if issue.Statement != nil {
+			if line, err := strconv.Atoi(issue.Statement.LineNumber); err == nil {
+				loc.Region = &sarifRegion{StartLine: line}
+			}
+		}
+		return sarifLocation{PhysicalLocation: &loc}
+	}
+	if len(r.Function) > 0 {
+		return sarifLocation{LogicalLocations: []sarifLogicalLocation{{
+			FullyQualifiedName: r.Function,
+			Kind:               "function",
+		}}}
+	}
+	return sarifLocation{LogicalLocations: []sarifLogicalLocation{{
+		FullyQualifiedName: r.Object,
+		Kind:               "table",
+	}}}
+}
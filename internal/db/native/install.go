@@ -0,0 +1,207 @@
+package native
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Pinned Postgres builds for each supported major version.
+var PostgresVersions = map[uint]string{
+	14: "14.13.0",
+	15: "15.8.0",
+}
+
+var BinaryHost = "https://github.com/theseus-rs/postgresql-binaries/releases/download"
+
+var (
+	// Release assets are verified against digests pinned in the source tree, in sha256sum format.
+	//go:embed templates/checksums.txt
+	checksumsTxt string
+
+	Checksums = parseChecksums(checksumsTxt)
+)
+
+func parseChecksums(data string) map[string]string {
+	result := map[string]string{}
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		result[strings.TrimPrefix(fields[1], "*")] = fields[0]
+	}
+	return result
+}
+
+// Locates the directory containing initdb, pg_ctl and psql, downloading a pinned build if necessary.
+func FindBinDir(ctx context.Context, fsys afero.Fs) (string, error) {
+	if dir := os.Getenv("SUPABASE_PG_BIN_DIR"); len(dir) > 0 {
+		return dir, nil
+	}
+	version, ok := PostgresVersions[utils.Config.Db.MajorVersion]
+	if !ok {
+		return "", fmt.Errorf("Native Postgres is not available for %s: %d", utils.Aqua("db.major_version"), utils.Config.Db.MajorVersion)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	installDir := filepath.Join(home, ".supabase", "postgres", version)
+	binDir := filepath.Join(installDir, "bin")
+	if _, err := fsys.Stat(filepath.Join(binDir, "pg_ctl")); err == nil {
+		return binDir, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	if err := install(ctx, version, installDir, fsys); err != nil {
+		return "", err
+	}
+	return binDir, nil
+}
+
+func getTarget() (string, error) {
+	switch runtime.GOOS + "/" + runtime.GOARCH {
+	case "linux/amd64":
+		return "x86_64-unknown-linux-gnu", nil
+	case "linux/arm64":
+		return "aarch64-unknown-linux-gnu", nil
+	case "darwin/amd64":
+		return "x86_64-apple-darwin", nil
+	case "darwin/arm64":
+		return "aarch64-apple-darwin", nil
+	}
+	return "", fmt.Errorf("Native Postgres is not available for %s/%s. Set SUPABASE_PG_BIN_DIR to the directory containing pg_ctl.", runtime.GOOS, runtime.GOARCH)
+}
+
+func install(ctx context.Context, version, installDir string, fsys afero.Fs) error {
+	target, err := getTarget()
+	if err != nil {
+		return err
+	}
+	assetName := fmt.Sprintf("postgresql-%s-%s.tar.gz", version, target)
+	checksum, ok := Checksums[assetName]
+	if !ok {
+		return fmt.Errorf("No pinned checksum for %s. Set SUPABASE_PG_BIN_DIR to the directory containing pg_ctl.", assetName)
+	}
+	fmt.Fprintln(os.Stderr, "Downloading Postgres", version+"...")
+	assetUrl := fmt.Sprintf("%s/%s/%s", BinaryHost, version, assetName)
+	archive, err := download(ctx, assetUrl)
+	if err != nil {
+		return err
+	}
+	if err := verifyChecksum(archive, checksum); err != nil {
+		return fmt.Errorf("failed to verify %s: %w", assetName, err)
+	}
+	// Extract to a temporary directory so that interrupted installs are not picked up
+	tmpDir := installDir + ".tmp"
+	if err := fsys.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := extract(bytes.NewReader(archive), tmpDir, fsys); err != nil {
+		return err
+	}
+	return fsys.Rename(tmpDir, installDir)
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func verifyChecksum(data []byte, expected string) error {
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// Extracts a gzipped tarball, stripping the top level directory.
+func extract(r io.Reader, dstDir string, fsys afero.Fs) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		name := path.Clean(hdr.Name)
+		parts := strings.SplitN(name, "/", 2)
+		if len(parts) < 2 || parts[1] == ".." || strings.HasPrefix(parts[1], "../") {
+			continue
+		}
+		dstPath := filepath.Join(dstDir, filepath.FromSlash(parts[1]))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := fsys.MkdirAll(dstPath, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(dstPath)); err != nil {
+				return err
+			}
+			f, err := fsys.OpenFile(dstPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			// Shared libraries are versioned through symlinks, which must not escape the install dir
+			if filepath.IsAbs(hdr.Linkname) {
+				return fmt.Errorf("refusing absolute symlink %s -> %s", hdr.Name, hdr.Linkname)
+			}
+			target := filepath.Join(filepath.Dir(dstPath), filepath.FromSlash(hdr.Linkname))
+			if rel, err := filepath.Rel(dstDir, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return fmt.Errorf("refusing symlink outside install dir %s -> %s", hdr.Name, hdr.Linkname)
+			}
+			if linker, ok := fsys.(afero.Linker); ok {
+				if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(dstPath)); err != nil {
+					return err
+				}
+				if err := linker.SymlinkIfPossible(hdr.Linkname, dstPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package native
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const ShadowDatabase = "shadow"
+
+var (
+	//go:embed templates/roles.sql
+	rolesSql string
+
+	DataDir = filepath.Join(utils.NativeDbDir, "data")
+	LogPath = filepath.Join(utils.NativeDbDir, "postgres.log")
+)
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := Start(ctx, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Started %s native Postgres without Docker.\n\n", utils.Aqua("supabase"))
+	fmt.Printf("DB URL: postgresql://postgres:%s@127.0.0.1:%d/postgres\n", utils.Config.Db.Password, utils.Config.Db.Port)
+	return nil
+}
+
+// Initialises the data directory with password authentication for the configured superuser.
+func initCluster(ctx context.Context, binDir string) error {
+	pwfile, err := os.CreateTemp("", "supabase-pwfile-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(pwfile.Name())
+	_, err = pwfile.WriteString(utils.Config.Db.Password + "\n")
+	if closeErr := pwfile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	initdb := exec.CommandContext(ctx, filepath.Join(binDir, "initdb"), "-D", DataDir, "-U", "postgres", "-A", "scram-sha-256", "--pwfile="+pwfile.Name(), "-E", "UTF8", "--no-sync")
+	initdb.Stderr = os.Stderr
+	if err := initdb.Run(); err != nil {
+		return fmt.Errorf("failed to initialise cluster: %w", err)
+	}
+	return nil
+}
+
+func Start(ctx context.Context, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if utils.IsNativeDbRunning() {
+		fmt.Fprintln(os.Stderr, "Native Postgres is already running.")
+		return nil
+	}
+	binDir, err := FindBinDir(ctx, fsys)
+	if err != nil {
+		return err
+	}
+	fresh := false
+	if _, err := fsys.Stat(filepath.Join(DataDir, "PG_VERSION")); errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintln(os.Stderr, "Initialising native Postgres cluster in", utils.Bold(DataDir)+"...")
+		if err := utils.MkdirIfNotExistFS(fsys, utils.NativeDbDir); err != nil {
+			return err
+		}
+		if err := initCluster(ctx, binDir); err != nil {
+			return err
+		}
+		fresh = true
+	} else if err != nil {
+		return err
+	}
+	if err := pgCtl(ctx, binDir, "start", "-l", LogPath, "-o", serverOptions()); err != nil {
+		return fmt.Errorf("failed to start Postgres, see %s: %w", LogPath, err)
+	}
+	if !fresh {
+		return nil
+	}
+	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{}, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	return initDatabase(ctx, "", conn, fsys)
+}
+
+func Stop(ctx context.Context, fsys afero.Fs) error {
+	binDir, err := FindBinDir(ctx, fsys)
+	if err != nil {
+		return err
+	}
+	return pgCtl(ctx, binDir, "stop", "-m", "fast")
+}
+
+// Recreates the postgres database, then applies roles, migrations and seed.
+func Reset(ctx context.Context, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	fmt.Fprintln(os.Stderr, "Recreating native database...")
+	admin, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Database: "template1"}, options...)
+	if err != nil {
+		return err
+	}
+	defer admin.Close(context.Background())
+	if _, err := admin.Exec(ctx, "DROP DATABASE IF EXISTS postgres WITH (FORCE)"); err != nil {
+		return err
+	}
+	if _, err := admin.Exec(ctx, "CREATE DATABASE postgres"); err != nil {
+		return err
+	}
+	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{}, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	return initDatabase(ctx, version, conn, fsys)
+}
+
+// Creates an empty database with roles and schemas on the native cluster for diffing migrations.
+func CreateShadowDatabase(ctx context.Context, options ...func(*pgx.ConnConfig)) (string, error) {
+	fmt.Fprintln(os.Stderr, "Creating native shadow database...")
+	admin, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Database: "template1"}, options...)
+	if err != nil {
+		return "", err
+	}
+	defer admin.Close(context.Background())
+	if _, err := admin.Exec(ctx, "DROP DATABASE IF EXISTS "+ShadowDatabase+" WITH (FORCE)"); err != nil {
+		return "", err
+	}
+	if _, err := admin.Exec(ctx, "CREATE DATABASE "+ShadowDatabase); err != nil {
+		return "", err
+	}
+	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Database: ShadowDatabase}, options...)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, rolesSql); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("postgresql://postgres:%s@127.0.0.1:%d/%s", utils.Config.Db.Password, utils.Config.Db.Port, ShadowDatabase), nil
+}
+
+func initDatabase(ctx context.Context, version string, conn *pgx.Conn, fsys afero.Fs) error {
+	fmt.Fprintln(os.Stderr, "Setting up roles and schemas...")
+	if _, err := conn.Exec(ctx, rolesSql); err != nil {
+		return err
+	}
+	return apply.MigrateAndSeed(ctx, version, conn, fsys)
+}
+
+// Listens on the same port as the database container so connection strings are unchanged.
+func serverOptions() string {
+	return strings.Join([]string{
+		"-p", strconv.FormatUint(uint64(utils.Config.Db.Port), 10),
+		"-c", "listen_addresses=127.0.0.1",
+		// Avoids permission errors on the default /var/run/postgresql socket directory
+		"-c", "unix_socket_directories=''",
+	}, " ")
+}
+
+func pgCtl(ctx context.Context, binDir string, args ...string) error {
+	args = append([]string{"-D", DataDir, "-w"}, args...)
+	cmd := exec.CommandContext(ctx, filepath.Join(binDir, "pg_ctl"), args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,166 @@
+package native
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestFindBinDir(t *testing.T) {
+	t.Run("uses bin dir from env", func(t *testing.T) {
+		t.Setenv("SUPABASE_PG_BIN_DIR", "/opt/postgres/bin")
+		// Run test
+		dir, err := FindBinDir(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "/opt/postgres/bin", dir)
+	})
+
+	t.Run("downloads pinned binaries", func(t *testing.T) {
+		target, err := getTarget()
+		if err != nil {
+			t.Skip(err)
+		}
+		utils.Config.Db.MajorVersion = 15
+		version := PostgresVersions[15]
+		archive := newArchive(t, map[string]string{
+			"postgresql-" + version + "/bin/pg_ctl": "binary",
+		})
+		sum := sha256.Sum256(archive)
+		assetName := fmt.Sprintf("postgresql-%s-%s.tar.gz", version, target)
+		Checksums[assetName] = hex.EncodeToString(sum[:])
+		defer delete(Checksums, assetName)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New(BinaryHost).
+			Get("/" + version + "/" + assetName).
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(archive))
+		// Run test
+		dir, err := FindBinDir(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, strings.HasSuffix(dir, filepath.Join(".supabase", "postgres", version, "bin")))
+		data, err := afero.ReadFile(fsys, filepath.Join(dir, "pg_ctl"))
+		assert.NoError(t, err)
+		assert.Equal(t, "binary", string(data))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on checksum mismatch", func(t *testing.T) {
+		target, err := getTarget()
+		if err != nil {
+			t.Skip(err)
+		}
+		utils.Config.Db.MajorVersion = 15
+		assetName := fmt.Sprintf("postgresql-%s-%s.tar.gz", PostgresVersions[15], target)
+		Checksums[assetName] = "0000"
+		defer delete(Checksums, assetName)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New(BinaryHost).
+			Get("/").
+			Reply(http.StatusOK).
+			BodyString("tampered")
+		// Run test
+		_, err = FindBinDir(context.Background(), fsys)
+		// Check error
+		assert.ErrorContains(t, err, "checksum mismatch")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on unpinned asset", func(t *testing.T) {
+		if _, err := getTarget(); err != nil {
+			t.Skip(err)
+		}
+		utils.Config.Db.MajorVersion = 15
+		// Run test
+		_, err := FindBinDir(context.Background(), afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "No pinned checksum for")
+	})
+}
+
+func TestExtract(t *testing.T) {
+	t.Run("throws error on symlink outside install dir", func(t *testing.T) {
+		for _, link := range []string{"/etc/passwd", "../../outside", "lib/../../../outside"} {
+			archive := newArchive(t, nil, map[string]string{"postgresql/lib/libpq.so": link})
+			// Run test
+			err := extract(bytes.NewReader(archive), "/tmp/postgres", afero.NewOsFs())
+			// Check error
+			assert.ErrorContains(t, err, "refusing", link)
+		}
+	})
+
+	t.Run("extracts relative symlink", func(t *testing.T) {
+		dstDir := filepath.Join(t.TempDir(), "postgres")
+		archive := newArchive(t, map[string]string{
+			"postgresql/lib/libpq.so.5.15": "library",
+		}, map[string]string{
+			"postgresql/lib/libpq.so": "libpq.so.5.15",
+		})
+		// Run test
+		err := extract(bytes.NewReader(archive), dstDir, afero.NewOsFs())
+		// Check error
+		assert.NoError(t, err)
+		data, err := os.ReadFile(filepath.Join(dstDir, "lib", "libpq.so"))
+		assert.NoError(t, err)
+		assert.Equal(t, "library", string(data))
+	})
+}
+
+func newArchive(t *testing.T, files map[string]string, links ...map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0755,
+			Size: int64(len(body)),
+		}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	for _, m := range links {
+		for name, target := range m {
+			require.NoError(t, tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeSymlink,
+				Name:     name,
+				Linkname: target,
+				Mode:     0777,
+			}))
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestServerOptions(t *testing.T) {
+	utils.Config.Db.Port = 54322
+	// Run test
+	opts := serverOptions()
+	// Check output
+	assert.Equal(t, "-p 54322 -c listen_addresses=127.0.0.1 -c unix_socket_directories=''", opts)
+}
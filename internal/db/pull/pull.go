@@ -53,7 +53,7 @@ func Run(ctx context.Context, schema []string, config pgconn.Config, name string
 	// 4. Insert a row to `schema_migrations`
 	fmt.Fprintln(os.Stderr, "Schema written to "+utils.Bold(path))
 	if shouldUpdate := utils.PromptYesNo("Update remote migration history table?", true, os.Stdin); shouldUpdate {
-		return repair.UpdateMigrationTable(ctx, conn, timestamp, repair.Applied, fsys)
+		return repair.UpdateMigrationTable(ctx, conn, []string{timestamp}, repair.Applied, fsys)
 	}
 	return nil
 }
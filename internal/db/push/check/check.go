@@ -0,0 +1,306 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/docker/go-units"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/diff"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/migration/up"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/fanout"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+const (
+	LockAccessExclusive      = "ACCESS EXCLUSIVE"
+	LockShareRowExclusive    = "SHARE ROW EXCLUSIVE"
+	LockShare                = "SHARE"
+	LockShareUpdateExclusive = "SHARE UPDATE EXCLUSIVE"
+	LockRowExclusive         = "ROW EXCLUSIVE"
+
+	// Table is read in full while holding the lock, ie. to validate a constraint or build an index
+	CostScan = "scan"
+	// Table and its indexes are rewritten while holding the lock
+	CostRewrite = "rewrite"
+
+	TABLE_SIZE_QUERY = "SELECT COALESCE(pg_total_relation_size(to_regclass($1)), 0)"
+)
+
+var (
+	commentPattern = regexp.MustCompile(`(?s)--[^\n]*|/\*.*?\*/`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+	// Each pattern captures the optionally schema qualified table name, ie. ALTER TABLE IF EXISTS ONLY public.todos
+	alterTablePattern = regexp.MustCompile(`(?i)^ALTER TABLE (?:IF EXISTS )?(?:ONLY )?` + qualifiedName)
+	dropTablePattern  = regexp.MustCompile(`(?i)^DROP TABLE (?:IF EXISTS )?` + qualifiedName)
+	truncatePattern   = regexp.MustCompile(`(?i)^TRUNCATE (?:TABLE )?(?:ONLY )?` + qualifiedName)
+	indexPattern      = regexp.MustCompile(`(?i)^CREATE (?:UNIQUE )?INDEX( CONCURRENTLY)?.*? ON (?:ONLY )?` + qualifiedName)
+	dmlPattern        = regexp.MustCompile(`(?i)^(?:INSERT INTO|UPDATE(?: ONLY)?|DELETE FROM(?: ONLY)?) ` + qualifiedName)
+
+	dropColumnPattern    = regexp.MustCompile(`\bDROP (?:COLUMN )?(?:IF EXISTS )?("[^"]+"|\w+)(?: CASCADE| RESTRICT)?(?:,|$)`)
+	alterTypePattern     = regexp.MustCompile(`\bALTER (?:COLUMN )?("[^"]+"|\w+) (?:SET DATA )?TYPE\b`)
+	setNotNullPattern    = regexp.MustCompile(`\bALTER (?:COLUMN )?("[^"]+"|\w+) SET NOT NULL\b`)
+	addConstraintPattern = regexp.MustCompile(`\bADD (?:CONSTRAINT \S+ )?(CHECK|FOREIGN KEY|UNIQUE|PRIMARY KEY|EXCLUDE)\b`)
+	volatileDefault      = regexp.MustCompile(`\bADD (?:COLUMN )?.*\bDEFAULT (?:GEN_RANDOM_UUID|UUID_GENERATE_V\d|RANDOM|CLOCK_TIMESTAMP|TIMEOFDAY)\s*\(`)
+	storedGenerated      = regexp.MustCompile(`\bADD (?:COLUMN )?.*\bGENERATED ALWAYS AS \(.*\) STORED\b`)
+	// Subcommands that start with DROP but do not remove a column
+	dropKeywords = map[string]bool{"CONSTRAINT": true, "DEFAULT": true, "EXPRESSION": true, "IDENTITY": true}
+)
+
+const qualifiedName = `("[^"]+"|[^\s"(.,;]+)(?:\.("[^"]+"|[^\s"(.,;]+))?`
+
+// Risk assessment of a single statement in a pending migration.
+type Finding struct {
+	Migration string `json:"migration"`
+	// Position of the statement within its migration file, starting from 1
+	Statement   int    `json:"statement"`
+	Sql         string `json:"sql"`
+	Lock        string `json:"lock,omitempty"`
+	Table       string `json:"table,omitempty"`
+	Cost        string `json:"cost,omitempty"`
+	TableSize   int64  `json:"table_size,omitempty"`
+	Destructive string `json:"destructive,omitempty"`
+}
+
+type Report struct {
+	Migrations  []string  `json:"migrations"`
+	Findings    []Finding `json:"findings"`
+	Destructive int       `json:"destructive"`
+	Blocked     bool      `json:"blocked"`
+}
+
+// Gates a push by analysing pending migrations and replaying them on a shadow database.
+func Run(ctx context.Context, ignoreVersionMismatch, allowDestructive bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if pending, err := Analyze(ctx, ignoreVersionMismatch, allowDestructive, config, fsys, options...); err != nil || len(pending) == 0 {
+		return err
+	}
+	return CheckShadowDatabase(ctx, fsys)
+}
+
+// Reports locking and destructive statements in migrations pending on the given database. Unlike
+// the shadow database check, this is safe to run concurrently for multiple projects.
+func Analyze(ctx context.Context, ignoreVersionMismatch, allowDestructive bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) ([]string, error) {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(context.Background())
+	pending, err := up.GetPendingMigrations(ctx, ignoreVersionMismatch, conn, fsys)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		fmt.Fprintln(fanout.Stderr(ctx), "No pending migrations to check.")
+		return nil, nil
+	}
+	report, err := AnalyzeMigrations(ctx, pending, conn, fsys)
+	if err != nil {
+		return nil, err
+	}
+	report.Blocked = report.Destructive > 0 && !allowDestructive
+	if err := writeReport(ctx, report); err != nil {
+		return nil, err
+	}
+	if report.Blocked {
+		return nil, fmt.Errorf("Found %d destructive statements in pending migrations. Re-run with %s to push anyway.", report.Destructive, utils.Aqua("--allow-destructive"))
+	}
+	return pending, nil
+}
+
+func AnalyzeMigrations(ctx context.Context, pending []string, conn *pgx.Conn, fsys afero.Fs) (Report, error) {
+	report := Report{Migrations: pending}
+	sizes := map[string]int64{}
+	for _, filename := range pending {
+		migration, err := repair.NewMigrationFromFile(filepath.Join(utils.MigrationsDir, filename), fsys)
+		if err != nil {
+			return report, err
+		}
+		for i, line := range migration.Lines {
+			finding := AnalyzeStatement(line)
+			if len(finding.Lock) == 0 && len(finding.Destructive) == 0 {
+				continue
+			}
+			finding.Migration = filename
+			finding.Statement = i + 1
+			// Only report sizes of existing tables that are scanned, rewritten or dropped
+			if len(finding.Table) > 0 && (len(finding.Cost) > 0 || len(finding.Destructive) > 0) {
+				size, ok := sizes[finding.Table]
+				if !ok {
+					if err := conn.QueryRow(ctx, TABLE_SIZE_QUERY, finding.Table).Scan(&size); err != nil {
+						return report, fmt.Errorf("failed to estimate table size: %w", err)
+					}
+					sizes[finding.Table] = size
+				}
+				finding.TableSize = size
+			}
+			if len(finding.Destructive) > 0 {
+				report.Destructive++
+			}
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+	return report, nil
+}
+
+// Estimates the lock level and cost of a statement from its syntax alone.
+func AnalyzeStatement(sql string) Finding {
+	finding := Finding{Sql: strings.TrimSpace(sql)}
+	stmt := normalize(sql)
+	upper := strings.ToUpper(stmt)
+	if m := alterTablePattern.FindStringSubmatch(stmt); m != nil {
+		finding.Table = tableName(m[1], m[2])
+		finding.Lock = LockAccessExclusive
+		body := strings.ToUpper(stmt[len(m[0]):])
+		if c := addConstraintPattern.FindStringSubmatch(body); c != nil {
+			if c[1] == "FOREIGN KEY" {
+				finding.Lock = LockShareRowExclusive
+			}
+			// Not valid constraints are only enforced on new rows, skipping the initial scan
+			if !strings.Contains(body, "NOT VALID") {
+				finding.Cost = CostScan
+			}
+		}
+		if strings.Contains(body, "VALIDATE CONSTRAINT") {
+			finding.Lock = LockShareUpdateExclusive
+			finding.Cost = CostScan
+		}
+		if setNotNullPattern.MatchString(body) {
+			finding.Cost = CostScan
+		}
+		if volatileDefault.MatchString(body) || storedGenerated.MatchString(body) {
+			finding.Cost = CostRewrite
+		}
+		if alterTypePattern.MatchString(body) {
+			finding.Cost = CostRewrite
+			finding.Destructive = "changes column type"
+		}
+		for _, c := range dropColumnPattern.FindAllStringSubmatch(body, -1) {
+			if !dropKeywords[c[1]] {
+				finding.Destructive = "drops column"
+			}
+		}
+	} else if m := dropTablePattern.FindStringSubmatch(stmt); m != nil {
+		finding.Table = tableName(m[1], m[2])
+		finding.Lock = LockAccessExclusive
+		finding.Destructive = "drops table"
+	} else if strings.HasPrefix(upper, "DROP SCHEMA ") {
+		finding.Lock = LockAccessExclusive
+		finding.Destructive = "drops schema"
+	} else if m := truncatePattern.FindStringSubmatch(stmt); m != nil {
+		finding.Table = tableName(m[1], m[2])
+		finding.Lock = LockAccessExclusive
+		finding.Destructive = "truncates table"
+	} else if m := indexPattern.FindStringSubmatch(stmt); m != nil {
+		finding.Table = tableName(m[2], m[3])
+		finding.Cost = CostScan
+		finding.Lock = LockShare
+		if len(m[1]) > 0 {
+			finding.Lock = LockShareUpdateExclusive
+		}
+	} else if strings.HasPrefix(upper, "CREATE TRIGGER ") || strings.HasPrefix(upper, "CREATE OR REPLACE TRIGGER ") {
+		finding.Lock = LockShareRowExclusive
+	} else if m := dmlPattern.FindStringSubmatch(stmt); m != nil {
+		finding.Table = tableName(m[1], m[2])
+		finding.Lock = LockRowExclusive
+		if strings.HasPrefix(upper, "DELETE ") && !strings.Contains(upper, " WHERE ") {
+			finding.Destructive = "deletes all rows"
+		}
+	}
+	return finding
+}
+
+// Collapses comments and whitespace so that patterns can match on single spaces.
+func normalize(sql string) string {
+	sql = commentPattern.ReplaceAllString(sql, " ")
+	sql = spacePattern.ReplaceAllString(sql, " ")
+	return strings.TrimRight(strings.TrimSpace(sql), "; ")
+}
+
+// Unquoted identifiers are folded to lower case, matching how Postgres resolves them.
+func tableName(parts ...string) string {
+	var result []string
+	for _, p := range parts {
+		if len(p) == 0 {
+			continue
+		}
+		if !strings.HasPrefix(p, `"`) {
+			p = strings.ToLower(p)
+		}
+		result = append(result, p)
+	}
+	return strings.Join(result, ".")
+}
+
+func writeReport(ctx context.Context, report Report) error {
+	if !render.IsPretty() {
+		return utils.EncodeOutput(render.Format.Value, fanout.Stdout(ctx), report)
+	}
+	if len(report.Findings) == 0 {
+		fmt.Fprintln(fanout.Stderr(ctx), "No locking or destructive statements found in pending migrations.")
+		return nil
+	}
+	table := "|MIGRATION|STATEMENT|LOCK|TABLE|COST|DESTRUCTIVE|\n|-|-|-|-|-|-|\n"
+	for _, f := range report.Findings {
+		cost := f.Cost
+		if len(cost) > 0 && len(f.Table) > 0 {
+			cost += " " + units.HumanSize(float64(f.TableSize))
+		}
+		table += fmt.Sprintf("|`%s`|%d|%s|%s|%s|%s|\n", f.Migration, f.Statement, cell(f.Lock), cell(f.Table), cell(cost), cell(f.Destructive))
+	}
+	return list.RenderTableTo(fanout.Stdout(ctx), table)
+}
+
+func cell(value string) string {
+	if len(value) == 0 {
+		return " "
+	}
+	return strings.ReplaceAll(value, "|", "\\|")
+}
+
+// Replays migrations on the shadow database at most once, when the first project with pending
+// migrations asks for it, so that pushing up to date projects never starts a container.
+type ShadowCheck struct {
+	once sync.Once
+	err  error
+}
+
+func (s *ShadowCheck) Run(ctx context.Context, fsys afero.Fs) error {
+	s.once.Do(func() {
+		s.err = CheckShadowDatabase(ctx, fsys)
+	})
+	return s.err
+}
+
+// Replays all local migrations on an ephemeral database to catch errors before touching the remote.
+// Migrations are the same for every project, so this only needs to run once per push.
+func CheckShadowDatabase(ctx context.Context, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Creating shadow database...")
+	shadow, err := diff.CreateShadowDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer utils.DockerRemove(shadow)
+	if !start.WaitForHealthyService(ctx, shadow, start.HealthTimeout) {
+		return start.ErrDatabase
+	}
+	if err := diff.MigrateShadowDatabase(ctx, shadow, fsys); err != nil {
+		return errors.New("Failed to apply migrations on shadow database: " + err.Error())
+	}
+	fmt.Fprintln(os.Stderr, "Migrations applied cleanly on shadow database.")
+	return nil
+}
@@ -0,0 +1,121 @@
+package check
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestAnalyzeStatement(t *testing.T) {
+	cases := []struct {
+		sql      string
+		expected Finding
+	}{{
+		sql:      "ALTER TABLE public.todos DROP COLUMN done;",
+		expected: Finding{Lock: LockAccessExclusive, Table: "public.todos", Destructive: "drops column"},
+	}, {
+		sql:      "alter table todos alter column id type bigint",
+		expected: Finding{Lock: LockAccessExclusive, Table: "todos", Cost: CostRewrite, Destructive: "changes column type"},
+	}, {
+		sql:      `ALTER TABLE "Todos" ALTER COLUMN done DROP DEFAULT, DROP CONSTRAINT todos_pkey`,
+		expected: Finding{Lock: LockAccessExclusive, Table: `"Todos"`},
+	}, {
+		sql:      "ALTER TABLE todos ADD CONSTRAINT fk FOREIGN KEY (user_id) REFERENCES users (id)",
+		expected: Finding{Lock: LockShareRowExclusive, Table: "todos", Cost: CostScan},
+	}, {
+		sql:      "ALTER TABLE todos ADD CONSTRAINT fk FOREIGN KEY (user_id) REFERENCES users (id) NOT VALID",
+		expected: Finding{Lock: LockShareRowExclusive, Table: "todos"},
+	}, {
+		sql:      "ALTER TABLE todos ADD COLUMN uid uuid DEFAULT gen_random_uuid()",
+		expected: Finding{Lock: LockAccessExclusive, Table: "todos", Cost: CostRewrite},
+	}, {
+		sql:      "-- add index\nCREATE INDEX CONCURRENTLY idx ON ONLY public.todos (done)",
+		expected: Finding{Lock: LockShareUpdateExclusive, Table: "public.todos", Cost: CostScan},
+	}, {
+		sql:      "DROP TABLE IF EXISTS public.todos CASCADE",
+		expected: Finding{Lock: LockAccessExclusive, Table: "public.todos", Destructive: "drops table"},
+	}, {
+		sql:      "DELETE FROM todos",
+		expected: Finding{Lock: LockRowExclusive, Table: "todos", Destructive: "deletes all rows"},
+	}, {
+		sql:      "CREATE TABLE todos (id bigint)",
+		expected: Finding{},
+	}}
+	for _, c := range cases {
+		t.Run(c.sql, func(t *testing.T) {
+			c.expected.Sql = c.sql
+			// Run test
+			finding := AnalyzeStatement(c.sql)
+			// Check error
+			assert.Equal(t, c.expected, finding)
+		})
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	t.Run("blocks destructive migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		sql := "create index on todos (done);\nalter table todos drop column done;"
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0").
+			Query(TABLE_SIZE_QUERY, "todos").
+			Reply("SELECT 1", []interface{}{int64(8192)})
+		// Run test
+		err := Run(context.Background(), false, false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Found 1 destructive statements in pending migrations.")
+	})
+
+	t.Run("ignores up to date", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), false, false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
+
+func TestShadowCheck(t *testing.T) {
+	t.Run("replays migrations only once", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		var shadow ShadowCheck
+		// Run test
+		err := shadow.Run(context.Background(), fsys)
+		// Check error
+		assert.Error(t, err)
+		// Subsequent calls return the same result without creating a shadow database
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		assert.Equal(t, err, shadow.Run(context.Background(), fsys))
+	})
+}
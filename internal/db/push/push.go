@@ -13,11 +13,15 @@ import (
 	"github.com/supabase/cli/internal/migration/apply"
 	"github.com/supabase/cli/internal/migration/up"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/fanout"
+	"github.com/supabase/cli/internal/utils/render"
 )
 
+const stepMigrations = "migrations"
+
 func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles, includeSeed bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	if dryRun {
-		fmt.Fprintln(os.Stderr, "DRY RUN: migrations will *not* be pushed to the database.")
+		fmt.Fprintln(fanout.Stderr(ctx), "DRY RUN: migrations will *not* be pushed to the database.")
 	}
 	conn, err := utils.ConnectByConfig(ctx, config, options...)
 	if err != nil {
@@ -26,7 +30,7 @@ func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles,
 	defer conn.Close(context.Background())
 	// Create roles
 	if !dryRun && includeRoles {
-		if err := CreateCustomRoles(ctx, conn, os.Stderr, fsys); err != nil {
+		if err := CreateCustomRoles(ctx, conn, fanout.Stderr(ctx), fsys); err != nil {
 			return err
 		}
 	}
@@ -34,14 +38,25 @@ func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles,
 	if err != nil {
 		return err
 	}
-	if len(pending) == 0 {
-		fmt.Println("Linked project is up to date.")
+	// Applied migrations are tracked in the remote history table, but a failed seed is not
+	state, err := utils.LoadResumeState("db-push-"+config.Host, fsys)
+	if err != nil {
+		return err
+	}
+	resumeSeed := !dryRun && includeSeed && state.Done(stepMigrations, "")
+	// Keeps stdout parseable when it carries a machine readable check report
+	out := fanout.Stdout(ctx)
+	if !render.IsPretty() {
+		out = fanout.Stderr(ctx)
+	}
+	if len(pending) == 0 && !resumeSeed {
+		fmt.Fprintln(out, "Linked project is up to date.")
 		return nil
 	}
 	// Push pending migrations
 	if dryRun {
 		for _, filename := range pending {
-			fmt.Fprintln(os.Stderr, "Would push migration "+utils.Bold(filename)+"...")
+			fmt.Fprintln(fanout.Stderr(ctx), "Would push migration "+utils.Bold(filename)+"...")
 		}
 	} else {
 		if err := apply.MigrateUp(ctx, conn, pending, fsys); err != nil {
@@ -50,11 +65,18 @@ func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles,
 	}
 	// Seed database
 	if !dryRun && includeSeed {
+		if err := state.MarkDone(stepMigrations, ""); err != nil {
+			return err
+		}
 		if err := apply.SeedDatabase(ctx, conn, fsys); err != nil {
+			fmt.Fprintln(fanout.Stderr(ctx), "Re-run the same command to resume seeding the database.")
+			return err
+		}
+		if err := state.Clear(); err != nil {
 			return err
 		}
 	}
-	fmt.Println("Finished " + utils.Aqua("supabase db push") + ".")
+	fmt.Fprintln(out, "Finished "+utils.Aqua("supabase db push")+".")
 	return nil
 }
 
@@ -132,4 +132,27 @@ func TestMigrationPush(t *testing.T) {
 		assert.ErrorContains(t, err, `ERROR: null value in column "version" of relation "schema_migrations" (SQLSTATE 23502)`)
 		assert.ErrorContains(t, err, "At statement 0: "+repair.INSERT_MIGRATION_VERSION)
 	})
+	t.Run("resumes seeding after failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		sql := "INSERT INTO employees(name) VALUES ('Alice')"
+		require.NoError(t, afero.WriteFile(fsys, utils.SeedDataPath, []byte(sql), 0644))
+		state, err := utils.LoadResumeState("db-push-"+dbConfig.Host, fsys)
+		require.NoError(t, err)
+		require.NoError(t, state.MarkDone(stepMigrations, ""))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0").
+			Query(sql).
+			Reply("INSERT 0 1")
+		// Run test
+		err = Run(context.Background(), false, false, false, true, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, filepath.Join(utils.ResumeDir, "db-push-"+dbConfig.Host+".json"))
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
 }
@@ -64,7 +64,7 @@ func run(p utils.Program, ctx context.Context, schema []string, config pgconn.Co
 	}
 
 	// 3. Insert a row to `schema_migrations`
-	return repair.UpdateMigrationTable(ctx, conn, timestamp, repair.Applied, fsys)
+	return repair.UpdateMigrationTable(ctx, conn, []string{timestamp}, repair.Applied, fsys)
 }
 
 func fetchRemote(p utils.Program, ctx context.Context, schema []string, timestamp string, config pgconn.Config, fsys afero.Fs) error {
@@ -74,7 +74,7 @@ func fetchRemote(p utils.Program, ctx context.Context, schema []string, timestam
 		return err
 	} else if len(migrations) == 0 {
 		p.Send(utils.StatusMsg("Committing initial migration on remote database..."))
-		return dump.Run(ctx, path, config, nil, false, false, false, false, false, fsys)
+		return dump.Run(ctx, path, config, nil, nil, nil, false, false, false, false, false, fsys)
 	}
 
 	w := utils.StatusWriter{Program: p}
@@ -0,0 +1,48 @@
+package drop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	DROP_SLOT        = "SELECT pg_drop_replication_slot(slot_name) FROM pg_replication_slots WHERE slot_name = $1"
+	DROP_PUBLICATION = "DROP PUBLICATION IF EXISTS %s"
+)
+
+// Removes the replication slot so the database stops retaining WAL for a consumer that is gone.
+func Run(ctx context.Context, slot, publication string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	title := fmt.Sprintf("Do you want to drop replication slot %s? Consumers will need a full resync.", utils.Aqua(slot))
+	if !utils.PromptYesNo(title, false, os.Stdin) {
+		return errors.New("Not dropping replication slot.")
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	tag, err := conn.Exec(ctx, DROP_SLOT, slot)
+	if err != nil {
+		return fmt.Errorf("failed to drop replication slot: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		fmt.Fprintln(os.Stderr, "Replication slot not found:", utils.Aqua(slot))
+	} else {
+		fmt.Println("Dropped replication slot " + utils.Aqua(slot) + ".")
+	}
+	if len(publication) == 0 {
+		return nil
+	}
+	if _, err := conn.Exec(ctx, fmt.Sprintf(DROP_PUBLICATION, pgx.Identifier{publication}.Sanitize())); err != nil {
+		return fmt.Errorf("failed to drop publication: %w", err)
+	}
+	fmt.Println("Dropped publication " + utils.Aqua(publication) + ".")
+	return nil
+}
@@ -0,0 +1,84 @@
+package drop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestDropCommand(t *testing.T) {
+	t.Run("drops slot and publication", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(DROP_SLOT, "supabase_etl").
+			Reply("SELECT 1", []interface{}{""}).
+			Query(`DROP PUBLICATION IF EXISTS "supabase_etl"`).
+			Reply("DROP PUBLICATION")
+		// Run test
+		err := Run(context.Background(), "supabase_etl", "supabase_etl", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips missing slot", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(DROP_SLOT, "supabase_etl").
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), "supabase_etl", "", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("declines drop by default", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "supabase_etl", "supabase_etl", dbConfig, fsys, func(cc *pgx.ConnConfig) {
+			t.Error("should not connect")
+		})
+		// Check error
+		assert.ErrorContains(t, err, "Not dropping replication slot.")
+	})
+
+	t.Run("throws error on active slot", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(DROP_SLOT, "supabase_etl").
+			ReplyError("55006", `replication slot "supabase_etl" is active for PID 42`)
+		// Run test
+		err := Run(context.Background(), "supabase_etl", "supabase_etl", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "failed to drop replication slot:")
+	})
+}
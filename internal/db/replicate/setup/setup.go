@@ -0,0 +1,255 @@
+package setup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	PluginPgoutput = "pgoutput"
+	PluginWal2json = "wal2json"
+
+	FormatSql  = "sql"
+	FormatJson = "json"
+
+	CHECK_WAL_LEVEL    = "SELECT current_setting('wal_level')"
+	CHECK_PUBLICATION  = "SELECT EXISTS (SELECT 1 FROM pg_publication WHERE pubname = $1)"
+	CHECK_SLOT         = "SELECT plugin FROM pg_replication_slots WHERE slot_name = $1 AND slot_type = 'logical'"
+	CREATE_SLOT        = "SELECT pg_create_logical_replication_slot($1, $2)"
+	CREATE_PUBLICATION = "CREATE PUBLICATION %s FOR TABLE %s"
+	ALTER_PUBLICATION  = "ALTER PUBLICATION %s SET TABLE %s"
+	LIST_USER_TABLES   = "SELECT schemaname, tablename FROM pg_tables WHERE schemaname = ANY($1) ORDER BY schemaname, tablename"
+
+	// Consumers are expected to supply the password themselves, so it is never printed.
+	passwordPlaceholder = "<password>"
+)
+
+var (
+	Plugins = []string{PluginPgoutput, PluginWal2json}
+	Formats = []string{FormatSql, FormatJson}
+)
+
+type Options struct {
+	Publication string
+	Slot        string
+	Plugin      string
+	Format      string
+	// Schema qualified table names, ie. public.orders. Empty means all tables in user schemas.
+	Tables []string
+}
+
+// Connection settings for a consumer of the replication slot, such as Debezium or a custom ETL job.
+type ConsumerConfig struct {
+	Host          string            `json:"host"`
+	Port          uint16            `json:"port"`
+	Database      string            `json:"database"`
+	User          string            `json:"user"`
+	SlotName      string            `json:"slot_name"`
+	Plugin        string            `json:"plugin"`
+	Publication   string            `json:"publication,omitempty"`
+	PluginOptions map[string]string `json:"plugin_options,omitempty"`
+}
+
+func Run(ctx context.Context, opts Options, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if opts.Plugin == PluginWal2json && opts.Format == FormatSql {
+		return errors.New("Subscriptions are only supported by the " + utils.Aqua(PluginPgoutput) + " plugin. Use --format json to generate consumer config for " + utils.Aqua(PluginWal2json) + ".")
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := assertLogicalWal(ctx, conn); err != nil {
+		return err
+	}
+	// Publications are only read by pgoutput, wal2json filters tables by plugin options instead
+	if opts.Plugin == PluginPgoutput {
+		tables := opts.Tables
+		if len(tables) == 0 {
+			// Publishing all tables requires superuser, which is not granted on hosted projects
+			if tables, err = ListUserTables(ctx, conn); err != nil {
+				return err
+			}
+		}
+		if err := CreatePublication(ctx, opts.Publication, tables, conn); err != nil {
+			return err
+		}
+	}
+	if err := CreateSlot(ctx, opts.Slot, opts.Plugin, conn); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "The database retains WAL for replication slot", utils.Aqua(opts.Slot), "until a consumer confirms receipt, which may fill up the disk. Run", utils.Aqua("supabase db replicate drop --slot "+opts.Slot), "once it is no longer consumed.")
+	if opts.Format == FormatSql {
+		return WriteSubscription(opts, config, os.Stdout)
+	}
+	return WriteConsumerConfig(opts, config, os.Stdout)
+}
+
+func assertLogicalWal(ctx context.Context, conn *pgx.Conn) error {
+	var level string
+	if err := conn.QueryRow(ctx, CHECK_WAL_LEVEL).Scan(&level); err != nil {
+		return fmt.Errorf("failed to check wal_level: %w", err)
+	}
+	if level != "logical" {
+		return fmt.Errorf("Logical replication requires %s, but the database is configured with %s.", utils.Aqua("wal_level = logical"), utils.Aqua("wal_level = "+level))
+	}
+	return nil
+}
+
+// Lists schema qualified names of tables outside of internal schemas.
+func ListUserTables(ctx context.Context, conn *pgx.Conn) ([]string, error) {
+	schemas, err := reset.ListSchemas(ctx, conn, utils.InternalSchemas...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas: %w", err)
+	}
+	rows, err := conn.Query(ctx, LIST_USER_TABLES, schemas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var schema, name string
+		if err := rows.Scan(&schema, &name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, schema+"."+name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return nil, errors.New("No tables found in user schemas. Pass " + utils.Aqua("--table") + " to choose the tables to replicate.")
+	}
+	return tables, nil
+}
+
+// Creates the publication if it does not exist, otherwise updates its table list.
+func CreatePublication(ctx context.Context, name string, tables []string, conn *pgx.Conn) error {
+	var exists bool
+	if err := conn.QueryRow(ctx, CHECK_PUBLICATION, name).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check publication: %w", err)
+	}
+	ident := pgx.Identifier{name}.Sanitize()
+	sql := fmt.Sprintf(CREATE_PUBLICATION, ident, quoteTables(tables))
+	if exists {
+		sql = fmt.Sprintf(ALTER_PUBLICATION, ident, quoteTables(tables))
+	}
+	if _, err := conn.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("failed to create publication: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Configured publication:", utils.Aqua(name))
+	return nil
+}
+
+// Creates the logical replication slot, which retains WAL until a consumer confirms receipt.
+func CreateSlot(ctx context.Context, name, plugin string, conn *pgx.Conn) error {
+	var existing string
+	if err := conn.QueryRow(ctx, CHECK_SLOT, name).Scan(&existing); errors.Is(err, pgx.ErrNoRows) {
+		if _, err := conn.Exec(ctx, CREATE_SLOT, name, plugin); err != nil {
+			return fmt.Errorf("failed to create replication slot: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, "Created replication slot:", utils.Aqua(name))
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to check replication slot: %w", err)
+	}
+	if existing != plugin {
+		return fmt.Errorf("Replication slot %s already exists with plugin %s. Run %s to recreate it.", utils.Aqua(name), utils.Aqua(existing), utils.Aqua("supabase db replicate drop --slot "+name))
+	}
+	fmt.Fprintln(os.Stderr, "Replication slot already exists:", utils.Aqua(name))
+	return nil
+}
+
+// Writes the statement to run on the external Postgres to start consuming changes.
+func WriteSubscription(opts Options, config pgconn.Config, w io.Writer) error {
+	config = directConfig(config)
+	conninfo := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s",
+		quoteConninfo(config.Host),
+		config.Port,
+		quoteConninfo(config.User),
+		passwordPlaceholder,
+		quoteConninfo(config.Database),
+	)
+	_, err := fmt.Fprintf(w, `-- Run on the target database after creating the replicated tables with matching schema.
+-- Replace %s with the database password, quoted with single quotes if it contains spaces.
+CREATE SUBSCRIPTION %s
+  CONNECTION %s
+  PUBLICATION %s
+  WITH (create_slot = false, slot_name = %s, copy_data = true);
+`,
+		passwordPlaceholder,
+		pgx.Identifier{opts.Slot}.Sanitize(),
+		quoteLiteral(conninfo),
+		pgx.Identifier{opts.Publication}.Sanitize(),
+		quoteLiteral(opts.Slot),
+	)
+	return err
+}
+
+func WriteConsumerConfig(opts Options, config pgconn.Config, w io.Writer) error {
+	config = directConfig(config)
+	consumer := ConsumerConfig{
+		Host:     config.Host,
+		Port:     config.Port,
+		Database: config.Database,
+		User:     config.User,
+		SlotName: opts.Slot,
+		Plugin:   opts.Plugin,
+	}
+	if opts.Plugin == PluginPgoutput {
+		consumer.Publication = opts.Publication
+		consumer.PluginOptions = map[string]string{
+			"proto_version":     "1",
+			"publication_names": opts.Publication,
+		}
+	} else {
+		consumer.PluginOptions = map[string]string{
+			"format-version":    "2",
+			"include-timestamp": "true",
+		}
+		if len(opts.Tables) > 0 {
+			consumer.PluginOptions["add-tables"] = strings.Join(opts.Tables, ",")
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(consumer)
+}
+
+// Consumers must connect to Postgres directly because the connection pooler does not speak the
+// replication protocol.
+func directConfig(config pgconn.Config) pgconn.Config {
+	if utils.ProjectHostPattern.MatchString(config.Host) && config.Port == 6543 {
+		config.Port = 5432
+	}
+	return config
+}
+
+// Quotes a keyword value in libpq connection string.
+func quoteConninfo(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+func quoteTables(tables []string) string {
+	quoted := make([]string, len(tables))
+	for i, t := range tables {
+		quoted[i] = pgx.Identifier(strings.SplitN(t, ".", 2)).Sanitize()
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,198 @@
+package setup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestSetupCommand(t *testing.T) {
+	opts := Options{
+		Publication: "supabase_etl",
+		Slot:        "supabase_etl",
+		Plugin:      PluginPgoutput,
+		Format:      FormatSql,
+		Tables:      []string{"public.orders"},
+	}
+
+	t.Run("creates publication and slot", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(CHECK_WAL_LEVEL).
+			Reply("SELECT 1", []interface{}{"logical"}).
+			Query(CHECK_PUBLICATION, opts.Publication).
+			Reply("SELECT 1", []interface{}{false}).
+			Query(`CREATE PUBLICATION "supabase_etl" FOR TABLE "public"."orders"`).
+			Reply("CREATE PUBLICATION").
+			Query(CHECK_SLOT, opts.Slot).
+			Reply("SELECT 0").
+			Query(CREATE_SLOT, opts.Slot, opts.Plugin).
+			Reply("SELECT 1")
+		// Run test
+		err := Run(context.Background(), opts, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on physical wal level", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(CHECK_WAL_LEVEL).
+			Reply("SELECT 1", []interface{}{"replica"})
+		// Run test
+		err := Run(context.Background(), opts, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Logical replication requires wal_level = logical")
+	})
+
+	t.Run("throws error on plugin mismatch", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(CHECK_WAL_LEVEL).
+			Reply("SELECT 1", []interface{}{"logical"}).
+			Query(CHECK_PUBLICATION, opts.Publication).
+			Reply("SELECT 1", []interface{}{true}).
+			Query(`ALTER PUBLICATION "supabase_etl" SET TABLE "public"."orders"`).
+			Reply("ALTER PUBLICATION").
+			Query(CHECK_SLOT, opts.Slot).
+			Reply("SELECT 1", []interface{}{PluginWal2json})
+		// Run test
+		err := Run(context.Background(), opts, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Replication slot supabase_etl already exists with plugin wal2json.")
+	})
+
+	t.Run("throws error on wal2json subscription", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), Options{Plugin: PluginWal2json, Format: FormatSql}, dbConfig, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Subscriptions are only supported by the pgoutput plugin.")
+	})
+}
+
+func TestListUserTables(t *testing.T) {
+	// Remote connections inline query parameters
+	remoteConfig := pgconn.Config{
+		Host:     "db.supabase.co",
+		Port:     5432,
+		User:     "admin",
+		Password: "password",
+		Database: "postgres",
+	}
+	listSchemas := strings.ReplaceAll(reset.LIST_SCHEMAS, "$1", `'{auth,extensions,pgbouncer,realtime,"\\_realtime",storage,"\\_analytics","supabase\\_functions","supabase\\_migrations","information\\_schema","pg\\_%",cron,graphql,"graphql\\_public",net,pgsodium,"pgsodium\\_masks",pgtle,repack,tiger,"tiger\\_data","timescaledb\\_%","\\_timescaledb\\_%",topology,vault}'`)
+
+	t.Run("lists tables in user schemas", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSchemas).
+			Reply("SELECT 2", []interface{}{"private"}, []interface{}{"public"}).
+			Query(strings.ReplaceAll(LIST_USER_TABLES, "$1", "'{private,public}'")).
+			Reply("SELECT 2", []interface{}{"private", "secrets"}, []interface{}{"public", "orders"})
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectByConfig(ctx, remoteConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		tables, err := ListUserTables(ctx, mock)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"private.secrets", "public.orders"}, tables)
+	})
+
+	t.Run("throws error on missing user tables", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSchemas).
+			Reply("SELECT 1", []interface{}{"public"}).
+			Query(strings.ReplaceAll(LIST_USER_TABLES, "$1", "'{public}'")).
+			Reply("SELECT 0")
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectByConfig(ctx, remoteConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		_, err = ListUserTables(ctx, mock)
+		// Check error
+		assert.ErrorContains(t, err, "No tables found in user schemas.")
+	})
+}
+
+func TestWriteSetup(t *testing.T) {
+	t.Run("writes subscription", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := WriteSubscription(Options{Publication: "pub", Slot: "slot"}, dbConfig, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `CREATE SUBSCRIPTION "slot"`)
+		assert.Contains(t, out.String(), `CONNECTION 'host=''127.0.0.1'' port=5432 user=''admin'' password=<password> dbname=''postgres'''`)
+		assert.NotContains(t, out.String(), "''password''")
+		assert.Contains(t, out.String(), `WITH (create_slot = false, slot_name = 'slot', copy_data = true);`)
+	})
+
+	t.Run("connects directly to linked project", func(t *testing.T) {
+		config := pgconn.Config{
+			Host:     "db.abcdefghijklmnopqrst.supabase.co",
+			Port:     6543,
+			User:     "postgres",
+			Password: `it's a \secret`,
+			Database: "postgres",
+		}
+		var out bytes.Buffer
+		// Run test
+		err := WriteSubscription(Options{Publication: "pub", Slot: "slot"}, config, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `host=''db.abcdefghijklmnopqrst.supabase.co'' port=5432 user=''postgres'' password=<password>`)
+		assert.NotContains(t, out.String(), "secret")
+	})
+
+	t.Run("writes wal2json consumer config", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := WriteConsumerConfig(Options{
+			Slot:   "slot",
+			Plugin: PluginWal2json,
+			Tables: []string{"public.orders", "public.users"},
+		}, dbConfig, &out)
+		// Check error
+		assert.NoError(t, err)
+		var consumer ConsumerConfig
+		require.NoError(t, json.Unmarshal(out.Bytes(), &consumer))
+		assert.Equal(t, "public.orders,public.users", consumer.PluginOptions["add-tables"])
+		assert.Empty(t, consumer.Publication)
+	})
+}
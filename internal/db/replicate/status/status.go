@@ -0,0 +1,67 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/pgxv5"
+)
+
+// Lag is measured from the current WAL position to the last position confirmed by the consumer.
+const QUERY = `
+SELECT
+  s.slot_name,
+  s.plugin,
+  s.active,
+  COALESCE(r.state, 'N/A') AS state,
+  COALESCE(r.client_addr::text, 'N/A') AS client_address,
+  COALESCE(pg_size_pretty(pg_wal_lsn_diff(pg_current_wal_lsn(), s.confirmed_flush_lsn)), 'N/A') AS lag_size,
+  COALESCE(r.replay_lag::text, 'N/A') AS lag_time
+FROM pg_replication_slots s
+LEFT JOIN pg_stat_replication r ON r.pid = s.active_pid
+WHERE s.slot_type = 'logical' AND ($1 = '' OR s.slot_name = $1)
+ORDER BY s.slot_name
+`
+
+type Result struct {
+	Slot_name      string
+	Plugin         string
+	Active         bool
+	State          string
+	Client_address string
+	Lag_size       string
+	Lag_time       string
+}
+
+func Run(ctx context.Context, slot string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	rows, err := conn.Query(ctx, QUERY, slot)
+	if err != nil {
+		return err
+	}
+	result, err := pgxv5.CollectRows[Result](rows)
+	if err != nil {
+		return err
+	}
+	if len(result) == 0 {
+		if len(slot) > 0 {
+			return fmt.Errorf("Replication slot not found: %s", utils.Aqua(slot))
+		}
+		fmt.Println("No logical replication slots found. Run " + utils.Aqua("supabase db replicate setup") + " to create one.")
+		return nil
+	}
+	table := "|SLOT|PLUGIN|ACTIVE|STATE|CLIENT ADDRESS|LAG SIZE|LAG TIME|\n|-|-|-|-|-|-|-|\n"
+	for _, r := range result {
+		table += fmt.Sprintf("|`%s`|`%s`|`%t`|`%s`|`%s`|`%s`|`%s`|\n", r.Slot_name, r.Plugin, r.Active, r.State, r.Client_address, r.Lag_size, r.Lag_time)
+	}
+	return list.RenderTable(table)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/native"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/gen/keys"
 	"github.com/supabase/cli/internal/migration/apply"
@@ -66,6 +67,14 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 		}
 	}
 
+	if utils.IsNativeDbRunning() {
+		if err := native.Reset(ctx, version, fsys, options...); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Finished "+utils.Aqua("supabase db reset")+" on native Postgres.")
+		return nil
+	}
+
 	// Reset postgres database because extensions (pg_cron, pg_net) require postgres
 	if err := resetDatabase(ctx, version, fsys, options...); err != nil {
 		return err
@@ -92,7 +101,7 @@ func toLogMessage(version string) string {
 }
 
 func resetDatabase14(ctx context.Context, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	if err := recreateDatabase(ctx, options...); err != nil {
+	if err := RecreateDatabase(ctx, options...); err != nil {
 		return err
 	}
 	if err := initDatabase(ctx, options...); err != nil {
@@ -166,7 +175,7 @@ func initDatabase(ctx context.Context, options ...func(*pgx.ConnConfig)) error {
 }
 
 // Recreate postgres database by connecting to template1
-func recreateDatabase(ctx context.Context, options ...func(*pgx.ConnConfig)) error {
+func RecreateDatabase(ctx context.Context, options ...func(*pgx.ConnConfig)) error {
 	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{User: "supabase_admin", Database: "template1"}, options...)
 	if err != nil {
 		return err
@@ -138,12 +138,12 @@ func TestRecreateDatabase(t *testing.T) {
 			Query("CREATE DATABASE postgres WITH OWNER postgres").
 			Reply("CREATE DATABASE")
 		// Run test
-		assert.NoError(t, recreateDatabase(context.Background(), conn.Intercept))
+		assert.NoError(t, RecreateDatabase(context.Background(), conn.Intercept))
 	})
 
 	t.Run("throws error on invalid port", func(t *testing.T) {
 		utils.Config.Db.Port = 0
-		assert.ErrorContains(t, recreateDatabase(context.Background()), "invalid port")
+		assert.ErrorContains(t, RecreateDatabase(context.Background()), "invalid port")
 	})
 
 	t.Run("continues on disconnecting missing database", func(t *testing.T) {
@@ -156,7 +156,7 @@ func TestRecreateDatabase(t *testing.T) {
 			Query(fmt.Sprintf(utils.TerminateDbSqlFmt, "postgres")).
 			ReplyError(pgerrcode.UndefinedTable, `relation "pg_stat_activity" does not exist`)
 		// Run test
-		err := recreateDatabase(context.Background(), conn.Intercept)
+		err := RecreateDatabase(context.Background(), conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, `ERROR: relation "pg_stat_activity" does not exist (SQLSTATE 42P01)`)
 	})
@@ -169,7 +169,7 @@ func TestRecreateDatabase(t *testing.T) {
 		conn.Query("ALTER DATABASE postgres ALLOW_CONNECTIONS false;").
 			ReplyError(pgerrcode.InvalidParameterValue, `cannot disallow connections for current database`)
 		// Run test
-		err := recreateDatabase(context.Background(), conn.Intercept)
+		err := RecreateDatabase(context.Background(), conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, "ERROR: cannot disallow connections for current database (SQLSTATE 22023)")
 	})
@@ -187,7 +187,7 @@ func TestRecreateDatabase(t *testing.T) {
 			ReplyError(pgerrcode.ObjectInUse, `database "postgres" is used by an active logical replication slot`).
 			Query("CREATE DATABASE postgres WITH OWNER postgres")
 		// Run test
-		err := recreateDatabase(context.Background(), conn.Intercept)
+		err := RecreateDatabase(context.Background(), conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, `ERROR: database "postgres" is used by an active logical replication slot (SQLSTATE 55006)`)
 	})
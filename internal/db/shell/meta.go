@@ -0,0 +1,95 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	// Internal schemas are hidden unless a schema is specified
+	systemSchemas = "('pg_catalog', 'information_schema', 'pg_toast')"
+
+	LIST_DATABASES = "SELECT datname AS name, pg_get_userbyid(datdba) AS owner FROM pg_database WHERE NOT datistemplate ORDER BY 1"
+	LIST_SCHEMAS   = "SELECT nspname AS name, pg_get_userbyid(nspowner) AS owner FROM pg_namespace WHERE nspname NOT LIKE 'pg\\_%' AND nspname <> 'information_schema' ORDER BY 1"
+	LIST_ROLES     = "SELECT rolname AS role, rolsuper AS superuser, rolcanlogin AS login FROM pg_roles ORDER BY 1"
+	LIST_TABLES    = "SELECT table_schema AS schema, table_name AS name FROM information_schema.tables WHERE table_type = $1 AND (($2 = '' AND table_schema NOT IN " + systemSchemas + ") OR table_schema = $2) ORDER BY 1, 2"
+	LIST_FUNCTIONS = "SELECT n.nspname AS schema, p.proname AS name, pg_get_function_result(p.oid) AS result, pg_get_function_arguments(p.oid) AS arguments FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace WHERE ($1 = '' AND n.nspname NOT IN " + systemSchemas + ") OR n.nspname = $1 ORDER BY 1, 2"
+	DESCRIBE_TABLE = "SELECT column_name AS column, data_type AS type, is_nullable AS nullable, coalesce(column_default, '') AS default FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position"
+)
+
+const help = `General
+  \q              quit
+  \?              show this help
+  \timing         toggle timing of queries
+  \i FILE         execute statements from file
+  \conninfo       show connection details
+
+Informational
+  \l              list databases
+  \dn             list schemas
+  \du             list roles
+  \dt [SCHEMA]    list tables
+  \dv [SCHEMA]    list views
+  \df [SCHEMA]    list functions
+  \d [NAME]       describe table, or list tables if NAME is omitted`
+
+// Handles psql style meta commands, ie. \dt public.
+func (s *Shell) Meta(ctx context.Context, line string) error {
+	fields := strings.Fields(line)
+	arg := ""
+	if len(fields) > 1 {
+		arg = fields[1]
+	}
+	switch fields[0] {
+	case `\?`:
+		fmt.Fprintln(s.out, help)
+	case `\timing`:
+		s.timing = !s.timing
+		state := "off"
+		if s.timing {
+			state = "on"
+		}
+		fmt.Fprintln(s.out, "Timing is "+state+".")
+	case `\conninfo`:
+		config := s.conn.Config()
+		fmt.Fprintf(s.out, "Connected to database %s as user %s on host %s at port %d.\n", utils.Aqua(config.Database), utils.Aqua(config.User), utils.Aqua(config.Host), config.Port)
+	case `\i`:
+		if len(arg) == 0 {
+			return errors.New(`\i: missing required argument`)
+		}
+		f, err := s.fsys.Open(arg)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+		return s.ExecScript(ctx, f)
+	case `\l`:
+		return s.Exec(ctx, LIST_DATABASES)
+	case `\dn`:
+		return s.Exec(ctx, LIST_SCHEMAS)
+	case `\du`:
+		return s.Exec(ctx, LIST_ROLES)
+	case `\dt`:
+		return s.Exec(ctx, LIST_TABLES, "BASE TABLE", arg)
+	case `\dv`:
+		return s.Exec(ctx, LIST_TABLES, "VIEW", arg)
+	case `\df`:
+		return s.Exec(ctx, LIST_FUNCTIONS, arg)
+	case `\d`:
+		if len(arg) == 0 {
+			return s.Exec(ctx, LIST_TABLES, "BASE TABLE", arg)
+		}
+		schema, table, found := strings.Cut(arg, ".")
+		if !found {
+			schema, table = "public", arg
+		}
+		return s.Exec(ctx, DESCRIBE_TABLE, schema, table)
+	default:
+		return fmt.Errorf(`invalid command %s, try \? for help`, fields[0])
+	}
+	return nil
+}
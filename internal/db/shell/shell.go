@@ -0,0 +1,316 @@
+package shell
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/parser"
+	"github.com/supabase/cli/internal/utils/render"
+	"golang.org/x/term"
+)
+
+type Shell struct {
+	conn    *pgx.Conn
+	connect func(context.Context) (*pgx.Conn, error)
+	out     io.Writer
+	fsys    afero.Fs
+	timing  bool
+}
+
+// Executes a file or piped stdin when available, otherwise starts an interactive session.
+func Run(ctx context.Context, file string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	interactive := len(file) == 0 && term.IsTerminal(int(os.Stdin.Fd()))
+	// Transaction pooler does not preserve session state, ie. SET and temp tables, across statements
+	if interactive && config.Port == 6543 && utils.ProjectHostPattern.MatchString(config.Host) {
+		config.Port = 5432
+	}
+	sh := Shell{
+		connect: func(ctx context.Context) (*pgx.Conn, error) {
+			return utils.ConnectByConfig(ctx, config, options...)
+		},
+		out:  os.Stdout,
+		fsys: fsys,
+	}
+	if err := sh.reconnect(ctx); err != nil {
+		return err
+	}
+	defer sh.Close()
+	if len(file) > 0 {
+		f, err := fsys.Open(file)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+		return sh.ExecScript(ctx, f)
+	}
+	if !interactive {
+		return sh.ExecScript(ctx, os.Stdin)
+	}
+	return sh.repl(ctx)
+}
+
+func (s *Shell) reconnect(ctx context.Context) (err error) {
+	s.conn, err = s.connect(ctx)
+	return err
+}
+
+func (s *Shell) Close() {
+	if s.conn != nil {
+		s.conn.Close(context.Background())
+	}
+}
+
+// Executes statements one at a time, stopping at the first error.
+func (s *Shell) ExecScript(ctx context.Context, r io.Reader) error {
+	scanner := parser.NewScanner(r)
+	for scanner.Scan() {
+		sql := strings.TrimSpace(scanner.Text())
+		if len(sql) == 0 {
+			continue
+		}
+		if err := s.Exec(ctx, sql); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Runs a single statement and prints its result set or command tag.
+func (s *Shell) Exec(ctx context.Context, sql string, args ...any) error {
+	start := time.Now()
+	rows, err := s.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var columns []string
+	for _, f := range rows.FieldDescriptions() {
+		columns = append(columns, string(f.Name))
+	}
+	var data [][]string
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return err
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = formatValue(v)
+		}
+		data = append(data, row)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !render.IsPretty() {
+		if len(columns) == 0 {
+			return nil
+		}
+		result := make([]map[string]string, len(data))
+		for i, row := range data {
+			result[i] = map[string]string{}
+			for j, c := range columns {
+				result[i][c] = row[j]
+			}
+		}
+		return utils.EncodeOutput(render.Format.Value, s.out, result)
+	}
+	if len(columns) > 0 {
+		printTable(s.out, columns, data)
+	} else {
+		fmt.Fprintln(s.out, rows.CommandTag().String())
+	}
+	if s.timing {
+		fmt.Fprintf(s.out, "Time: %.3f ms\n", float64(time.Since(start).Microseconds())/1000)
+	}
+	return nil
+}
+
+func (s *Shell) repl(ctx context.Context) error {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = term.Restore(fd, state)
+	}()
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, "")
+	s.out = t
+	fmt.Fprintln(t, `Type \? for help, \q to quit.`)
+	// Restore the terminal while running queries so that Ctrl+C cancels the current query only
+	cooked := func(fn func(context.Context) error) error {
+		if err := term.Restore(fd, state); err != nil {
+			return err
+		}
+		stop := s.cancelOnInterrupt()
+		err := fn(ctx)
+		stop()
+		if _, rawErr := term.MakeRaw(fd); rawErr != nil {
+			return rawErr
+		}
+		return err
+	}
+	var buf string
+	for {
+		database := s.conn.Config().Database
+		if len(buf) == 0 {
+			t.SetPrompt(database + "=> ")
+		} else {
+			t.SetPrompt(database + "-> ")
+		}
+		line, err := t.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var stats []string
+		if trim := strings.TrimSpace(line); len(buf) == 0 && strings.HasPrefix(trim, `\`) {
+			if trim == `\q` {
+				return nil
+			}
+			err = cooked(func(ctx context.Context) error {
+				return s.Meta(ctx, trim)
+			})
+		} else {
+			stats, buf = SplitComplete(buf + line + "\n")
+			for _, sql := range stats {
+				if err = cooked(func(ctx context.Context) error {
+					return s.Exec(ctx, sql)
+				}); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(t, utils.Red("ERROR:"), err)
+		}
+		if s.conn.IsClosed() {
+			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Connection to the database was lost. Reconnecting resets session state, such as open transactions and settings.")
+			if err := s.reconnect(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Sends a cancel request to the server on Ctrl+C, which aborts the running query but keeps the
+// session. Cancelling the query context instead would close the connection.
+func (s *Shell) cancelOnInterrupt() func() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sigCh:
+				if err := s.conn.PgConn().CancelRequest(context.Background()); err != nil {
+					fmt.Fprintln(os.Stderr, "failed to cancel query:", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
+
+// Returns terminated statements and any incomplete statement that should be buffered.
+func SplitComplete(sql string) ([]string, string) {
+	tokens, err := parser.Split(strings.NewReader(sql))
+	if err != nil {
+		return nil, sql
+	}
+	var stats []string
+	for i, token := range tokens {
+		trim := strings.TrimSpace(token)
+		if strings.HasSuffix(trim, ";") {
+			stats = append(stats, trim)
+		} else if i == len(tokens)-1 && len(trim) > 0 {
+			return stats, token
+		}
+	}
+	return stats, ""
+}
+
+func printTable(w io.Writer, columns []string, data [][]string) {
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		widths[i] = len(c)
+	}
+	for _, row := range data {
+		for i, v := range row {
+			widths[i] = max(widths[i], len(v))
+		}
+	}
+	format := func(values []string) string {
+		cells := make([]string, len(values))
+		for i, v := range values {
+			cells[i] = " " + v + strings.Repeat(" ", widths[i]-len(v)) + " "
+		}
+		return strings.TrimRight(strings.Join(cells, "|"), " ")
+	}
+	fmt.Fprintln(w, format(columns))
+	separators := make([]string, len(columns))
+	for i := range separators {
+		separators[i] = strings.Repeat("-", widths[i]+2)
+	}
+	fmt.Fprintln(w, strings.Join(separators, "+"))
+	for _, row := range data {
+		fmt.Fprintln(w, format(row))
+	}
+	if len(data) == 1 {
+		fmt.Fprintln(w, "(1 row)")
+	} else {
+		fmt.Fprintf(w, "(%d rows)\n", len(data))
+	}
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case driver.Valuer:
+		// Postgres types like interval and numeric encode to their text representation
+		if dv, err := v.Value(); err == nil {
+			if _, ok := dv.(driver.Valuer); !ok {
+				return formatValue(dv)
+			}
+		}
+	}
+	return fmt.Sprint(value)
+}
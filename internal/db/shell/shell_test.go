@@ -0,0 +1,137 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func newShell(t *testing.T, conn *pgtest.MockConn, fsys afero.Fs) (*Shell, *bytes.Buffer) {
+	var out bytes.Buffer
+	sh := Shell{
+		connect: func(ctx context.Context) (*pgx.Conn, error) {
+			return utils.ConnectByConfig(ctx, dbConfig, conn.Intercept)
+		},
+		out:  &out,
+		fsys: fsys,
+	}
+	require.NoError(t, sh.reconnect(context.Background()))
+	return &sh, &out
+}
+
+func TestSplitComplete(t *testing.T) {
+	t.Run("buffers incomplete statement", func(t *testing.T) {
+		stats, rest := SplitComplete("select 1;\nselect\n")
+		assert.Equal(t, []string{"select 1;"}, stats)
+		assert.Equal(t, "\nselect\n", rest)
+	})
+
+	t.Run("ignores separator in dollar quote", func(t *testing.T) {
+		stats, rest := SplitComplete("do $$ begin perform 1; end $$\n")
+		assert.Empty(t, stats)
+		assert.Equal(t, "do $$ begin perform 1; end $$\n", rest)
+	})
+
+	t.Run("discards trailing whitespace", func(t *testing.T) {
+		stats, rest := SplitComplete("select 1; select 2;\n")
+		assert.Equal(t, []string{"select 1;", "select 2;"}, stats)
+		assert.Empty(t, rest)
+	})
+}
+
+func TestExecScript(t *testing.T) {
+	t.Run("prints result of each statement", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("create table t (id int);").
+			Reply("CREATE TABLE").
+			Query("select 1;").
+			Reply("SELECT 1", []interface{}{1})
+		sh, out := newShell(t, conn, afero.NewMemMapFs())
+		defer sh.Close()
+		// Run test
+		err := sh.ExecScript(context.Background(), strings.NewReader("create table t (id int);\nselect 1;\n"))
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "CREATE TABLE\n c_00\n------\n 1\n(1 row)\n", out.String())
+	})
+
+	t.Run("stops at first error", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("select * from missing;").
+			ReplyError(pgerrcode.UndefinedTable, `relation "missing" does not exist`)
+		sh, _ := newShell(t, conn, afero.NewMemMapFs())
+		defer sh.Close()
+		// Run test
+		err := sh.ExecScript(context.Background(), strings.NewReader("select * from missing;\nselect 1;\n"))
+		// Check error
+		assert.ErrorContains(t, err, `relation "missing" does not exist`)
+	})
+}
+
+func TestMetaCommand(t *testing.T) {
+	t.Run("describes table in public schema", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(DESCRIBE_TABLE, "public", "todos").
+			Reply("SELECT 1", []interface{}{"id", "bigint", "NO", ""})
+		sh, out := newShell(t, conn, afero.NewMemMapFs())
+		defer sh.Close()
+		// Run test
+		err := sh.Meta(context.Background(), `\d todos`)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "bigint")
+	})
+
+	t.Run("executes statements from file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "seed.sql", []byte("select 1;"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("select 1;").
+			Reply("SELECT 1", []interface{}{1})
+		sh, _ := newShell(t, conn, fsys)
+		defer sh.Close()
+		// Run test
+		err := sh.Meta(context.Background(), `\i seed.sql`)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on unknown command", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		sh, _ := newShell(t, conn, afero.NewMemMapFs())
+		defer sh.Close()
+		// Run test
+		err := sh.Meta(context.Background(), `\z`)
+		// Check error
+		assert.ErrorContains(t, err, `invalid command \z`)
+	})
+}
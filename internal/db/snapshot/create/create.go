@@ -0,0 +1,72 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/snapshot"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, name string, fsys afero.Fs) error {
+	// Sanity checks.
+	{
+		if len(name) == 0 {
+			name = utils.GetCurrentTimestamp()
+		}
+		if err := snapshot.AssertNameIsValid(name); err != nil {
+			return err
+		}
+		if err := utils.LoadConfigFS(fsys); err != nil {
+			return err
+		}
+		if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+			return err
+		}
+	}
+
+	path := snapshot.GetPath(name)
+	if _, err := fsys.Stat(path); err == nil {
+		return errors.New("Snapshot " + utils.Aqua(name) + " already exists.")
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Creating snapshot", utils.Aqua(name)+"...")
+	if err := dumpDatabase(ctx, path, fsys); err != nil {
+		return err
+	}
+	if err := snapshot.Prune(utils.Config.Db.Snapshots.MaxCount, fsys); err != nil {
+		return err
+	}
+	fmt.Println("Created snapshot " + utils.Aqua(name) + " at " + utils.Bold(path) + ".")
+	return nil
+}
+
+// Streams pg_dump output in custom format from the database container to a local file.
+func dumpDatabase(ctx context.Context, path string, fsys afero.Fs) error {
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return err
+	}
+	// Write to a temporary file so that failed dumps do not leave a partial snapshot
+	tmp := path + ".tmp"
+	f, err := fsys.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	cmd := []string{"pg_dump", "--username", "supabase_admin", "--host", "127.0.0.1", "--format", "custom", "postgres"}
+	if err := utils.DockerExecOnceWithStream(ctx, utils.DbId, "", nil, cmd, f, os.Stderr); err != nil {
+		f.Close()
+		if err := fsys.Remove(tmp); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return fmt.Errorf("failed to dump database: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return fsys.Rename(tmp, path)
+}
@@ -0,0 +1,68 @@
+package create
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/snapshot"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateSnapshot(t *testing.T) {
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "my snapshot", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, snapshot.ErrInvalidName)
+	})
+
+	t.Run("throws error on existing snapshot", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, snapshot.GetPath("seeded"), []byte{}, 0644))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		// Run test
+		err := Run(context.Background(), "seeded", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "already exists")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("removes partial dump on failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId + "/exec").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), "seeded", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to dump database")
+		files, err := afero.ReadDir(fsys, utils.SnapshotsDir)
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,34 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/snapshot"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs) error {
+	snapshots, err := snapshot.ListSnapshots(fsys)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintln(os.Stderr, "No snapshots found in "+utils.Bold(utils.SnapshotsDir)+".")
+		return nil
+	}
+	table := `|NAME|SIZE|CREATED AT (UTC)|
+|-|-|-|
+`
+	for _, s := range snapshots {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n",
+			s.Name,
+			units.HumanSize(float64(s.Size)),
+			s.CreatedAt.UTC().Format("2006-01-02 15:04:05"),
+		)
+	}
+	return list.RenderTable(table)
+}
@@ -0,0 +1,84 @@
+package restore
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/docker/docker/api/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/db/snapshot"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const dstDir = "/tmp"
+
+func Run(ctx context.Context, name string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	// Sanity checks.
+	{
+		if err := snapshot.AssertNameIsValid(name); err != nil {
+			return err
+		}
+		if err := utils.LoadConfigFS(fsys); err != nil {
+			return err
+		}
+		if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+			return err
+		}
+	}
+
+	data, err := afero.ReadFile(fsys, snapshot.GetPath(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.New("Snapshot " + utils.Aqua(name) + " does not exist. Run " + utils.Aqua("supabase db snapshot list") + " to see available snapshots.")
+	} else if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Restoring snapshot", utils.Aqua(name)+"...")
+	if err := copyToContainer(ctx, name+snapshot.Ext, data); err != nil {
+		return err
+	}
+	if err := reset.RecreateDatabase(ctx, options...); err != nil {
+		return err
+	}
+	if err := restoreDatabase(ctx, path.Join(dstDir, name+snapshot.Ext)); err != nil {
+		return err
+	}
+	if err := reset.RestartDatabase(ctx, os.Stderr); err != nil {
+		return err
+	}
+	fmt.Println("Restored snapshot " + utils.Aqua(name) + ".")
+	return nil
+}
+
+func copyToContainer(ctx context.Context, filename string, data []byte) error {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name: filename,
+		Mode: 0644,
+		Size: int64(len(data)),
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return utils.Docker.CopyToContainer(ctx, utils.DbId, dstDir, &buf, types.CopyToContainerOptions{})
+}
+
+func restoreDatabase(ctx context.Context, dumpPath string) error {
+	cmd := []string{"/bin/bash", "-c", `pg_restore --username supabase_admin --host 127.0.0.1 --dbname postgres --exit-on-error "$1"; status=$?; rm -f "$1"; exit $status`, "--", dumpPath}
+	if err := utils.DockerExecOnceWithStream(ctx, utils.DbId, "", nil, cmd, os.Stderr, os.Stderr); err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const Ext = ".dump"
+
+var (
+	namePattern = regexp.MustCompile(`^[[:word:]-]+$`)
+
+	ErrInvalidName = errors.New("Snapshot name is invalid. Must match [0-9A-Za-z_-]+.")
+)
+
+type Snapshot struct {
+	Name      string
+	Size      int64
+	CreatedAt time.Time
+}
+
+func AssertNameIsValid(name string) error {
+	if !namePattern.MatchString(name) {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+// Snapshots are stored in pg_dump custom format.
+func GetPath(name string) string {
+	return filepath.Join(utils.SnapshotsDir, name+Ext)
+}
+
+// Lists local snapshots, newest first.
+func ListSnapshots(fsys afero.Fs) ([]Snapshot, error) {
+	entries, err := afero.ReadDir(fsys, utils.SnapshotsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var result []Snapshot
+	for _, fi := range entries {
+		if !fi.Mode().IsRegular() || filepath.Ext(fi.Name()) != Ext {
+			continue
+		}
+		result = append(result, Snapshot{
+			Name:      strings.TrimSuffix(fi.Name(), Ext),
+			Size:      fi.Size(),
+			CreatedAt: fi.ModTime(),
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// Removes the oldest snapshots so that at most maxCount are kept. Zero disables pruning.
+func Prune(maxCount uint, fsys afero.Fs) error {
+	if maxCount == 0 {
+		return nil
+	}
+	snapshots, err := ListSnapshots(fsys)
+	if err != nil {
+		return err
+	}
+	for i := int(maxCount); i < len(snapshots); i++ {
+		fmt.Fprintln(os.Stderr, "Pruning snapshot:", utils.Aqua(snapshots[i].Name))
+		if err := fsys.Remove(GetPath(snapshots[i].Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
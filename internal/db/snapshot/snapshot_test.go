@@ -0,0 +1,78 @@
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSnapshots(t *testing.T, fsys afero.Fs, names ...string) {
+	now := time.Now()
+	for i, name := range names {
+		path := GetPath(name)
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(name), 0644))
+		ts := now.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, fsys.Chtimes(path, ts, ts))
+	}
+}
+
+func TestListSnapshots(t *testing.T) {
+	t.Run("lists newest first", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeSnapshots(t, fsys, "seeded", "with_users")
+		// Run test
+		snapshots, err := ListSnapshots(fsys)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.Equal(t, "with_users", snapshots[0].Name)
+		assert.Equal(t, int64(len("with_users")), snapshots[0].Size)
+		assert.Equal(t, "seeded", snapshots[1].Name)
+	})
+
+	t.Run("ignores missing directory", func(t *testing.T) {
+		// Run test
+		snapshots, err := ListSnapshots(afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, snapshots)
+	})
+}
+
+func TestPrune(t *testing.T) {
+	t.Run("removes oldest snapshots", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeSnapshots(t, fsys, "a", "b", "c")
+		// Run test
+		assert.NoError(t, Prune(2, fsys))
+		// Check output
+		snapshots, err := ListSnapshots(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c", "b"}, []string{snapshots[0].Name, snapshots[1].Name})
+		exists, err := afero.Exists(fsys, GetPath("a"))
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("keeps all snapshots when disabled", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		writeSnapshots(t, fsys, "a", "b")
+		// Run test
+		assert.NoError(t, Prune(0, fsys))
+		// Check output
+		snapshots, err := ListSnapshots(fsys)
+		assert.NoError(t, err)
+		assert.Len(t, snapshots, 2)
+	})
+}
+
+func TestAssertNameIsValid(t *testing.T) {
+	assert.NoError(t, AssertNameIsValid("before-migration_2"))
+	assert.ErrorIs(t, AssertNameIsValid("../escape"), ErrInvalidName)
+}
@@ -2,7 +2,9 @@ package start
 
 import (
 	"context"
+	"crypto/rand"
 	_ "embed"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -101,6 +103,12 @@ func NewHostConfig() container.HostConfig {
 }
 
 func StartDatabase(ctx context.Context, fsys afero.Fs, w io.Writer, options ...func(*pgx.ConnConfig)) error {
+	// Creating volume will not override existing volume, so we must inspect explicitly
+	_, err := utils.Docker.VolumeInspect(ctx, utils.DbId)
+	noBackupVolume := client.IsErrNotFound(err)
+	if err := loadRootKey(noBackupVolume, fsys); err != nil {
+		return err
+	}
 	config := NewContainerConfig()
 	hostConfig := NewHostConfig()
 	networkingConfig := network.NetworkingConfig{
@@ -114,9 +122,6 @@ func StartDatabase(ctx context.Context, fsys afero.Fs, w io.Writer, options ...f
 		config.Entrypoint = nil
 		hostConfig.Tmpfs = map[string]string{"/docker-entrypoint-initdb.d": ""}
 	}
-	// Creating volume will not override existing volume, so we must inspect explicitly
-	_, err := utils.Docker.VolumeInspect(ctx, utils.DbId)
-	noBackupVolume := client.IsErrNotFound(err)
 	if noBackupVolume {
 		fmt.Fprintln(w, "Starting database...")
 	} else {
@@ -137,6 +142,30 @@ func StartDatabase(ctx context.Context, fsys afero.Fs, w io.Writer, options ...f
 	return initCurrentBranch(fsys)
 }
 
+// Vault secrets are encrypted with the pgsodium root key, so a random key is provisioned for
+// each new database. Existing databases keep using the default key they were created with.
+func loadRootKey(provision bool, fsys afero.Fs) error {
+	// Respect keys overridden by env
+	if utils.Config.Db.RootKey != utils.DefaultRootKey {
+		return nil
+	}
+	if key, err := afero.ReadFile(fsys, utils.RootKeyPath); err == nil {
+		utils.Config.Db.RootKey = strings.TrimSpace(string(key))
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if !provision {
+		return nil
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	utils.Config.Db.RootKey = hex.EncodeToString(key)
+	return utils.WriteFile(utils.RootKeyPath, []byte(utils.Config.Db.RootKey), fsys)
+}
+
 func RetryEverySecond(ctx context.Context, callback func() bool, timeout time.Duration) bool {
 	now := time.Now()
 	expiry := now.Add(timeout)
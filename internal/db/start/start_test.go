@@ -49,6 +49,47 @@ func TestInitBranch(t *testing.T) {
 	})
 }
 
+func TestLoadRootKey(t *testing.T) {
+	t.Run("provisions key for new database", func(t *testing.T) {
+		utils.Config.Db.RootKey = utils.DefaultRootKey
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, loadRootKey(true, fsys))
+		// Check key
+		assert.Len(t, utils.Config.Db.RootKey, 64)
+		assert.NotEqual(t, utils.DefaultRootKey, utils.Config.Db.RootKey)
+		contents, err := afero.ReadFile(fsys, utils.RootKeyPath)
+		assert.NoError(t, err)
+		assert.Equal(t, utils.Config.Db.RootKey, string(contents))
+	})
+
+	t.Run("keeps default key for existing database", func(t *testing.T) {
+		utils.Config.Db.RootKey = utils.DefaultRootKey
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, loadRootKey(false, fsys))
+		// Check key
+		assert.Equal(t, utils.DefaultRootKey, utils.Config.Db.RootKey)
+		exists, err := afero.Exists(fsys, utils.RootKeyPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("loads provisioned key", func(t *testing.T) {
+		utils.Config.Db.RootKey = utils.DefaultRootKey
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.RootKeyPath, []byte("test-key\n"), 0600))
+		// Run test
+		assert.NoError(t, loadRootKey(false, fsys))
+		// Check key
+		assert.Equal(t, "test-key", utils.Config.Db.RootKey)
+		utils.Config.Db.RootKey = utils.DefaultRootKey
+	})
+}
+
 func TestStartDatabase(t *testing.T) {
 	teardown := func() {
 		utils.Containers = []string{}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Skipped   *junitMessage `xml:"skipped"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+func writeJUnit(files []fileResult, w io.Writer) error {
+	var report junitTestSuites
+	var total float64
+	for _, f := range files {
+		suite := junitTestSuite{
+			Name: f.path,
+			Time: fmt.Sprintf("%.3f", f.duration.Seconds()),
+		}
+		for _, tc := range f.tap.Cases {
+			name := tc.Name
+			if len(name) == 0 {
+				name = fmt.Sprintf("test %d", tc.Number)
+			}
+			c := junitTestCase{Name: name, Classname: f.path}
+			body := strings.Join(tc.Diagnostics, "\n")
+			if tc.Skipped {
+				c.Skipped = &junitMessage{Message: body}
+				suite.Skipped++
+			} else if !tc.Passed && !tc.Todo {
+				c.Failure = &junitMessage{Message: "not ok " + fmt.Sprint(tc.Number), Body: body}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+		if f.tap.Plan > len(f.tap.Cases) && f.err == nil {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      "plan",
+				Classname: f.path,
+				Failure:   &junitMessage{Message: fmt.Sprintf("planned %d tests but ran %d", f.tap.Plan, len(f.tap.Cases))},
+			})
+			suite.Failures++
+		}
+		if f.err != nil {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      "psql",
+				Classname: f.path,
+				Error:     &junitMessage{Message: f.err.Error(), Body: f.stderr},
+			})
+			suite.Errors++
+		}
+		suite.Tests = len(suite.Cases)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		total += f.duration.Seconds()
+		report.Suites = append(report.Suites, suite)
+	}
+	report.Time = fmt.Sprintf("%.3f", total)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
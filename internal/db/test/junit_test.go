@@ -0,0 +1,36 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	// Setup test results
+	tap, err := ParseTap(strings.NewReader(tapOutput))
+	require.NoError(t, err)
+	results := []fileResult{{
+		path:     "supabase/tests/todos.sql",
+		tap:      tap,
+		duration: time.Second,
+	}, {
+		path:   "supabase/tests/broken.sql",
+		err:    errors.New("error executing command"),
+		stderr: "syntax error at or near \"selec\"",
+	}}
+	// Run test
+	var buf bytes.Buffer
+	require.NoError(t, writeJUnit(results, &buf))
+	// Check output
+	report := buf.String()
+	assert.Contains(t, report, `<testsuites tests="5" failures="1" errors="1" time="1.000">`)
+	assert.Contains(t, report, `<testsuite name="supabase/tests/todos.sql" tests="4" failures="1" errors="0" skipped="1" time="1.000">`)
+	assert.Contains(t, report, `<failure message="not ok 2">Failed test 2: &#34;owner can read own todos&#34;`)
+	assert.Contains(t, report, `<error message="error executing command">syntax error`)
+}
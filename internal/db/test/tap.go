@@ -0,0 +1,74 @@
+package test
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	planPattern = regexp.MustCompile(`^1\.\.(\d+)`)
+	testPattern = regexp.MustCompile(`^(not )?ok\b\s*(\d+)?\s*(?:- )?([^#]*)(?:#\s*(\w+)\s*(.*))?$`)
+)
+
+type TapCase struct {
+	Number      int
+	Name        string
+	Passed      bool
+	Skipped     bool
+	Todo        bool
+	Diagnostics []string
+}
+
+type TapResult struct {
+	Plan  int
+	Cases []TapCase
+}
+
+// Parses the TAP stream emitted by pgTAP. Unrecognised lines are ignored.
+func ParseTap(r io.Reader) (TapResult, error) {
+	var result TapResult
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		trimmed := strings.TrimSpace(line)
+		if matches := planPattern.FindStringSubmatch(trimmed); len(matches) > 1 {
+			result.Plan, _ = strconv.Atoi(matches[1])
+		} else if matches := testPattern.FindStringSubmatch(trimmed); len(matches) > 0 {
+			tc := TapCase{
+				Name:   strings.TrimSpace(matches[3]),
+				Passed: len(matches[1]) == 0,
+			}
+			if n, err := strconv.Atoi(matches[2]); err == nil {
+				tc.Number = n
+			} else {
+				tc.Number = len(result.Cases) + 1
+			}
+			switch strings.ToUpper(matches[4]) {
+			case "SKIP":
+				tc.Skipped = true
+				tc.Diagnostics = append(tc.Diagnostics, strings.TrimSpace(matches[5]))
+			case "TODO":
+				tc.Todo = true
+			}
+			result.Cases = append(result.Cases, tc)
+		} else if strings.HasPrefix(trimmed, "#") && len(result.Cases) > 0 {
+			last := &result.Cases[len(result.Cases)-1]
+			last.Diagnostics = append(last.Diagnostics, strings.TrimSpace(strings.TrimPrefix(trimmed, "#")))
+		}
+	}
+	return result, scanner.Err()
+}
+
+// Todo tests are expected to fail and do not count towards failures.
+func (r TapResult) Failed() []TapCase {
+	var failed []TapCase
+	for _, tc := range r.Cases {
+		if !tc.Passed && !tc.Todo {
+			failed = append(failed, tc)
+		}
+	}
+	return failed
+}
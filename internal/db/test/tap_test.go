@@ -0,0 +1,51 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tapOutput = `1..4
+ok 1 - table todos exists
+not ok 2 - owner can read own todos
+# Failed test 2: "owner can read own todos"
+#     have: 0
+#     want: 1
+ok 3 - skipped # SKIP no realtime
+not ok 4 - pending # TODO not implemented
+`
+
+func TestParseTap(t *testing.T) {
+	t.Run("parses pgtap output", func(t *testing.T) {
+		// Run test
+		result, err := ParseTap(strings.NewReader(tapOutput))
+		// Check error
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Plan)
+		assert.Len(t, result.Cases, 4)
+		assert.True(t, result.Cases[2].Skipped)
+		assert.True(t, result.Cases[3].Todo)
+		failed := result.Failed()
+		require.Len(t, failed, 1)
+		assert.Equal(t, TapCase{
+			Number: 2,
+			Name:   "owner can read own todos",
+			Diagnostics: []string{
+				`Failed test 2: "owner can read own todos"`,
+				"have: 0",
+				"want: 1",
+			},
+		}, failed[0])
+	})
+
+	t.Run("ignores unknown lines", func(t *testing.T) {
+		// Run test
+		result, err := ParseTap(strings.NewReader("NOTICE: extension exists\n# header\nok\n"))
+		// Check error
+		require.NoError(t, err)
+		assert.Equal(t, []TapCase{{Number: 1, Passed: true}}, result.Cases)
+	})
+}
@@ -0,0 +1,5 @@
+#!/bin/bash
+set -euo pipefail
+
+# temporarily enable pgtap, printing a notice if it was already enabled
+psql -h 127.0.0.1 -U postgres -p 5432 -d postgres -c "create extension if not exists pgtap with schema extensions" 2>&1 >/dev/null
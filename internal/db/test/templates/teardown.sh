@@ -0,0 +1,10 @@
+#!/bin/bash
+set -euo pipefail
+
+files=$1
+# disable pgtap if previously not enabled
+if [ "$2" = "drop" ]; then
+    psql -h 127.0.0.1 -U postgres -p 5432 -d postgres -c "drop extension if exists pgtap" 2>&1 >/dev/null
+fi
+# clean up test files
+rm -rf "$files"
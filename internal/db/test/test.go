@@ -5,21 +5,53 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/native"
 	"github.com/supabase/cli/internal/utils"
 )
 
 var (
-	//go:embed templates/test.sh
-	testScript string
+	//go:embed templates/setup.sh
+	setupScript string
+	//go:embed templates/teardown.sh
+	teardownScript string
 )
 
-func Run(ctx context.Context, fsys afero.Fs) error {
+type Options struct {
+	// Number of test files to run concurrently, each on its own connection
+	Jobs uint
+	// Glob pattern matched against the file name or path relative to tests directory
+	Filter string
+	// Path to write a JUnit XML report
+	Report string
+}
+
+type fileResult struct {
+	path     string
+	tap      TapResult
+	stderr   string
+	err      error
+	duration time.Duration
+}
+
+func (r fileResult) passed() bool {
+	return r.err == nil && len(r.tap.Failed()) == 0 && r.tap.Plan <= len(r.tap.Cases)
+}
+
+func Run(ctx context.Context, opts Options, fsys afero.Fs) error {
 	// Sanity checks.
 	{
 		if err := utils.LoadConfigFS(fsys); err != nil {
@@ -31,10 +63,54 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 		}
 	}
 
-	return pgProve(ctx, "/tmp", fsys)
+	files, err := findTests(utils.DbTestsDir, opts.Filter, fsys)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 && len(opts.Filter) > 0 {
+		return errors.New("No tests found matching " + utils.Aqua(opts.Filter))
+	} else if len(files) == 0 {
+		return errors.New("No tests found in " + utils.Bold(utils.DbTestsDir))
+	}
+	if utils.IsNativeDbRunning() {
+		return nativeProve(ctx, files, opts, fsys)
+	}
+	return pgProve(ctx, files, "/tmp", opts, fsys)
+}
+
+func findTests(testsDir, filter string, fsys afero.Fs) ([]string, error) {
+	if _, err := filepath.Match(filter, ""); err != nil {
+		return nil, fmt.Errorf("invalid filter %s: %w", filter, err)
+	}
+	var files []string
+	if err := afero.Walk(fsys, testsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := filepath.Ext(path); !info.Mode().IsRegular() || (ext != ".sql" && ext != ".pg") {
+			return nil
+		}
+		if len(filter) > 0 {
+			rel, err := filepath.Rel(testsDir, path)
+			if err != nil {
+				return err
+			}
+			byPath, _ := filepath.Match(filter, filepath.ToSlash(rel))
+			byName, _ := filepath.Match(filter, info.Name())
+			if !byPath && !byName {
+				return nil
+			}
+		}
+		files = append(files, path)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
 }
 
-func pgProve(ctx context.Context, dstPath string, fsys afero.Fs) error {
+func pgProve(ctx context.Context, files []string, dstPath string, opts Options, fsys afero.Fs) error {
 	// Copy tests into database container
 	var buf bytes.Buffer
 	if err := compress(utils.DbTestsDir, &buf, fsys); err != nil {
@@ -43,11 +119,171 @@ func pgProve(ctx context.Context, dstPath string, fsys afero.Fs) error {
 	if err := utils.Docker.CopyToContainer(ctx, utils.DbId, dstPath, &buf, types.CopyToContainerOptions{}); err != nil {
 		return err
 	}
-	// Passing in script string means command line args must be set manually, ie. "$@"
-	args := "set -- " + filepath.ToSlash(utils.DbTestsDir) + ";"
-	// Requires unix path inside container
-	cmd := []string{"/bin/bash", "-c", args + testScript}
-	return utils.DockerExecOnceWithStream(ctx, utils.DbId, dstPath, nil, cmd, os.Stdout, os.Stderr)
+	var notice bytes.Buffer
+	if err := utils.DockerExecOnceWithStream(ctx, utils.DbId, dstPath, nil, []string{"/bin/bash", "-c", setupScript}, &notice, os.Stderr); err != nil {
+		return err
+	}
+	defer func() {
+		// Drop pgtap only if it was enabled by setup script
+		drop := "keep"
+		if notice.Len() == 0 {
+			drop = "drop"
+		}
+		// Passing in script string means command line args must be set manually, ie. "$@"
+		args := fmt.Sprintf("set -- %s %s;", filepath.ToSlash(utils.DbTestsDir), drop)
+		cmd := []string{"/bin/bash", "-c", args + teardownScript}
+		if err := utils.DockerExecOnceWithStream(context.Background(), utils.DbId, dstPath, nil, cmd, io.Discard, os.Stderr); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to clean up tests:", err)
+		}
+	}()
+	psql := func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		cmd := append([]string{"psql"}, args...)
+		return utils.DockerExecOnceWithStream(ctx, utils.DbId, dstPath, nil, cmd, stdout, stderr)
+	}
+	start := time.Now()
+	results := runAll(ctx, files, psql, 5432, opts.Jobs)
+	return report(results, time.Since(start), opts, fsys)
+}
+
+// Runs tests with psql from the native Postgres installation against local files.
+func nativeProve(ctx context.Context, files []string, opts Options, fsys afero.Fs) error {
+	binDir, err := native.FindBinDir(ctx, fsys)
+	if err != nil {
+		return err
+	}
+	psql := func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		cmd := exec.CommandContext(ctx, filepath.Join(binDir, "psql"), args...)
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+utils.Config.Db.Password)
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		return cmd.Run()
+	}
+	port := utils.Config.Db.Port
+	var notice bytes.Buffer
+	enable := append(connectArgs(port), "-c", "create extension if not exists pgtap with schema extensions")
+	if err := psql(ctx, enable, io.Discard, &notice); err != nil {
+		return fmt.Errorf("failed to enable pgtap: %w: %s", err, notice.String())
+	}
+	defer func() {
+		// Drop pgtap only if it was not previously enabled
+		if notice.Len() > 0 {
+			return
+		}
+		disable := append(connectArgs(port), "-c", "drop extension if exists pgtap")
+		if err := psql(context.Background(), disable, io.Discard, os.Stderr); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to disable pgtap:", err)
+		}
+	}()
+	start := time.Now()
+	results := runAll(ctx, files, psql, port, opts.Jobs)
+	return report(results, time.Since(start), opts, fsys)
+}
+
+func report(results []fileResult, elapsed time.Duration, opts Options, fsys afero.Fs) error {
+	failed := printResults(results, elapsed, os.Stdout)
+	if len(opts.Report) > 0 {
+		if err := writeReport(opts.Report, results, fsys); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Wrote JUnit report to", utils.Bold(opts.Report))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d test files failed.", failed, len(results))
+	}
+	return nil
+}
+
+// Executes psql with the given arguments, either in the database container or natively.
+type psqlFunc func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+
+func runAll(ctx context.Context, files []string, psql psqlFunc, port uint, jobs uint) []fileResult {
+	if jobs == 0 {
+		jobs = 1
+	}
+	results := make([]fileResult, len(files))
+	indexCh := make(chan int)
+	var wg sync.WaitGroup
+	for i := uint(0); i < jobs && int(i) < len(files); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range indexCh {
+				results[j] = runFile(ctx, files[j], psql, port)
+			}
+		}()
+	}
+	for i := range files {
+		indexCh <- i
+	}
+	close(indexCh)
+	wg.Wait()
+	return results
+}
+
+func connectArgs(port uint) []string {
+	return []string{"-h", "127.0.0.1", "-U", "postgres", "-p", strconv.FormatUint(uint64(port), 10), "-d", "postgres"}
+}
+
+// Runs a single test file through psql, which prints the TAP stream produced by pgTAP.
+func runFile(ctx context.Context, path string, psql psqlFunc, port uint) fileResult {
+	result := fileResult{path: filepath.ToSlash(path)}
+	args := append(connectArgs(port), "-X", "-q", "-t", "-A", "-v", "ON_ERROR_STOP=1", "--pset", "pager=off", "-f", result.path)
+	var stdout, stderr bytes.Buffer
+	start := time.Now()
+	result.err = psql(ctx, args, &stdout, &stderr)
+	result.duration = time.Since(start)
+	result.stderr = stderr.String()
+	if tap, err := ParseTap(&stdout); err != nil && result.err == nil {
+		result.err = err
+	} else {
+		result.tap = tap
+	}
+	return result
+}
+
+// Prints a summary in the style of pg_prove, returning the number of failed files.
+func printResults(results []fileResult, elapsed time.Duration, w io.Writer) int {
+	failed, tests := 0, 0
+	for _, r := range results {
+		tests += len(r.tap.Cases)
+		if r.passed() {
+			fmt.Fprintf(w, "%s .. ok\n", r.path)
+			continue
+		}
+		failed++
+		fmt.Fprintf(w, "%s .. %s\n", r.path, utils.Red("failed"))
+		for _, tc := range r.tap.Failed() {
+			fmt.Fprintf(w, "  not ok %d - %s\n", tc.Number, tc.Name)
+			for _, line := range tc.Diagnostics {
+				fmt.Fprintln(w, "    #", line)
+			}
+		}
+		if r.tap.Plan > len(r.tap.Cases) {
+			fmt.Fprintf(w, "  planned %d tests but ran %d\n", r.tap.Plan, len(r.tap.Cases))
+		}
+		if r.err != nil {
+			fmt.Fprintln(w, " ", strings.TrimSpace(r.stderr))
+		}
+	}
+	fmt.Fprintf(w, "Files=%d, Tests=%d, %.2f wallclock secs\n", len(results), tests, elapsed.Seconds())
+	if failed > 0 {
+		fmt.Fprintln(w, "Result: FAIL")
+	} else {
+		fmt.Fprintln(w, "Result: PASS")
+	}
+	return failed
+}
+
+func writeReport(path string, results []fileResult, fsys afero.Fs) error {
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return err
+	}
+	f, err := fsys.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeJUnit(results, f)
 }
 
 // Ref 1: https://medium.com/@skdomino/taring-untaring-files-in-go-6b07cf56bc07
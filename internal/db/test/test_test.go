@@ -56,6 +56,48 @@ func TestTarDirectory(t *testing.T) {
 	})
 }
 
+func TestFindTests(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	for _, name := range []string{"auth/users_test.sql", "todos_test.sql", "todos.pg", "README.md"} {
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.DbTestsDir, name), []byte{}, 0644))
+	}
+
+	t.Run("finds all tests", func(t *testing.T) {
+		// Run test
+		files, err := findTests(utils.DbTestsDir, "", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, files, 3)
+	})
+
+	t.Run("filters by name", func(t *testing.T) {
+		// Run test
+		files, err := findTests(utils.DbTestsDir, "*_test.sql", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(utils.DbTestsDir, "auth", "users_test.sql"),
+			filepath.Join(utils.DbTestsDir, "todos_test.sql"),
+		}, files)
+	})
+
+	t.Run("filters by relative path", func(t *testing.T) {
+		// Run test
+		files, err := findTests(utils.DbTestsDir, "auth/*", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(utils.DbTestsDir, "auth", "users_test.sql")}, files)
+	})
+
+	t.Run("throws error on invalid pattern", func(t *testing.T) {
+		// Run test
+		_, err := findTests(utils.DbTestsDir, "[", fsys)
+		// Check error
+		assert.ErrorIs(t, err, filepath.ErrBadPattern)
+	})
+}
+
 func TestPgProve(t *testing.T) {
 	t.Run("throws error on copy failure", func(t *testing.T) {
 		utils.DbId = "test_db"
@@ -68,7 +110,7 @@ func TestPgProve(t *testing.T) {
 			Put("/v" + utils.Docker.ClientVersion() + "/containers/test_db/archive").
 			Reply(http.StatusServiceUnavailable)
 		// Run test
-		err := pgProve(context.Background(), "/tmp", fsys)
+		err := pgProve(context.Background(), nil, "/tmp", Options{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "request returned Service Unavailable for API route and version")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -88,7 +130,7 @@ func TestPgProve(t *testing.T) {
 			Post("/v" + utils.Docker.ClientVersion() + "/containers/test_db/exec").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := pgProve(context.Background(), "/tmp", fsys)
+		err := pgProve(context.Background(), nil, "/tmp", Options{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -100,7 +142,7 @@ func TestRunCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), fsys)
+		err := Run(context.Background(), Options{}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
@@ -115,7 +157,7 @@ func TestRunCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), fsys)
+		err := Run(context.Background(), Options{}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, utils.ErrNotRunning)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -132,7 +174,7 @@ func TestRunCommand(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON(types.ContainerJSON{})
 		// Run test
-		err := Run(context.Background(), fsys)
+		err := Run(context.Background(), Options{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "open supabase/tests: file does not exist")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
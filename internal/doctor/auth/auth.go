@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/zalando/go-keyring"
+)
+
+// Where an access token of the current profile was looked up.
+type Source struct {
+	Name     string
+	Location string
+	Found    bool
+	Error    error
+}
+
+func Run(ctx context.Context, migrateTo string, fsys afero.Fs) error {
+	if len(migrateTo) > 0 {
+		if err := Migrate(migrateTo, fsys); err != nil {
+			return err
+		}
+	}
+	sources := ListSources(fsys)
+	pinned := utils.GetTokenStore(fsys)
+	fmt.Fprintf(os.Stderr, "%s %s\n", utils.Bold("Profile:"), utils.GetCurrentProfile(fsys))
+	if len(pinned) > 0 {
+		fmt.Fprintf(os.Stderr, "%s %s (pinned by %s)\n", utils.Bold("Token store:"), pinned, utils.Aqua("supabase login --store"))
+	} else {
+		fmt.Fprintf(os.Stderr, "%s %s with fallback to %s\n", utils.Bold("Token store:"), utils.TokenStoreKeyring, utils.TokenStoreFile)
+	}
+	if err := list.RenderTable(makeSourceTable(sources, activeSource(sources, pinned))); err != nil {
+		return err
+	}
+	if sources[1].Found && sources[2].Found {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Access tokens found in both stores. Run", utils.Aqua("supabase doctor auth --migrate-to "+utils.TokenStoreKeyring), "to remove the plaintext copy.")
+	}
+	token, err := utils.LoadAccessTokenFS(fsys)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Validating access token "+utils.Bold(mask(token))+"...")
+	return ValidateToken(ctx)
+}
+
+// Lists the env var and both token stores in the order they are resolved.
+func ListSources(fsys afero.Fs) []Source {
+	env := Source{Name: "env", Location: "SUPABASE_ACCESS_TOKEN"}
+	env.Found = len(os.Getenv("SUPABASE_ACCESS_TOKEN")) > 0
+	keyring := Source{Name: utils.TokenStoreKeyring, Location: "native keyring"}
+	if !credentials.IsKeyring() {
+		keyring.Location = "configured credentials store"
+	}
+	file := Source{Name: utils.TokenStoreFile}
+	if path, err := utils.GetAccessTokenPath(fsys); err == nil {
+		file.Location = path
+	}
+	result := []Source{env, keyring, file}
+	for i := 1; i < len(result); i++ {
+		_, err := utils.LoadTokenFromStore(result[i].Name, fsys)
+		result[i].Found = err == nil
+		if err != nil && !errors.Is(err, utils.ErrMissingToken) {
+			result[i].Error = err
+		}
+	}
+	return result
+}
+
+// Returns the name of the source that commands will read the access token from.
+func activeSource(sources []Source, pinned string) string {
+	for _, s := range sources {
+		if !s.Found {
+			continue
+		}
+		if len(pinned) == 0 || s.Name == pinned || s.Name == "env" {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+func makeSourceTable(sources []Source, active string) string {
+	table := "|SOURCE|LOCATION|TOKEN|ACTIVE|\n|-|-|-|-|\n"
+	for _, s := range sources {
+		status := "not found"
+		if s.Found {
+			status = "found"
+		} else if s.Error != nil {
+			status = s.Error.Error()
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|%s|`%t`|\n", s.Name, s.Location, status, s.Name == active)
+	}
+	return table
+}
+
+// Checks that the access token is accepted by the Management API.
+func ValidateToken(ctx context.Context) error {
+	resp, err := utils.GetSupabase().GetOrganizationsWithResponse(ctx)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() == http.StatusUnauthorized {
+		utils.CmdSuggestion = "Run " + utils.Aqua("supabase login") + " to generate a new access token."
+		return errors.New("Access token is invalid or has been revoked.")
+	}
+	if resp.JSON200 == nil {
+		return errors.New("Unexpected error validating access token: " + string(resp.Body))
+	}
+	fmt.Printf("Access token is valid with access to %d organizations.\n", len(*resp.JSON200))
+	return nil
+}
+
+// Moves the access token to the target store and pins it for subsequent commands. If the target
+// store already holds a token, that token is kept and only the copy in the other store is removed.
+func Migrate(target string, fsys afero.Fs) error {
+	source := utils.TokenStoreKeyring
+	if target == utils.TokenStoreKeyring {
+		source = utils.TokenStoreFile
+	}
+	if _, err := utils.LoadTokenFromStore(target, fsys); err == nil {
+		if err := utils.DeleteTokenFromStore(source, fsys); err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to delete access token from %s: %w", source, err)
+		}
+		return utils.SetTokenStore(target, fsys)
+	} else if !errors.Is(err, utils.ErrMissingToken) {
+		return err
+	}
+	token, err := utils.LoadTokenFromStore(source, fsys)
+	if err != nil {
+		return err
+	}
+	if err := utils.SaveTokenToStore(target, token, fsys); err != nil {
+		return fmt.Errorf("failed to save access token to %s: %w", target, err)
+	}
+	if err := utils.DeleteTokenFromStore(source, fsys); err != nil {
+		return fmt.Errorf("failed to delete access token from %s: %w", source, err)
+	}
+	if err := utils.SetTokenStore(target, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Moved access token from "+utils.Aqua(source)+" to "+utils.Aqua(target)+".")
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, os.ErrNotExist) || errors.Is(err, keyring.ErrNotFound)
+}
+
+// Only the prefix is shown so that tokens are not leaked in CI logs. Short tokens, which are
+// invalid anyway, are masked entirely.
+func mask(token string) string {
+	if len(token) <= 8 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:8] + "..."
+}
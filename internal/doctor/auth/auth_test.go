@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/zalando/go-keyring"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestMigrateToken(t *testing.T) {
+	token := string(apitest.RandomAccessToken(t))
+
+	t.Run("moves token from file to keyring", func(t *testing.T) {
+		keyring.MockInit()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.SaveTokenToStore(utils.TokenStoreFile, token, fsys))
+		// Run test
+		err := Migrate(utils.TokenStoreKeyring, fsys)
+		// Check error
+		assert.NoError(t, err)
+		sources := ListSources(fsys)
+		assert.True(t, sources[1].Found)
+		assert.False(t, sources[2].Found)
+		assert.Equal(t, utils.TokenStoreKeyring, utils.GetTokenStore(fsys))
+	})
+
+	t.Run("pins store if token already moved", func(t *testing.T) {
+		keyring.MockInit()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.SaveTokenToStore(utils.TokenStoreFile, token, fsys))
+		// Run test
+		err := Migrate(utils.TokenStoreFile, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, utils.TokenStoreFile, utils.GetTokenStore(fsys))
+		loaded, err := utils.LoadAccessTokenFS(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, token, loaded)
+	})
+
+	t.Run("keeps token already in target store", func(t *testing.T) {
+		keyring.MockInit()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.SaveTokenToStore(utils.TokenStoreKeyring, token, fsys))
+		require.NoError(t, utils.SaveTokenToStore(utils.TokenStoreFile, "sbp_stale", fsys))
+		// Run test
+		err := Migrate(utils.TokenStoreKeyring, fsys)
+		// Check error
+		assert.NoError(t, err)
+		loaded, err := utils.LoadTokenFromStore(utils.TokenStoreKeyring, fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, token, loaded)
+		_, err = utils.LoadTokenFromStore(utils.TokenStoreFile, fsys)
+		assert.ErrorIs(t, err, utils.ErrMissingToken)
+	})
+
+	t.Run("throws error on missing token", func(t *testing.T) {
+		keyring.MockInit()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Migrate(utils.TokenStoreFile, fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrMissingToken)
+	})
+}
+
+func TestMaskToken(t *testing.T) {
+	assert.Equal(t, "sbp_0123...", mask("sbp_0123456789"))
+	assert.Equal(t, "********", mask("sbp_0123"))
+}
+
+func TestValidateToken(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("throws error on revoked token", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/organizations").
+			Reply(http.StatusUnauthorized).
+			JSON(map[string]string{"message": "Unauthorized"})
+		// Run test
+		err := ValidateToken(context.Background())
+		// Check error
+		assert.ErrorContains(t, err, "Access token is invalid or has been revoked.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -3,22 +3,34 @@ package deploy
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/docker/go-units"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/webhook"
+	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/fanout"
 	"github.com/supabase/cli/pkg/api"
 )
 
 const eszipContentType = "application/vnd.denoland.eszip"
 
-func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bool, importMapPath string, fsys afero.Fs) error {
+func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bool, importMapPath string, jobs uint, fsys afero.Fs) error {
+	return RunMany(ctx, slugs, []string{projectRef}, noVerifyJWT, importMapPath, jobs, fsys)
+}
+
+// Deploys the same Functions to each project concurrently, loading config only once.
+func RunMany(ctx context.Context, slugs []string, projectRefs []string, noVerifyJWT *bool, importMapPath string, jobs uint, fsys afero.Fs) error {
 	// Load function config if any for fallbacks for some flags, but continue on error.
 	_ = utils.LoadConfigFS(fsys)
 	if len(slugs) == 0 {
@@ -37,7 +49,28 @@ func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bo
 	if len(slugs) == 0 {
 		return errors.New("No Functions specified or found in " + utils.Bold(utils.FunctionsDir))
 	}
-	return deployAll(ctx, slugs, projectRef, importMapPath, noVerifyJWT, fsys)
+	// Fail fast on broken local imports, which would otherwise only surface after bundling
+	if err := validateImports(slugs, importMapPath, fsys); err != nil {
+		return err
+	}
+	// Setup deno binaries once since concurrent projects share the same install paths
+	if err := utils.InstallOrUpgradeDeno(ctx, fsys); err != nil {
+		return err
+	}
+	scriptDir, err := utils.CopyDenoScripts(ctx, fsys)
+	if err != nil {
+		return err
+	}
+	// Remote modules shared between Functions are fetched once per deploy
+	cacheDir, err := os.MkdirTemp("", "supabase-bundle-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(cacheDir)
+	b := newBundler(ctx, scriptDir.BuildPath, cacheDir)
+	return fanout.Run(ctx, projectRefs, func(ctx context.Context, projectRef string) error {
+		return deployAll(ctx, slugs, projectRef, importMapPath, b, noVerifyJWT, jobs, fsys)
+	})
 }
 
 func getFunctionSlugs(fsys afero.Fs) ([]string, error) {
@@ -56,7 +89,89 @@ func getFunctionSlugs(fsys afero.Fs) ([]string, error) {
 	return slugs, nil
 }
 
-func bundleFunction(ctx context.Context, entrypointPath, importMapPath, buildScriptPath string) (*bytes.Buffer, error) {
+func resolveImportMapPath(importMapPath, slug string, fsys afero.Fs) (string, error) {
+	resolved, err := utils.AbsImportMapPath(importMapPath, slug, fsys)
+	if err != nil {
+		return "", err
+	}
+	// Upstream server expects import map to be always defined
+	if len(resolved) == 0 {
+		return filepath.Abs(utils.FallbackImportMapPath)
+	}
+	return resolved, nil
+}
+
+func validateImports(slugs []string, importMapPath string, fsys afero.Fs) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	graph := newModuleGraph(fsys)
+	var errs []error
+	for _, slug := range slugs {
+		resolved, err := resolveImportMapPath(importMapPath, slug, fsys)
+		if err != nil {
+			return err
+		}
+		importMap, err := utils.NewImportMap(resolved, fsys)
+		if errors.Is(err, os.ErrNotExist) {
+			importMap = &utils.ImportMap{}
+		} else if err != nil {
+			return fmt.Errorf("Failed to parse import map %s: %w", utils.Bold(resolved), err)
+		}
+		// Relative paths in the import map are resolved against its own directory
+		importMapDir, err := filepath.Rel(cwd, filepath.Dir(resolved))
+		if err != nil {
+			return err
+		}
+		warnings, err := graph.Validate(slug, importMap, importMapDir)
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), w)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Bundles each Function at most once, so that deploying to many projects reuses the same eszip.
+type bundler struct {
+	// Bundles are shared by all projects, so they must not be cancelled with the project that started them
+	ctx        context.Context
+	scriptPath string
+	cacheDir   string
+
+	mu      sync.Mutex
+	bundles map[string]*bundle
+}
+
+type bundle struct {
+	once sync.Once
+	body []byte
+	err  error
+}
+
+func newBundler(ctx context.Context, scriptPath, cacheDir string) *bundler {
+	return &bundler{ctx: ctx, scriptPath: scriptPath, cacheDir: cacheDir, bundles: map[string]*bundle{}}
+}
+
+func (b *bundler) Bundle(entrypointPath, importMapPath string) ([]byte, error) {
+	key := entrypointPath + "\n" + importMapPath
+	b.mu.Lock()
+	result, ok := b.bundles[key]
+	if !ok {
+		result = &bundle{}
+		b.bundles[key] = result
+	}
+	b.mu.Unlock()
+	result.once.Do(func() {
+		result.body, result.err = bundleFunction(b.ctx, entrypointPath, importMapPath, b.scriptPath, b.cacheDir)
+	})
+	return result.body, result.err
+}
+
+func bundleFunction(ctx context.Context, entrypointPath, importMapPath, buildScriptPath, cacheDir string) ([]byte, error) {
 	denoPath, err := utils.GetDenoPath()
 	if err != nil {
 		return nil, err
@@ -64,13 +179,16 @@ func bundleFunction(ctx context.Context, entrypointPath, importMapPath, buildScr
 	// Bundle function and import_map with deno
 	args := []string{"run", "-A", buildScriptPath, entrypointPath, importMapPath}
 	cmd := exec.CommandContext(ctx, denoPath, args...)
+	if len(cacheDir) > 0 {
+		cmd.Env = append(os.Environ(), "SUPABASE_BUNDLE_CACHE_DIR="+cacheDir)
+	}
 	var outBuf, errBuf bytes.Buffer
 	cmd.Stdout = &outBuf
 	cmd.Stderr = &errBuf
 	if err := cmd.Run(); err != nil {
 		return nil, fmt.Errorf("Error bundling function: %w\n%v", err, errBuf.String())
 	}
-	return &outBuf, nil
+	return outBuf.Bytes(), nil
 }
 
 func deployFunction(ctx context.Context, projectRef, slug, entrypointUrl, importMapUrl string, verifyJWT bool, functionBody io.Reader) error {
@@ -110,13 +228,13 @@ func deployFunction(ctx context.Context, projectRef, slug, entrypointUrl, import
 		return errors.New("Unexpected error deploying Function: " + string(resp.Body))
 	}
 
-	fmt.Println("Deployed Function " + utils.Aqua(slug) + " on project " + utils.Aqua(projectRef))
+	fmt.Fprintln(fanout.Stdout(ctx), "Deployed Function "+utils.Aqua(slug)+" on project "+utils.Aqua(projectRef))
 	url := fmt.Sprintf("%s/project/%v/functions/%v/details", utils.GetSupabaseDashboardURL(), projectRef, slug)
-	fmt.Println("You can inspect your deployment in the Dashboard: " + url)
+	fmt.Fprintln(fanout.Stdout(ctx), "You can inspect your deployment in the Dashboard: "+url)
 	return nil
 }
 
-func deployOne(ctx context.Context, slug, projectRef, importMapPath, buildScriptPath string, noVerifyJWT *bool, fsys afero.Fs) error {
+func deployOne(ctx context.Context, slug, projectRef, importMapPath string, b *bundler, noVerifyJWT *bool, state *utils.ResumeState, fsys afero.Fs) error {
 	// 1. Ensure noVerifyJWT is not nil.
 	if noVerifyJWT == nil {
 		x := false
@@ -125,64 +243,132 @@ func deployOne(ctx context.Context, slug, projectRef, importMapPath, buildScript
 		}
 		noVerifyJWT = &x
 	}
-	resolved, err := utils.AbsImportMapPath(importMapPath, slug, fsys)
+	importMapPath, err := resolveImportMapPath(importMapPath, slug, fsys)
 	if err != nil {
 		return err
 	}
-	// Upstream server expects import map to be always defined
-	if importMapPath == "" {
-		resolved, err = filepath.Abs(utils.FallbackImportMapPath)
-		if err != nil {
-			return err
-		}
-	}
-	importMapPath = resolved
 	// 2. Bundle Function.
 	entrypointPath, err := filepath.Abs(filepath.Join(utils.FunctionsDir, slug, "index.ts"))
 	if err != nil {
 		return err
 	}
-	fmt.Println("Bundling " + utils.Bold(slug))
-	functionBody, err := bundleFunction(ctx, entrypointPath, importMapPath, buildScriptPath)
+	fmt.Fprintln(fanout.Stdout(ctx), "Bundling "+utils.Bold(slug))
+	functionBody, err := b.Bundle(entrypointPath, importMapPath)
 	if err != nil {
 		return err
 	}
-	// 3. Deploy new Function.
-	functionSize := units.HumanSize(float64(functionBody.Len()))
-	fmt.Println("Deploying " + utils.Bold(slug) + " (script size: " + utils.Bold(functionSize) + ")")
-	return deployFunction(
+	// 3. Skip unchanged Function deployed by a previous interrupted run.
+	digest := sha256.Sum256(functionBody)
+	checksum := fmt.Sprintf("%x-%t", digest, *noVerifyJWT)
+	if state.Done(slug, checksum) {
+		fmt.Fprintln(fanout.Stdout(ctx), "Skipping "+utils.Bold(slug)+" already deployed by a previous run")
+		return nil
+	}
+	// 4. Deploy new Function.
+	functionSize := units.HumanSize(float64(len(functionBody)))
+	fmt.Fprintln(fanout.Stdout(ctx), "Deploying "+utils.Bold(slug)+" (script size: "+utils.Bold(functionSize)+")")
+	if err := deployFunction(
 		ctx,
 		projectRef,
 		slug,
 		"file://"+entrypointPath,
 		"file://"+importMapPath,
 		!*noVerifyJWT,
-		functionBody,
-	)
+		bytes.NewReader(functionBody),
+	); err != nil {
+		return err
+	}
+	return state.MarkDone(slug, checksum)
 }
 
-// TODO: api has a race condition that prevents deploying in parallel
-const maxConcurrency = 1
+type deployResult struct {
+	slug string
+	err  error
+}
 
-func deployAll(ctx context.Context, slugs []string, projectRef, importMapPath string, noVerifyJWT *bool, fsys afero.Fs) error {
-	// Setup deno binaries
-	if err := utils.InstallOrUpgradeDeno(ctx, fsys); err != nil {
+func deployAll(ctx context.Context, slugs []string, projectRef, importMapPath string, b *bundler, noVerifyJWT *bool, jobs uint, fsys afero.Fs) error {
+	state, err := utils.LoadResumeState("functions-deploy-"+projectRef, fsys)
+	if err != nil {
 		return err
 	}
-	scriptDir, err := utils.CopyDenoScripts(ctx, fsys)
+	// TODO: api has a race condition that prevents deploying in parallel, so --jobs defaults to 1
+	// until it is fixed. Bundling is safe to run concurrently.
+	if jobs == 0 {
+		jobs = 1
+	}
+	// Bundle and upload concurrently with a fixed pool of workers
+	slugCh := make(chan string)
+	resultCh := make(chan deployResult, len(slugs))
+	var wg sync.WaitGroup
+	for i := uint(0); i < jobs && int(i) < len(slugs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for slug := range slugCh {
+				err := deployOne(ctx, slug, projectRef, importMapPath, b, noVerifyJWT, state, fsys)
+				resultCh <- deployResult{slug: slug, err: err}
+			}
+		}()
+	}
+	for _, slug := range slugs {
+		slugCh <- slug
+	}
+	close(slugCh)
+	wg.Wait()
+	close(resultCh)
+	// Log all errors and proceed
+	results := map[string]error{}
+	for r := range resultCh {
+		results[r.slug] = r.err
+	}
+	var errs []error
+	for _, slug := range slugs {
+		if err := results[slug]; err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", slug, err))
+		}
+	}
+	if len(slugs) > 1 {
+		if err := list.RenderTable(makeSummaryTable(slugs, results)); err != nil {
+			return err
+		}
+	}
+	if len(errs) == 0 {
+		if err := state.Clear(); err != nil {
+			return err
+		}
+		return configureWebhooks(ctx, projectRef, results, fsys)
+	}
+	fmt.Fprintln(fanout.Stderr(ctx), "Re-run the same command to resume deploying the remaining Functions.")
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// Points database triggers at the project once any Function they call is deployed.
+func configureWebhooks(ctx context.Context, projectRef string, results map[string]error, fsys afero.Fs) error {
+	slugs, err := webhook.ListSlugs(fsys)
 	if err != nil {
 		return err
 	}
-	errCh := make(chan error, maxConcurrency)
-	errCh <- nil
 	for _, slug := range slugs {
-		// Log all errors and proceed
-		if err := <-errCh; err != nil {
-			return err
+		if err, ok := results[slug]; ok && err == nil {
+			return webhook.ConfigureRemote(ctx, projectRef)
+		}
+	}
+	return nil
+}
+
+func makeSummaryTable(slugs []string, results map[string]error) string {
+	table := "|FUNCTION|STATUS|ERROR|\n|-|-|-|\n"
+	for _, slug := range slugs {
+		status, message := "deployed", " "
+		if err := results[slug]; err != nil {
+			status = "failed"
+			message, _, _ = strings.Cut(err.Error(), "\n")
+			message = strings.ReplaceAll(message, "|", "\\|")
 		}
-		go func(slug string) {
-			errCh <- deployOne(ctx, slug, projectRef, importMapPath, scriptDir.BuildPath, noVerifyJWT, fsys)
-		}(slug)
+		table += fmt.Sprintf("|`%s`|`%s`|%s|\n", slug, status, message)
 	}
-	return <-errCh
+	return table
 }
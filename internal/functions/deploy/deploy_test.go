@@ -72,7 +72,7 @@ func TestDeployOne(t *testing.T) {
 			JSON(api.FunctionResponse{Id: "1"})
 		// Run test
 		noVerifyJWT := true
-		err = deployOne(context.Background(), slug, project, "", "", &noVerifyJWT, fsys)
+		err = deployOne(context.Background(), slug, project, "", newBundler(context.Background(), "", ""), &noVerifyJWT, newResumeState(t, fsys), fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -100,7 +100,7 @@ func TestDeployOne(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON(api.FunctionResponse{Id: "1"})
 		// Run test
-		err = deployOne(context.Background(), slug, project, "", "", nil, fsys)
+		err = deployOne(context.Background(), slug, project, "", newBundler(context.Background(), "", ""), nil, newResumeState(t, fsys), fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -112,7 +112,7 @@ func TestDeployOne(t *testing.T) {
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
 		// Run test
-		err := deployOne(context.Background(), slug, project, "import_map.json", "", nil, fsys)
+		err := deployOne(context.Background(), slug, project, "import_map.json", newBundler(context.Background(), "", ""), nil, newResumeState(t, fsys), fsys)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
@@ -138,13 +138,34 @@ func TestDeployOne(t *testing.T) {
 			Reply(http.StatusOK).
 			Body(&body)
 		// Run test
-		err = deployOne(context.Background(), slug, project, "", "", nil, fsys)
+		err = deployOne(context.Background(), slug, project, "", newBundler(context.Background(), "", ""), nil, newResumeState(t, fsys), fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Error bundling function: exit status 1\nbundle failed\n")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
 
+func TestBundler(t *testing.T) {
+	t.Run("bundles each function once", func(t *testing.T) {
+		b := newBundler(context.Background(), "", "")
+		// Run test
+		_, err := b.Bundle("index.ts", "import_map.json")
+		assert.NoError(t, err)
+		// Check reused bundle
+		t.Setenv("TEST_DENO_ERROR", "bundle failed")
+		_, err = b.Bundle("index.ts", "import_map.json")
+		assert.NoError(t, err)
+		_, err = b.Bundle("other.ts", "import_map.json")
+		assert.ErrorContains(t, err, "bundle failed")
+	})
+}
+
+func newResumeState(t *testing.T, fsys afero.Fs) *utils.ResumeState {
+	state, err := utils.LoadResumeState("test", fsys)
+	require.NoError(t, err)
+	return state
+}
+
 func TestDeployAll(t *testing.T) {
 	const slug = "test-func"
 
@@ -152,6 +173,10 @@ func TestDeployAll(t *testing.T) {
 		functions := []string{slug, slug + "-2"}
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
+		for _, name := range functions {
+			entrypointPath := filepath.Join(utils.FunctionsDir, name, "index.ts")
+			require.NoError(t, afero.WriteFile(fsys, entrypointPath, []byte{}, 0644))
+		}
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
 		// Setup valid access token
@@ -174,19 +199,100 @@ func TestDeployAll(t *testing.T) {
 		}
 		// Run test
 		noVerifyJWT := true
-		err = deployAll(context.Background(), functions, project, "", &noVerifyJWT, fsys)
+		err = deployAll(context.Background(), functions, project, "", newBundler(context.Background(), "", ""), &noVerifyJWT, 1, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("deploys in parallel and reports failures", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup valid deno path
+		_, err := fsys.Create(utils.DenoPathOverride)
+		require.NoError(t, err)
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/alpha").
+			Reply(http.StatusNotFound)
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/functions").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Id: "1"})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/beta").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		noVerifyJWT := true
+		err = deployAll(context.Background(), []string{"alpha", "beta"}, project, "", newBundler(context.Background(), "", ""), &noVerifyJWT, 2, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error deploying Function:")
+		assert.NotContains(t, err.Error(), "alpha")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("resumes deploy after partial failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup valid deno path
+		_, err := fsys.Create(utils.DenoPathOverride)
+		require.NoError(t, err)
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/alpha").
+			Reply(http.StatusNotFound)
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/functions").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Id: "1"})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/beta").
+			Reply(http.StatusServiceUnavailable)
+		noVerifyJWT := true
+		err = deployAll(context.Background(), []string{"alpha", "beta"}, project, "", newBundler(context.Background(), "", ""), &noVerifyJWT, 1, fsys)
+		require.ErrorContains(t, err, "beta: Unexpected error deploying Function:")
+		// Only the failed function is redeployed
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/beta").
+			Reply(http.StatusNotFound)
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/functions").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Id: "2"})
+		// Run test
+		err = deployAll(context.Background(), []string{"alpha", "beta"}, project, "", newBundler(context.Background(), "", ""), &noVerifyJWT, 1, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
+		exists, err := afero.DirExists(fsys, utils.ResumeDir)
+		assert.NoError(t, err)
+		entries, err := afero.ReadDir(fsys, utils.ResumeDir)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Empty(t, entries)
 	})
 
 	t.Run("throws error on failure to install deno", func(t *testing.T) {
 		// Setup in-memory fs
-		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
+		fsys := afero.NewMemMapFs()
+		entrypointPath := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypointPath, []byte{}, 0644))
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
 		// Run test
-		err := deployAll(context.Background(), []string{slug}, project, "", nil, fsys)
+		err := RunMany(context.Background(), []string{slug}, []string{project}, nil, "", 1, afero.NewReadOnlyFs(fsys))
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 	})
@@ -194,13 +300,15 @@ func TestDeployAll(t *testing.T) {
 	t.Run("throws error on copy failure", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
+		entrypointPath := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypointPath, []byte{}, 0644))
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
 		// Setup valid deno path
 		_, err := fsys.Create(utils.DenoPathOverride)
 		require.NoError(t, err)
 		// Run test
-		err = deployAll(context.Background(), []string{slug}, project, "", nil, afero.NewReadOnlyFs(fsys))
+		err = RunMany(context.Background(), []string{slug}, []string{project}, nil, "", 1, afero.NewReadOnlyFs(fsys))
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 	})
@@ -213,6 +321,10 @@ func TestDeployCommand(t *testing.T) {
 		functions := []string{slug, slug + "-2"}
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
+		for _, name := range functions {
+			entrypointPath := filepath.Join(utils.FunctionsDir, name, "index.ts")
+			require.NoError(t, afero.WriteFile(fsys, entrypointPath, []byte{}, 0644))
+		}
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
 		// Setup valid access token
@@ -235,7 +347,7 @@ func TestDeployCommand(t *testing.T) {
 		}
 		// Run test
 		noVerifyJWT := true
-		err = Run(context.Background(), functions, project, &noVerifyJWT, "", fsys)
+		err = Run(context.Background(), functions, project, &noVerifyJWT, "", 1, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -272,7 +384,7 @@ func TestDeployCommand(t *testing.T) {
 			Reply(http.StatusCreated).
 			JSON(api.FunctionResponse{Id: "1"})
 		// Run test
-		err = Run(context.Background(), nil, project, nil, "", fsys)
+		err = Run(context.Background(), nil, project, nil, "", 1, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -282,7 +394,7 @@ func TestDeployCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), []string{"_invalid"}, "", nil, "", fsys)
+		err := Run(context.Background(), []string{"_invalid"}, "", nil, "", 1, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Invalid Function name.")
 	})
@@ -292,7 +404,7 @@ func TestDeployCommand(t *testing.T) {
 		fsys := afero.NewMemMapFs()
 		require.NoError(t, fsys.MkdirAll(utils.FunctionsDir, 0755))
 		// Run test
-		err := Run(context.Background(), nil, "", nil, "", fsys)
+		err := Run(context.Background(), nil, "", nil, "", 1, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "No Functions specified or found in supabase/functions")
 	})
@@ -309,6 +421,8 @@ verify_jwt = false
 `)
 		require.NoError(t, err)
 		require.NoError(t, f.Close())
+		entrypointPath := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypointPath, []byte{}, 0644))
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
 		// Setup valid access token
@@ -328,7 +442,7 @@ verify_jwt = false
 			Reply(http.StatusCreated).
 			JSON(api.FunctionResponse{Id: "1"})
 		// Run test
-		assert.NoError(t, Run(context.Background(), []string{slug}, project, nil, "", fsys))
+		assert.NoError(t, Run(context.Background(), []string{slug}, project, nil, "", 1, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -345,6 +459,8 @@ verify_jwt = false
 `)
 		require.NoError(t, err)
 		require.NoError(t, f.Close())
+		entrypointPath := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypointPath, []byte{}, 0644))
 		// Setup valid project ref
 		project := apitest.RandomProjectRef()
 		// Setup valid access token
@@ -365,7 +481,7 @@ verify_jwt = false
 			JSON(api.FunctionResponse{Id: "1"})
 		// Run test
 		noVerifyJwt := false
-		assert.NoError(t, Run(context.Background(), []string{slug}, project, &noVerifyJwt, "", fsys))
+		assert.NoError(t, Run(context.Background(), []string{slug}, project, &noVerifyJwt, "", 1, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -465,3 +581,10 @@ func TestDeployFunction(t *testing.T) {
 		assert.ErrorContains(t, err, "Failed to update an existing Function's body on the Supabase project:")
 	})
 }
+
+func TestSummaryTable(t *testing.T) {
+	table := makeSummaryTable([]string{"alpha", "beta"}, map[string]error{
+		"beta": errors.New("Error bundling function: exit status 1\nstack trace"),
+	})
+	assert.Equal(t, "|FUNCTION|STATUS|ERROR|\n|-|-|-|\n|`alpha`|`deployed`| |\n|`beta`|`failed`|Error bundling function: exit status 1|\n", table)
+}
@@ -0,0 +1,254 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	// Static imports and re-exports, ie. import x from "y", import "y", export * from "y"
+	staticImportPattern = regexp.MustCompile(`(?m)^\s*(?:import|export)\b[^'";]*?\bfrom\s*['"]([^'"\n]+)['"]|^\s*import\s*['"]([^'"\n]+)['"]`)
+	// Dynamic imports with a literal specifier, ie. await import("y")
+	dynamicImportPattern = regexp.MustCompile(`\bimport\s*\(\s*['"]([^'"\n]+)['"]\s*\)`)
+	// Only source files are parsed for further imports
+	sourceExts = []string{".ts", ".tsx", ".mts", ".js", ".jsx", ".mjs"}
+)
+
+// Directory for modules shared between Functions. Its name is not a valid slug so it is never deployed.
+const sharedDir = "_shared"
+
+// Caches the imports of each local module so that code shared between
+// Functions is read and parsed only once per deploy.
+type moduleGraph struct {
+	fsys    afero.Fs
+	imports map[string][]specifier
+}
+
+type specifier struct {
+	value string
+	// Dynamic imports may be guarded by the caller, so failing to resolve them is not fatal
+	dynamic bool
+}
+
+func newModuleGraph(fsys afero.Fs) *moduleGraph {
+	return &moduleGraph{fsys: fsys, imports: map[string][]specifier{}}
+}
+
+// Checks that every local module reachable from the Function entrypoint exists and
+// lives either in the Function's own directory or a shared directory, and that bare
+// specifiers are declared in the import map. Problems with dynamic imports are returned
+// as warnings instead.
+func (g *moduleGraph) Validate(slug string, importMap *utils.ImportMap, importMapDir string) ([]error, error) {
+	entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+	visited := map[string]bool{entrypoint: true}
+	queue := []string{entrypoint}
+	var warnings []error
+	for len(queue) > 0 {
+		module := queue[0]
+		queue = queue[1:]
+		specifiers, err := g.parse(module)
+		if errors.Is(err, os.ErrNotExist) {
+			return warnings, errors.New("Module not found: " + utils.Bold(module))
+		} else if err != nil {
+			return warnings, err
+		}
+		for _, spec := range specifiers {
+			local, err := g.resolve(slug, spec.value, module, importMap, importMapDir, visited)
+			if err != nil {
+				if spec.dynamic {
+					warnings = append(warnings, err)
+					continue
+				}
+				return warnings, err
+			}
+			if len(local) == 0 {
+				continue
+			}
+			visited[local] = true
+			if isSourceFile(local) {
+				queue = append(queue, local)
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// Returns the path of a local module that has not been visited, or empty string otherwise.
+func (g *moduleGraph) resolve(slug, spec, referrer string, importMap *utils.ImportMap, importMapDir string, visited map[string]bool) (string, error) {
+	local, err := resolveSpecifier(spec, referrer, importMap, importMapDir)
+	if err != nil || len(local) == 0 || visited[local] {
+		return "", err
+	}
+	if err := checkModuleScope(slug, local); err != nil {
+		return "", err
+	}
+	if exists, err := afero.Exists(g.fsys, local); err != nil {
+		return "", err
+	} else if !exists {
+		return "", fmt.Errorf("Module not found: %s imported from %s", utils.Bold(local), utils.Bold(referrer))
+	}
+	return local, nil
+}
+
+func (g *moduleGraph) parse(module string) ([]specifier, error) {
+	if specifiers, ok := g.imports[module]; ok {
+		return specifiers, nil
+	}
+	contents, err := afero.ReadFile(g.fsys, module)
+	if err != nil {
+		return nil, err
+	}
+	// Match against masked source so that imports in comments and strings are ignored
+	src := string(contents)
+	masked := maskSource(src)
+	var specifiers []specifier
+	for _, m := range staticImportPattern.FindAllStringSubmatchIndex(masked, -1) {
+		if m[2] >= 0 {
+			specifiers = append(specifiers, specifier{value: src[m[2]:m[3]]})
+		} else {
+			specifiers = append(specifiers, specifier{value: src[m[4]:m[5]]})
+		}
+	}
+	for _, m := range dynamicImportPattern.FindAllStringSubmatchIndex(masked, -1) {
+		specifiers = append(specifiers, specifier{value: src[m[2]:m[3]], dynamic: true})
+	}
+	g.imports[module] = specifiers
+	return specifiers, nil
+}
+
+// Blanks out comments and the contents of string literals while preserving offsets and quotes,
+// so that import specifiers can be read back from the original source.
+func maskSource(src string) string {
+	masked := []byte(src)
+	blank := func(start, end int) {
+		for i := start; i < end; i++ {
+			if masked[i] != '\n' {
+				masked[i] = ' '
+			}
+		}
+	}
+	for i := 0; i < len(src); {
+		switch {
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			blank(i, i+end)
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := len(src)
+			if j := strings.Index(src[i+2:], "*/"); j >= 0 {
+				end = i + 2 + j + 2
+			}
+			blank(i, end)
+			i = end
+		case src[i] == '\'' || src[i] == '"' || src[i] == '`':
+			end := closingQuote(src, i)
+			blank(i+1, end)
+			i = end + 1
+		default:
+			i++
+		}
+	}
+	return string(masked)
+}
+
+// Returns the index of the quote closing the string literal starting at i.
+func closingQuote(src string, i int) int {
+	quote := src[i]
+	for j := i + 1; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			j++
+		case quote:
+			return j
+		case '\n':
+			// Only template literals may span multiple lines
+			if quote != '`' {
+				return j
+			}
+		}
+	}
+	return len(src)
+}
+
+// Returns the local file path of an import specifier, or empty string for remote modules.
+func resolveSpecifier(spec, referrer string, importMap *utils.ImportMap, importMapDir string) (string, error) {
+	if mapped, ok := lookupImportMap(spec, importMap); ok {
+		if isRemote(mapped) {
+			return "", nil
+		}
+		if strings.HasPrefix(mapped, "file://") {
+			return filepath.FromSlash(strings.TrimPrefix(mapped, "file://")), nil
+		}
+		if filepath.IsAbs(mapped) {
+			return filepath.Clean(mapped), nil
+		}
+		return filepath.Join(importMapDir, filepath.FromSlash(mapped)), nil
+	}
+	if strings.HasPrefix(spec, "./") || strings.HasPrefix(spec, "../") {
+		return filepath.Join(filepath.Dir(referrer), filepath.FromSlash(spec)), nil
+	}
+	if isRemote(spec) {
+		return "", nil
+	}
+	return "", fmt.Errorf("Unresolved import %s in %s: add it to the import map or use a fully qualified specifier.", utils.Aqua(spec), utils.Bold(referrer))
+}
+
+// Matches exact keys first, then the longest key ending with a trailing slash.
+func lookupImportMap(spec string, importMap *utils.ImportMap) (string, bool) {
+	if importMap == nil {
+		return "", false
+	}
+	if mapped, ok := importMap.Imports[spec]; ok {
+		return mapped, true
+	}
+	var prefix string
+	for k := range importMap.Imports {
+		if strings.HasSuffix(k, "/") && strings.HasPrefix(spec, k) && len(k) > len(prefix) {
+			prefix = k
+		}
+	}
+	if len(prefix) == 0 {
+		return "", false
+	}
+	return importMap.Imports[prefix] + strings.TrimPrefix(spec, prefix), true
+}
+
+// Remote and built-in modules are resolved by the bundler, ie. https:, npm:, jsr:, node:
+func isRemote(spec string) bool {
+	scheme, _, found := strings.Cut(spec, ":")
+	return found && len(scheme) > 1 && scheme != "file"
+}
+
+func isSourceFile(module string) bool {
+	ext := filepath.Ext(module)
+	for _, e := range sourceExts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Modules within the functions directory may only be imported from the same Function or a
+// directory that is not itself a Function, such as _shared.
+func checkModuleScope(slug, module string) error {
+	rel, err := filepath.Rel(utils.FunctionsDir, module)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	dir, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+	if dir == slug || !utils.FuncSlugPattern.MatchString(dir) {
+		return nil
+	}
+	return fmt.Errorf("Function %s imports %s from another Function. Move shared code to %s instead.", utils.Aqua(slug), utils.Bold(module), utils.Bold(filepath.Join(utils.FunctionsDir, sharedDir)))
+}
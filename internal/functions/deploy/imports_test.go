@@ -0,0 +1,119 @@
+package deploy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestValidateImports(t *testing.T) {
+	sharedPath := filepath.Join(utils.FunctionsDir, "_shared", "cors.ts")
+
+	t.Run("resolves shared and mapped modules", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, sharedPath, []byte(`export * from "std/http/server.ts"`), 0644))
+		for _, slug := range []string{"hello", "world"} {
+			entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+			require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import { corsHeaders } from "../_shared/cors.ts";
+import {
+  createClient,
+} from "npm:@supabase/supabase-js@2";
+import "https://deno.land/x/xhr@0.3.0/mod.ts";
+const { handler } = await import("./handler.ts");
+`), 0644))
+			handler := filepath.Join(utils.FunctionsDir, slug, "handler.ts")
+			require.NoError(t, afero.WriteFile(fsys, handler, []byte(`import { corsHeaders } from "shared/cors.ts";`), 0644))
+		}
+		importMap := &utils.ImportMap{Imports: map[string]string{
+			"std/":    "https://deno.land/std@0.177.0/",
+			"shared/": "./_shared/",
+		}}
+		graph := newModuleGraph(fsys)
+		// Run test
+		warnings, err := graph.Validate("hello", importMap, utils.FunctionsDir)
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+		warnings, err = graph.Validate("world", importMap, utils.FunctionsDir)
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+		// Check shared module is parsed once
+		assert.Len(t, graph.imports, 5)
+	})
+
+	t.Run("ignores imports in comments and strings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`// import "./legacy.ts";
+/*
+import { z } from "zod";
+*/
+const usage = "await import('./missing.ts')";
+const docs = `+"`"+`
+import x from "y"
+`+"`"+`;
+import "https://deno.land/x/xhr@0.3.0/mod.ts";
+`), 0644))
+		// Run test
+		warnings, err := newModuleGraph(fsys).Validate("hello", &utils.ImportMap{}, utils.FunctionsDir)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("warns on unresolved dynamic import", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`const { z } = await import("zod");
+const plugin = await import("./plugins/optional.ts").catch(() => null);
+`), 0644))
+		// Run test
+		warnings, err := newModuleGraph(fsys).Validate("hello", &utils.ImportMap{}, utils.FunctionsDir)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, warnings, 2)
+		assert.ErrorContains(t, warnings[0], "Unresolved import zod")
+		assert.ErrorContains(t, warnings[1], "Module not found: supabase/functions/hello/plugins/optional.ts")
+	})
+
+	t.Run("throws error on missing module", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import "../_shared/cors.ts";`), 0644))
+		// Run test
+		_, err := newModuleGraph(fsys).Validate("hello", &utils.ImportMap{}, utils.FunctionsDir)
+		// Check error
+		assert.ErrorContains(t, err, "Module not found: supabase/functions/_shared/cors.ts imported from supabase/functions/hello/index.ts")
+	})
+
+	t.Run("throws error on unmapped bare specifier", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import { z } from "zod";`), 0644))
+		// Run test
+		_, err := newModuleGraph(fsys).Validate("hello", &utils.ImportMap{}, utils.FunctionsDir)
+		// Check error
+		assert.ErrorContains(t, err, "Unresolved import zod in supabase/functions/hello/index.ts")
+	})
+
+	t.Run("throws error on importing another function", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(`import "../world/utils.ts";`), 0644))
+		other := filepath.Join(utils.FunctionsDir, "world", "utils.ts")
+		require.NoError(t, afero.WriteFile(fsys, other, []byte{}, 0644))
+		// Run test
+		_, err := newModuleGraph(fsys).Validate("hello", &utils.ImportMap{}, utils.FunctionsDir)
+		// Check error
+		assert.ErrorContains(t, err, "Function hello imports supabase/functions/world/utils.ts from another Function.")
+	})
+}
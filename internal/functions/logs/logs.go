@@ -0,0 +1,316 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/supabase/cli/internal/logs/tail"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+const (
+	// Each row is a request served by the Function
+	INVOCATIONS_QUERY = "select t.id, t.timestamp, t.event_message, m.execution_id, m.execution_time_ms, req.method, req.url, r.status_code " +
+		"from function_edge_logs as t cross join unnest(t.metadata) as m cross join unnest(m.request) as req cross join unnest(m.response) as r " +
+		"where m.function_id = '%s'%s order by t.timestamp desc limit %d"
+	// Each row is a console log or lifecycle event of a Function worker
+	WORKER_QUERY = "select t.id, t.timestamp, t.event_message, m.execution_id, m.event_type, m.level, mem.heap_used " +
+		"from function_logs as t cross join unnest(t.metadata) as m left join unnest(m.memory_used) as mem " +
+		"where m.function_id = '%s' order by t.timestamp desc limit %d"
+
+	TypeInvocation = "invocation"
+	TypeLog        = "log"
+)
+
+var (
+	statusPattern = regexp.MustCompile(`^([1-5])(xx|[0-9]{2})$`)
+
+	// Interval between queries in follow mode
+	pollInterval = 5 * time.Second
+	// Workers are forgotten after being idle for longer than their maximum wall clock time
+	workerRetention = 15 * time.Minute
+)
+
+type Options struct {
+	// Status codes to include, ie. 500 or 5xx
+	Status []string
+	Since  time.Duration
+	Until  time.Duration
+	Limit  uint
+	Follow bool
+}
+
+type Event struct {
+	Id          string    `json:"id"`
+	Timestamp   time.Time `json:"timestamp"`
+	Type        string    `json:"type"`
+	ExecutionId string    `json:"execution_id"`
+	// Invocation fields
+	Method       string  `json:"method,omitempty"`
+	Url          string  `json:"url,omitempty"`
+	StatusCode   int     `json:"status_code,omitempty"`
+	DurationMs   float64 `json:"duration_ms,omitempty"`
+	ColdStart    bool    `json:"cold_start,omitempty"`
+	MemoryUsedMb float64 `json:"memory_used_mb,omitempty"` // Only known after the worker shuts down
+	// Console log fields
+	Level   string `json:"level,omitempty"`
+	Message string `json:"event_message,omitempty"`
+}
+
+func Run(ctx context.Context, slug, projectRef string, opts Options) error {
+	statusFilter, err := buildStatusFilter(opts.Status)
+	if err != nil {
+		return err
+	}
+	functionId, err := getFunctionId(ctx, slug, projectRef)
+	if err != nil {
+		return err
+	}
+	invocationSql := fmt.Sprintf(INVOCATIONS_QUERY, functionId, statusFilter, opts.Limit)
+	workerSql := fmt.Sprintf(WORKER_QUERY, functionId, opts.Limit)
+	t := newTracer(len(statusFilter) > 0)
+	start := time.Now().Add(-opts.Since)
+	for {
+		end := time.Now().Add(-opts.Until)
+		invocations, err := tail.Query(ctx, projectRef, invocationSql, start, end)
+		if err != nil {
+			return err
+		}
+		workerEvents, err := tail.Query(ctx, projectRef, workerSql, start, end)
+		if err != nil {
+			return err
+		}
+		for _, e := range t.trace(invocations, workerEvents) {
+			if err := printEvent(e, os.Stdout); err != nil {
+				return err
+			}
+			if e.Timestamp.After(start) {
+				start = e.Timestamp
+			}
+		}
+		if !opts.Follow {
+			return nil
+		}
+		t.evict(start)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func getFunctionId(ctx context.Context, slug, projectRef string) (string, error) {
+	resp, err := utils.GetSupabase().GetFunctionWithResponse(ctx, projectRef, slug)
+	if err != nil {
+		return "", err
+	}
+	switch resp.StatusCode() {
+	case http.StatusNotFound:
+		return "", errors.New("Function " + utils.Aqua(slug) + " does not exist on the Supabase project.")
+	case http.StatusOK:
+		return resp.JSON200.Id, nil
+	default:
+		return "", errors.New("Failed to retrieve Function " + utils.Aqua(slug) + " on the Supabase project: " + string(resp.Body))
+	}
+}
+
+// Converts status codes and classes, ie. 500 or 5xx, to a Logflare SQL predicate.
+func buildStatusFilter(status []string) (string, error) {
+	var clauses []string
+	for _, s := range status {
+		s = strings.ToLower(strings.TrimSpace(s))
+		matches := statusPattern.FindStringSubmatch(s)
+		if len(matches) == 0 {
+			return "", fmt.Errorf("Invalid status code %s, must be like 500 or 5xx", utils.Aqua(s))
+		}
+		if matches[2] == "xx" {
+			clauses = append(clauses, fmt.Sprintf("r.status_code between %s00 and %s99", matches[1], matches[1]))
+		} else {
+			clauses = append(clauses, "r.status_code = "+s)
+		}
+	}
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " and (" + strings.Join(clauses, " or ") + ")", nil
+}
+
+// Correlates invocations with worker events across polls.
+type tracer struct {
+	// Only print console logs of workers that served a matching invocation
+	filtered bool
+	workers  map[string]*worker
+	// Timestamps of printed events, which are queried again from the last timestamp
+	seen map[string]time.Time
+}
+
+type worker struct {
+	// Workers with a boot event, whose first invocation is a cold start
+	booted  bool
+	invoked bool
+	// Heap used in MB, which is only known after the worker shuts down
+	memory   float64
+	lastSeen time.Time
+}
+
+func newTracer(filtered bool) tracer {
+	return tracer{
+		filtered: filtered,
+		workers:  map[string]*worker{},
+		seen:     map[string]time.Time{},
+	}
+}
+
+func (t *tracer) getWorker(executionId string, ts time.Time) *worker {
+	w, ok := t.workers[executionId]
+	if !ok {
+		w = &worker{}
+		t.workers[executionId] = w
+	}
+	if ts.After(w.lastSeen) {
+		w.lastSeen = ts
+	}
+	return w
+}
+
+// Returns new events in chronological order.
+func (t *tracer) trace(invocations, workerEvents []tail.LogEvent) []Event {
+	var result []Event
+	for _, row := range workerEvents {
+		e := newWorkerEvent(row)
+		w := t.getWorker(e.ExecutionId, e.Timestamp)
+		switch strings.ToLower(fmt.Sprint(row.Extra["event_type"])) {
+		case "boot":
+			w.booted = true
+		case "shutdown":
+			if heap, ok := row.Extra["heap_used"].(float64); ok {
+				w.memory = heap / 1024 / 1024
+			}
+		default:
+			result = append(result, e)
+		}
+	}
+	for _, i := range invocations {
+		e := newInvocation(i)
+		w := t.getWorker(e.ExecutionId, e.Timestamp)
+		if !w.invoked {
+			w.invoked = true
+			e.ColdStart = w.booted
+		}
+		e.MemoryUsedMb = w.memory
+		result = append(result, e)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	var events []Event
+	for _, e := range result {
+		if _, ok := t.seen[e.Id]; ok {
+			continue
+		}
+		if w := t.workers[e.ExecutionId]; t.filtered && e.Type == TypeLog && (w == nil || !w.invoked) {
+			continue
+		}
+		t.seen[e.Id] = e.Timestamp
+		events = append(events, e)
+	}
+	return events
+}
+
+// Forgets events before the next query window and idle workers, so that memory stays bounded in follow mode.
+func (t *tracer) evict(start time.Time) {
+	for id, ts := range t.seen {
+		if ts.Before(start) {
+			delete(t.seen, id)
+		}
+	}
+	for id, w := range t.workers {
+		if w.lastSeen.Before(start.Add(-workerRetention)) {
+			delete(t.workers, id)
+		}
+	}
+}
+
+func newInvocation(row tail.LogEvent) Event {
+	e := Event{
+		Id:          row.Id,
+		Timestamp:   row.Timestamp,
+		Type:        TypeInvocation,
+		ExecutionId: toString(row.Extra["execution_id"]),
+		Method:      toString(row.Extra["method"]),
+		Url:         toString(row.Extra["url"]),
+	}
+	if code, ok := row.Extra["status_code"].(float64); ok {
+		e.StatusCode = int(code)
+	}
+	if ms, ok := row.Extra["execution_time_ms"].(float64); ok {
+		e.DurationMs = ms
+	}
+	return e
+}
+
+func newWorkerEvent(row tail.LogEvent) Event {
+	return Event{
+		Id:          row.Id,
+		Timestamp:   row.Timestamp,
+		Type:        TypeLog,
+		ExecutionId: toString(row.Extra["execution_id"]),
+		Level:       row.Level,
+		Message:     strings.TrimSpace(row.Message),
+	}
+}
+
+func toString(value any) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func printEvent(e Event, w io.Writer) error {
+	if render.Format.Value == utils.OutputJson {
+		// One object per line so that output can be piped to jq
+		return json.NewEncoder(w).Encode(e)
+	} else if !render.IsPretty() {
+		return utils.EncodeOutput(render.Format.Value, w, e)
+	}
+	ts := utils.Bold(e.Timestamp.Format(time.RFC3339))
+	if e.Type == TypeLog {
+		level := e.Level
+		switch level {
+		case "error", "fatal", "panic":
+			level = utils.Red(level)
+		case "warning", "warn":
+			level = utils.Yellow(level)
+		}
+		_, err := fmt.Fprintf(w, "%s [%s] %s %s\n", ts, e.ExecutionId, level, e.Message)
+		return err
+	}
+	status := strconv.Itoa(e.StatusCode)
+	if e.StatusCode >= 500 {
+		status = utils.Red(status)
+	} else if e.StatusCode >= 400 {
+		status = utils.Yellow(status)
+	}
+	line := fmt.Sprintf("%s [%s] %s %s %s %.0fms", ts, e.ExecutionId, status, e.Method, e.Url, e.DurationMs)
+	if e.MemoryUsedMb > 0 {
+		line += fmt.Sprintf(" %.1fMB", e.MemoryUsedMb)
+	}
+	if e.ColdStart {
+		line += " " + utils.Aqua("(cold start)")
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
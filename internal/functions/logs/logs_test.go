@@ -0,0 +1,141 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/logs/tail"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestBuildStatusFilter(t *testing.T) {
+	t.Run("filters by code and class", func(t *testing.T) {
+		filter, err := buildStatusFilter([]string{"404", "5XX"})
+		assert.NoError(t, err)
+		assert.Equal(t, " and (r.status_code = 404 or r.status_code between 500 and 599)", filter)
+	})
+
+	t.Run("throws error on invalid status", func(t *testing.T) {
+		_, err := buildStatusFilter([]string{"50x"})
+		assert.ErrorContains(t, err, "Invalid status code")
+	})
+}
+
+func TestTrace(t *testing.T) {
+	now := time.Now().UTC()
+	workerEvents := []tail.LogEvent{
+		{Id: "boot", Timestamp: now, Extra: map[string]any{"execution_id": "w1", "event_type": "Boot"}},
+		{Id: "log", Timestamp: now.Add(time.Second), Level: "error", Message: "boom\n", Extra: map[string]any{"execution_id": "w1", "event_type": "Log"}},
+		{Id: "other", Timestamp: now.Add(time.Second), Level: "info", Message: "ok", Extra: map[string]any{"execution_id": "w2", "event_type": "Log"}},
+		{Id: "shutdown", Timestamp: now.Add(3 * time.Second), Extra: map[string]any{"execution_id": "w1", "event_type": "Shutdown", "heap_used": float64(8 * 1024 * 1024)}},
+	}
+	invocations := []tail.LogEvent{
+		{Id: "i1", Timestamp: now.Add(time.Second), Extra: map[string]any{"execution_id": "w1", "status_code": float64(500), "execution_time_ms": float64(120), "method": "POST"}},
+		{Id: "i2", Timestamp: now.Add(2 * time.Second), Extra: map[string]any{"execution_id": "w1", "status_code": float64(500), "execution_time_ms": float64(20), "method": "POST"}},
+	}
+
+	t.Run("marks cold starts and memory", func(t *testing.T) {
+		tr := newTracer(false)
+		// Run test
+		events := tr.trace(invocations, workerEvents)
+		// Check result
+		assert.Len(t, events, 4)
+		assert.Equal(t, TypeLog, events[0].Type)
+		assert.Equal(t, "boom", events[0].Message)
+		assert.Equal(t, "i1", events[2].Id)
+		assert.True(t, events[2].ColdStart)
+		assert.Equal(t, float64(8), events[2].MemoryUsedMb)
+		assert.Equal(t, 500, events[2].StatusCode)
+		assert.False(t, events[3].ColdStart)
+		// Events are not repeated on subsequent polls
+		assert.Empty(t, tr.trace(invocations, workerEvents))
+	})
+
+	t.Run("skips logs of unmatched workers", func(t *testing.T) {
+		tr := newTracer(true)
+		// Run test
+		events := tr.trace(invocations, workerEvents)
+		// Check result
+		assert.Len(t, events, 3)
+		for _, e := range events {
+			assert.Equal(t, "w1", e.ExecutionId)
+		}
+	})
+
+	t.Run("evicts old events and idle workers", func(t *testing.T) {
+		tr := newTracer(false)
+		tr.trace(invocations, workerEvents)
+		// Run test
+		tr.evict(now.Add(2 * time.Second))
+		// Check result
+		assert.Equal(t, map[string]time.Time{"i2": now.Add(2 * time.Second)}, tr.seen)
+		assert.Len(t, tr.workers, 2)
+		tr.evict(now.Add(workerRetention + 2*time.Second))
+		assert.Empty(t, tr.seen)
+		assert.Len(t, tr.workers, 1)
+		assert.Contains(t, tr.workers, "w1")
+	})
+}
+
+func TestFunctionLogs(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	projectRef := apitest.RandomProjectRef()
+
+	t.Run("prints function logs", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/functions/test-func").
+			Reply(http.StatusOK).
+			JSON(api.FunctionResponse{Id: "test-id", Slug: "test-func"})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+projectRef+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "function_edge_logs").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"result": []map[string]any{
+				{"id": "a", "timestamp": 1700000000000000, "execution_id": "w1", "status_code": 200},
+			}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+projectRef+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "m.function_id = 'test-id'").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"result": []map[string]any{}})
+		// Run test
+		err := Run(context.Background(), "test-func", projectRef, Options{Status: []string{"2xx"}, Since: time.Hour, Limit: 10})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing function", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/functions/test-func").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), "test-func", projectRef, Options{})
+		// Check error
+		assert.ErrorContains(t, err, "Function test-func does not exist on the Supabase project.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("prints cold start marker", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := printEvent(Event{Id: "a", Type: TypeInvocation, StatusCode: 500, ColdStart: true}, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "500")
+		assert.Contains(t, out.String(), "(cold start)")
+	})
+}
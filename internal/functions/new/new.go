@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/webhook"
 	"github.com/supabase/cli/internal/utils"
 )
 
@@ -25,13 +26,21 @@ type indexConfig struct {
 	Token string
 }
 
-func Run(ctx context.Context, slug string, fsys afero.Fs) error {
+func Run(ctx context.Context, slug, dbTrigger string, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	funcDir := filepath.Join(utils.FunctionsDir, slug)
+	var trigger *webhook.Trigger
 	{
 		if err := utils.ValidateFunctionSlug(slug); err != nil {
 			return err
 		}
+		if len(dbTrigger) > 0 {
+			parsed, err := webhook.ParseTrigger(dbTrigger)
+			if err != nil {
+				return err
+			}
+			trigger = &parsed
+		}
 		if _, err := fsys.Stat(funcDir); !errors.Is(err, os.ErrNotExist) {
 			return errors.New("Function " + utils.Aqua(slug) + " already exists locally.")
 		}
@@ -64,5 +73,14 @@ func Run(ctx context.Context, slug string, fsys afero.Fs) error {
 	}
 
 	fmt.Println("Created new Function at " + utils.Bold(funcDir))
+
+	// 3. Create database trigger that calls the function.
+	if trigger != nil {
+		path, err := webhook.WriteMigration(slug, *trigger, fsys)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Created new migration at " + utils.Bold(path))
+	}
 	return nil
 }
@@ -16,7 +16,7 @@ func TestNewCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		assert.NoError(t, Run(context.Background(), "test-func", fsys))
+		assert.NoError(t, Run(context.Background(), "test-func", "", fsys))
 		// Validate output
 		funcPath := filepath.Join(utils.FunctionsDir, "test-func", "index.ts")
 		contains, err := afero.FileContainsBytes(fsys, funcPath, []byte(
@@ -26,8 +26,30 @@ func TestNewCommand(t *testing.T) {
 		assert.True(t, contains)
 	})
 
+	t.Run("creates function with db trigger", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, Run(context.Background(), "test-func", "todos:INSERT", fsys))
+		// Validate output
+		migrations, err := afero.Glob(fsys, filepath.Join(utils.MigrationsDir, "*_test-func_webhook.sql"))
+		assert.NoError(t, err)
+		assert.Len(t, migrations, 1)
+	})
+
+	t.Run("throws error on invalid db trigger", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.ErrorContains(t, Run(context.Background(), "test-func", "todos", fsys), "Invalid db trigger")
+		// Validate output
+		exists, err := afero.DirExists(fsys, filepath.Join(utils.FunctionsDir, "test-func"))
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
 	t.Run("throws error on malformed slug", func(t *testing.T) {
-		assert.Error(t, Run(context.Background(), "@", afero.NewMemMapFs()))
+		assert.Error(t, Run(context.Background(), "@", "", afero.NewMemMapFs()))
 	})
 
 	t.Run("throws error on duplicate slug", func(t *testing.T) {
@@ -36,13 +58,13 @@ func TestNewCommand(t *testing.T) {
 		funcDir := filepath.Join(utils.FunctionsDir, "test-func")
 		require.NoError(t, fsys.Mkdir(funcDir, 0755))
 		// Run test
-		assert.Error(t, Run(context.Background(), "test-func", fsys))
+		assert.Error(t, Run(context.Background(), "test-func", "", fsys))
 	})
 
 	t.Run("throws error on permission denied", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
 		// Run test
-		assert.Error(t, Run(context.Background(), "test-func", fsys))
+		assert.Error(t, Run(context.Background(), "test-func", "", fsys))
 	})
 }
@@ -0,0 +1,88 @@
+package serve
+
+import (
+	"bytes"
+	"hash/fnv"
+	"io"
+	"regexp"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Main service logs these lines before and after forwarding each request to a user worker
+var (
+	servingPattern = regexp.MustCompile(`serving the request with .*/functions/([^/\s]+)`)
+	servedPattern  = regexp.MustCompile(`served the request with .*/functions/([^/\s]+)`)
+)
+
+var functionColors = []lipgloss.Color{"10", "12", "13", "14", "11", "6", "5", "4"}
+
+// FunctionLogMux prefixes runtime log lines with the name of the function being served, so that
+// logs from multiple functions can be told apart in a single stream. Worker logs carry no name,
+// so lines are only prefixed while requests to a single function are in flight.
+type FunctionLogMux struct {
+	mu       sync.Mutex
+	inflight map[string]int
+}
+
+func NewFunctionLogMux() *FunctionLogMux {
+	return &FunctionLogMux{inflight: map[string]int{}}
+}
+
+func (m *FunctionLogMux) Writer(w io.Writer) io.Writer {
+	return &functionLogWriter{mux: m, w: w}
+}
+
+func (m *FunctionLogMux) prefix(line []byte) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if matches := servingPattern.FindSubmatch(line); len(matches) > 1 {
+		slug := string(matches[1])
+		m.inflight[slug]++
+		return colorFunction(slug) + " | "
+	}
+	if matches := servedPattern.FindSubmatch(line); len(matches) > 1 {
+		slug := string(matches[1])
+		if m.inflight[slug]--; m.inflight[slug] <= 0 {
+			delete(m.inflight, slug)
+		}
+		return colorFunction(slug) + " | "
+	}
+	if len(m.inflight) != 1 {
+		return ""
+	}
+	for slug := range m.inflight {
+		return colorFunction(slug) + " | "
+	}
+	return ""
+}
+
+type functionLogWriter struct {
+	mux *FunctionLogMux
+	w   io.Writer
+	buf []byte
+}
+
+func (f *functionLogWriter) Write(p []byte) (int, error) {
+	f.buf = append(f.buf, p...)
+	for {
+		i := bytes.IndexByte(f.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := f.buf[:i+1]
+		if _, err := io.WriteString(f.w, f.mux.prefix(line)+string(line)); err != nil {
+			return 0, err
+		}
+		f.buf = f.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func colorFunction(slug string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(slug))
+	color := functionColors[h.Sum32()%uint32(len(functionColors))]
+	return lipgloss.NewStyle().Foreground(color).Render(slug)
+}
@@ -0,0 +1,53 @@
+package serve
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionLogMux(t *testing.T) {
+	t.Run("prefixes lines with current function", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		mux := NewFunctionLogMux()
+		// Run test
+		_, err := mux.Writer(&stderr).Write([]byte("booted\nserving the request with /home/deno/functions/hello\n"))
+		assert.NoError(t, err)
+		_, err = mux.Writer(&stdout).Write([]byte("hello "))
+		assert.NoError(t, err)
+		assert.Empty(t, stdout.String())
+		w := mux.Writer(&stdout)
+		_, err = w.Write([]byte("world\n"))
+		assert.NoError(t, err)
+		// Check output
+		prefix := colorFunction("hello") + " | "
+		assert.Equal(t, "booted\n"+prefix+"serving the request with /home/deno/functions/hello\n", stderr.String())
+		assert.Equal(t, prefix+"world\n", stdout.String())
+	})
+
+	t.Run("skips prefix for interleaved requests", func(t *testing.T) {
+		var stdout bytes.Buffer
+		mux := NewFunctionLogMux()
+		w := mux.Writer(&stdout)
+		// Run test
+		_, err := w.Write([]byte("serving the request with /home/deno/functions/hello\n" +
+			"serving the request with /home/deno/functions/world\n" +
+			"ambiguous\n" +
+			"served the request with /home/deno/functions/world\n" +
+			"from hello\n" +
+			"served the request with /home/deno/functions/hello\n" +
+			"shutdown\n"))
+		assert.NoError(t, err)
+		// Check output
+		hello := colorFunction("hello") + " | "
+		world := colorFunction("world") + " | "
+		assert.Equal(t, hello+"serving the request with /home/deno/functions/hello\n"+
+			world+"serving the request with /home/deno/functions/world\n"+
+			"ambiguous\n"+
+			world+"served the request with /home/deno/functions/world\n"+
+			hello+"from hello\n"+
+			hello+"served the request with /home/deno/functions/hello\n"+
+			"shutdown\n", stdout.String())
+	})
+}
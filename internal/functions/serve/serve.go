@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/docker/docker/api/types"
@@ -19,6 +21,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
 	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/functions/webhook"
 	"github.com/supabase/cli/internal/utils"
 )
 
@@ -27,14 +30,45 @@ const (
 	// Import Map from CLI flag, i.e. --import-map, takes priority over config.toml & fallback.
 	dockerFlagImportMapPath     = utils.DockerDenoDir + "/flag_import_map.json"
 	dockerFallbackImportMapPath = utils.DockerDenoDir + "/fallback_import_map.json"
+	dockerRuntimeInspectorPort  = 8083
 )
 
+type InspectMode string
+
+const (
+	InspectModeRun  InspectMode = "run"
+	InspectModeBrk  InspectMode = "brk"
+	InspectModeWait InspectMode = "wait"
+)
+
+func (mode InspectMode) toFlag() string {
+	switch mode {
+	case InspectModeBrk:
+		return "inspect-brk"
+	case InspectModeWait:
+		return "inspect-wait"
+	case InspectModeRun:
+		fallthrough
+	default:
+		return "inspect"
+	}
+}
+
+type RuntimeOption struct {
+	// Restarts the runtime when files under functions directory change
+	Watch bool
+	// Exposes V8 inspector on the host when set
+	InspectMode *InspectMode
+	// Host port to bind the inspector to
+	InspectPort uint16
+}
+
 var (
 	//go:embed templates/main.ts
 	mainFuncEmbed string
 )
 
-func Run(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPath string, fsys afero.Fs) error {
+func Run(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPath string, runtimeOption RuntimeOption, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	if err := utils.LoadConfigFS(fsys); err != nil {
 		return err
@@ -42,24 +76,70 @@ func Run(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPa
 	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
 		return err
 	}
-	// 2. Remove existing container.
+	if err := webhook.ConfigureLocal(ctx, fsys); err != nil {
+		return err
+	}
+	// 2. Serve and log to console until cancelled
+	for {
+		restart, err := serveOnce(ctx, envFilePath, noVerifyJWT, importMapPath, runtimeOption, fsys)
+		if err != nil {
+			return err
+		}
+		if !restart {
+			break
+		}
+		fmt.Fprintln(os.Stderr, "File change detected, restarting Edge Functions runtime...")
+	}
+	fmt.Println("Stopped serving " + utils.Bold(utils.FunctionsDir))
+	return nil
+}
+
+// Returns true if the runtime should be restarted because of file changes.
+func serveOnce(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPath string, runtimeOption RuntimeOption, fsys afero.Fs) (bool, error) {
+	// 1. Remove existing container.
 	_ = utils.Docker.ContainerRemove(ctx, utils.EdgeRuntimeId, types.ContainerRemoveOptions{
 		RemoveVolumes: true,
 		Force:         true,
 	})
-	// 3. Serve and log to console
+	// Use a fresh watcher on each restart so that new directories are included
+	var changes <-chan struct{}
+	var watchErrs <-chan error
+	if runtimeOption.Watch {
+		watcher, err := NewDebounceWatcher(utils.FunctionsDir, debounceDuration)
+		if err != nil {
+			return false, err
+		}
+		defer watcher.Close()
+		changes = watcher.Changes
+		watchErrs = watcher.Errors
+	}
+	// 2. Serve and stream logs
 	dbUrl := "postgresql://postgres:postgres@" + utils.DbId + ":5432/postgres"
-	if err := ServeFunctions(ctx, envFilePath, noVerifyJWT, importMapPath, dbUrl, os.Stderr, fsys); err != nil {
-		return err
+	if err := ServeFunctions(ctx, envFilePath, noVerifyJWT, importMapPath, dbUrl, runtimeOption, os.Stderr, fsys); err != nil {
+		return false, err
 	}
-	if err := utils.DockerStreamLogs(ctx, utils.EdgeRuntimeId, os.Stdout, os.Stderr); err != nil {
-		return err
+	logCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	logs := NewFunctionLogMux()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- utils.DockerStreamLogs(logCtx, utils.EdgeRuntimeId, logs.Writer(os.Stdout), logs.Writer(os.Stderr))
+	}()
+	select {
+	case err := <-errCh:
+		return false, err
+	case <-changes:
+		cancel()
+		<-errCh
+		return true, nil
+	case err := <-watchErrs:
+		cancel()
+		<-errCh
+		return false, err
 	}
-	fmt.Println("Stopped serving " + utils.Bold(utils.FunctionsDir))
-	return nil
 }
 
-func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPath string, dbUrl string, w io.Writer, fsys afero.Fs) error {
+func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPath string, dbUrl string, runtimeOption RuntimeOption, w io.Writer, fsys afero.Fs) error {
 	// 1. Load default values
 	if envFilePath == "" {
 		if f, err := fsys.Stat(utils.FallbackEnvFilePath); err == nil && !f.IsDir() {
@@ -145,9 +225,25 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 		if viper.GetBool("DEBUG") {
 			cmd = append(cmd, "--verbose")
 		}
+		if runtimeOption.InspectMode != nil {
+			cmd = append(cmd, fmt.Sprintf("--%s=0.0.0.0:%d", runtimeOption.InspectMode.toFlag(), dockerRuntimeInspectorPort))
+		}
 		cmdString = strings.Join(cmd, " ")
 	}
 
+	exposedPorts := nat.PortSet{"8081/tcp": {}}
+	portBindings := nat.PortMap{}
+	if runtimeOption.InspectMode != nil {
+		inspectorPort := nat.Port(fmt.Sprintf("%d/tcp", dockerRuntimeInspectorPort))
+		exposedPorts[inspectorPort] = struct{}{}
+		// The inspector allows arbitrary code execution, so it must not be reachable from other hosts
+		portBindings[inspectorPort] = []nat.PortBinding{{
+			HostIP:   "127.0.0.1",
+			HostPort: strconv.FormatUint(uint64(runtimeOption.InspectPort), 10),
+		}}
+		fmt.Fprintln(w, "Debugger listening on", utils.Aqua(fmt.Sprintf("ws://127.0.0.1:%d", runtimeOption.InspectPort)))
+	}
+
 	entrypoint := []string{"sh", "-c", `mkdir -p /home/deno/main && cat <<'EOF' > /home/deno/main/index.ts && ` + cmdString + `
 ` + mainFuncEmbed + `
 EOF
@@ -158,12 +254,13 @@ EOF
 			Image:        utils.EdgeRuntimeImage,
 			Env:          append(env, userEnv...),
 			Entrypoint:   entrypoint,
-			ExposedPorts: nat.PortSet{"8081/tcp": {}},
+			ExposedPorts: exposedPorts,
 			// No tcp health check because edge runtime logs them as client connection error
 		},
 		start.WithSyslogConfig(container.HostConfig{
-			Binds:      binds,
-			ExtraHosts: []string{"host.docker.internal:host-gateway"},
+			Binds:        binds,
+			PortBindings: portBindings,
+			ExtraHosts:   []string{"host.docker.internal:host-gateway"},
 		}),
 		network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -240,6 +337,19 @@ func populatePerFunctionConfigs(binds []string, importMapPath string, noVerifyJW
 				return nil, "", err
 			}
 			binds = append(binds, modules...)
+		} else if local, err := utils.FunctionImportMapPath(functionName, fsys); err != nil {
+			return nil, "", err
+		} else if len(local) > 0 {
+			rel, err := filepath.Rel(utils.FunctionsDir, local)
+			if err != nil {
+				return nil, "", err
+			}
+			if strings.HasPrefix(rel, "..") {
+				return nil, "", errors.New("Import map must be within the functions directory: " + utils.Bold(local))
+			}
+			// Colocated import maps are mounted along with the functions directory, so
+			// relative imports such as ../_shared resolve without rewriting.
+			dockerImportMapPath = path.Join(dockerFuncDirPath, filepath.ToSlash(rel))
 		}
 
 		verifyJWT := true
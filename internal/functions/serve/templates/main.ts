@@ -3,6 +3,8 @@ import * as jose from "https://deno.land/x/jose@v4.13.1/index.ts";
 
 const JWT_SECRET = Deno.env.get("SUPABASE_INTERNAL_JWT_SECRET")!;
 const HOST_PORT = Deno.env.get("SUPABASE_INTERNAL_HOST_PORT")!;
+// Not routed through the API gateway, so metrics are only reachable from the docker network.
+const METRICS_PORT = 8082;
 // OS stuff - we don't want to expose these to the functions.
 const EXCLUDED_ENVS = ["HOME", "HOSTNAME", "PATH", "PWD"];
 const FUNCTIONS_PATH = Deno.env.get("SUPABASE_INTERNAL_FUNCTIONS_PATH")!;
@@ -76,7 +78,68 @@ async function verifyJWT(jwt: string): Promise<boolean> {
   return true;
 }
 
+// Request counters exposed in Prometheus text format for the metrics exporter
+const requestsTotal = new Map<string, number>();
+const requestSecondsTotal = new Map<string, number>();
+
+function recordRequest(functionName: string, status: number, startMs: number) {
+  const labels = `function="${functionName}",status="${status}"`;
+  requestsTotal.set(labels, (requestsTotal.get(labels) ?? 0) + 1);
+  const seconds = (performance.now() - startMs) / 1000;
+  requestSecondsTotal.set(
+    functionName,
+    (requestSecondsTotal.get(functionName) ?? 0) + seconds,
+  );
+}
+
+function renderMetrics(): string {
+  const lines = [
+    "# HELP edge_runtime_requests_total Requests served by each function.",
+    "# TYPE edge_runtime_requests_total counter",
+  ];
+  for (const [labels, count] of requestsTotal) {
+    lines.push(`edge_runtime_requests_total{${labels}} ${count}`);
+  }
+  lines.push(
+    "# HELP edge_runtime_request_seconds_total Time spent until response headers are sent.",
+    "# TYPE edge_runtime_request_seconds_total counter",
+  );
+  for (const [functionName, seconds] of requestSecondsTotal) {
+    lines.push(
+      `edge_runtime_request_seconds_total{function="${functionName}"} ${seconds}`,
+    );
+  }
+  return lines.join("\n") + "\n";
+}
+
+serve((req: Request) => {
+  if (new URL(req.url).pathname !== "/metrics") {
+    return new Response(null, { status: 404 });
+  }
+  return new Response(renderMetrics(), {
+    status: 200,
+    headers: { "Content-Type": "text/plain; version=0.0.4" },
+  });
+}, { port: METRICS_PORT });
+
 serve(async (req: Request) => {
+  const { pathname } = new URL(req.url);
+  const startMs = performance.now();
+  const res = await handleRequest(req);
+  const functionName = pathname.split("/")[1];
+  if (functionName in functionsConfig) {
+    recordRequest(functionName, res.status, startMs);
+  }
+  return res;
+}, {
+  onListen: () => {
+    console.log(
+      `Serving functions on http://127.0.0.1:${HOST_PORT}/functions/v1/<function-name>`,
+    );
+  },
+});
+
+async function handleRequest(req: Request): Promise<Response> {
   const url = new URL(req.url);
   const { pathname } = url;
 
@@ -178,11 +241,8 @@ serve(async (req: Request) => {
       },
       Status.InternalServerError,
     );
+  } finally {
+    // Marks the end of the request so that the CLI can attribute worker logs
+    console.error(`served the request with ${servicePath}`);
   }
-}, {
-  onListen: () => {
-    console.log(
-      `Serving functions on http://127.0.0.1:${HOST_PORT}/functions/v1/<function-name>`,
-    );
-  },
-});
+}
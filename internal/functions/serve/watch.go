@@ -0,0 +1,123 @@
+package serve
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const debounceDuration = 500 * time.Millisecond
+
+// Editors and package managers touch these paths frequently without changing function code
+var ignoredDirs = []string{".git", "node_modules", ".temp"}
+
+// DebounceWatcher sends on Changes once file events under a directory have settled.
+// Errors from the underlying watcher, such as inotify queue overflows, are sent on Errors.
+type DebounceWatcher struct {
+	Changes <-chan struct{}
+	Errors  <-chan error
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+	once    sync.Once
+}
+
+func NewDebounceWatcher(root string, debounce time.Duration) (*DebounceWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// fsnotify does not watch subdirectories recursively
+	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && isIgnored(path) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	}); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	changes := make(chan struct{}, 1)
+	errs := make(chan error, 1)
+	w := &DebounceWatcher{Changes: changes, Errors: errs, watcher: watcher, done: make(chan struct{})}
+	go w.run(changes, errs, debounce)
+	return w, nil
+}
+
+func (w *DebounceWatcher) run(changes chan<- struct{}, errs chan<- error, debounce time.Duration) {
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if isIgnored(event.Name) || event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			// Watch new directories so that files added to them trigger a restart
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := w.watcher.Add(event.Name); err != nil {
+						w.sendError(errs, err)
+					}
+				}
+			}
+			timer.Reset(debounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.sendError(errs, err)
+		case <-timer.C:
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (w *DebounceWatcher) sendError(errs chan<- error, err error) {
+	select {
+	case errs <- fmt.Errorf("failed to watch %s: %w", utils.FunctionsDir, err):
+	default:
+	}
+}
+
+func (w *DebounceWatcher) Close() error {
+	w.once.Do(func() { close(w.done) })
+	return w.watcher.Close()
+}
+
+func isIgnored(path string) bool {
+	name := filepath.Base(path)
+	// Skip editor swap and backup files
+	if strings.HasSuffix(name, "~") || strings.HasSuffix(name, ".swp") {
+		return true
+	}
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		for _, dir := range ignoredDirs {
+			if part == dir {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package serve
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebounceWatcher(t *testing.T) {
+	t.Run("notifies once on file changes", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(root, "hello"), 0755))
+		watcher, err := NewDebounceWatcher(root, 50*time.Millisecond)
+		require.NoError(t, err)
+		defer watcher.Close()
+		// Run test
+		path := filepath.Join(root, "hello", "index.ts")
+		require.NoError(t, os.WriteFile(path, []byte("1"), 0644))
+		require.NoError(t, os.WriteFile(path, []byte("2"), 0644))
+		// Check notification
+		select {
+		case <-watcher.Changes:
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "timed out waiting for changes")
+		}
+		select {
+		case <-watcher.Changes:
+			assert.Fail(t, "expected a single notification")
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	t.Run("ignores node modules", func(t *testing.T) {
+		assert.True(t, isIgnored(filepath.Join("supabase", "functions", "node_modules", "lib.js")))
+		assert.True(t, isIgnored(filepath.Join("supabase", "functions", "hello", "index.ts~")))
+		assert.False(t, isIgnored(filepath.Join("supabase", "functions", "hello", "index.ts")))
+	})
+}
@@ -0,0 +1,38 @@
+-- Calls the {{ .Slug }} Function after {{ .Events }} on {{ .Table }}.
+-- The endpoint is configured by `supabase functions serve` locally and by `supabase functions deploy` on the linked project.
+create extension if not exists pg_net with schema extensions;
+
+create or replace function {{ .Function }}()
+returns trigger
+language plpgsql
+security definer
+set search_path = ''
+as $$
+declare
+  endpoint text := nullif(current_setting('{{ .UrlSetting }}', true), '');
+begin
+  -- Skip the request until the Functions endpoint has been configured
+  if endpoint is null then
+    return coalesce(new, old);
+  end if;
+  perform net.http_post(
+    url := endpoint || '/{{ .Slug }}',
+    body := jsonb_build_object(
+      'type', tg_op,
+      'schema', tg_table_schema,
+      'table', tg_table_name,
+      'record', case when tg_op = 'DELETE' then null else to_jsonb(new) end,
+      'old_record', case when tg_op = 'INSERT' then null else to_jsonb(old) end
+    ),
+    headers := jsonb_build_object(
+      'Content-Type', 'application/json',
+      'Authorization', 'Bearer ' || coalesce(current_setting('{{ .KeySetting }}', true), '')
+    )
+  );
+  return coalesce(new, old);
+end;
+$$;
+
+create trigger {{ .Trigger }}
+after {{ .Events }} on {{ .Table }}
+for each row execute function {{ .Function }}();
@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const (
+	// Database settings read by the generated trigger functions
+	UrlSetting = "app.functions_url"
+	KeySetting = "app.functions_key"
+
+	migrationSuffix = "_webhook.sql"
+)
+
+var (
+	//go:embed templates/webhook.sql
+	webhookEmbed    string
+	webhookTemplate = template.Must(template.New("webhook").Parse(webhookEmbed))
+
+	Events = []string{"INSERT", "UPDATE", "DELETE"}
+)
+
+type Trigger struct {
+	Schema string
+	Table  string
+	Events []string
+}
+
+// Parses a trigger spec of the form [schema.]table:EVENT[,EVENT].
+func ParseTrigger(spec string) (Trigger, error) {
+	table, events, found := strings.Cut(spec, ":")
+	if !found || len(table) == 0 || len(events) == 0 {
+		return Trigger{}, errors.New("Invalid db trigger " + utils.Aqua(spec) + ", must be of the form table:EVENT")
+	}
+	result := Trigger{Schema: "public", Table: table}
+	if schema, name, found := strings.Cut(table, "."); found {
+		result.Schema, result.Table = schema, name
+	}
+	for _, e := range strings.Split(events, ",") {
+		e = strings.ToUpper(strings.TrimSpace(e))
+		if !utils.SliceContains(Events, e) {
+			return Trigger{}, fmt.Errorf("Invalid db trigger event %s, must be one of: %s", utils.Aqua(e), strings.Join(Events, ", "))
+		}
+		if !utils.SliceContains(result.Events, e) {
+			result.Events = append(result.Events, e)
+		}
+	}
+	return result, nil
+}
+
+type migrationConfig struct {
+	Slug       string
+	Table      string
+	Events     string
+	Function   string
+	Trigger    string
+	UrlSetting string
+	KeySetting string
+}
+
+// Writes a migration that calls the Function from a database trigger, returning the migration path.
+func WriteMigration(slug string, trigger Trigger, fsys afero.Fs) (string, error) {
+	name := strings.ReplaceAll(slug, "-", "_") + "_webhook"
+	config := migrationConfig{
+		Slug:       slug,
+		Table:      pgx.Identifier{trigger.Schema, trigger.Table}.Sanitize(),
+		Events:     strings.Join(trigger.Events, " or "),
+		Function:   pgx.Identifier{trigger.Schema, name}.Sanitize(),
+		Trigger:    pgx.Identifier{name}.Sanitize(),
+		UrlSetting: UrlSetting,
+		KeySetting: KeySetting,
+	}
+	path := filepath.Join(utils.MigrationsDir, utils.GetCurrentTimestamp()+"_"+slug+migrationSuffix)
+	if err := utils.MkdirIfNotExistFS(fsys, utils.MigrationsDir); err != nil {
+		return "", err
+	}
+	f, err := fsys.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return path, webhookTemplate.Execute(f, config)
+}
+
+// Returns the slugs of Functions that are called from database triggers.
+func ListSlugs(fsys afero.Fs) ([]string, error) {
+	paths, err := afero.Glob(fsys, filepath.Join(utils.MigrationsDir, "*"+migrationSuffix))
+	if err != nil {
+		return nil, err
+	}
+	var slugs []string
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), migrationSuffix)
+		// Strip the migration timestamp
+		if _, slug, found := strings.Cut(name, "_"); found && utils.FuncSlugPattern.MatchString(slug) {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs, nil
+}
+
+func alterDatabase(url, key string) string {
+	return fmt.Sprintf("ALTER DATABASE postgres SET %s = '%s';\nALTER DATABASE postgres SET %s = '%s';",
+		UrlSetting, strings.ReplaceAll(url, "'", "''"),
+		KeySetting, strings.ReplaceAll(key, "'", "''"),
+	)
+}
+
+// Points database triggers at the local Functions runtime, reachable from the db container via Kong.
+func ConfigureLocal(ctx context.Context, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if slugs, err := ListSlugs(fsys); err != nil || len(slugs) == 0 {
+		return err
+	}
+	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{}, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	url := "http://" + utils.KongId + ":8000/functions/v1"
+	if _, err := conn.Exec(ctx, alterDatabase(url, utils.Config.Auth.AnonKey)); err != nil {
+		return fmt.Errorf("failed to configure database webhooks: %w", err)
+	}
+	return nil
+}
+
+// Points database triggers of the linked project at its deployed Functions.
+func ConfigureRemote(ctx context.Context, projectRef string) error {
+	keys, err := tenant.GetApiKeys(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	url := "https://" + utils.GetSupabaseHost(projectRef) + "/functions/v1"
+	// Query results are returned as an array of rows, so skip parsing the response body
+	resp, err := utils.GetSupabase().V1RunQuery(ctx, projectRef, api.V1RunQueryJSONRequestBody{
+		Query: alterDatabase(url, keys.Anon),
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return errors.New("Failed to configure database webhooks: " + string(body))
+	}
+	return nil
+}
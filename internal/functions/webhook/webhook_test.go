@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestParseTrigger(t *testing.T) {
+	t.Run("parses schema and events", func(t *testing.T) {
+		trigger, err := ParseTrigger("private.todos:insert,UPDATE,insert")
+		assert.NoError(t, err)
+		assert.Equal(t, Trigger{Schema: "private", Table: "todos", Events: []string{"INSERT", "UPDATE"}}, trigger)
+	})
+
+	t.Run("defaults to public schema", func(t *testing.T) {
+		trigger, err := ParseTrigger("todos:DELETE")
+		assert.NoError(t, err)
+		assert.Equal(t, Trigger{Schema: "public", Table: "todos", Events: []string{"DELETE"}}, trigger)
+	})
+
+	t.Run("throws error on missing event", func(t *testing.T) {
+		_, err := ParseTrigger("todos")
+		assert.ErrorContains(t, err, "Invalid db trigger")
+	})
+
+	t.Run("throws error on unknown event", func(t *testing.T) {
+		_, err := ParseTrigger("todos:TRUNCATE")
+		assert.ErrorContains(t, err, "Invalid db trigger event")
+	})
+}
+
+func TestWriteMigration(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	trigger := Trigger{Schema: "public", Table: "todos", Events: []string{"INSERT", "UPDATE"}}
+	// Run test
+	path, err := WriteMigration("on-todo", trigger, fsys)
+	// Check error
+	assert.NoError(t, err)
+	contents, err := afero.ReadFile(fsys, path)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), `after INSERT or UPDATE on "public"."todos"`)
+	assert.Contains(t, string(contents), `execute function "public"."on_todo_webhook"()`)
+	assert.Contains(t, string(contents), `url := endpoint || '/on-todo'`)
+	// Validate slugs
+	slugs, err := ListSlugs(fsys)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"on-todo"}, slugs)
+}
+
+func TestConfigureRemote(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	projectRef := apitest.RandomProjectRef()
+	// Setup mock api
+	defer gock.OffAll()
+	gock.New(utils.DefaultApiHost).
+		Get("/v1/projects/" + projectRef + "/api-keys").
+		Reply(http.StatusOK).
+		JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key"}})
+	gock.New(utils.DefaultApiHost).
+		Post("/v1/projects/" + projectRef + "/database/query").
+		JSON(api.RunQueryBody{Query: alterDatabase("https://"+utils.GetSupabaseHost(projectRef)+"/functions/v1", "anon-key")}).
+		Reply(http.StatusCreated).
+		JSON([]any{})
+	// Run test
+	err := ConfigureRemote(context.Background(), projectRef)
+	// Check error
+	assert.NoError(t, err)
+	assert.Empty(t, apitest.ListUnmatchedRequests())
+}
@@ -0,0 +1,83 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+
+	"github.com/supabase/cli/internal/gen/types/model"
+)
+
+func Generate(w io.Writer, schema model.Schema) error {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by supabase gen types. DO NOT EDIT.\n\npackage database\n\n")
+	useJson := schema.Uses(func(c model.Column) bool { return c.Kind == model.KindJson })
+	useTime := schema.Uses(func(c model.Column) bool { return c.Kind == model.KindTimestamp })
+	if useJson || useTime {
+		buf.WriteString("import (\n")
+		if useJson {
+			buf.WriteString("\t\"encoding/json\"\n")
+		}
+		if useTime {
+			buf.WriteString("\t\"time\"\n")
+		}
+		buf.WriteString(")\n\n")
+	}
+	for _, e := range schema.Enums {
+		name := model.TypeName(e.Schema, e.Name)
+		fmt.Fprintf(&buf, "type %s string\n\nconst (\n", name)
+		for _, v := range e.Values {
+			fmt.Fprintf(&buf, "\t%s%s %s = %s\n", name, model.PascalCase(v), name, strconv.Quote(v))
+		}
+		buf.WriteString(")\n\n")
+	}
+	for _, t := range schema.Tables {
+		fmt.Fprintf(&buf, "type %s struct {\n", model.TypeName(t.Schema, t.Name))
+		for _, c := range t.Columns {
+			fmt.Fprintf(&buf, "\t%s %s `json:%s`\n", model.PascalCase(c.Name), goType(c), strconv.Quote(c.Name))
+		}
+		buf.WriteString("}\n\n")
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+func goType(c model.Column) string {
+	var name string
+	switch c.Kind {
+	case model.KindString:
+		name = "string"
+	case model.KindInteger:
+		name = "int32"
+	case model.KindBigInt:
+		name = "int64"
+	case model.KindFloat:
+		name = "float64"
+	case model.KindBoolean:
+		name = "bool"
+	case model.KindJson:
+		name = "json.RawMessage"
+	case model.KindTimestamp:
+		name = "time.Time"
+	case model.KindBytes:
+		name = "[]byte"
+	case model.KindEnum:
+		name = model.TypeName(c.Enum.Schema, c.Enum.Name)
+	default:
+		name = "any"
+	}
+	if c.Array {
+		return "[]" + name
+	}
+	// Slices and interfaces are already nullable
+	if c.Nullable && c.Kind != model.KindJson && c.Kind != model.KindBytes && c.Kind != model.KindUnknown {
+		return "*" + name
+	}
+	return name
+}
@@ -0,0 +1,38 @@
+package golang
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/gen/types/model"
+)
+
+func TestGenerateGo(t *testing.T) {
+	enum := &model.Enum{Schema: "public", Name: "status", Values: []string{"active"}}
+	schema := model.Schema{
+		Enums: []*model.Enum{enum},
+		Tables: []model.Table{{
+			Schema: "public",
+			Name:   "todos",
+			Columns: []model.Column{
+				{Name: "id", Kind: model.KindBigInt},
+				{Name: "done_at", Kind: model.KindTimestamp, Nullable: true},
+				{Name: "status", Kind: model.KindEnum, Enum: enum},
+			},
+		}},
+	}
+	// Run test
+	var out bytes.Buffer
+	err := Generate(&out, schema)
+	// Check error
+	assert.NoError(t, err)
+	assert.Equal(t, "// Code generated by supabase gen types. DO NOT EDIT.\n\npackage database\n\n"+
+		"import (\n\t\"time\"\n)\n\n"+
+		"type Status string\n\nconst (\n\tStatusActive Status = \"active\"\n)\n\n"+
+		"type Todos struct {\n"+
+		"\tId     int64      `json:\"id\"`\n"+
+		"\tDoneAt *time.Time `json:\"done_at\"`\n"+
+		"\tStatus Status     `json:\"status\"`\n"+
+		"}\n", out.String())
+}
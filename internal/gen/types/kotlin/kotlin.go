@@ -0,0 +1,81 @@
+package kotlin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/supabase/cli/internal/gen/types/model"
+)
+
+var keywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true, "else": true,
+	"false": true, "for": true, "fun": true, "if": true, "in": true, "interface": true,
+	"is": true, "null": true, "object": true, "package": true, "return": true, "super": true,
+	"this": true, "throw": true, "true": true, "try": true, "typealias": true, "typeof": true,
+	"val": true, "var": true, "when": true, "while": true,
+}
+
+func Generate(w io.Writer, schema model.Schema) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "// Code generated by supabase gen types. DO NOT EDIT.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "import kotlinx.serialization.SerialName")
+	fmt.Fprintln(out, "import kotlinx.serialization.Serializable")
+	if schema.Uses(func(c model.Column) bool { return c.Kind == model.KindJson }) {
+		fmt.Fprintln(out, "import kotlinx.serialization.json.JsonElement")
+	}
+	for _, e := range schema.Enums {
+		fmt.Fprintf(out, "\n@Serializable\nenum class %s {\n", model.TypeName(e.Schema, e.Name))
+		for _, v := range e.Values {
+			fmt.Fprintf(out, "    @SerialName(%s) %s,\n", strconv.Quote(v), model.UpperSnakeCase(v))
+		}
+		fmt.Fprintln(out, "}")
+	}
+	for _, t := range schema.Tables {
+		fmt.Fprintf(out, "\n@Serializable\ndata class %s(\n", model.TypeName(t.Schema, t.Name))
+		for _, c := range t.Columns {
+			fmt.Fprintf(out, "    @SerialName(%s) val %s: %s,\n", strconv.Quote(c.Name), identifier(c.Name), kotlinType(c))
+		}
+		fmt.Fprintln(out, ")")
+	}
+	return out.Flush()
+}
+
+func identifier(name string) string {
+	id := model.CamelCase(name)
+	if keywords[id] {
+		return "`" + id + "`"
+	}
+	return id
+}
+
+func kotlinType(c model.Column) string {
+	var name string
+	switch c.Kind {
+	case model.KindString, model.KindTimestamp, model.KindBytes:
+		name = "String"
+	case model.KindInteger:
+		name = "Int"
+	case model.KindBigInt:
+		name = "Long"
+	case model.KindFloat:
+		name = "Double"
+	case model.KindBoolean:
+		name = "Boolean"
+	case model.KindJson:
+		name = "JsonElement"
+	case model.KindEnum:
+		name = model.TypeName(c.Enum.Schema, c.Enum.Name)
+	default:
+		name = "String"
+	}
+	if c.Array {
+		name = "List<" + name + ">"
+	}
+	if c.Nullable {
+		return name + "?"
+	}
+	return name
+}
@@ -0,0 +1,215 @@
+// Package model defines a language agnostic description of database types that code generators
+// consume, so adding a new language only requires mapping these types to its own syntax.
+package model
+
+import (
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/supabase/cli/internal/db/diff/catalog"
+)
+
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindString
+	KindInteger
+	KindBigInt
+	KindFloat
+	KindBoolean
+	KindJson
+	KindTimestamp
+	KindBytes
+	KindEnum
+)
+
+type Column struct {
+	Name     string
+	Kind     Kind
+	Enum     *Enum
+	Array    bool
+	Nullable bool
+}
+
+type Table struct {
+	Schema  string
+	Name    string
+	Columns []Column
+}
+
+type Enum struct {
+	Schema string
+	Name   string
+	Values []string
+}
+
+type Schema struct {
+	Enums  []*Enum
+	Tables []Table
+}
+
+// Generator renders the schema as source code of a specific language.
+type Generator func(w io.Writer, schema Schema) error
+
+// Uses checks whether any column in the schema satisfies the predicate.
+func (s Schema) Uses(match func(Column) bool) bool {
+	for _, t := range s.Tables {
+		for _, c := range t.Columns {
+			if match(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func FromCatalog(result catalog.Catalog) Schema {
+	var schema Schema
+	// Enums with the same name may exist in different schemas
+	lookup := map[[2]string]*Enum{}
+	for _, key := range sortedKeys(result.Enums) {
+		ns, name := splitQualifiedName(key)
+		enum := Enum{Schema: ns, Name: name, Values: result.Enums[key]}
+		schema.Enums = append(schema.Enums, &enum)
+		lookup[[2]string{ns, name}] = &enum
+	}
+	for _, key := range sortedKeys(result.Tables) {
+		t := result.Tables[key]
+		table := Table{Schema: t.Schema, Name: t.Name}
+		for _, c := range t.Columns {
+			column := Column{Name: c.Name, Nullable: !c.NotNull}
+			column.Kind, column.Array = parseType(c.Type)
+			if column.Kind == KindUnknown {
+				if enum, ok := lookup[parseTypeName(strings.TrimSuffix(c.Type, "[]"))]; ok {
+					column.Kind = KindEnum
+					column.Enum = enum
+				}
+			}
+			table.Columns = append(table.Columns, column)
+		}
+		schema.Tables = append(schema.Tables, table)
+	}
+	return schema
+}
+
+var typeModifier = regexp.MustCompile(`\([0-9, ]+\)`)
+
+// Maps the output of Postgres format_type to a kind, ignoring modifiers such as varchar length.
+func parseType(formatted string) (Kind, bool) {
+	array := strings.HasSuffix(formatted, "[]")
+	name := typeModifier.ReplaceAllString(strings.TrimSuffix(formatted, "[]"), "")
+	switch name {
+	case "text", "character varying", "character", "citext", "uuid", "name", "inet", "cidr", "macaddr",
+		"date", "time without time zone", "time with time zone", "interval", "tsvector":
+		return KindString, array
+	case "smallint", "integer", "oid":
+		return KindInteger, array
+	case "bigint":
+		return KindBigInt, array
+	case "real", "double precision", "numeric", "money":
+		return KindFloat, array
+	case "boolean":
+		return KindBoolean, array
+	case "json", "jsonb":
+		return KindJson, array
+	case "timestamp without time zone", "timestamp with time zone":
+		return KindTimestamp, array
+	case "bytea":
+		return KindBytes, array
+	}
+	return KindUnknown, array
+}
+
+// Splits the output of Postgres format_type into schema and name. Types on the default
+// search_path, such as public, are not qualified.
+func parseTypeName(formatted string) [2]string {
+	var parts []string
+	var current strings.Builder
+	quoted := false
+	runes := []rune(formatted)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '"' && quoted && i+1 < len(runes) && runes[i+1] == '"':
+			current.WriteRune(r)
+			i++
+		case r == '"':
+			quoted = !quoted
+		case r == '.' && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	parts = append(parts, current.String())
+	if len(parts) < 2 {
+		return [2]string{"public", parts[0]}
+	}
+	return [2]string{parts[0], parts[1]}
+}
+
+func splitQualifiedName(sanitized string) (string, string) {
+	parts := strings.SplitN(sanitized, `"."`, 2)
+	unquote := func(s string) string {
+		return strings.ReplaceAll(strings.Trim(s, `"`), `""`, `"`)
+	}
+	if len(parts) < 2 {
+		return "", unquote(sanitized)
+	}
+	return unquote(parts[0]), unquote(parts[1])
+}
+
+// TypeName returns a PascalCase type name, prefixed by schema unless it is public.
+func TypeName(schema, name string) string {
+	if schema != "public" && len(schema) > 0 {
+		name = schema + "_" + name
+	}
+	return PascalCase(name)
+}
+
+func PascalCase(s string) string {
+	var sb strings.Builder
+	for _, w := range splitWords(s) {
+		r := []rune(w)
+		sb.WriteRune(unicode.ToUpper(r[0]))
+		sb.WriteString(string(r[1:]))
+	}
+	if sb.Len() == 0 || unicode.IsDigit([]rune(sb.String())[0]) {
+		return "T" + sb.String()
+	}
+	return sb.String()
+}
+
+func CamelCase(s string) string {
+	p := []rune(PascalCase(s))
+	p[0] = unicode.ToLower(p[0])
+	return string(p)
+}
+
+func UpperSnakeCase(s string) string {
+	words := splitWords(s)
+	result := strings.ToUpper(strings.Join(words, "_"))
+	if len(result) == 0 || unicode.IsDigit([]rune(result)[0]) {
+		return "_" + result
+	}
+	return result
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
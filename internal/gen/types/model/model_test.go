@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/db/diff/catalog"
+)
+
+func TestFromCatalog(t *testing.T) {
+	result := catalog.Catalog{
+		Enums: map[string][]string{
+			catalog.QualifiedName("public", "status"):   {"active", "archived"},
+			catalog.QualifiedName("private", "status"):  {"hidden"},
+			catalog.QualifiedName("private", "My Mood"): {"happy"},
+		},
+		Tables: map[string]*catalog.Table{
+			catalog.QualifiedName("public", "todos"): {
+				Schema: "public",
+				Name:   "todos",
+				Columns: []catalog.Column{
+					{Name: "id", Type: "bigint", NotNull: true},
+					{Name: "title", Type: "character varying(255)"},
+					{Name: "tags", Type: "text[]", NotNull: true},
+					{Name: "status", Type: "status", NotNull: true},
+					{Name: "geom", Type: "geometry"},
+					{Name: "visibility", Type: "private.status"},
+					{Name: "moods", Type: `private."My Mood"[]`, NotNull: true},
+				},
+			},
+		},
+	}
+	// Run test
+	schema := FromCatalog(result)
+	// Check result
+	mood := &Enum{Schema: "private", Name: "My Mood", Values: []string{"happy"}}
+	hidden := &Enum{Schema: "private", Name: "status", Values: []string{"hidden"}}
+	enum := &Enum{Schema: "public", Name: "status", Values: []string{"active", "archived"}}
+	assert.Equal(t, []*Enum{mood, hidden, enum}, schema.Enums)
+	assert.Equal(t, []Table{{
+		Schema: "public",
+		Name:   "todos",
+		Columns: []Column{
+			{Name: "id", Kind: KindBigInt},
+			{Name: "title", Kind: KindString, Nullable: true},
+			{Name: "tags", Kind: KindString, Array: true},
+			{Name: "status", Kind: KindEnum, Enum: enum},
+			{Name: "geom", Kind: KindUnknown, Nullable: true},
+			{Name: "visibility", Kind: KindEnum, Enum: hidden, Nullable: true},
+			{Name: "moods", Kind: KindEnum, Enum: mood, Array: true},
+		},
+	}}, schema.Tables)
+}
+
+func TestNamingConventions(t *testing.T) {
+	assert.Equal(t, "UserProfiles", TypeName("public", "user_profiles"))
+	assert.Equal(t, "AuthUsers", TypeName("auth", "users"))
+	assert.Equal(t, "createdAt", CamelCase("created_at"))
+	assert.Equal(t, "T2fa", PascalCase("2fa"))
+	assert.Equal(t, "IN_PROGRESS", UpperSnakeCase("in-progress"))
+	assert.Equal(t, "_1ST", UpperSnakeCase("1st"))
+}
@@ -0,0 +1,102 @@
+package python
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/supabase/cli/internal/gen/types/model"
+)
+
+var keywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true, "def": true,
+	"del": true, "elif": true, "else": true, "except": true, "finally": true, "for": true,
+	"from": true, "global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+func Generate(w io.Writer, schema model.Schema) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "# Code generated by supabase gen types. DO NOT EDIT.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "from __future__ import annotations")
+	fmt.Fprintln(out)
+	if schema.Uses(func(c model.Column) bool { return c.Kind == model.KindTimestamp }) {
+		fmt.Fprintln(out, "import datetime")
+	}
+	if schema.Uses(func(c model.Column) bool { return identifier(c.Name) != c.Name }) {
+		fmt.Fprintln(out, "from dataclasses import dataclass, field")
+	} else {
+		fmt.Fprintln(out, "from dataclasses import dataclass")
+	}
+	if len(schema.Enums) > 0 {
+		fmt.Fprintln(out, "from enum import Enum")
+	}
+	fmt.Fprintln(out, "from typing import Any, List, Optional")
+	for _, e := range schema.Enums {
+		fmt.Fprintf(out, "\n\nclass %s(str, Enum):\n", model.TypeName(e.Schema, e.Name))
+		for _, v := range e.Values {
+			fmt.Fprintf(out, "    %s = %s\n", model.UpperSnakeCase(v), strconv.Quote(v))
+		}
+	}
+	for _, t := range schema.Tables {
+		fmt.Fprintf(out, "\n\n@dataclass\nclass %s:\n", model.TypeName(t.Schema, t.Name))
+		if len(t.Columns) == 0 {
+			fmt.Fprintln(out, "    pass")
+		}
+		for _, c := range t.Columns {
+			id := identifier(c.Name)
+			if id == c.Name {
+				fmt.Fprintf(out, "    %s: %s\n", id, pythonType(c))
+				continue
+			}
+			// Keeps the column name for serialisers since it is not a valid attribute name
+			fmt.Fprintf(out, "    %s: %s = field(metadata={\"name\": %s})\n", id, pythonType(c), strconv.Quote(c.Name))
+		}
+	}
+	return out.Flush()
+}
+
+// Returns the column name unchanged if it is a valid attribute name.
+func identifier(name string) string {
+	id := strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}), "_")
+	if len(id) == 0 || id[0] >= '0' && id[0] <= '9' || keywords[id] {
+		return "_" + id
+	}
+	return id
+}
+
+func pythonType(c model.Column) string {
+	var name string
+	switch c.Kind {
+	case model.KindString:
+		name = "str"
+	case model.KindInteger, model.KindBigInt:
+		name = "int"
+	case model.KindFloat:
+		name = "float"
+	case model.KindBoolean:
+		name = "bool"
+	case model.KindTimestamp:
+		name = "datetime.datetime"
+	case model.KindBytes:
+		name = "bytes"
+	case model.KindEnum:
+		name = model.TypeName(c.Enum.Schema, c.Enum.Name)
+	default:
+		name = "Any"
+	}
+	if c.Array {
+		name = "List[" + name + "]"
+	}
+	if c.Nullable {
+		return "Optional[" + name + "]"
+	}
+	return name
+}
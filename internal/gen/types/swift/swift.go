@@ -0,0 +1,126 @@
+package swift
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/supabase/cli/internal/gen/types/model"
+)
+
+var keywords = map[string]bool{
+	"as": true, "break": true, "case": true, "class": true, "continue": true, "default": true,
+	"defer": true, "do": true, "else": true, "enum": true, "extension": true, "false": true,
+	"for": true, "func": true, "if": true, "import": true, "in": true, "init": true, "is": true,
+	"let": true, "nil": true, "operator": true, "private": true, "protocol": true, "public": true,
+	"repeat": true, "return": true, "self": true, "static": true, "struct": true, "super": true,
+	"switch": true, "throw": true, "true": true, "try": true, "var": true, "where": true, "while": true,
+}
+
+// Swift has no built-in Codable type for arbitrary JSON values.
+const jsonValue = `
+enum JSONValue: Codable, Hashable {
+    case string(String)
+    case number(Double)
+    case bool(Bool)
+    case object([String: JSONValue])
+    case array([JSONValue])
+    case null
+
+    init(from decoder: Decoder) throws {
+        let container = try decoder.singleValueContainer()
+        if container.decodeNil() {
+            self = .null
+        } else if let value = try? container.decode(Bool.self) {
+            self = .bool(value)
+        } else if let value = try? container.decode(Double.self) {
+            self = .number(value)
+        } else if let value = try? container.decode(String.self) {
+            self = .string(value)
+        } else if let value = try? container.decode([JSONValue].self) {
+            self = .array(value)
+        } else {
+            self = .object(try container.decode([String: JSONValue].self))
+        }
+    }
+
+    func encode(to encoder: Encoder) throws {
+        var container = encoder.singleValueContainer()
+        switch self {
+        case .string(let value): try container.encode(value)
+        case .number(let value): try container.encode(value)
+        case .bool(let value): try container.encode(value)
+        case .object(let value): try container.encode(value)
+        case .array(let value): try container.encode(value)
+        case .null: try container.encodeNil()
+        }
+    }
+}
+`
+
+func Generate(w io.Writer, schema model.Schema) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "// Code generated by supabase gen types. DO NOT EDIT.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "import Foundation")
+	if schema.Uses(func(c model.Column) bool { return c.Kind == model.KindJson }) {
+		fmt.Fprint(out, jsonValue)
+	}
+	for _, e := range schema.Enums {
+		fmt.Fprintf(out, "\nenum %s: String, Codable, Hashable {\n", model.TypeName(e.Schema, e.Name))
+		for _, v := range e.Values {
+			fmt.Fprintf(out, "    case %s = %s\n", identifier(v), strconv.Quote(v))
+		}
+		fmt.Fprintln(out, "}")
+	}
+	for _, t := range schema.Tables {
+		fmt.Fprintf(out, "\nstruct %s: Codable, Hashable {\n", model.TypeName(t.Schema, t.Name))
+		for _, c := range t.Columns {
+			fmt.Fprintf(out, "    let %s: %s\n", identifier(c.Name), swiftType(c))
+		}
+		fmt.Fprintln(out, "\n    enum CodingKeys: String, CodingKey {")
+		for _, c := range t.Columns {
+			fmt.Fprintf(out, "        case %s = %s\n", identifier(c.Name), strconv.Quote(c.Name))
+		}
+		fmt.Fprintln(out, "    }\n}")
+	}
+	return out.Flush()
+}
+
+func identifier(name string) string {
+	id := model.CamelCase(name)
+	if keywords[id] {
+		return "`" + id + "`"
+	}
+	return id
+}
+
+func swiftType(c model.Column) string {
+	var name string
+	switch c.Kind {
+	case model.KindString, model.KindTimestamp, model.KindBytes:
+		name = "String"
+	case model.KindInteger:
+		name = "Int32"
+	case model.KindBigInt:
+		name = "Int64"
+	case model.KindFloat:
+		name = "Double"
+	case model.KindBoolean:
+		name = "Bool"
+	case model.KindJson:
+		name = "JSONValue"
+	case model.KindEnum:
+		name = model.TypeName(c.Enum.Schema, c.Enum.Name)
+	default:
+		name = "String"
+	}
+	if c.Array {
+		name = "[" + name + "]"
+	}
+	if c.Nullable {
+		return name + "?"
+	}
+	return name
+}
@@ -0,0 +1,39 @@
+package swift
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/gen/types/model"
+)
+
+func TestGenerateSwift(t *testing.T) {
+	enum := &model.Enum{Schema: "public", Name: "status", Values: []string{"active", "in-progress"}}
+	schema := model.Schema{
+		Enums: []*model.Enum{enum},
+		Tables: []model.Table{{
+			Schema: "private",
+			Name:   "todos",
+			Columns: []model.Column{
+				{Name: "id", Kind: model.KindBigInt},
+				{Name: "done_at", Kind: model.KindTimestamp, Nullable: true},
+				{Name: "Due Date", Kind: model.KindString},
+				{Name: "class", Kind: model.KindJson, Nullable: true},
+				{Name: "tags", Kind: model.KindString, Array: true},
+				{Name: "status", Kind: model.KindEnum, Enum: enum},
+			},
+		}},
+	}
+	// Run test
+	var out bytes.Buffer
+	err := Generate(&out, schema)
+	// Check error
+	require.NoError(t, err)
+	golden, err := os.ReadFile(filepath.Join("testdata", "todos.swift"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(golden), out.String())
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/db/diff/catalog"
+	"github.com/supabase/cli/internal/gen/types/golang"
+	"github.com/supabase/cli/internal/gen/types/kotlin"
+	"github.com/supabase/cli/internal/gen/types/model"
+	"github.com/supabase/cli/internal/gen/types/python"
+	"github.com/supabase/cli/internal/gen/types/swift"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	LangTypescript = "typescript"
+	LangGo         = "go"
+	LangKotlin     = "kotlin"
+	LangSwift      = "swift"
+	LangPython     = "python"
+)
+
+// Generators for languages other than TypeScript, which is served by pg-meta.
+var generators = map[string]model.Generator{
+	LangGo:     golang.Generate,
+	LangKotlin: kotlin.Generate,
+	LangSwift:  swift.Generate,
+	LangPython: python.Generate,
+}
+
+func Languages() []string {
+	result := []string{LangTypescript}
+	for lang := range generators {
+		result = append(result, lang)
+	}
+	sort.Strings(result[1:])
+	return result
+}
+
+func Run(ctx context.Context, lang string, schemas []string, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	generate, ok := generators[lang]
+	if !ok {
+		return fmt.Errorf("Unsupported language: %s", lang)
+	}
+	if len(schemas) == 0 {
+		schemas = []string{"public"}
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	result, err := catalog.Inspect(ctx, conn, schemas)
+	if err != nil {
+		return err
+	}
+	return generate(os.Stdout, model.FromCatalog(result))
+}
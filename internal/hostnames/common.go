@@ -75,6 +75,13 @@ func appendRawOutputIfNeeded(status string, response *api.UpdateCustomHostnameRe
 	return fmt.Sprintf("%s\nRaw output follows:\n%s\n", status, rawOutput)
 }
 
+func certificateStatus(res RawResponse) string {
+	if len(res.Result.Ssl.Status) == 0 {
+		return ""
+	}
+	return "\nSSL certificate status: " + res.Result.Ssl.Status + "\n"
+}
+
 func TranslateStatus(response *api.UpdateCustomHostnameResponse, includeRawOutput bool) (string, error) {
 	if response.Status == api.N5ServicesReconfigured {
 		return appendRawOutputIfNeeded(fmt.Sprintf("Custom hostname setup completed. Project is now accessible at %s.", response.CustomHostname), response, includeRawOutput), nil
@@ -92,7 +99,7 @@ func TranslateStatus(response *api.UpdateCustomHostnameResponse, includeRawOutpu
 		return appendRawOutputIfNeeded(fmt.Sprintf(`Custom hostname configuration complete, and ready for activation.
 
 Please ensure that your custom domain is set up as a CNAME record to your Supabase subdomain:
-	%s CNAME -> %s`, response.CustomHostname, res.Result.CustomOriginServer), response, includeRawOutput), nil
+	%s CNAME -> %s%s`, response.CustomHostname, res.Result.CustomOriginServer, certificateStatus(res)), response, includeRawOutput), nil
 	}
 	if response.Status == api.N2Initiated {
 		var res RawResponse
@@ -131,8 +138,8 @@ Please ensure that your custom domain is set up as a CNAME record to your Supaba
 			records = fmt.Sprintf("%s\n\t%s TXT -> %s (replace any existing CNAME records)", records, ssl[0].TxtName, ssl[0].TxtValue)
 		}
 		status := fmt.Sprintf("Custom hostname verification in-progress; please configure the appropriate DNS entries and request re-verification.\n"+
-			"Required outstanding validation records: %s\n",
-			records)
+			"Required outstanding validation records: %s\n%s",
+			records, certificateStatus(res))
 		return appendRawOutputIfNeeded(status, response, includeRawOutput), nil
 	}
 	return appendRawOutputIfNeeded("Custom hostname configuration not started.", response, includeRawOutput), nil
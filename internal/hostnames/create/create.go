@@ -5,14 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/hostnames"
+	"github.com/supabase/cli/internal/hostnames/reverify"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, projectRef string, customHostname string, includeRawOutput bool, fsys afero.Fs) error {
+func Run(ctx context.Context, projectRef string, customHostname string, includeRawOutput bool, timeout time.Duration, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	hostname := strings.TrimSpace(customHostname)
 	{
@@ -42,6 +44,11 @@ func Run(ctx context.Context, projectRef string, customHostname string, includeR
 			return err
 		}
 		fmt.Println(status)
-		return nil
 	}
+
+	// 3. optionally wait for dns verification
+	if timeout > 0 {
+		return reverify.Wait(ctx, projectRef, includeRawOutput, timeout)
+	}
+	return nil
 }
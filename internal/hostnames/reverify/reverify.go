@@ -4,28 +4,81 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/hostnames"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
 )
 
-func Run(ctx context.Context, projectRef string, includeRawOutput bool, fsys afero.Fs) error {
+// Interval between re-verification requests while waiting for DNS records to propagate.
+var pollInterval = 10 * time.Second
+
+var ErrWaitTimeout = errors.New("Timed out waiting for custom hostname verification.")
+
+func Run(ctx context.Context, projectRef string, includeRawOutput bool, timeout time.Duration, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	// 2. attempt to re-verify custom hostname config
-	{
-		resp, err := utils.GetSupabase().ReverifyWithResponse(ctx, projectRef)
-		if err != nil {
+	if timeout > 0 {
+		return Wait(ctx, projectRef, includeRawOutput, timeout)
+	}
+	resp, err := reverify(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	status, err := hostnames.TranslateStatus(resp, includeRawOutput)
+	if err != nil {
+		return err
+	}
+	fmt.Println(status)
+	return nil
+}
+
+// Requests re-verification repeatedly until the custom hostname is ready for activation.
+func Wait(ctx context.Context, projectRef string, includeRawOutput bool, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	last := ""
+	for {
+		resp, err := reverify(ctx, projectRef)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ErrWaitTimeout
+		} else if err != nil {
 			return err
 		}
-		if resp.JSON201 == nil {
-			return errors.New("failed to re-verify custom hostname config: " + string(resp.Body))
-		}
-		status, err := hostnames.TranslateStatus(resp.JSON201, includeRawOutput)
+		status, err := hostnames.TranslateStatus(resp, includeRawOutput)
 		if err != nil {
 			return err
 		}
-		fmt.Println(status)
-		return nil
+		// Only print status on change to avoid flooding the terminal
+		if status != last {
+			fmt.Println(status)
+			last = status
+		}
+		if resp.Status == api.N4OriginSetupCompleted || resp.Status == api.N5ServicesReconfigured {
+			return nil
+		}
+		fmt.Fprintf(os.Stderr, "Waiting for verification, retrying in %v...\n", pollInterval)
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ErrWaitTimeout
+			}
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func reverify(ctx context.Context, projectRef string) (*api.UpdateCustomHostnameResponse, error) {
+	resp, err := utils.GetSupabase().ReverifyWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON201 == nil {
+		return nil, errors.New("failed to re-verify custom hostname config: " + string(resp.Body))
 	}
+	return resp.JSON201, nil
 }
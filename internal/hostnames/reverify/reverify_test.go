@@ -0,0 +1,65 @@
+package reverify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestWaitVerification(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	projectRef := apitest.RandomProjectRef()
+	pollInterval = time.Millisecond
+
+	t.Run("polls until origin setup completes", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + projectRef + "/custom-hostname/reverify").
+			Times(2).
+			Reply(http.StatusCreated).
+			JSON(api.UpdateCustomHostnameResponse{
+				CustomHostname: "example.com",
+				Status:         api.N1NotStarted,
+			})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + projectRef + "/custom-hostname/reverify").
+			Reply(http.StatusCreated).
+			JSON(api.UpdateCustomHostnameResponse{
+				CustomHostname: "example.com",
+				Status:         api.N4OriginSetupCompleted,
+				Data:           map[string]any{"result": map[string]any{"ssl": map[string]any{"status": "active"}}},
+			})
+		// Run test
+		err := Run(context.Background(), projectRef, false, time.Second, nil)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on timeout", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + projectRef + "/custom-hostname/reverify").
+			Persist().
+			Reply(http.StatusCreated).
+			JSON(api.UpdateCustomHostnameResponse{
+				CustomHostname: "example.com",
+				Status:         api.N1NotStarted,
+			})
+		// Run test
+		err := Wait(context.Background(), projectRef, false, 50*time.Millisecond)
+		// Check error
+		assert.ErrorIs(t, err, ErrWaitTimeout)
+	})
+}
@@ -1,6 +1,7 @@
 package init
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -24,7 +25,7 @@ var (
 	errAlreadyInitialized = errors.New("Project already initialized. Remove " + utils.Bold(utils.ConfigPath) + " to reinitialize.")
 )
 
-func Run(fsys afero.Fs, createVscodeWorkspace *bool, useOrioleDB bool) error {
+func Run(ctx context.Context, fsys afero.Fs, createVscodeWorkspace *bool, useOrioleDB bool, templateName string) error {
 	// Sanity checks.
 	{
 		if _, err := fsys.Stat(utils.ConfigPath); err == nil {
@@ -34,24 +35,39 @@ func Run(fsys afero.Fs, createVscodeWorkspace *bool, useOrioleDB bool) error {
 		}
 	}
 
-	// 1. Write `config.toml`.
-	if err := utils.InitConfig(utils.InitParams{UseOrioleDB: useOrioleDB}, fsys); err != nil {
+	// 1. Copy starter files from template.
+	if len(templateName) > 0 {
+		if err := initTemplate(ctx, templateName, fsys); err != nil {
+			return err
+		}
+	}
+
+	// 2. Write `config.toml`.
+	if exists, err := afero.Exists(fsys, utils.ConfigPath); err != nil {
 		return err
+	} else if !exists {
+		if err := utils.InitConfig(utils.InitParams{UseOrioleDB: useOrioleDB}, fsys); err != nil {
+			return err
+		}
 	}
 
-	// 2. Create `seed.sql`.
-	if _, err := fsys.Create(utils.SeedDataPath); err != nil {
+	// 3. Create `seed.sql`.
+	if exists, err := afero.Exists(fsys, utils.SeedDataPath); err != nil {
 		return err
+	} else if !exists {
+		if _, err := fsys.Create(utils.SeedDataPath); err != nil {
+			return err
+		}
 	}
 
-	// 3. Append to `.gitignore`.
+	// 4. Append to `.gitignore`.
 	if utils.IsGitRepo() {
 		if err := updateGitIgnore(utils.GitIgnorePath, fsys); err != nil {
 			return err
 		}
 	}
 
-	// 4. Generate VS Code workspace settings.
+	// 5. Generate VS Code workspace settings.
 	if createVscodeWorkspace != nil {
 		if *createVscodeWorkspace {
 			return writeVscodeConfig(fsys)
@@ -65,6 +81,23 @@ func Run(fsys afero.Fs, createVscodeWorkspace *bool, useOrioleDB bool) error {
 	return nil
 }
 
+func initTemplate(ctx context.Context, name string, fsys afero.Fs) error {
+	src, err := ParseSource(name)
+	if err != nil {
+		return err
+	}
+	params, err := NewTemplateParams()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Downloading template from", utils.Bold(src.Url)+"...")
+	files, err := FetchTemplate(ctx, src)
+	if err != nil {
+		return err
+	}
+	return WriteTemplate(files, params, fsys)
+}
+
 func updateGitIgnore(ignorePath string, fsys afero.Fs) error {
 	var contents []byte
 
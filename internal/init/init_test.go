@@ -1,6 +1,7 @@
 package init
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -20,7 +21,7 @@ func TestInitCommand(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, fsys.Mkdir(".git", 0755))
 		// Run test
-		assert.NoError(t, Run(fsys, nil, false))
+		assert.NoError(t, Run(context.Background(), fsys, nil, false, ""))
 		// Validate generated config.toml
 		exists, err := afero.Exists(fsys, utils.ConfigPath)
 		assert.NoError(t, err)
@@ -45,14 +46,14 @@ func TestInitCommand(t *testing.T) {
 		_, err := fsys.Create(utils.ConfigPath)
 		require.NoError(t, err)
 		// Run test
-		assert.Error(t, Run(fsys, nil, false))
+		assert.Error(t, Run(context.Background(), fsys, nil, false, ""))
 	})
 
 	t.Run("throws error on permission denied", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := &fstest.StatErrorFs{DenyPath: utils.ConfigPath}
 		// Run test
-		err := Run(fsys, nil, false)
+		err := Run(context.Background(), fsys, nil, false, "")
 		// Check error
 		assert.ErrorIs(t, err, os.ErrPermission)
 	})
@@ -61,14 +62,14 @@ func TestInitCommand(t *testing.T) {
 		// Setup read-only fs
 		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
 		// Run test
-		assert.Error(t, Run(fsys, nil, false))
+		assert.Error(t, Run(context.Background(), fsys, nil, false, ""))
 	})
 
 	t.Run("throws error on seed failure", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := &fstest.CreateErrorFs{DenyPath: utils.SeedDataPath}
 		// Run test
-		err := Run(fsys, nil, false)
+		err := Run(context.Background(), fsys, nil, false, "")
 		// Check error
 		assert.ErrorIs(t, err, os.ErrPermission)
 	})
@@ -79,7 +80,7 @@ func TestInitCommand(t *testing.T) {
 		cwd, err := os.Getwd()
 		require.NoError(t, err)
 		// Run test
-		assert.NoError(t, Run(fsys, boolPointer(true), false))
+		assert.NoError(t, Run(context.Background(), fsys, boolPointer(true), false, ""))
 		// Validate generated vscode workspace
 		exists, err := afero.Exists(fsys, filepath.Join(cwd, "init.code-workspace"))
 		assert.NoError(t, err)
@@ -92,7 +93,7 @@ func TestInitCommand(t *testing.T) {
 		cwd, err := os.Getwd()
 		require.NoError(t, err)
 		// Run test
-		assert.NoError(t, Run(fsys, boolPointer(false), false))
+		assert.NoError(t, Run(context.Background(), fsys, boolPointer(false), false, ""))
 		// Validate vscode workspace isn't generated
 		exists, err := afero.Exists(fsys, filepath.Join(cwd, "init.code-workspace"))
 		assert.NoError(t, err)
@@ -0,0 +1,222 @@
+package init
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/google/go-github/v53/github"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"golang.org/x/oauth2"
+)
+
+// Source points to a directory in a git repository containing a supabase directory.
+type Source struct {
+	Url string
+	Ref string
+	Dir string
+}
+
+// Curated starters are maintained as examples in the main Supabase repository.
+var Registry = map[string]Source{
+	"nextjs":    {Url: "https://github.com/supabase/supabase", Ref: "master", Dir: "examples/user-management/nextjs-user-management"},
+	"flutter":   {Url: "https://github.com/supabase/supabase", Ref: "master", Dir: "examples/user-management/flutter-user-management"},
+	"expo":      {Url: "https://github.com/supabase/supabase", Ref: "master", Dir: "examples/user-management/expo-user-management"},
+	"sveltekit": {Url: "https://github.com/supabase/supabase", Ref: "master", Dir: "examples/user-management/sveltekit-user-management"},
+}
+
+func TemplateNames() []string {
+	var names []string
+	for k := range Registry {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Variables substituted into template files with .tmpl extension.
+type TemplateParams struct {
+	ProjectId    string
+	ApiPort      uint
+	DbPort       uint
+	StudioPort   uint
+	InbucketPort uint
+}
+
+func NewTemplateParams() (TemplateParams, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return TemplateParams{}, err
+	}
+	return TemplateParams{
+		ProjectId:    utils.SanitizeProjectId(filepath.Base(cwd)),
+		ApiPort:      54321,
+		DbPort:       54322,
+		StudioPort:   54323,
+		InbucketPort: 54324,
+	}, nil
+}
+
+var githubPattern = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/#]+?)(?:\.git)?(?:/tree/([^/]+)(?:/(.*))?)?/?$`)
+
+// Resolves a registry name, GitHub tree URL, or git URL with optional #ref fragment.
+func ParseSource(name string) (Source, error) {
+	if src, ok := Registry[name]; ok {
+		return src, nil
+	}
+	if matches := githubPattern.FindStringSubmatch(name); len(matches) > 0 {
+		return Source{
+			Url: fmt.Sprintf("https://github.com/%s/%s", matches[1], matches[2]),
+			Ref: matches[3],
+			Dir: strings.TrimSuffix(matches[4], "/"),
+		}, nil
+	}
+	if parsed, err := url.Parse(name); err == nil && len(parsed.Scheme) > 0 && len(parsed.Host) > 0 {
+		src := Source{Ref: parsed.Fragment}
+		parsed.Fragment = ""
+		src.Url = parsed.String()
+		return src, nil
+	}
+	return Source{}, fmt.Errorf("Unknown template %s. Choose one of %s or a git URL.", utils.Aqua(name), strings.Join(TemplateNames(), ", "))
+}
+
+// Fetches all files under the supabase directory of a template, keyed by path relative to the directory.
+func FetchTemplate(ctx context.Context, src Source) (map[string][]byte, error) {
+	root := path.Join(src.Dir, utils.SupabaseDirPath)
+	if matches := githubPattern.FindStringSubmatch(src.Url); len(matches) > 0 {
+		return fetchGitHub(ctx, matches[1], matches[2], src.Ref, root)
+	}
+	return fetchGit(ctx, src.Url, src.Ref, root)
+}
+
+func fetchGitHub(ctx context.Context, owner, repo, ref, root string) (map[string][]byte, error) {
+	client := github.NewClient(nil)
+	if token := os.Getenv("GITHUB_TOKEN"); len(token) > 0 {
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+		client = github.NewClient(oauth2.NewClient(ctx, ts))
+	}
+	opts := &github.RepositoryContentGetOptions{Ref: ref}
+	files := map[string][]byte{}
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		_, entries, _, err := client.Repositories.GetContents(ctx, owner, repo, dir, opts)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			switch entry.GetType() {
+			case "dir":
+				if err := walk(entry.GetPath()); err != nil {
+					return err
+				}
+			case "file":
+				file, _, _, err := client.Repositories.GetContents(ctx, owner, repo, entry.GetPath(), opts)
+				if err != nil {
+					return err
+				}
+				content, err := file.GetContent()
+				if err != nil {
+					return err
+				}
+				rel := strings.TrimPrefix(entry.GetPath(), root+"/")
+				files[rel] = []byte(content)
+			}
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return nil, fmt.Errorf("failed to fetch template: %w", err)
+	}
+	return files, nil
+}
+
+func fetchGit(ctx context.Context, remote, ref, root string) (map[string][]byte, error) {
+	opts := &git.CloneOptions{URL: remote, Depth: 1, SingleBranch: true}
+	if len(ref) > 0 {
+		opts.ReferenceName = plumbing.NewBranchReferenceName(ref)
+	}
+	worktree := memfs.New()
+	if _, err := git.CloneContext(ctx, memory.NewStorage(), worktree, opts); err != nil {
+		return nil, fmt.Errorf("failed to clone template: %w", err)
+	}
+	files := map[string][]byte{}
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		entries, err := worktree.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			fp := path.Join(dir, entry.Name())
+			if entry.IsDir() {
+				if err := walk(fp); err != nil {
+					return err
+				}
+				continue
+			}
+			f, err := worktree.Open(fp)
+			if err != nil {
+				return err
+			}
+			data, err := io.ReadAll(f)
+			f.Close()
+			if err != nil {
+				return err
+			}
+			files[strings.TrimPrefix(fp, root+"/")] = data
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	return files, nil
+}
+
+var projectIdPattern = regexp.MustCompile(`(?m)^project_id\s*=\s*".*"`)
+
+// Writes template files into the supabase directory, rendering .tmpl files with params.
+func WriteTemplate(files map[string][]byte, params TemplateParams, fsys afero.Fs) error {
+	if len(files) == 0 {
+		return errors.New("Template does not contain a " + utils.Bold(utils.SupabaseDirPath) + " directory.")
+	}
+	for rel, data := range files {
+		if strings.HasSuffix(rel, ".tmpl") {
+			tmpl, err := template.New(rel).Parse(string(data))
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", rel, err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, params); err != nil {
+				return fmt.Errorf("failed to render %s: %w", rel, err)
+			}
+			rel, data = strings.TrimSuffix(rel, ".tmpl"), buf.Bytes()
+		}
+		dst := filepath.Join(utils.SupabaseDirPath, filepath.FromSlash(rel))
+		if dst == utils.ConfigPath {
+			data = projectIdPattern.ReplaceAll(data, []byte(fmt.Sprintf("project_id = %q", params.ProjectId)))
+		}
+		if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(dst)); err != nil {
+			return err
+		}
+		if err := afero.WriteFile(fsys, dst, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package init
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestParseSource(t *testing.T) {
+	t.Run("resolves registry name", func(t *testing.T) {
+		src, err := ParseSource("nextjs")
+		assert.NoError(t, err)
+		assert.Equal(t, Registry["nextjs"], src)
+	})
+
+	t.Run("parses github tree url", func(t *testing.T) {
+		src, err := ParseSource("https://github.com/acme/starters/tree/main/todo-app")
+		assert.NoError(t, err)
+		assert.Equal(t, Source{Url: "https://github.com/acme/starters", Ref: "main", Dir: "todo-app"}, src)
+	})
+
+	t.Run("parses git url with ref", func(t *testing.T) {
+		src, err := ParseSource("https://gitlab.com/acme/starter.git#v1")
+		assert.NoError(t, err)
+		assert.Equal(t, Source{Url: "https://gitlab.com/acme/starter.git", Ref: "v1"}, src)
+	})
+
+	t.Run("throws error on unknown template", func(t *testing.T) {
+		_, err := ParseSource("rails")
+		assert.ErrorContains(t, err, "Unknown template")
+	})
+}
+
+func TestInitTemplate(t *testing.T) {
+	params := TemplateParams{ProjectId: "demo", ApiPort: 54321}
+
+	t.Run("fetches template from github", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/acme/starters/contents/todo/supabase").
+			MatchParam("ref", "main").
+			Reply(http.StatusOK).
+			JSON([]map[string]string{
+				{"type": "file", "path": "todo/supabase/config.toml"},
+				{"type": "dir", "path": "todo/supabase/migrations"},
+			})
+		gock.New("https://api.github.com").
+			Get("/repos/acme/starters/contents/todo/supabase/config.toml").
+			Reply(http.StatusOK).
+			JSON(map[string]string{
+				"type":     "file",
+				"encoding": "base64",
+				"content":  base64.StdEncoding.EncodeToString([]byte(`project_id = "todo"`)),
+			})
+		gock.New("https://api.github.com").
+			Get("/repos/acme/starters/contents/todo/supabase/migrations").
+			Reply(http.StatusOK).
+			JSON([]map[string]string{
+				{"type": "file", "path": "todo/supabase/migrations/0_init.sql.tmpl"},
+			})
+		gock.New("https://api.github.com").
+			Get("/repos/acme/starters/contents/todo/supabase/migrations/0_init.sql.tmpl").
+			Reply(http.StatusOK).
+			JSON(map[string]string{
+				"type":     "file",
+				"encoding": "base64",
+				"content":  base64.StdEncoding.EncodeToString([]byte("-- {{ .ProjectId }} on port {{ .ApiPort }}")),
+			})
+		// Run test
+		files, err := FetchTemplate(context.Background(), Source{Url: "https://github.com/acme/starters", Ref: "main", Dir: "todo"})
+		require.NoError(t, err)
+		assert.NoError(t, WriteTemplate(files, params, fsys))
+		// Check output
+		config, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `project_id = "demo"`, string(config))
+		migration, err := afero.ReadFile(fsys, filepath.Join(utils.MigrationsDir, "0_init.sql"))
+		assert.NoError(t, err)
+		assert.Equal(t, "-- demo on port 54321", string(migration))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on empty template", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := WriteTemplate(nil, params, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Template does not contain")
+	})
+}
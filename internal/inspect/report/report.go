@@ -0,0 +1,194 @@
+package report
+
+import (
+	"context"
+	"database/sql/driver"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/inspect/bloat"
+	"github.com/supabase/cli/internal/inspect/cache"
+	"github.com/supabase/cli/internal/inspect/locks"
+	"github.com/supabase/cli/internal/inspect/outliers"
+	"github.com/supabase/cli/internal/inspect/table_sizes"
+	"github.com/supabase/cli/internal/inspect/unused_indexes"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+var (
+	//go:embed templates/report.html
+	reportEmbed    string
+	reportTemplate = template.Must(template.New("report").Parse(reportEmbed))
+
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+type query struct {
+	Title       string
+	Description string
+	Sql         string
+}
+
+// Reuses the queries of individual inspect commands so that the report stays consistent with them.
+var queries = []query{
+	{"Slowest queries", "Statements from pg_stat_statements ordered by total execution time.", outliers.QUERY},
+	{"Cache hit ratios", "Ratios below 0.99 suggest the instance needs more memory.", cache.QUERY},
+	{"Bloat", "Estimated space wasted by dead tuples in tables and indexes.", bloat.QUERY},
+	{"Unused indexes", "Indexes that are rarely scanned but still slow down writes.", unused_indexes.QUERY},
+	{"Locks", "Exclusive locks held by running queries, ordered by age.", locks.QUERY},
+	{"Table sizes", "Size of each table excluding indexes.", table_sizes.QUERY},
+}
+
+type Section struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Columns     []string   `json:"columns"`
+	Rows        [][]string `json:"rows"`
+	// Set when a query fails, ie. pg_stat_statements is not enabled
+	Error string `json:"error,omitempty"`
+}
+
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Sections    []Section `json:"sections"`
+}
+
+// Collects all inspect queries into a single report, written as HTML or JSON when path is set.
+func Run(ctx context.Context, path string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	report := Report{GeneratedAt: time.Now().UTC()}
+	for _, q := range queries {
+		report.Sections = append(report.Sections, runQuery(ctx, conn, q))
+	}
+	if len(path) > 0 {
+		if err := writeReport(path, report, fsys); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Wrote inspection report to", utils.Bold(path))
+		return nil
+	}
+	if !render.IsPretty() {
+		return render.Encode(report)
+	}
+	return printReport(report)
+}
+
+func runQuery(ctx context.Context, conn *pgx.Conn, q query) Section {
+	section := Section{Title: q.Title, Description: q.Description}
+	rows, err := conn.Query(ctx, q.Sql)
+	if err != nil {
+		section.Error = err.Error()
+		return section
+	}
+	defer rows.Close()
+	for _, fd := range rows.FieldDescriptions() {
+		section.Columns = append(section.Columns, string(fd.Name))
+	}
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			section.Error = err.Error()
+			return section
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = formatValue(v)
+		}
+		section.Rows = append(section.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		section.Error = err.Error()
+	}
+	return section
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case driver.Valuer:
+		// Postgres types like interval and numeric encode to their text representation
+		if dv, err := v.Value(); err == nil {
+			if _, ok := dv.(driver.Valuer); !ok {
+				return formatValue(dv)
+			}
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+func writeReport(path string, report Report, fsys afero.Fs) error {
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return err
+	}
+	f, err := fsys.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	return writeHtml(report, f)
+}
+
+func writeHtml(report Report, w io.Writer) error {
+	return reportTemplate.Execute(w, report)
+}
+
+func printReport(report Report) error {
+	for _, section := range report.Sections {
+		fmt.Println(utils.Bold(section.Title))
+		if len(section.Error) > 0 {
+			fmt.Fprintln(os.Stderr, utils.Red("Failed to run query:"), section.Error)
+			fmt.Println()
+			continue
+		}
+		if err := list.RenderTable(toMarkdown(section)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toMarkdown(section Section) string {
+	var table strings.Builder
+	table.WriteString("|" + strings.Join(section.Columns, "|") + "|\n")
+	table.WriteString(strings.Repeat("|-", len(section.Columns)) + "|\n")
+	for _, row := range section.Rows {
+		for _, cell := range row {
+			cell = whitespacePattern.ReplaceAllString(cell, " ")
+			table.WriteString("|`" + strings.ReplaceAll(cell, "|", `\|`) + "`")
+		}
+		table.WriteString("|\n")
+	}
+	return table.String()
+}
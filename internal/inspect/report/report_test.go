@@ -0,0 +1,65 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestReportCommand(t *testing.T) {
+	t.Run("writes report with failed sections", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(queries[0].Sql).
+			ReplyError(pgerrcode.UndefinedTable, `relation "pg_stat_statements" does not exist`)
+		for _, q := range queries[1:] {
+			conn.Query(q.Sql).Reply("SELECT 1", []interface{}{"public.todos", 0.5})
+		}
+		// Run test
+		err := Run(context.Background(), "report.json", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, "report.json")
+		require.NoError(t, err)
+		var report Report
+		require.NoError(t, json.Unmarshal(data, &report))
+		require.Len(t, report.Sections, len(queries))
+		assert.Contains(t, report.Sections[0].Error, "pg_stat_statements")
+		assert.Equal(t, [][]string{{"public.todos", "0.5"}}, report.Sections[1].Rows)
+	})
+
+	t.Run("renders html report", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		report := Report{Sections: []Section{{
+			Title:   "Table sizes",
+			Columns: []string{"name", "size"},
+			Rows:    [][]string{{"<script>", "8 kB"}},
+		}}}
+		// Run test
+		err := writeReport("out/report.html", report, fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, "out/report.html")
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "<td>&lt;script&gt;</td><td>8 kB</td>")
+	})
+}
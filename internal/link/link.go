@@ -267,12 +267,20 @@ func updatePoolerConfig(config api.V1PgbouncerConfigResponse) {
 	}
 }
 
-func PromptPassword(stdin *os.File) string {
+func PromptPassword(stdin *os.File) (string, error) {
+	suggestion := "Set the " + utils.Aqua("SUPABASE_DB_PASSWORD") + " environment variable or use " + utils.Aqua("--password") + " flag instead."
+	if err := utils.AssertInteractive("Enter your database password", suggestion); err != nil {
+		return "", err
+	}
 	fmt.Fprint(os.Stderr, "Enter your database password: ")
-	return credentials.PromptMasked(stdin)
+	return credentials.PromptMasked(stdin), nil
 }
 
 func PromptPasswordAllowBlank(stdin *os.File) string {
+	// Blank password skips database connection
+	if utils.IsNonInteractive() {
+		return ""
+	}
 	fmt.Fprint(os.Stderr, "Enter your database password (or leave blank to skip): ")
 	return credentials.PromptMasked(stdin)
 }
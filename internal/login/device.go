@@ -0,0 +1,187 @@
+package login
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+var (
+	ErrDeviceCodeExpired = errors.New("Device code expired before login was approved. Please run " + utils.Aqua("supabase login --device") + " again.")
+	ErrAccessDenied      = errors.New("Login request was denied in the browser.")
+
+	// Ref: https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+	minPollInterval   = 5 * time.Second
+	slowDownIncrement = 5 * time.Second
+)
+
+// Ref: https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationUri         string `json:"verification_uri"`
+	VerificationUriComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// Ref: https://datatracker.ietf.org/doc/html/rfc6749#section-5.1
+type DeviceTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+func runDeviceFlow(ctx context.Context, stdout *os.File, params RunParams) error {
+	code, err := requestDeviceCode(ctx, params.TokenName)
+	if err != nil {
+		return err
+	}
+	verificationUrl := code.VerificationUriComplete
+	if len(verificationUrl) == 0 {
+		verificationUrl = code.VerificationUri
+	}
+	fmt.Fprintf(stdout, "Your one-time code is %s\n", utils.Bold(code.UserCode))
+	if params.OpenBrowser {
+		fmt.Fprintf(stdout, "Here is your login link in case browser did not open %s\n\n", utils.Bold(verificationUrl))
+		if err := RunOpenCmd(ctx, verificationUrl); err != nil {
+			fmt.Fprintln(os.Stderr, "cannot open default browser:", err)
+		}
+	} else {
+		fmt.Fprintf(stdout, "Open this link on any device to approve the login %s\n\n", utils.Bold(verificationUrl))
+	}
+	err = utils.RunProgram(ctx, func(p utils.Program, ctx context.Context) error {
+		p.Send(utils.StatusMsg("Waiting for login to be approved in the browser..."))
+		token, err := pollForDeviceToken(ctx, code)
+		if err != nil {
+			return err
+		}
+		if len(token.RefreshToken) == 0 {
+			return utils.SaveAccessToken(token.AccessToken, params.Fsys)
+		}
+		session := utils.TokenSession{RefreshToken: token.RefreshToken}
+		if token.ExpiresIn > 0 {
+			session.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+		}
+		return utils.SaveTokenSession(token.AccessToken, session, params.Fsys)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, loggedInMsg)
+	return nil
+}
+
+func requestDeviceCode(ctx context.Context, tokenName string) (DeviceCodeResponse, error) {
+	var code DeviceCodeResponse
+	form := url.Values{"token_name": {tokenName}}
+	resp, err := postForm(ctx, utils.GetSupabaseAPIHost()+"/platform/cli/login/device/code", form)
+	if err != nil {
+		return code, fmt.Errorf("cannot request device code: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return code, fmt.Errorf("cannot read device code response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return code, fmt.Errorf("HTTP %s: cannot request device code: %s", resp.Status, body)
+	}
+	if err := json.Unmarshal(body, &code); err != nil {
+		return code, fmt.Errorf("cannot unmarshal device code response: %w", err)
+	}
+	return code, nil
+}
+
+func pollForDeviceToken(ctx context.Context, code DeviceCodeResponse) (DeviceTokenResponse, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval < minPollInterval {
+		interval = minPollInterval
+	}
+	if code.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(code.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+	form := url.Values{
+		"grant_type":  {deviceCodeGrantType},
+		"device_code": {code.DeviceCode},
+	}
+	for {
+		t := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return DeviceTokenResponse{}, ErrDeviceCodeExpired
+			}
+			return DeviceTokenResponse{}, ctx.Err()
+		case <-t.C:
+		}
+		token, err := exchangeDeviceCode(ctx, form)
+		if err != nil {
+			return token, err
+		}
+		switch token.Error {
+		case "":
+			return token, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += slowDownIncrement
+		case "expired_token":
+			return token, ErrDeviceCodeExpired
+		case "access_denied":
+			return token, ErrAccessDenied
+		default:
+			return token, fmt.Errorf("cannot retrieve access token: %s %s", token.Error, token.Description)
+		}
+	}
+}
+
+func exchangeDeviceCode(ctx context.Context, form url.Values) (DeviceTokenResponse, error) {
+	var token DeviceTokenResponse
+	resp, err := postForm(ctx, utils.GetSupabaseAPIHost()+"/platform/cli/login/device/token", form)
+	if err != nil {
+		return token, fmt.Errorf("cannot fetch access token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return token, fmt.Errorf("cannot read access token response body: %w", err)
+	}
+	// Pending errors are returned with 400 status code
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return token, fmt.Errorf("HTTP %s: cannot retrieve access token: %s", resp.Status, body)
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return token, fmt.Errorf("cannot unmarshal access token response: %w", err)
+	}
+	if resp.StatusCode == http.StatusBadRequest && len(token.Error) == 0 {
+		return token, fmt.Errorf("HTTP %s: cannot retrieve access token: %s", resp.Status, body)
+	}
+	return token, nil
+}
+
+func postForm(ctx context.Context, endpoint string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+	return http.DefaultClient.Do(req)
+}
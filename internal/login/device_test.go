@@ -0,0 +1,105 @@
+package login
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/zalando/go-keyring"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestDeviceLogin(t *testing.T) {
+	keyring.MockInit()
+	minPollInterval, slowDownIncrement = 0, 0
+	code := DeviceCodeResponse{
+		DeviceCode:              "device-code",
+		UserCode:                "ABCD-EFGH",
+		VerificationUri:         "https://supabase.com/dashboard/cli/device",
+		VerificationUriComplete: "https://supabase.com/dashboard/cli/device?code=ABCD-EFGH",
+		ExpiresIn:               600,
+	}
+
+	t.Run("polls until login is approved", func(t *testing.T) {
+		token := string(apitest.RandomAccessToken(t))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/platform/cli/login/device/code").
+			BodyString("token_name=test").
+			Reply(http.StatusOK).
+			JSON(code)
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/platform/cli/login/device/token").
+			Reply(http.StatusBadRequest).
+			JSON(DeviceTokenResponse{Error: "authorization_pending"})
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/platform/cli/login/device/token").
+			BodyString("device_code=device-code&grant_type=urn%3Aietf%3Aparams%3Aoauth%3Agrant-type%3Adevice_code").
+			Reply(http.StatusOK).
+			JSON(DeviceTokenResponse{AccessToken: token, TokenType: "bearer"})
+		// Run test
+		err := Run(context.Background(), os.Stdout, RunParams{
+			TokenName:  "test",
+			DeviceFlow: true,
+			Fsys:       afero.NewMemMapFs(),
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		saved, err := credentials.Get(utils.AccessTokenKey)
+		assert.NoError(t, err)
+		assert.Equal(t, token, saved)
+	})
+
+	t.Run("throws error on denied login", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/platform/cli/login/device/token").
+			Reply(http.StatusBadRequest).
+			JSON(DeviceTokenResponse{Error: "slow_down"})
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/platform/cli/login/device/token").
+			Reply(http.StatusBadRequest).
+			JSON(DeviceTokenResponse{Error: "access_denied"})
+		// Run test
+		_, err := pollForDeviceToken(context.Background(), DeviceCodeResponse{DeviceCode: "device-code"})
+		// Check error
+		assert.ErrorIs(t, err, ErrAccessDenied)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on expired code", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/platform/cli/login/device/token").
+			Reply(http.StatusBadRequest).
+			JSON(DeviceTokenResponse{Error: "expired_token"})
+		// Run test
+		_, err := pollForDeviceToken(context.Background(), DeviceCodeResponse{DeviceCode: "device-code"})
+		// Check error
+		assert.ErrorIs(t, err, ErrDeviceCodeExpired)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on server failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.GetSupabaseAPIHost()).
+			Post("/platform/cli/login/device/code").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := requestDeviceCode(context.Background(), "test")
+		// Check error
+		assert.ErrorContains(t, err, "cannot request device code")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -26,6 +26,7 @@ type RunParams struct {
 	TokenName   string
 	OpenBrowser bool
 	SessionId   string
+	DeviceFlow  bool
 	Encryption  LoginEncryptor
 	Fsys        afero.Fs
 }
@@ -177,7 +178,11 @@ func Run(ctx context.Context, stdout *os.File, params RunParams) error {
 		return nil
 	}
 
-	if params.OpenBrowser {
+	if params.DeviceFlow {
+		return runDeviceFlow(ctx, stdout, params)
+	}
+
+	if params.OpenBrowser && !utils.IsNonInteractive() {
 		fmt.Fprint(stdout, "Hello from ", utils.Aqua("Supabase"), "! Press ", utils.Aqua("Enter"), " to open browser and login automatically.\n")
 		fmt.Scanln()
 	}
@@ -0,0 +1,141 @@
+package tail
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	fieldPattern = regexp.MustCompile(`^(?:m|metadata)(?:\.[A-Za-z_][A-Za-z0-9_]*)+$`)
+	rangePattern = regexp.MustCompile(`^(>=|<=|>|<)(.+)$`)
+)
+
+// Translates a Logflare Query Language filter, such as `error m.status_code:>=500 -m.level:info`,
+// to SQL predicates. Timestamp and chart filters are not supported as the time range is set by flags.
+func parseLql(query string, aliases map[string]string) ([]string, error) {
+	tokens, err := splitLql(query)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, token := range tokens {
+		negate := strings.HasPrefix(token, "-") && len(token) > 1
+		if negate {
+			token = token[1:]
+		}
+		var clause string
+		if key, value, found := strings.Cut(token, ":"); found && !strings.HasPrefix(key, `"`) && !strings.HasPrefix(key, "~") {
+			if !fieldPattern.MatchString(key) {
+				return nil, fmt.Errorf("Unsupported LQL filter %s, only metadata fields prefixed by %s are supported.", utils.Aqua(token), utils.Aqua("m."))
+			}
+			if clause, err = fieldClause(resolveField(key, aliases), value); err != nil {
+				return nil, err
+			}
+		} else if pattern, ok := strings.CutPrefix(token, "~"); ok {
+			clause = fmt.Sprintf("regexp_contains(t.event_message, %s)", quoteString(unquote(pattern)))
+		} else {
+			clause = fmt.Sprintf("regexp_contains(t.event_message, %s)", quoteString(regexp.QuoteMeta(unquote(token))))
+		}
+		if negate {
+			clause = "not " + clause
+		}
+		result = append(result, clause)
+	}
+	return result, nil
+}
+
+// Splits on whitespace, keeping double quoted strings together.
+func splitLql(query string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, errors.New("Unterminated quote in LQL query: " + query)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// Replaces the longest metadata path that is unnested by the source query with its alias.
+func resolveField(key string, aliases map[string]string) string {
+	if rest, ok := strings.CutPrefix(key, "metadata."); ok {
+		key = "m." + rest
+	}
+	prefixes := make([]string, 0, len(aliases))
+	for p := range aliases {
+		prefixes = append(prefixes, p)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+	for _, p := range prefixes {
+		if rest, ok := strings.CutPrefix(key, p+"."); ok {
+			return aliases[p] + "." + rest
+		}
+	}
+	return key
+}
+
+func fieldClause(field, value string) (string, error) {
+	if len(value) == 0 {
+		return "", errors.New("Missing value for LQL filter on " + utils.Aqua(field))
+	}
+	if pattern, ok := strings.CutPrefix(value, "~"); ok {
+		return fmt.Sprintf("regexp_contains(cast(%s as string), %s)", field, quoteString(unquote(pattern))), nil
+	}
+	if matches := rangePattern.FindStringSubmatch(value); len(matches) > 0 {
+		return fmt.Sprintf("%s %s %s", field, matches[1], literal(matches[2])), nil
+	}
+	if strings.EqualFold(value, "NULL") {
+		return field + " is null", nil
+	}
+	return fmt.Sprintf("%s = %s", field, literal(value)), nil
+}
+
+// Unquoted numbers and booleans are compared by value, everything else as strings.
+func literal(value string) string {
+	if !strings.HasPrefix(value, `"`) {
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+		if b, err := strconv.ParseBool(value); err == nil {
+			return strconv.FormatBool(b)
+		}
+	}
+	return quoteString(unquote(value))
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// Quotes a string literal for Logflare SQL, which escapes quotes with backslashes.
+func quoteString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+}
@@ -0,0 +1,316 @@
+package tail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+	"github.com/supabase/cli/pkg/api"
+)
+
+type source struct {
+	Table string
+	// Unnests nested metadata so that the level expression can reference it
+	Joins string
+	// Expression that evaluates to the severity of each log event
+	Level string
+	// Maps nested metadata paths in LQL filters to the alias they are unnested as
+	Aliases map[string]string
+}
+
+var (
+	Sources = map[string]source{
+		"api": {
+			Table: "edge_logs",
+			Joins: "cross join unnest(t.metadata) as m cross join unnest(m.response) as r",
+			Level: "case when r.status_code >= 500 then 'error' when r.status_code >= 400 then 'warning' else 'info' end",
+			Aliases: map[string]string{
+				"m.response": "r",
+			},
+		},
+		"auth": {
+			Table: "auth_logs",
+			Joins: "cross join unnest(t.metadata) as m",
+			Level: "m.level",
+		},
+		"db": {
+			Table: "postgres_logs",
+			Joins: "cross join unnest(t.metadata) as m cross join unnest(m.parsed) as p",
+			Level: "p.error_severity",
+			Aliases: map[string]string{
+				"m.parsed": "p",
+			},
+		},
+		"functions": {
+			Table: "function_logs",
+			Joins: "cross join unnest(t.metadata) as m",
+			Level: "m.level",
+		},
+		"realtime": {
+			Table: "realtime_logs",
+			Joins: "cross join unnest(t.metadata) as m",
+			Level: "m.level",
+		},
+		"storage": {
+			Table: "storage_logs",
+			Joins: "cross join unnest(t.metadata) as m",
+			Level: "m.level",
+		},
+	}
+
+	// Interval between queries in follow mode
+	pollInterval = 5 * time.Second
+)
+
+func SourceNames() []string {
+	var names []string
+	for k := range Sources {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type Options struct {
+	Service  string
+	Severity []string
+	// Custom Logflare SQL that replaces the generated query, or LQL that filters it
+	Query  string
+	Since  time.Duration
+	Limit  uint
+	Follow bool
+}
+
+type LogEvent struct {
+	Id        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level,omitempty"`
+	Message   string    `json:"event_message"`
+	// Additional columns selected by a custom query
+	Extra map[string]any `json:"extra,omitempty"`
+}
+
+func Run(ctx context.Context, projectRef string, opts Options) error {
+	// The first query prints the latest events, like tail
+	latest, err := buildQuery(opts, false)
+	if err != nil {
+		return err
+	}
+	t := tailer{
+		projectRef: projectRef,
+		start:      time.Now().Add(-opts.Since),
+		seen:       seenEvents{},
+	}
+	if _, _, err := t.poll(ctx, latest, time.Now()); err != nil {
+		return err
+	}
+	if !opts.Follow {
+		return nil
+	}
+	next, err := buildQuery(opts, true)
+	if err != nil {
+		return err
+	}
+	// Custom SQL is run as is, so its results cannot be paged
+	paged := !isSql(opts.Query)
+	for {
+		t.seen.prune(t.start)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+		end := time.Now()
+		// Pages forward until a short page so that bursts larger than limit are not skipped
+		for {
+			rows, printed, err := t.poll(ctx, next, end)
+			if err != nil {
+				return err
+			}
+			if !paged || rows < int(opts.Limit) || printed == 0 {
+				break
+			}
+		}
+	}
+}
+
+type tailer struct {
+	projectRef string
+	// Timestamp of the last printed event
+	start time.Time
+	seen  seenEvents
+}
+
+// Prints events between start and end that have not been printed, returning the number of rows queried and printed.
+func (t *tailer) poll(ctx context.Context, sql string, end time.Time) (int, int, error) {
+	events, err := Query(ctx, t.projectRef, sql, t.start, end)
+	if err != nil {
+		return 0, 0, err
+	}
+	var printed int
+	for _, e := range events {
+		if _, ok := t.seen[e.Id]; ok {
+			continue
+		}
+		t.seen[e.Id] = e.Timestamp
+		if err := printEvent(e, os.Stdout); err != nil {
+			return 0, 0, err
+		}
+		printed++
+		if e.Timestamp.After(t.start) {
+			t.start = e.Timestamp
+		}
+	}
+	return len(events), printed, nil
+}
+
+// Remembers printed events by id. Only events at the start of the next query window can be returned
+// again, so older entries are evicted to keep memory bounded while following.
+type seenEvents map[string]time.Time
+
+func (s seenEvents) prune(start time.Time) {
+	for id, ts := range s {
+		if ts.Before(start) {
+			delete(s, id)
+		}
+	}
+}
+
+var sqlPattern = regexp.MustCompile(`(?i)^\s*(select|with)(\s|$)`)
+
+func isSql(query string) bool {
+	return sqlPattern.MatchString(query)
+}
+
+// Builds the service query, with the newest events first unless ascending is set.
+func buildQuery(opts Options, ascending bool) (string, error) {
+	if isSql(opts.Query) {
+		if len(opts.Severity) > 0 {
+			return "", errors.New("Severity filters cannot be combined with a custom SQL query.")
+		}
+		return opts.Query, nil
+	}
+	src, ok := Sources[opts.Service]
+	if !ok {
+		return "", fmt.Errorf("Unknown service %s, must be one of: %s", utils.Aqua(opts.Service), strings.Join(SourceNames(), ", "))
+	}
+	sql := fmt.Sprintf("select t.id, t.timestamp, t.event_message, %s as level from %s as t %s", src.Level, src.Table, src.Joins)
+	var where []string
+	if len(opts.Severity) > 0 {
+		levels := make([]string, len(opts.Severity))
+		for i, s := range opts.Severity {
+			levels[i] = quoteString(strings.ToLower(s))
+		}
+		where = append(where, fmt.Sprintf("lower(%s) in (%s)", src.Level, strings.Join(levels, ", ")))
+	}
+	if len(opts.Query) > 0 {
+		filters, err := parseLql(opts.Query, src.Aliases)
+		if err != nil {
+			return "", err
+		}
+		where = append(where, filters...)
+	}
+	if len(where) > 0 {
+		sql += " where " + strings.Join(where, " and ")
+	}
+	order := "desc"
+	if ascending {
+		order = "asc"
+	}
+	return sql + fmt.Sprintf(" order by t.timestamp %s limit %d", order, opts.Limit), nil
+}
+
+// Returns events in chronological order.
+func Query(ctx context.Context, projectRef, sql string, start, end time.Time) ([]LogEvent, error) {
+	startTs := start.UTC().Format(time.RFC3339Nano)
+	endTs := end.UTC().Format(time.RFC3339Nano)
+	resp, err := utils.GetSupabase().GetLogsWithResponse(ctx, projectRef, &api.GetLogsParams{
+		Sql:               &sql,
+		IsoTimestampStart: &startTs,
+		IsoTimestampEnd:   &endTs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, errors.New("Unexpected error querying logs: " + string(resp.Body))
+	}
+	if e := resp.JSON200.Error; e != nil && e.Message != nil {
+		return nil, errors.New("Failed to query logs: " + *e.Message)
+	}
+	var events []LogEvent
+	if resp.JSON200.Result != nil {
+		for _, row := range *resp.JSON200.Result {
+			events = append(events, parseRow(row))
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events, nil
+}
+
+func parseRow(row map[string]any) LogEvent {
+	var event LogEvent
+	for k, v := range row {
+		switch k {
+		case "id":
+			event.Id = fmt.Sprint(v)
+		case "timestamp":
+			// Logflare returns timestamps as microseconds since epoch
+			if ts, ok := v.(float64); ok {
+				event.Timestamp = time.UnixMicro(int64(ts)).UTC()
+			}
+		case "level":
+			event.Level = strings.ToLower(fmt.Sprint(v))
+		case "event_message":
+			event.Message = fmt.Sprint(v)
+		default:
+			if event.Extra == nil {
+				event.Extra = map[string]any{}
+			}
+			event.Extra[k] = v
+		}
+	}
+	// Custom queries may not select an id column
+	if len(event.Id) == 0 {
+		data, _ := json.Marshal(row)
+		event.Id = string(data)
+	}
+	return event
+}
+
+func printEvent(event LogEvent, w io.Writer) error {
+	if render.Format.Value == utils.OutputJson {
+		// One object per line so that output can be piped to jq
+		return json.NewEncoder(w).Encode(event)
+	} else if !render.IsPretty() {
+		return utils.EncodeOutput(render.Format.Value, w, event)
+	}
+	level := event.Level
+	switch level {
+	case "error", "fatal", "panic":
+		level = utils.Red(level)
+	case "warning", "warn":
+		level = utils.Yellow(level)
+	}
+	line := fmt.Sprintf("%s %s %s", utils.Bold(event.Timestamp.Format(time.RFC3339)), level, event.Message)
+	if len(event.Extra) > 0 {
+		extra, err := json.Marshal(event.Extra)
+		if err != nil {
+			return err
+		}
+		line += " " + string(extra)
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
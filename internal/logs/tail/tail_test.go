@@ -0,0 +1,160 @@
+package tail
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestBuildQuery(t *testing.T) {
+	t.Run("filters by severity", func(t *testing.T) {
+		sql, err := buildQuery(Options{Service: "db", Severity: []string{"ERROR", "warning"}, Limit: 10}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, "select t.id, t.timestamp, t.event_message, p.error_severity as level from postgres_logs as t "+
+			"cross join unnest(t.metadata) as m cross join unnest(m.parsed) as p "+
+			"where lower(p.error_severity) in ('error', 'warning') order by t.timestamp desc limit 10", sql)
+	})
+
+	t.Run("uses custom query", func(t *testing.T) {
+		sql, err := buildQuery(Options{Service: "unknown", Query: "select 1"}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "select 1", sql)
+	})
+
+	t.Run("filters by lql", func(t *testing.T) {
+		sql, err := buildQuery(Options{Service: "api", Query: `m.response.status_code:>=500 -"health check" m.request.method:~"^P"`, Limit: 10}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "select t.id, t.timestamp, t.event_message, "+Sources["api"].Level+" as level from edge_logs as t "+
+			"cross join unnest(t.metadata) as m cross join unnest(m.response) as r "+
+			"where r.status_code >= 500 and not regexp_contains(t.event_message, 'health check') "+
+			"and regexp_contains(cast(m.request.method as string), '^P') order by t.timestamp asc limit 10", sql)
+	})
+
+	t.Run("throws error on unsupported lql", func(t *testing.T) {
+		_, err := buildQuery(Options{Service: "db", Query: "t:today"}, false)
+		assert.ErrorContains(t, err, "Unsupported LQL filter")
+	})
+
+	t.Run("throws error on severity with custom query", func(t *testing.T) {
+		_, err := buildQuery(Options{Query: "select 1", Severity: []string{"error"}}, false)
+		assert.ErrorContains(t, err, "Severity filters cannot be combined with a custom SQL query.")
+	})
+
+	t.Run("throws error on unknown service", func(t *testing.T) {
+		_, err := buildQuery(Options{Service: "kong"}, false)
+		assert.ErrorContains(t, err, "Unknown service")
+	})
+}
+
+func TestSeenEvents(t *testing.T) {
+	now := time.Now()
+	seen := seenEvents{"old": now.Add(-time.Second), "current": now}
+	// Run test
+	seen.prune(now)
+	// Check output
+	assert.Equal(t, seenEvents{"current": now}, seen)
+}
+
+func TestTailLogs(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	projectRef := apitest.RandomProjectRef()
+
+	t.Run("queries logs in chronological order", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+projectRef+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "from auth_logs").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"result": []map[string]any{
+				{"id": "b", "timestamp": 1700000001000000, "event_message": "second", "level": "error"},
+				{"id": "a", "timestamp": 1700000000000000, "event_message": "first", "level": "info"},
+			}})
+		// Run test
+		events, err := Query(context.Background(), projectRef, "select * from auth_logs", time.Now().Add(-time.Hour), time.Now())
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, "first", events[0].Message)
+		assert.Equal(t, "error", events[1].Level)
+		assert.Equal(t, time.UnixMicro(1700000001000000).UTC(), events[1].Timestamp)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("pages forward in follow mode", func(t *testing.T) {
+		interval := pollInterval
+		pollInterval = 100 * time.Millisecond
+		defer func() { pollInterval = interval }()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+projectRef+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "desc limit 2").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"result": []map[string]any{}})
+		page := gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+projectRef+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "asc limit 2").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"result": []map[string]any{
+				{"id": "a", "timestamp": 1700000000000000, "event_message": "first"},
+				{"id": "b", "timestamp": 1700000001000000, "event_message": "second"},
+			}})
+		next := gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+projectRef+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "asc limit 2").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"result": []map[string]any{
+				{"id": "c", "timestamp": 1700000002000000, "event_message": "third"},
+			}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/analytics/endpoints/logs.all").
+			Persist().
+			Reply(http.StatusOK).
+			JSON(map[string]any{"result": []map[string]any{}})
+		// Stops before the second poll interval elapses
+		ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+		defer cancel()
+		// Run test
+		err := Run(ctx, projectRef, Options{Service: "auth", Limit: 2, Follow: true})
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, page.Done())
+		assert.True(t, next.Done())
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/analytics/endpoints/logs.all").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"error": map[string]any{"message": "syntax error"}})
+		// Run test
+		err := Run(context.Background(), projectRef, Options{Query: "select"})
+		// Check error
+		assert.ErrorContains(t, err, "Failed to query logs: syntax error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("prints events as json lines", func(t *testing.T) {
+		render.Format.Value = utils.OutputJson
+		defer func() { render.Format.Value = utils.OutputPretty }()
+		var out bytes.Buffer
+		// Run test
+		err := printEvent(LogEvent{Id: "a", Message: "hello"}, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"a","timestamp":"0001-01-01T00:00:00Z","event_message":"hello"}`+"\n", out.String())
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/repair"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/fanout"
 )
 
 func MigrateAndSeed(ctx context.Context, version string, conn *pgx.Conn, fsys afero.Fs) error {
@@ -33,7 +34,7 @@ func SeedDatabase(ctx context.Context, conn *pgx.Conn, fsys afero.Fs) error {
 	} else if err != nil {
 		return err
 	}
-	fmt.Fprintln(os.Stderr, "Seeding data "+utils.Bold(utils.SeedDataPath)+"...")
+	fmt.Fprintln(fanout.Stderr(ctx), "Seeding data "+utils.Bold(utils.SeedDataPath)+"...")
 	// Batch seed commands, safe to use statement cache
 	return seed.ExecBatchWithCache(ctx, conn)
 }
@@ -53,7 +54,7 @@ func MigrateUp(ctx context.Context, conn *pgx.Conn, pending []string, fsys afero
 }
 
 func applyMigration(ctx context.Context, conn *pgx.Conn, filename string, fsys afero.Fs) error {
-	fmt.Fprintln(os.Stderr, "Applying migration "+utils.Bold(filename)+"...")
+	fmt.Fprintln(fanout.Stderr(ctx), "Applying migration "+utils.Bold(filename)+"...")
 	path := filepath.Join(utils.MigrationsDir, filename)
 	migration, err := repair.NewMigrationFromFile(path, fsys)
 	if err != nil {
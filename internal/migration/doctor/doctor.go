@@ -0,0 +1,123 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+// Divergence between local migration files and the remote history table.
+type Diagnosis struct {
+	// Versions applied on remote without a local migration file
+	RemoteOnly []string `json:"remote_only"`
+	// Local versions older than the last remote version that were never applied
+	OutOfOrder []string `json:"out_of_order"`
+	// Local versions newer than the last remote version
+	Pending []string `json:"pending"`
+}
+
+func (d Diagnosis) IsDiverged() bool {
+	return len(d.RemoteOnly) > 0 || len(d.OutOfOrder) > 0
+}
+
+func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	remoteVersions, err := list.LoadRemoteMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	localVersions, err := list.LoadLocalVersions(fsys)
+	if err != nil {
+		return err
+	}
+	result := Diagnose(remoteVersions, localVersions)
+	if !render.IsPretty() {
+		return render.Encode(result)
+	}
+	PrintPlan(result, os.Stdout)
+	return nil
+}
+
+// Compares sorted remote and local versions.
+func Diagnose(remoteVersions, localVersions []string) Diagnosis {
+	result := Diagnosis{
+		RemoteOnly: []string{},
+		OutOfOrder: []string{},
+		Pending:    []string{},
+	}
+	for _, v := range remoteVersions {
+		if !utils.SliceContains(localVersions, v) {
+			result.RemoteOnly = append(result.RemoteOnly, v)
+		}
+	}
+	last := ""
+	if n := len(remoteVersions); n > 0 {
+		last = remoteVersions[n-1]
+	}
+	for _, v := range localVersions {
+		if utils.SliceContains(remoteVersions, v) {
+			continue
+		}
+		// Versions share the same timestamp format so lexical order is chronological
+		if len(v) < len(last) || (len(v) == len(last) && v < last) {
+			result.OutOfOrder = append(result.OutOfOrder, v)
+		} else {
+			result.Pending = append(result.Pending, v)
+		}
+	}
+	return result
+}
+
+// Explains the divergence and prints commands that reconcile local and remote history.
+func PrintPlan(d Diagnosis, w io.Writer) {
+	if !d.IsDiverged() {
+		fmt.Fprintln(w, "Local and remote migration history are in sync.")
+		if len(d.Pending) > 0 {
+			fmt.Fprintf(w, "\n%d local migration(s) are pending. Apply them with:\n", len(d.Pending))
+			fmt.Fprintln(w, utils.Bold("supabase db push"))
+		}
+		return
+	}
+	step := 0
+	next := func() int {
+		step++
+		return step
+	}
+	if len(d.RemoteOnly) > 0 {
+		fmt.Fprintf(w, "Found %d migration(s) applied to the remote database but missing from %s:\n", len(d.RemoteOnly), utils.Bold(utils.MigrationsDir))
+		fmt.Fprintln(w, "  "+strings.Join(d.RemoteOnly, "\n  "))
+	}
+	if len(d.OutOfOrder) > 0 {
+		fmt.Fprintf(w, "Found %d local migration(s) older than the last remote migration that were never applied:\n", len(d.OutOfOrder))
+		fmt.Fprintln(w, "  "+strings.Join(d.OutOfOrder, "\n  "))
+	}
+	fmt.Fprintln(w, "\nSuggested fix:")
+	if len(d.RemoteOnly) > 0 {
+		fmt.Fprintf(w, "%d. Pull remote-only migrations into local files:\n", next())
+		fmt.Fprintln(w, "   "+utils.Bold("supabase migration fetch"))
+		fmt.Fprintln(w, "   Or, if these migrations were rolled back manually, remove them from the remote history:")
+		fmt.Fprintln(w, "   "+utils.Bold("supabase migration repair --status reverted "+strings.Join(d.RemoteOnly, " ")))
+	}
+	if len(d.OutOfOrder) > 0 {
+		fmt.Fprintf(w, "%d. Apply out-of-order local migrations:\n", next())
+		fmt.Fprintln(w, "   "+utils.Bold("supabase db push --include-all"))
+		fmt.Fprintln(w, "   Or, if their changes already exist on the remote database, mark them as applied:")
+		fmt.Fprintln(w, "   "+utils.Bold("supabase migration repair --status applied "+strings.Join(d.OutOfOrder, " ")))
+	} else if len(d.Pending) > 0 {
+		fmt.Fprintf(w, "%d. Apply pending local migrations:\n", next())
+		fmt.Fprintln(w, "   "+utils.Bold("supabase db push"))
+	}
+}
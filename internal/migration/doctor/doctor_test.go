@@ -0,0 +1,82 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestDiagnose(t *testing.T) {
+	t.Run("detects in sync history", func(t *testing.T) {
+		result := Diagnose([]string{"1", "2"}, []string{"1", "2", "3"})
+		// Check result
+		assert.False(t, result.IsDiverged())
+		assert.Equal(t, []string{"3"}, result.Pending)
+	})
+
+	t.Run("detects diverged history", func(t *testing.T) {
+		result := Diagnose(
+			[]string{"20230101000000", "20230103000000", "20230105000000"},
+			[]string{"20230101000000", "20230102000000", "20230105000000", "20230106000000"},
+		)
+		// Check result
+		assert.True(t, result.IsDiverged())
+		assert.Equal(t, []string{"20230103000000"}, result.RemoteOnly)
+		assert.Equal(t, []string{"20230102000000"}, result.OutOfOrder)
+		assert.Equal(t, []string{"20230106000000"}, result.Pending)
+	})
+
+	t.Run("prints fix plan", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		PrintPlan(Diagnose([]string{"1", "3"}, []string{"2"}), &out)
+		// Check output
+		assert.Contains(t, out.String(), "supabase migration fetch")
+		assert.Contains(t, out.String(), "supabase migration repair --status reverted 1 3")
+		assert.Contains(t, out.String(), "supabase migration repair --status applied 2")
+	})
+}
+
+func TestDoctorCommand(t *testing.T) {
+	t.Run("diagnoses migration history", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(""), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(list.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"1"})
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on connect failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), pgconn.Config{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "invalid port (outside range)")
+	})
+}
@@ -0,0 +1,117 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const LIST_MIGRATION_HISTORY = "SELECT version, coalesce(name, '') AS name, coalesce(statements, '{}') AS statements FROM supabase_migrations.schema_migrations ORDER BY version"
+
+// Remote history is untrusted input, so names must not contain path separators.
+var (
+	versionPattern = regexp.MustCompile(`^[0-9]+$`)
+	namePattern    = regexp.MustCompile(`^[[:word:]-]*$`)
+)
+
+type RemoteMigration struct {
+	Version    string
+	Name       string
+	Statements []string
+}
+
+// Writes migrations found only in the remote history table to local migration files.
+func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	remoteMigrations, err := LoadRemoteHistory(ctx, conn)
+	if err != nil {
+		return err
+	}
+	localVersions, err := list.LoadLocalVersions(fsys)
+	if err != nil {
+		return err
+	}
+	count := 0
+	for _, m := range remoteMigrations {
+		if utils.SliceContains(localVersions, m.Version) {
+			continue
+		}
+		path, err := WriteMigration(m, fsys)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Fetched migration:", utils.Bold(path))
+		count++
+	}
+	if count == 0 {
+		fmt.Fprintln(os.Stderr, "Local migrations are up to date.")
+	}
+	return nil
+}
+
+func LoadRemoteHistory(ctx context.Context, conn *pgx.Conn) ([]RemoteMigration, error) {
+	rows, err := conn.Query(ctx, LIST_MIGRATION_HISTORY)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []RemoteMigration
+	for rows.Next() {
+		var m RemoteMigration
+		if err := rows.Scan(&m.Version, &m.Name, &m.Statements); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	if err := rows.Err(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UndefinedTable {
+			// If migration history table is undefined, the remote project has no migrations
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func WriteMigration(m RemoteMigration, fsys afero.Fs) (string, error) {
+	if !versionPattern.MatchString(m.Version) || !namePattern.MatchString(m.Name) {
+		return "", fmt.Errorf("Invalid remote migration name: %s_%s", m.Version, m.Name)
+	}
+	name := m.Name
+	if len(name) == 0 {
+		name = "remote_migration"
+	}
+	path := filepath.Join(utils.MigrationsDir, m.Version+"_"+name+".sql")
+	if err := utils.MkdirIfNotExistFS(fsys, utils.MigrationsDir); err != nil {
+		return "", err
+	}
+	var sql strings.Builder
+	if len(m.Statements) == 0 {
+		// Versions recorded by older CLI releases do not keep their statements
+		sql.WriteString("-- Statements of this migration were not recorded in the remote history table.\n")
+	}
+	for _, line := range m.Statements {
+		sql.WriteString(line)
+		sql.WriteString(";\n\n")
+	}
+	if err := afero.WriteFile(fsys, path, []byte(sql.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write migration: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,110 @@
+package fetch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestFetchCommand(t *testing.T) {
+	t.Run("writes remote only migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		local := filepath.Join(utils.MigrationsDir, "0_init.sql")
+		require.NoError(t, afero.WriteFile(fsys, local, []byte("select 0"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LIST_MIGRATION_HISTORY).
+			Reply("SELECT 3",
+				[]interface{}{"0", "init", []string{"select 0"}},
+				[]interface{}{"1", "create_table", []string{"create table t (id int)", "select 1"}},
+				[]interface{}{"2", "", []string{}},
+			)
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, filepath.Join(utils.MigrationsDir, "1_create_table.sql"))
+		assert.NoError(t, err)
+		assert.Equal(t, "create table t (id int);\n\nselect 1;\n\n", string(contents))
+		exists, err := afero.Exists(fsys, filepath.Join(utils.MigrationsDir, "2_remote_migration.sql"))
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		// Local migration is left untouched
+		contents, err = afero.ReadFile(fsys, local)
+		assert.NoError(t, err)
+		assert.Equal(t, "select 0", string(contents))
+	})
+
+	t.Run("ignores missing history table", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LIST_MIGRATION_HISTORY).
+			ReplyError(pgerrcode.UndefinedTable, `relation "supabase_migrations.schema_migrations" does not exist`)
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on connect failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), pgconn.Config{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "invalid port (outside range)")
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, fsys.MkdirAll(utils.MigrationsDir, 0755))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LIST_MIGRATION_HISTORY).
+			Reply("SELECT 1", []interface{}{"1", "test", []string{"select 1"}})
+		// Run test
+		err := Run(context.Background(), dbConfig, afero.NewReadOnlyFs(fsys), conn.Intercept)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrPermission)
+	})
+	t.Run("throws error on path traversal", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LIST_MIGRATION_HISTORY).
+			Reply("SELECT 1", []interface{}{"1", "../../../etc/cron.d/test", []string{"select 1"}})
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid remote migration name")
+		exists, err := afero.DirExists(fsys, "/etc")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
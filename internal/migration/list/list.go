@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"regexp"
@@ -16,6 +17,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
 )
 
 const LIST_MIGRATION_VERSION = "SELECT version FROM supabase_migrations.schema_migrations ORDER BY version"
@@ -27,10 +29,13 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
-	localVersions, err := loadLocalVersions(fsys)
+	localVersions, err := LoadLocalVersions(fsys)
 	if err != nil {
 		return err
 	}
+	if !render.IsPretty() {
+		return render.Encode(makeRows(remoteVersions, localVersions))
+	}
 	table := makeTable(remoteVersions, localVersions)
 	return RenderTable(table)
 }
@@ -78,9 +83,16 @@ func formatTimestamp(version string) string {
 	return timestamp.Format(layoutHuman)
 }
 
-func makeTable(remoteMigrations, localMigrations []string) string {
+// MigrationStatus is a row in the output of migration list.
+type MigrationStatus struct {
+	Local  string `json:"local,omitempty" yaml:"local,omitempty" toml:"local,omitempty"`
+	Remote string `json:"remote,omitempty" yaml:"remote,omitempty" toml:"remote,omitempty"`
+	Time   string `json:"time" yaml:"time" toml:"time"`
+}
+
+func makeRows(remoteMigrations, localMigrations []string) []MigrationStatus {
 	var err error
-	table := "|Local|Remote|Time (UTC)|\n|-|-|-|\n"
+	rows := []MigrationStatus{}
 	for i, j := 0, 0; i < len(remoteMigrations) || j < len(localMigrations); {
 		remoteTimestamp := math.MaxInt
 		if i < len(remoteMigrations) {
@@ -98,21 +110,40 @@ func makeTable(remoteMigrations, localMigrations []string) string {
 		}
 		// Top to bottom chronological order
 		if localTimestamp < remoteTimestamp {
-			table += fmt.Sprintf("|`%s`|` `|`%s`|\n", localMigrations[j], formatTimestamp(localMigrations[j]))
+			rows = append(rows, MigrationStatus{Local: localMigrations[j], Time: formatTimestamp(localMigrations[j])})
 			j++
 		} else if remoteTimestamp < localTimestamp {
-			table += fmt.Sprintf("|` `|`%s`|`%s`|\n", remoteMigrations[i], formatTimestamp(remoteMigrations[i]))
+			rows = append(rows, MigrationStatus{Remote: remoteMigrations[i], Time: formatTimestamp(remoteMigrations[i])})
 			i++
 		} else {
-			table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", localMigrations[j], remoteMigrations[i], formatTimestamp(remoteMigrations[i]))
+			rows = append(rows, MigrationStatus{Local: localMigrations[j], Remote: remoteMigrations[i], Time: formatTimestamp(remoteMigrations[i])})
 			i++
 			j++
 		}
 	}
+	return rows
+}
+
+func makeTable(remoteMigrations, localMigrations []string) string {
+	table := "|Local|Remote|Time (UTC)|\n|-|-|-|\n"
+	for _, r := range makeRows(remoteMigrations, localMigrations) {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", orBlank(r.Local), orBlank(r.Remote), r.Time)
+	}
 	return table
 }
 
+func orBlank(version string) string {
+	if len(version) == 0 {
+		return " "
+	}
+	return version
+}
+
 func RenderTable(markdown string) error {
+	return RenderTableTo(os.Stdout, markdown)
+}
+
+func RenderTableTo(w io.Writer, markdown string) error {
 	r, err := glamour.NewTermRenderer(
 		glamour.WithAutoStyle(),
 		glamour.WithWordWrap(-1),
@@ -124,11 +155,11 @@ func RenderTable(markdown string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Print(out)
-	return nil
+	_, err = io.WriteString(w, out)
+	return err
 }
 
-func loadLocalVersions(fsys afero.Fs) ([]string, error) {
+func LoadLocalVersions(fsys afero.Fs) ([]string, error) {
 	names, err := LoadLocalMigrations(fsys)
 	if err != nil {
 		return nil, err
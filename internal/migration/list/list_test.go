@@ -131,7 +131,7 @@ func TestLocalMigrations(t *testing.T) {
 		path = filepath.Join(utils.MigrationsDir, "20220727064248_test.sql")
 		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
 		// Run test
-		versions, err := loadLocalVersions(fsys)
+		versions, err := LoadLocalVersions(fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.ElementsMatch(t, []string{"20220727064246", "20220727064248"}, versions)
@@ -145,7 +145,7 @@ func TestLocalMigrations(t *testing.T) {
 		path = filepath.Join(utils.MigrationsDir, "20211208000001_invalid.ts")
 		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
 		// Run test
-		versions, err := loadLocalVersions(fsys)
+		versions, err := LoadLocalVersions(fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, versions)
@@ -155,7 +155,7 @@ func TestLocalMigrations(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		_, err := loadLocalVersions(afero.NewReadOnlyFs(fsys))
+		_, err := LoadLocalVersions(afero.NewReadOnlyFs(fsys))
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 	})
@@ -164,7 +164,7 @@ func TestLocalMigrations(t *testing.T) {
 		// Setup in-memory fs
 		fsys := MockFs{DenyPath: utils.MigrationsDir}
 		// Run test
-		_, err := loadLocalVersions(&fsys)
+		_, err := LoadLocalVersions(&fsys)
 		// Check error
 		assert.ErrorContains(t, err, "permission denied")
 	})
@@ -210,3 +210,16 @@ func TestMakeTable(t *testing.T) {
 		}, lines)
 	})
 }
+
+func TestMakeRows(t *testing.T) {
+	t.Run("omits missing versions", func(t *testing.T) {
+		// Run test
+		rows := makeRows([]string{"20220727064246", "20220727064248"}, []string{"20220727064246", "20220727064247"})
+		// Check output
+		assert.Equal(t, []MigrationStatus{
+			{Local: "20220727064246", Remote: "20220727064246", Time: "2022-07-27 06:42:46"},
+			{Local: "20220727064247", Time: "2022-07-27 06:42:47"},
+			{Remote: "20220727064248", Time: "2022-07-27 06:42:48"},
+		}, rows)
+	})
+}
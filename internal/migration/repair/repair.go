@@ -34,9 +34,11 @@ const (
 
 var ErrInvalidVersion = errors.New("invalid version number")
 
-func Run(ctx context.Context, config pgconn.Config, version, status string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	if _, err := strconv.Atoi(version); err != nil {
-		return ErrInvalidVersion
+func Run(ctx context.Context, config pgconn.Config, versions []string, status string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	for _, v := range versions {
+		if _, err := strconv.Atoi(v); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidVersion, v)
+		}
 	}
 	conn, err := utils.ConnectByConfig(ctx, config, options...)
 	if err != nil {
@@ -44,24 +46,29 @@ func Run(ctx context.Context, config pgconn.Config, version, status string, fsys
 	}
 	defer conn.Close(context.Background())
 	// Update migration history
-	if err := UpdateMigrationTable(ctx, conn, version, status, fsys); err != nil {
+	if err := UpdateMigrationTable(ctx, conn, versions, status, fsys); err != nil {
 		return err
 	}
-	fmt.Fprintln(os.Stderr, "Repaired migration history:", version, "=>", status)
+	for _, v := range versions {
+		fmt.Fprintln(os.Stderr, "Repaired migration history:", v, "=>", status)
+	}
 	return nil
 }
 
-func UpdateMigrationTable(ctx context.Context, conn *pgx.Conn, version, status string, fsys afero.Fs) error {
+// Updates all versions in a single batch, which is implicitly transactional.
+func UpdateMigrationTable(ctx context.Context, conn *pgx.Conn, versions []string, status string, fsys afero.Fs) error {
 	batch := batchCreateTable()
-	switch status {
-	case Applied:
-		f, err := NewMigrationFromVersion(version, fsys)
-		if err != nil {
-			return err
+	for _, v := range versions {
+		switch status {
+		case Applied:
+			f, err := NewMigrationFromVersion(v, fsys)
+			if err != nil {
+				return err
+			}
+			InsertVersionSQL(&batch, f.Version, f.Name, f.Lines)
+		case Reverted:
+			DeleteVersionSQL(&batch, v)
 		}
-		InsertVersionSQL(&batch, f.Version, f.Name, f.Lines)
-	case Reverted:
-		DeleteVersionSQL(&batch, version)
 	}
 	_, err := conn.PgConn().ExecBatch(ctx, &batch).ReadAll()
 	return err
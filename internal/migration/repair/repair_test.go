@@ -47,7 +47,7 @@ func TestRepairCommand(t *testing.T) {
 			Query(INSERT_MIGRATION_VERSION, "0", "test", "{}").
 			Reply("INSERT 0 1")
 		// Run test
-		err := Run(context.Background(), dbConfig, "0", Applied, fsys, conn.Intercept)
+		err := Run(context.Background(), dbConfig, []string{"0"}, Applied, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 	})
@@ -69,16 +69,49 @@ func TestRepairCommand(t *testing.T) {
 			Query(DELETE_MIGRATION_VERSION, "0").
 			Reply("DELETE 1")
 		// Run test
-		err := Run(context.Background(), dbConfig, "0", Reverted, fsys, conn.Intercept)
+		err := Run(context.Background(), dbConfig, []string{"0"}, Reverted, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 	})
 
+	t.Run("reverts multiple versions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(CREATE_VERSION_SCHEMA).
+			Reply("CREATE SCHEMA").
+			Query(CREATE_VERSION_TABLE).
+			Reply("CREATE TABLE").
+			Query(ADD_STATEMENTS_COLUMN).
+			Reply("ALTER TABLE").
+			Query(ADD_NAME_COLUMN).
+			Reply("ALTER TABLE").
+			Query(DELETE_MIGRATION_VERSION, "0").
+			Reply("DELETE 1").
+			Query(DELETE_MIGRATION_VERSION, "1").
+			Reply("DELETE 1")
+		// Run test
+		err := Run(context.Background(), dbConfig, []string{"0", "1"}, Reverted, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on invalid version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), dbConfig, []string{"0", "abc"}, Applied, fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
 	t.Run("throws error on connect failure", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), pgconn.Config{}, "0", Applied, fsys)
+		err := Run(context.Background(), pgconn.Config{}, []string{"0"}, Applied, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "invalid port (outside range)")
 	})
@@ -102,7 +135,7 @@ func TestRepairCommand(t *testing.T) {
 			Query(INSERT_MIGRATION_VERSION, "0", "test", "{}").
 			ReplyError(pgerrcode.DuplicateObject, `relation "supabase_migrations.schema_migrations" does not exist`)
 		// Run test
-		err := Run(context.Background(), dbConfig, "0", Applied, fsys, conn.Intercept)
+		err := Run(context.Background(), dbConfig, []string{"0"}, Applied, fsys, conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, `ERROR: relation "supabase_migrations.schema_migrations" does not exist (SQLSTATE 42710)`)
 	})
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
@@ -16,16 +18,26 @@ import (
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/repair"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/parser"
 )
 
-var ErrMissingVersion = errors.New("version not found")
+var (
+	ErrMissingVersion = errors.New("version not found")
+	// Squashed migration files are moved here instead of being deleted
+	ArchiveDir = filepath.Join(utils.SupabaseDirPath, "archive", "migrations")
 
-func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	if len(version) > 0 {
-		if _, err := strconv.Atoi(version); err != nil {
+	dataPattern = regexp.MustCompile(`(?i)^(INSERT|UPDATE|DELETE|COPY)\s`)
+)
+
+func Run(ctx context.Context, version, from string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	for _, v := range []string{version, from} {
+		if len(v) == 0 {
+			continue
+		}
+		if _, err := strconv.Atoi(v); err != nil {
 			return repair.ErrInvalidVersion
 		}
-		if _, err := repair.GetMigrationFile(version, fsys); err != nil {
+		if _, err := repair.GetMigrationFile(v, fsys); err != nil {
 			return err
 		}
 	}
@@ -33,21 +45,39 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 		return err
 	}
 	// 1. Squash local migrations
-	if err := squashToVersion(ctx, version, fsys, options...); err != nil {
+	if err := squashToVersion(ctx, version, from, fsys, options...); err != nil {
 		return err
 	}
 	// 2. Update migration history
 	if utils.IsLoopback(config.Host) || !utils.PromptYesNo("Update remote migration history table?", true, os.Stdin) {
 		return nil
 	}
+	if len(from) > 0 {
+		return rewriteMigrations(ctx, config, from, version, fsys, options...)
+	}
 	return baselineMigrations(ctx, config, version, fsys, options...)
 }
 
-func squashToVersion(ctx context.Context, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func squashToVersion(ctx context.Context, version, from string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	migrations, err := list.LoadPartialMigrations(version, fsys)
 	if err != nil {
 		return err
 	}
+	// Migrations before the starting version are replayed but left untouched
+	var base []string
+	if len(from) > 0 {
+		index := -1
+		for i, name := range migrations {
+			if strings.HasPrefix(name, from+"_") {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return ErrMissingVersion
+		}
+		base, migrations = migrations[:index], migrations[index:]
+	}
 	if len(migrations) == 0 {
 		return ErrMissingVersion
 	}
@@ -57,17 +87,29 @@ func squashToVersion(ctx context.Context, version string, fsys afero.Fs, options
 		fmt.Fprintln(os.Stderr, utils.Bold(path), "is already the earliest migration.")
 		return nil
 	}
-	if err := squashMigrations(ctx, migrations, fsys, options...); err != nil {
+	if err := warnDataStatements(migrations, fsys); err != nil {
+		return err
+	}
+	if len(base) > 0 {
+		err = diffMigrations(ctx, base, migrations, fsys, options...)
+	} else {
+		err = squashMigrations(ctx, migrations, fsys, options...)
+	}
+	if err != nil {
 		return err
 	}
 	fmt.Fprintln(os.Stderr, "Squashed local migrations to", utils.Bold(path))
-	// Remove merged files
+	// Archive merged files
+	if err := utils.MkdirIfNotExistFS(fsys, ArchiveDir); err != nil {
+		return err
+	}
 	for _, name := range migrations[:len(migrations)-1] {
 		path := filepath.Join(utils.MigrationsDir, name)
-		if err := fsys.Remove(path); err != nil {
+		if err := fsys.Rename(path, filepath.Join(ArchiveDir, name)); err != nil {
 			fmt.Fprintln(os.Stderr, err)
 		}
 	}
+	fmt.Fprintln(os.Stderr, "Archived", len(migrations)-1, "migration files to", utils.Bold(ArchiveDir))
 	return nil
 }
 
@@ -98,6 +140,39 @@ func squashMigrations(ctx context.Context, migrations []string, fsys afero.Fs, o
 	return dump.DumpSchema(ctx, config, nil, false, false, f)
 }
 
+// Replays base migrations into a shadow database, then generates the schema changes made by the
+// remaining migrations as a single file.
+func diffMigrations(ctx context.Context, base, migrations []string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	out, err := diff.DiffMigrationRange(ctx, base, migrations, os.Stderr, fsys, options...)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(utils.MigrationsDir, migrations[len(migrations)-1])
+	return afero.WriteFile(fsys, path, []byte(out), 0644)
+}
+
+// Data statements are not captured by schema dumps, so they must be moved out before squashing.
+func warnDataStatements(migrations []string, fsys afero.Fs) error {
+	for _, name := range migrations {
+		f, err := fsys.Open(filepath.Join(utils.MigrationsDir, name))
+		if err != nil {
+			return err
+		}
+		stats, err := parser.SplitAndTrim(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		for _, stat := range stats {
+			if dataPattern.MatchString(stat) {
+				fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Data statements in", utils.Bold(name), "will not be kept in the squashed migration. Move them to", utils.Bold(utils.SeedDataPath), "before squashing.")
+				break
+			}
+		}
+	}
+	return nil
+}
+
 const DELETE_MIGRATION_BEFORE = "DELETE FROM supabase_migrations.schema_migrations WHERE version <= $1"
 
 func baselineMigrations(ctx context.Context, config pgconn.Config, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -128,3 +203,35 @@ func baselineMigrations(ctx context.Context, config pgconn.Config, version strin
 	batch.Queue(repair.INSERT_MIGRATION_VERSION, m.Version, m.Name, m.Lines)
 	return conn.SendBatch(ctx, &batch).Close()
 }
+
+const DELETE_MIGRATION_RANGE = "DELETE FROM supabase_migrations.schema_migrations WHERE version >= $1 AND version <= $2"
+
+// Replaces remote history of squashed versions with the single squashed migration.
+func rewriteMigrations(ctx context.Context, config pgconn.Config, from, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	migrations, err := list.LoadPartialMigrations(version, fsys)
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		return ErrMissingVersion
+	}
+	// Squashed file always takes the last version
+	last := utils.MigrateFilePattern.FindStringSubmatch(migrations[len(migrations)-1])[1]
+	fmt.Fprintf(os.Stderr, "Rewriting migration history from %s to %s\n", from, last)
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := repair.CreateMigrationTable(ctx, conn); err != nil {
+		return err
+	}
+	m, err := repair.NewMigrationFromVersion(last, fsys)
+	if err != nil {
+		return err
+	}
+	batch := pgx.Batch{}
+	batch.Queue(DELETE_MIGRATION_RANGE, from, m.Version)
+	batch.Queue(repair.INSERT_MIGRATION_VERSION, m.Version, m.Name, m.Lines)
+	return conn.SendBatch(ctx, &batch).Close()
+}
@@ -72,14 +72,17 @@ func TestSquashCommand(t *testing.T) {
 			Query(repair.INSERT_MIGRATION_VERSION, "1", "target", "{}").
 			Reply("INSERT 1")
 		// Run test
-		err := Run(context.Background(), "", pgconn.Config{Host: "127.0.0.1"}, fsys, conn.Intercept)
+		err := Run(context.Background(), "", "", pgconn.Config{Host: "127.0.0.1"}, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 		exists, err := afero.Exists(fsys, paths[0])
 		assert.NoError(t, err)
 		assert.False(t, exists)
-		match, err := afero.FileContainsBytes(fsys, paths[1], []byte(sql))
+		match, err := afero.FileContainsBytes(fsys, filepath.Join(ArchiveDir, "0_init.sql"), []byte(sql))
+		assert.NoError(t, err)
+		assert.True(t, match)
+		match, err = afero.FileContainsBytes(fsys, paths[1], []byte(sql))
 		assert.NoError(t, err)
 		assert.True(t, match)
 	})
@@ -105,7 +108,7 @@ func TestSquashCommand(t *testing.T) {
 			Query(fmt.Sprintf("DELETE FROM supabase_migrations.schema_migrations WHERE version <= '0';INSERT INTO supabase_migrations.schema_migrations(version, name, statements) VALUES('0', 'init', '{%s}')", sql)).
 			Reply("INSERT 1")
 		// Run test
-		err := Run(context.Background(), "0", dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), "0", "", dbConfig, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		match, err := afero.FileContainsBytes(fsys, path, []byte(sql))
@@ -117,7 +120,7 @@ func TestSquashCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "0_init", pgconn.Config{}, fsys)
+		err := Run(context.Background(), "0_init", "", pgconn.Config{}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, repair.ErrInvalidVersion)
 	})
@@ -126,7 +129,7 @@ func TestSquashCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "0", pgconn.Config{}, fsys)
+		err := Run(context.Background(), "0", "", pgconn.Config{}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
@@ -137,7 +140,7 @@ func TestSquashVersion(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := squashToVersion(context.Background(), "0", afero.NewReadOnlyFs(fsys))
+		err := squashToVersion(context.Background(), "0", "", afero.NewReadOnlyFs(fsys))
 		// Check error
 		assert.ErrorIs(t, err, os.ErrPermission)
 	})
@@ -146,11 +149,40 @@ func TestSquashVersion(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := squashToVersion(context.Background(), "0", fsys)
+		err := squashToVersion(context.Background(), "0", "", fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingVersion)
+	})
+
+	t.Run("throws error on missing from version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "1_target.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		// Run test
+		err := squashToVersion(context.Background(), "", "0", fsys)
 		// Check error
 		assert.ErrorIs(t, err, ErrMissingVersion)
 	})
 
+	t.Run("skips single migration from version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		paths := []string{
+			filepath.Join(utils.MigrationsDir, "0_init.sql"),
+			filepath.Join(utils.MigrationsDir, "1_target.sql"),
+		}
+		require.NoError(t, afero.WriteFile(fsys, paths[0], []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, paths[1], []byte{}, 0644))
+		// Run test
+		err := squashToVersion(context.Background(), "", "1", fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, paths[0])
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
 	t.Run("throws error on shadow create failure", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
@@ -165,11 +197,38 @@ func TestSquashVersion(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Db.Image) + "/json").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := squashToVersion(context.Background(), "1", fsys)
+		err := squashToVersion(context.Background(), "1", "", fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+
+	t.Run("keeps migrations on range diff failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		paths := []string{
+			filepath.Join(utils.MigrationsDir, "0_init.sql"),
+			filepath.Join(utils.MigrationsDir, "1_trigger.sql"),
+			filepath.Join(utils.MigrationsDir, "2_target.sql"),
+		}
+		for _, p := range paths {
+			require.NoError(t, afero.WriteFile(fsys, p, []byte{}, 0644))
+		}
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Db.Image) + "/json").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := squashToVersion(context.Background(), "2", "1", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		exists, err := afero.Exists(fsys, paths[1])
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
 }
 
 func TestSquashMigrations(t *testing.T) {
@@ -333,3 +392,39 @@ func TestBaselineMigration(t *testing.T) {
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
 }
+
+func TestRewriteMigration(t *testing.T) {
+	t.Run("replaces squashed versions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		sql := "create schema test"
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "0_init.sql"), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "2_target.sql"), []byte(sql), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(repair.CREATE_VERSION_SCHEMA).
+			Reply("CREATE SCHEMA").
+			Query(repair.CREATE_VERSION_TABLE).
+			Reply("CREATE TABLE").
+			Query(repair.ADD_STATEMENTS_COLUMN).
+			Reply("ALTER TABLE").
+			Query(repair.ADD_NAME_COLUMN).
+			Reply("ALTER TABLE").
+			Query(fmt.Sprintf("DELETE FROM supabase_migrations.schema_migrations WHERE version >= '1' AND version <= '2';INSERT INTO supabase_migrations.schema_migrations(version, name, statements) VALUES('2', 'target', '{%s}')", sql)).
+			Reply("INSERT 1")
+		// Run test
+		err := rewriteMigrations(context.Background(), dbConfig, "1", "", fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := rewriteMigrations(context.Background(), dbConfig, "1", "", fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingVersion)
+	})
+}
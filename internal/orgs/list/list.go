@@ -8,6 +8,7 @@ import (
 
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
 )
 
 func Run(ctx context.Context) error {
@@ -20,6 +21,10 @@ func Run(ctx context.Context) error {
 		return errors.New("Unexpected error retrieving organizations: " + string(resp.Body))
 	}
 
+	if !render.IsPretty() {
+		return render.Encode(resp.JSON200)
+	}
+
 	table := `|ID|NAME|
 |-|-|
 `
@@ -0,0 +1,33 @@
+package invite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func Run(ctx context.Context, slug, email, role string) error {
+	body := api.V1InviteOrganizationMemberJSONRequestBody{Email: email}
+	if len(role) > 0 {
+		body.RoleName = &role
+	}
+	resp, err := utils.GetSupabase().V1InviteOrganizationMemberWithResponse(ctx, slug, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.JSON201 == nil {
+		return errors.New("Failed to invite organization member: " + string(resp.Body))
+	}
+
+	if !render.IsPretty() {
+		return render.Encode(resp.JSON201)
+	}
+
+	fmt.Println("Invited " + utils.Aqua(resp.JSON201.Email) + " to organization " + utils.Aqua(slug) + " as " + resp.JSON201.RoleName)
+	return nil
+}
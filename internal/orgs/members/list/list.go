@@ -0,0 +1,46 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+func Run(ctx context.Context, slug string) error {
+	resp, err := utils.GetSupabase().V1ListOrganizationMembersWithResponse(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	if resp.JSON200 == nil {
+		return errors.New("Unexpected error retrieving organization members: " + string(resp.Body))
+	}
+
+	if !render.IsPretty() {
+		return render.Encode(resp.JSON200)
+	}
+
+	table := `|USER ID|NAME|EMAIL|ROLE|
+|-|-|-|-|
+`
+	for _, m := range *resp.JSON200 {
+		var email string
+		if m.Email != nil {
+			email = *m.Email
+		}
+		table += fmt.Sprintf(
+			"|`%s`|`%s`|`%s`|`%s`|\n",
+			m.UserId,
+			strings.ReplaceAll(m.UserName, "|", "\\|"),
+			email,
+			m.RoleName,
+		)
+	}
+
+	return list.RenderTable(table)
+}
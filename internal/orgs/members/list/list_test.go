@@ -0,0 +1,49 @@
+package list
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestMembersListCommand(t *testing.T) {
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("lists organization members", func(t *testing.T) {
+		email := "jane@example.com"
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/organizations/test-org/members").
+			Reply(http.StatusOK).
+			JSON([]api.V1OrganizationMemberResponse{{
+				UserId:   "test-user",
+				UserName: "Jane",
+				Email:    &email,
+				RoleName: "Owner",
+			}})
+		// Run test
+		assert.NoError(t, Run(context.Background(), "test-org"))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on server unavailable", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/organizations/test-org/members").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), "test-org")
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving organization members:")
+	})
+}
@@ -0,0 +1,34 @@
+package remove
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+func PreRun(slug, userId, confirm string) error {
+	return utils.PromptConfirmToken("Do you want to remove member "+utils.Aqua(userId)+" from organization "+utils.Aqua(slug)+"?", userId, confirm, os.Stdin)
+}
+
+func Run(ctx context.Context, slug, userId string) error {
+	resp, err := utils.GetSupabase().V1RemoveOrganizationMemberWithResponse(ctx, slug, userId)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusNotFound:
+		return errors.New("Organization member does not exist: " + utils.Aqua(userId))
+	case http.StatusOK:
+		break
+	default:
+		return errors.New("Failed to remove organization member: " + string(resp.Body))
+	}
+
+	fmt.Println("Removed member " + utils.Aqua(userId) + " from organization " + utils.Aqua(slug))
+	return nil
+}
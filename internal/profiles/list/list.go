@@ -0,0 +1,30 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	config, err := utils.LoadProfileConfig(fsys)
+	if err != nil {
+		return err
+	}
+	profiles := config.Profiles
+	if !utils.SliceContains(profiles, utils.DefaultProfile) {
+		profiles = append([]string{utils.DefaultProfile}, profiles...)
+	}
+	current := utils.GetCurrentProfile(fsys)
+	for _, name := range profiles {
+		if name == current {
+			fmt.Fprintln(os.Stdout, "*", utils.Aqua(name))
+		} else {
+			fmt.Fprintln(os.Stdout, " ", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package use
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, name string, fsys afero.Fs) error {
+	if err := utils.SwitchProfile(name, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Switched to profile:", utils.Aqua(name))
+	return nil
+}
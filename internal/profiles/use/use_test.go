@@ -0,0 +1,54 @@
+package use
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/zalando/go-keyring"
+)
+
+func TestSwitchCommand(t *testing.T) {
+	keyring.MockInit()
+	token := string(apitest.RandomAccessToken(t))
+
+	t.Run("switches to logged in profile", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.SaveAccessToken(token, fsys))
+		viper.Set("PROFILE", "work")
+		require.NoError(t, utils.SaveAccessToken(token, fsys))
+		viper.Set("PROFILE", "")
+		// Run test
+		assert.NoError(t, Run(context.Background(), "work", fsys))
+		// Check current profile
+		assert.Equal(t, "work", utils.GetCurrentProfile(fsys))
+		loaded, err := utils.LoadAccessTokenFS(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, token, loaded)
+	})
+
+	t.Run("throws error on unknown profile", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "personal", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Profile not found: personal")
+		assert.Equal(t, utils.DefaultProfile, utils.GetCurrentProfile(fsys))
+	})
+
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "../work", fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrInvalidProfile)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
 )
 
 func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
@@ -21,6 +22,10 @@ func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
 		return errors.New("Unexpected error retrieving project api-keys: " + string(resp.Body))
 	}
 
+	if !render.IsPretty() {
+		return render.Encode(resp.JSON200)
+	}
+
 	table := `|NAME|KEY VALUE|
 |-|-|
 `
@@ -13,14 +13,11 @@ import (
 	"github.com/zalando/go-keyring"
 )
 
-func PreRun(ref string) error {
+func PreRun(ref, confirm string) error {
 	if err := utils.AssertProjectRefIsValid(ref); err != nil {
 		return err
 	}
-	if !utils.PromptYesNo("Do you want to delete project "+utils.Aqua(ref)+"? This action is irreversible.", true, os.Stdin) {
-		return errors.New("Not deleting project: " + utils.Aqua(ref))
-	}
-	return nil
+	return utils.PromptConfirmToken("Do you want to delete project "+utils.Aqua(ref)+"? This action is irreversible.", ref, confirm, os.Stdin)
 }
 
 func Run(ctx context.Context, ref string, fsys afero.Fs) error {
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
 )
 
 func Run(ctx context.Context, fsys afero.Fs) error {
@@ -23,6 +24,10 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 		return errors.New("Unexpected error retrieving projects: " + string(resp.Body))
 	}
 
+	if !render.IsPretty() {
+		return render.Encode(resp.JSON200)
+	}
+
 	projectRef, err := utils.LoadProjectRef(fsys)
 	if err != nil && err != utils.ErrNotLinked {
 		fmt.Fprintln(os.Stderr, err)
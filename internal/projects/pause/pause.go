@@ -0,0 +1,37 @@
+package pause
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+func PreRun(ref, confirm string) error {
+	if err := utils.AssertProjectRefIsValid(ref); err != nil {
+		return err
+	}
+	return utils.PromptConfirmToken("Do you want to pause project "+utils.Aqua(ref)+"? All services will be unavailable until the project is restored.", ref, confirm, os.Stdin)
+}
+
+func Run(ctx context.Context, ref string) error {
+	resp, err := utils.GetSupabase().V1PauseProjectWithResponse(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusNotFound:
+		return errors.New("Project does not exist: " + utils.Aqua(ref))
+	case http.StatusOK:
+		break
+	default:
+		return errors.New("Failed to pause project " + utils.Aqua(ref) + ": " + string(resp.Body))
+	}
+
+	fmt.Println("Pausing project: " + utils.Aqua(ref))
+	return nil
+}
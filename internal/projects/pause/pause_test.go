@@ -0,0 +1,63 @@
+package pause
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestPauseCommand(t *testing.T) {
+	ref := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("pauses project", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/pause").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), ref)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/pause").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := Run(context.Background(), ref)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+	})
+
+	t.Run("throws error on project not found", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/pause").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), ref)
+		// Check error
+		assert.ErrorContains(t, err, "Project does not exist:")
+	})
+
+	t.Run("throws error on invalid confirmation", func(t *testing.T) {
+		err := PreRun(ref, "invalid")
+		// Check error
+		assert.ErrorContains(t, err, "does not match")
+	})
+}
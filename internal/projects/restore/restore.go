@@ -0,0 +1,32 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, ref string) error {
+	if err := utils.AssertProjectRefIsValid(ref); err != nil {
+		return err
+	}
+	resp, err := utils.GetSupabase().V1RestoreProjectWithResponse(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusNotFound:
+		return errors.New("Project does not exist: " + utils.Aqua(ref))
+	case http.StatusOK:
+		break
+	default:
+		return errors.New("Failed to restore project " + utils.Aqua(ref) + ": " + string(resp.Body))
+	}
+
+	fmt.Println("Restoring project: " + utils.Aqua(ref))
+	return nil
+}
@@ -0,0 +1,39 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func PreRun(ref, targetOrg, confirm string) error {
+	if err := utils.AssertProjectRefIsValid(ref); err != nil {
+		return err
+	}
+	return utils.PromptConfirmToken("Do you want to transfer project "+utils.Aqua(ref)+" to organization "+utils.Aqua(targetOrg)+"? Billing of the project moves to the target organization.", ref, confirm, os.Stdin)
+}
+
+func Run(ctx context.Context, ref, targetOrg string) error {
+	resp, err := utils.GetSupabase().V1TransferProjectWithResponse(ctx, ref, api.V1TransferProjectJSONRequestBody{
+		TargetOrganizationSlug: targetOrg,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.JSON200 == nil {
+		return errors.New("Failed to transfer project " + utils.Aqua(ref) + ": " + string(resp.Body))
+	}
+
+	if !render.IsPretty() {
+		return render.Encode(resp.JSON200)
+	}
+
+	fmt.Println("Transferred project " + utils.Aqua(ref) + " to organization: " + utils.Aqua(resp.JSON200.OrganizationId))
+	return nil
+}
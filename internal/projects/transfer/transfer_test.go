@@ -0,0 +1,48 @@
+package transfer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestTransferCommand(t *testing.T) {
+	ref := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("transfers project", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/transfer").
+			JSON(api.V1TransferProjectBody{TargetOrganizationSlug: "target-org"}).
+			Reply(http.StatusOK).
+			JSON(api.ProjectResponse{Id: ref, OrganizationId: "target-org"})
+		// Run test
+		err := Run(context.Background(), ref, "target-org")
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on forbidden", func(t *testing.T) {
+		// Setup api mock
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + ref + "/transfer").
+			Reply(http.StatusForbidden).
+			JSON(map[string]string{"message": "forbidden"})
+		// Run test
+		err := Run(context.Background(), ref, "target-org")
+		// Check error
+		assert.ErrorContains(t, err, "Failed to transfer project")
+	})
+}
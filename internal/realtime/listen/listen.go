@@ -0,0 +1,258 @@
+package listen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+	"github.com/supabase/cli/internal/utils/tenant"
+)
+
+const (
+	EventPostgresChanges = "postgres_changes"
+	EventBroadcast       = "broadcast"
+	EventPresence        = "presence"
+
+	// Phoenix closes idle sockets after 60 seconds without heartbeat
+	heartbeatInterval = 30 * time.Second
+)
+
+var (
+	AllEvents = []string{EventPostgresChanges, EventBroadcast, EventPresence}
+
+	ErrJoin = errors.New("Failed to join channel")
+)
+
+type Options struct {
+	// Channel names to subscribe to, ie. room:lobby
+	Channels []string
+	// Kinds of events to print, defaults to all
+	Events []string
+	// Postgres changes are only streamed for the matching schema, table and filter
+	Schema string
+	Table  string
+	Filter string
+}
+
+// Message follows the Phoenix channels v1 serialisation used by Realtime.
+type message struct {
+	Topic   string          `json:"topic"`
+	Event   string          `json:"event"`
+	Payload json.RawMessage `json:"payload"`
+	Ref     *string         `json:"ref"`
+}
+
+type Event struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Channel   string          `json:"channel"`
+	Kind      string          `json:"kind"`
+	Event     string          `json:"event"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// Streams events from local Realtime, or from the project's Realtime when projectRef is set.
+func Run(ctx context.Context, projectRef string, opts Options, fsys afero.Fs) error {
+	if len(opts.Channels) == 0 {
+		return errors.New("Missing required flag: " + utils.Aqua("--channel"))
+	}
+	endpoint, apiKey, token, err := getEndpoint(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	return Listen(ctx, endpoint, apiKey, token, opts, os.Stdout)
+}
+
+func getEndpoint(ctx context.Context, projectRef string, fsys afero.Fs) (string, string, string, error) {
+	if len(projectRef) > 0 {
+		keys, err := tenant.GetApiKeys(ctx, projectRef)
+		if err != nil {
+			return "", "", "", err
+		}
+		endpoint := "wss://" + utils.GetSupabaseHost(projectRef) + "/realtime/v1/websocket"
+		return endpoint, keys.Anon, keys.ServiceRole, nil
+	}
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return "", "", "", err
+	}
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		return "", "", "", err
+	}
+	endpoint := fmt.Sprintf("ws://127.0.0.1:%d/realtime/v1/websocket", utils.Config.Api.Port)
+	return endpoint, utils.Config.Auth.AnonKey, utils.Config.Auth.ServiceRoleKey, nil
+}
+
+func Listen(ctx context.Context, endpoint, apiKey, token string, opts Options, stdout io.Writer) error {
+	query := url.Values{"apikey": {apiKey}, "vsn": {"1.0.0"}}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to realtime: %w", err)
+	}
+	defer conn.Close()
+	// Gorilla connections support one concurrent writer only
+	var mu sync.Mutex
+	ref := 0
+	send := func(topic, event string, payload any) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		ref++
+		id := strconv.Itoa(ref)
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return "", err
+		}
+		return id, conn.WriteJSON(message{Topic: topic, Event: event, Payload: data, Ref: &id})
+	}
+	joins := map[string]string{}
+	for _, channel := range opts.Channels {
+		id, err := send("realtime:"+channel, "phx_join", joinPayload(opts, token))
+		if err != nil {
+			return err
+		}
+		joins[id] = channel
+	}
+	// Close the socket on cancel so that the read loop below is unblocked
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if _, err := send("phoenix", "heartbeat", struct{}{}); err != nil {
+					fmt.Fprintln(os.Stderr, "Failed to send heartbeat:", err)
+				}
+			}
+		}
+	}()
+	for {
+		var msg message
+		if err := conn.ReadJSON(&msg); err != nil {
+			if ctx.Err() != nil || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return fmt.Errorf("failed to read from realtime: %w", err)
+		}
+		channel := strings.TrimPrefix(msg.Topic, "realtime:")
+		if msg.Event == "phx_reply" {
+			if msg.Ref == nil {
+				continue
+			}
+			if name, ok := joins[*msg.Ref]; ok {
+				if err := checkReply(name, msg.Payload); err != nil {
+					return err
+				}
+				fmt.Fprintln(os.Stderr, "Listening on channel:", utils.Aqua(name))
+			}
+			continue
+		}
+		event, ok := parseEvent(channel, msg)
+		if !ok || !utils.SliceContains(opts.Events, event.Kind) {
+			continue
+		}
+		if err := printEvent(event, stdout); err != nil {
+			return err
+		}
+	}
+}
+
+func joinPayload(opts Options, token string) map[string]any {
+	config := map[string]any{
+		"broadcast": map[string]any{"self": false, "ack": false},
+		"presence":  map[string]any{"key": ""},
+	}
+	if utils.SliceContains(opts.Events, EventPostgresChanges) {
+		change := map[string]string{"event": "*", "schema": opts.Schema}
+		if len(opts.Table) > 0 {
+			change["table"] = opts.Table
+		}
+		if len(opts.Filter) > 0 {
+			change["filter"] = opts.Filter
+		}
+		config["postgres_changes"] = []map[string]string{change}
+	}
+	return map[string]any{"config": config, "access_token": token}
+}
+
+func checkReply(channel string, payload json.RawMessage) error {
+	var reply struct {
+		Status   string          `json:"status"`
+		Response json.RawMessage `json:"response"`
+	}
+	if err := json.Unmarshal(payload, &reply); err != nil {
+		return fmt.Errorf("failed to parse reply: %w", err)
+	}
+	if reply.Status != "ok" {
+		return fmt.Errorf("%w %s: %s", ErrJoin, utils.Aqua(channel), string(reply.Response))
+	}
+	return nil
+}
+
+func parseEvent(channel string, msg message) (Event, bool) {
+	event := Event{Timestamp: time.Now().UTC(), Channel: channel, Event: msg.Event, Payload: msg.Payload}
+	switch msg.Event {
+	case "postgres_changes":
+		var body struct {
+			Data struct {
+				Type   string `json:"type"`
+				Schema string `json:"schema"`
+				Table  string `json:"table"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(msg.Payload, &body); err != nil {
+			return event, false
+		}
+		event.Kind = EventPostgresChanges
+		event.Event = fmt.Sprintf("%s %s.%s", body.Data.Type, body.Data.Schema, body.Data.Table)
+	case "broadcast":
+		var body struct {
+			Event   string          `json:"event"`
+			Payload json.RawMessage `json:"payload"`
+		}
+		if err := json.Unmarshal(msg.Payload, &body); err != nil {
+			return event, false
+		}
+		event.Kind = EventBroadcast
+		event.Event = body.Event
+		event.Payload = body.Payload
+	case "presence_state", "presence_diff":
+		event.Kind = EventPresence
+	default:
+		// Ignore system messages, ie. heartbeat replies and subscription status
+		return event, false
+	}
+	return event, true
+}
+
+func printEvent(event Event, w io.Writer) error {
+	if render.Format.Value == utils.OutputJson {
+		// One object per line so that output can be piped to jq
+		return json.NewEncoder(w).Encode(event)
+	} else if !render.IsPretty() {
+		return utils.EncodeOutput(render.Format.Value, w, event)
+	}
+	_, err := fmt.Fprintf(w, "%s %s %s %s\n",
+		utils.Bold(event.Timestamp.Format("15:04:05")),
+		utils.Aqua(event.Channel),
+		event.Event,
+		string(event.Payload),
+	)
+	return err
+}
@@ -0,0 +1,91 @@
+package listen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+// Replies to the first join with status and sends the given frames before closing.
+func mockRealtime(t *testing.T, status string, frames ...string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "anon-key", r.URL.Query().Get("apikey"))
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		var join message
+		require.NoError(t, conn.ReadJSON(&join))
+		assert.Equal(t, "realtime:room:lobby", join.Topic)
+		assert.Equal(t, "phx_join", join.Event)
+		reply := `{"topic":"realtime:room:lobby","event":"phx_reply","ref":"` + *join.Ref + `","payload":{"status":"` + status + `","response":{"reason":"denied"}}}`
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(reply)))
+		for _, f := range frames {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(f)))
+		}
+		msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		require.NoError(t, conn.WriteMessage(websocket.CloseMessage, msg))
+	}))
+}
+
+func TestListen(t *testing.T) {
+	opts := Options{Channels: []string{"room:lobby"}, Events: AllEvents, Schema: "public"}
+
+	t.Run("prints filtered events as json", func(t *testing.T) {
+		render.Format.Value = utils.OutputJson
+		defer func() { render.Format.Value = utils.OutputPretty }()
+		server := mockRealtime(t, "ok",
+			`{"topic":"realtime:room:lobby","event":"system","ref":null,"payload":{"status":"ok"}}`,
+			`{"topic":"realtime:room:lobby","event":"postgres_changes","ref":null,"payload":{"data":{"type":"INSERT","schema":"public","table":"todos","record":{"id":1}}}}`,
+			`{"topic":"realtime:room:lobby","event":"broadcast","ref":null,"payload":{"event":"cursor","payload":{"x":1}}}`,
+			`{"topic":"realtime:room:lobby","event":"presence_diff","ref":null,"payload":{"joins":{},"leaves":{}}}`,
+		)
+		defer server.Close()
+		endpoint := "ws" + strings.TrimPrefix(server.URL, "http")
+		// Run test
+		var out bytes.Buffer
+		err := Listen(context.Background(), endpoint, "anon-key", "token", Options{
+			Channels: opts.Channels,
+			Events:   []string{EventPostgresChanges, EventBroadcast},
+			Schema:   "public",
+		}, &out)
+		// Check error
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+		var event Event
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+		assert.Equal(t, EventPostgresChanges, event.Kind)
+		assert.Equal(t, "INSERT public.todos", event.Event)
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+		assert.Equal(t, EventBroadcast, event.Kind)
+		assert.Equal(t, "cursor", event.Event)
+		assert.JSONEq(t, `{"x":1}`, string(event.Payload))
+	})
+
+	t.Run("throws error on rejected join", func(t *testing.T) {
+		server := mockRealtime(t, "error")
+		defer server.Close()
+		endpoint := "ws" + strings.TrimPrefix(server.URL, "http")
+		// Run test
+		err := Listen(context.Background(), endpoint, "anon-key", "token", opts, &bytes.Buffer{})
+		// Check error
+		assert.ErrorIs(t, err, ErrJoin)
+		assert.ErrorContains(t, err, "denied")
+	})
+
+	t.Run("throws error on missing channel", func(t *testing.T) {
+		err := Run(context.Background(), "", Options{}, nil)
+		assert.ErrorContains(t, err, "Missing required flag")
+	})
+}
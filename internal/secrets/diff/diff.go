@@ -0,0 +1,135 @@
+package diff
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+type Change string
+
+const (
+	Added   Change = "added"
+	Changed Change = "changed"
+	Removed Change = "removed"
+)
+
+type SecretChange struct {
+	Name   string `json:"name"`
+	Change Change `json:"change"`
+	// Digests of low entropy values can be brute forced, so they are never encoded
+	LocalDigest  string `json:"-"`
+	RemoteDigest string `json:"-"`
+	// Plaintext value is never rendered
+	Value string `json:"-"`
+}
+
+// Reserved secrets are managed by the platform and cannot be removed.
+const reservedPrefix = "SUPABASE_"
+
+func Run(ctx context.Context, projectRef, envFilePath string, fsys afero.Fs) error {
+	changes, err := LoadChanges(ctx, projectRef, envFilePath, fsys)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "Remote secrets are up to date with", utils.Bold(envFilePath))
+		return nil
+	}
+	return PrintChanges(changes)
+}
+
+func LoadChanges(ctx context.Context, projectRef, envFilePath string, fsys afero.Fs) ([]SecretChange, error) {
+	local, err := readEnvFile(envFilePath, fsys)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := utils.GetSupabase().GetSecretsWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, errors.New("Unexpected error retrieving project secrets: " + string(resp.Body))
+	}
+	remote := make(map[string]string, len(*resp.JSON200))
+	for _, secret := range *resp.JSON200 {
+		remote[secret.Name] = secret.Value
+	}
+	return computeChanges(local, remote), nil
+}
+
+func readEnvFile(envFilePath string, fsys afero.Fs) (map[string]string, error) {
+	f, err := fsys.Open(envFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return godotenv.Parse(f)
+}
+
+// Remote secrets only expose the sha256 digest of their values, so local values are compared by digest.
+func computeChanges(local, remote map[string]string) []SecretChange {
+	var changes []SecretChange
+	for name, value := range local {
+		digest := sha256Hex(value)
+		if remoteDigest, ok := remote[name]; !ok {
+			changes = append(changes, SecretChange{Name: name, Change: Added, LocalDigest: digest, Value: value})
+		} else if !strings.EqualFold(remoteDigest, digest) {
+			changes = append(changes, SecretChange{Name: name, Change: Changed, LocalDigest: digest, RemoteDigest: remoteDigest, Value: value})
+		}
+	}
+	for name, remoteDigest := range remote {
+		if _, ok := local[name]; !ok && !strings.HasPrefix(name, reservedPrefix) {
+			changes = append(changes, SecretChange{Name: name, Change: Removed, RemoteDigest: remoteDigest})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func sha256Hex(value string) string {
+	digest := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(digest[:])
+}
+
+func PrintChanges(changes []SecretChange) error {
+	if !render.IsPretty() {
+		return render.Encode(changes)
+	}
+	table := `|NAME|CHANGE|LOCAL DIGEST|REMOTE DIGEST|
+|-|-|-|-|
+`
+	for _, c := range changes {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|\n",
+			strings.ReplaceAll(c.Name, "|", "\\|"),
+			c.Change,
+			mask(c.LocalDigest),
+			mask(c.RemoteDigest),
+		)
+	}
+	return list.RenderTable(table)
+}
+
+// Shows only a short prefix of the digest, enough to tell values apart.
+func mask(digest string) string {
+	if len(digest) > 8 {
+		return digest[:8] + "…"
+	}
+	if len(digest) == 0 {
+		return " "
+	}
+	return digest
+}
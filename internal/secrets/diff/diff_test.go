@@ -0,0 +1,91 @@
+package diff
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSecretDiffCommand(t *testing.T) {
+	t.Run("shows changed secrets", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte("API_KEY=new\nSAME=value"), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "SAME", Value: sha256Hex("value")}})
+		// Run test
+		err := Run(context.Background(), project, ".env", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing env file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), apitest.RandomProjectRef(), ".env", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "open .env: file does not exist")
+	})
+
+	t.Run("throws error on network error", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte("API_KEY=new"), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), project, ".env", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving project secrets:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestComputeChanges(t *testing.T) {
+	local := map[string]string{
+		"ADDED":   "a",
+		"CHANGED": "b",
+		"SAME":    "c",
+	}
+	remote := map[string]string{
+		"CHANGED":      sha256Hex("old"),
+		"SAME":         sha256Hex("c"),
+		"REMOVED":      sha256Hex("d"),
+		"SUPABASE_URL": sha256Hex("e"),
+	}
+	// Run test
+	changes := computeChanges(local, remote)
+	// Check result
+	assert.Equal(t, []SecretChange{
+		{Name: "ADDED", Change: Added, LocalDigest: sha256Hex("a"), Value: "a"},
+		{Name: "CHANGED", Change: Changed, LocalDigest: sha256Hex("b"), RemoteDigest: sha256Hex("old"), Value: "b"},
+		{Name: "REMOVED", Change: Removed, RemoteDigest: sha256Hex("d")},
+	}, changes)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/fanout"
 	"github.com/supabase/cli/pkg/api"
 )
 
@@ -58,6 +59,6 @@ func Run(ctx context.Context, projectRef, envFilePath string, args []string, fsy
 		}
 	}
 
-	fmt.Println("Finished " + utils.Aqua("supabase secrets set") + ".")
+	fmt.Fprintln(fanout.Stdout(ctx), "Finished "+utils.Aqua("supabase secrets set")+".")
 	return nil
 }
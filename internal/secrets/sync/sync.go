@@ -0,0 +1,73 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/secrets/diff"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Remote secrets missing from the env file are only removed when prune is set.
+func Run(ctx context.Context, projectRef, envFilePath string, prune bool, fsys afero.Fs) error {
+	all, err := diff.LoadChanges(ctx, projectRef, envFilePath, fsys)
+	if err != nil {
+		return err
+	}
+	var changes []diff.SecretChange
+	var skipped int
+	for _, c := range all {
+		if c.Change == diff.Removed && !prune {
+			skipped++
+			continue
+		}
+		changes = append(changes, c)
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipping %d remote secrets not found in %s. Pass %s to remove them.\n", skipped, utils.Bold(envFilePath), utils.Aqua("--prune"))
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stderr, "Remote secrets are up to date with", utils.Bold(envFilePath))
+		return nil
+	}
+	if err := diff.PrintChanges(changes); err != nil {
+		return err
+	}
+	if !utils.PromptYesNo("Do you want to apply these changes to project "+utils.Aqua(projectRef)+"?", false, os.Stdin) {
+		return errors.New("Sync cancelled by user.")
+	}
+	var upsert api.CreateSecretsJSONBody
+	var removed []string
+	for _, c := range changes {
+		if c.Change == diff.Removed {
+			removed = append(removed, c.Name)
+		} else {
+			upsert = append(upsert, api.CreateSecretBody{Name: c.Name, Value: c.Value})
+		}
+	}
+	if len(upsert) > 0 {
+		resp, err := utils.GetSupabase().CreateSecretsWithResponse(ctx, projectRef, upsert)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+			return errors.New("Unexpected error setting project secrets: " + string(resp.Body))
+		}
+	}
+	if len(removed) > 0 {
+		resp, err := utils.GetSupabase().DeleteSecretsWithResponse(ctx, projectRef, removed)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return errors.New("Unexpected error unsetting project secrets: " + string(resp.Body))
+		}
+	}
+	fmt.Println("Finished " + utils.Aqua("supabase secrets sync") + ".")
+	return nil
+}
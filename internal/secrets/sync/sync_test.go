@@ -0,0 +1,132 @@
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSecretSyncCommand(t *testing.T) {
+	digest := sha256.Sum256([]byte("value"))
+
+	t.Run("applies only the delta", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte("API_KEY=new\nSAME=value"), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{
+				{Name: "SAME", Value: hex.EncodeToString(digest[:])},
+				{Name: "STALE", Value: "digest"},
+			})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/secrets").
+			MatchType("json").
+			JSON(api.CreateSecretsJSONBody{{Name: "API_KEY", Value: "new"}}).
+			Reply(http.StatusCreated)
+		gock.New(utils.DefaultApiHost).
+			Delete("/v1/projects/" + project + "/secrets").
+			MatchType("json").
+			JSON([]string{"STALE"}).
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), project, ".env", true, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("keeps remote secrets without prune", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte("API_KEY=new"), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "STALE", Value: "digest"}})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/secrets").
+			MatchType("json").
+			JSON(api.CreateSecretsJSONBody{{Name: "API_KEY", Value: "new"}}).
+			Reply(http.StatusCreated)
+		// Run test
+		err := Run(context.Background(), project, ".env", false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("cancels sync by default", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte("API_KEY=new"), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{})
+		// Run test
+		err := Run(context.Background(), project, ".env", false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Sync cancelled by user.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips when up to date", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte("SAME=value"), 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "SAME", Value: hex.EncodeToString(digest[:])}})
+		// Run test
+		err := Run(context.Background(), project, ".env", false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,204 @@
+package run
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	CREATE_SEED_TABLE = "CREATE TABLE IF NOT EXISTS supabase_migrations.seed_files (path text NOT NULL PRIMARY KEY, hash text NOT NULL, applied_at timestamptz NOT NULL DEFAULT now())"
+	SELECT_SEED_TABLE = "SELECT path, hash FROM supabase_migrations.seed_files"
+	UPSERT_SEED_FILE  = "INSERT INTO supabase_migrations.seed_files(path, hash) VALUES($1, $2) ON CONFLICT (path) DO UPDATE SET hash = EXCLUDED.hash, applied_at = now()"
+)
+
+var (
+	envPattern    = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	ErrInvalidEnv = errors.New("Invalid seed environment. Must contain only alphanumeric, hyphen, or underscore.")
+)
+
+type SeedFile struct {
+	Path  string
+	Hash  string
+	Lines []string
+}
+
+func Run(ctx context.Context, env string, dryRun bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if len(env) > 0 && !envPattern.MatchString(env) {
+		return ErrInvalidEnv
+	}
+	seeds, err := LoadSeedFiles(env, fsys)
+	if err != nil {
+		return err
+	}
+	if len(seeds) == 0 {
+		fmt.Fprintln(os.Stderr, "No seed files found in", utils.Bold(getSeedDir(env)))
+		return nil
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	pending, err := GetPendingSeeds(ctx, seeds, conn)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		fmt.Fprintln(os.Stderr, "Seed data is up to date.")
+		return nil
+	}
+	if dryRun {
+		fmt.Fprintln(os.Stderr, "DRY RUN: seed files will not be applied.")
+		return printSeeds(pending, os.Stdout)
+	}
+	if err := CreateSeedTable(ctx, conn); err != nil {
+		return err
+	}
+	for _, seed := range pending {
+		fmt.Fprintln(os.Stderr, "Seeding data "+utils.Bold(seed.Path)+"...")
+		if err := seed.ExecBatch(ctx, conn); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Finished "+utils.Aqua("supabase seed run")+".")
+	return nil
+}
+
+func getSeedDir(env string) string {
+	return filepath.Join(utils.SeedsDir, env)
+}
+
+// Shared seeds directly under the seeds directory are applied before environment specific ones.
+// Files in each directory are applied in lexical order.
+func LoadSeedFiles(env string, fsys afero.Fs) ([]SeedFile, error) {
+	dirs := []string{utils.SeedsDir}
+	if len(env) > 0 {
+		dirs = append(dirs, getSeedDir(env))
+	}
+	var result []SeedFile
+	for _, dir := range dirs {
+		entries, err := afero.ReadDir(fsys, dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() || filepath.Ext(e.Name()) != ".sql" {
+				continue
+			}
+			seed, err := NewSeedFromFile(filepath.Join(dir, e.Name()), fsys)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, *seed)
+		}
+	}
+	return result, nil
+}
+
+func NewSeedFromFile(path string, fsys afero.Fs) (*SeedFile, error) {
+	data, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(data)
+	m, err := repair.NewMigrationFromReader(strings.NewReader(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	return &SeedFile{
+		Path:  filepath.ToSlash(path),
+		Hash:  hex.EncodeToString(digest[:]),
+		Lines: m.Lines,
+	}, nil
+}
+
+// Returns seed files that are new or have changed since they were last applied.
+func GetPendingSeeds(ctx context.Context, seeds []SeedFile, conn *pgx.Conn) ([]SeedFile, error) {
+	applied, err := loadSeedHistory(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	var pending []SeedFile
+	for _, seed := range seeds {
+		if hash, ok := applied[seed.Path]; ok {
+			if hash == seed.Hash {
+				continue
+			}
+			fmt.Fprintln(os.Stderr, "Seed file has changed:", utils.Bold(seed.Path))
+		}
+		pending = append(pending, seed)
+	}
+	return pending, nil
+}
+
+func loadSeedHistory(ctx context.Context, conn *pgx.Conn) (map[string]string, error) {
+	applied := map[string]string{}
+	rows, err := conn.Query(ctx, SELECT_SEED_TABLE)
+	if err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var path, hash string
+			if err := rows.Scan(&path, &hash); err != nil {
+				return nil, err
+			}
+			applied[path] = hash
+		}
+		err = rows.Err()
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (pgErr.Code == pgerrcode.UndefinedTable || pgErr.Code == pgerrcode.InvalidSchemaName) {
+		// If seed history table is undefined, no seed files have been applied
+		return applied, nil
+	}
+	return applied, err
+}
+
+func CreateSeedTable(ctx context.Context, conn *pgx.Conn) error {
+	batch := pgconn.Batch{}
+	batch.ExecParams(repair.CREATE_VERSION_SCHEMA, nil, nil, nil, nil)
+	batch.ExecParams(CREATE_SEED_TABLE, nil, nil, nil, nil)
+	_, err := conn.PgConn().ExecBatch(ctx, &batch).ReadAll()
+	return err
+}
+
+func (s *SeedFile) ExecBatch(ctx context.Context, conn *pgx.Conn) error {
+	// Data statements don't mutate schemas, safe to use statement cache
+	batch := pgx.Batch{}
+	for _, line := range s.Lines {
+		batch.Queue(line)
+	}
+	batch.Queue(UPSERT_SEED_FILE, s.Path, s.Hash)
+	// Batch is implicitly transactional so history is only updated on success
+	return conn.SendBatch(ctx, &batch).Close()
+}
+
+func printSeeds(seeds []SeedFile, w io.Writer) error {
+	for _, seed := range seeds {
+		if _, err := fmt.Fprintf(w, "-- %s\n", seed.Path); err != nil {
+			return err
+		}
+		for _, line := range seed.Lines {
+			if _, err := fmt.Fprintln(w, strings.TrimRight(line, ";")+";"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
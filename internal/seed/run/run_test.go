@@ -0,0 +1,115 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "db.supabase.co",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestSeedRun(t *testing.T) {
+	t.Run("applies new seed files", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		sql := "INSERT INTO employees(name) VALUES ('Alice')"
+		path := filepath.Join(utils.SeedsDir, "staging", "01_employees.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		seed, err := NewSeedFromFile(path, fsys)
+		require.NoError(t, err)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_SEED_TABLE).
+			ReplyError(pgerrcode.UndefinedTable, `relation "supabase_migrations.seed_files" does not exist`).
+			Query(repair.CREATE_VERSION_SCHEMA).
+			Reply("CREATE SCHEMA").
+			Query(CREATE_SEED_TABLE).
+			Reply("CREATE TABLE").
+			Query(fmt.Sprintf("%s;INSERT INTO supabase_migrations.seed_files(path, hash) VALUES('%s', '%s') ON CONFLICT (path) DO UPDATE SET hash = EXCLUDED.hash, applied_at = now()", sql, seed.Path, seed.Hash)).
+			Reply("INSERT 0 1").
+			Reply("INSERT 0 1")
+		// Run test
+		err = Run(context.Background(), "staging", false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips unchanged seed files", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.SeedsDir, "01_roles.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("select 1"), 0644))
+		seed, err := NewSeedFromFile(path, fsys)
+		require.NoError(t, err)
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_SEED_TABLE).
+			Reply("SELECT 1", []interface{}{seed.Path, seed.Hash})
+		// Run test
+		err = Run(context.Background(), "", false, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on invalid env", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "../prod", false, dbConfig, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, ErrInvalidEnv)
+	})
+}
+
+func TestLoadSeedFiles(t *testing.T) {
+	t.Run("loads shared seeds before env", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		for _, path := range []string{
+			filepath.Join(utils.SeedsDir, "staging", "01_users.sql"),
+			filepath.Join(utils.SeedsDir, "production", "01_users.sql"),
+			filepath.Join(utils.SeedsDir, "02_config.sql"),
+			filepath.Join(utils.SeedsDir, "01_roles.sql"),
+			filepath.Join(utils.SeedsDir, "README.md"),
+		} {
+			require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		}
+		// Run test
+		seeds, err := LoadSeedFiles("staging", fsys)
+		// Check error
+		assert.NoError(t, err)
+		var paths []string
+		for _, s := range seeds {
+			paths = append(paths, s.Path)
+		}
+		assert.Equal(t, []string{
+			"supabase/seeds/01_roles.sql",
+			"supabase/seeds/02_config.sql",
+			"supabase/seeds/staging/01_users.sql",
+		}, paths)
+	})
+
+	t.Run("ignores missing directory", func(t *testing.T) {
+		// Run test
+		seeds, err := LoadSeedFiles("staging", afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, seeds)
+	})
+}
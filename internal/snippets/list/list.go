@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
 	"github.com/supabase/cli/pkg/api"
 )
 
@@ -26,6 +27,10 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 		return errors.New("Unexpected error listing SQL snippets: " + string(resp.Body))
 	}
 
+	if !render.IsPretty() {
+		return render.Encode(resp.JSON200)
+	}
+
 	table := `|ID|NAME|VISIBILITY|OWNER|CREATED AT (UTC)|UPDATED AT (UTC)|
 |-|-|-|-|-|-|
 `
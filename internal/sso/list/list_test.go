@@ -93,4 +93,30 @@ func TestSSOProvidersListCommand(t *testing.T) {
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+	t.Run("lists providers as json", func(t *testing.T) {
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+
+		projectRef := "abcdefghijklmnopqrst"
+
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/config/auth/sso/providers").
+			Reply(200).
+			JSON(map[string]any{
+				"items": []map[string]any{{
+					"id":         "0b0d48f6-878b-4190-88d7-2ca33ed800bc",
+					"created_at": "2023-03-28T13:50:14.464Z",
+					"updated_at": "2023-03-28T13:50:14.464Z",
+				}},
+			})
+
+		// Run test
+		assert.NoError(t, Run(context.Background(), projectRef, utils.OutputJson))
+		// Validate api
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
 }
@@ -37,6 +37,11 @@ func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignore
 		if err := utils.AssertDockerIsRunning(ctx); err != nil {
 			return err
 		}
+		var err error
+		excludedContainers, err = ResolveExcluded(append(utils.Config.Services.Exclude, excludedContainers...))
+		if err != nil {
+			return err
+		}
 		if _, err := utils.Docker.ContainerInspect(ctx, utils.DbId); err == nil {
 			fmt.Fprintln(os.Stderr, utils.Aqua("supabase start")+" is already running.")
 			fmt.Fprintln(os.Stderr, "Run "+utils.Aqua("supabase status")+" to show status of local Supabase containers.")
@@ -117,7 +122,16 @@ type vectorConfig struct {
 	DbId          string
 }
 
+type metricsConfig struct {
+	DbUrl     string
+	Endpoints []string
+}
+
 var (
+	//go:embed templates/metrics.yaml
+	metricsConfigEmbed    string
+	metricsConfigTemplate = template.Must(template.New("metricsConfig").Parse(metricsConfigEmbed))
+
 	//go:embed templates/vector.yaml
 	vectorConfigEmbed    string
 	vectorConfigTemplate = template.Must(template.New("vectorConfig").Parse(vectorConfigEmbed))
@@ -432,6 +446,14 @@ EOF
 			}
 		}
 
+		if utils.Config.Metrics.Enabled {
+			// Serves prometheus metrics on port 9100 for the exporter to scrape
+			env = append(env,
+				"GOTRUE_METRICS_ENABLED=true",
+				"GOTRUE_METRICS_EXPORTER=prometheus",
+			)
+		}
+
 		if utils.Config.Auth.Sms.Twilio.Enabled {
 			env = append(
 				env,
@@ -745,7 +767,7 @@ EOF
 	// Start all functions.
 	if !isContainerExcluded(utils.EdgeRuntimeImage, excluded) {
 		dbUrl := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database)
-		if err := serve.ServeFunctions(ctx, "", nil, "", dbUrl, w, fsys); err != nil {
+		if err := serve.ServeFunctions(ctx, "", nil, "", dbUrl, serve.RuntimeOption{}, w, fsys); err != nil {
 			return err
 		}
 		started = append(started, utils.EdgeRuntimeId)
@@ -880,6 +902,68 @@ EOF
 		started = append(started, utils.PoolerId)
 	}
 
+	// Start metrics exporter.
+	if utils.Config.Metrics.Enabled {
+		var endpoints []string
+		if utils.Config.Auth.Enabled && !isContainerExcluded(utils.Config.Auth.Image, excluded) {
+			endpoints = append(endpoints, "http://"+utils.GotrueId+":9100/metrics")
+		}
+		// PostgREST serves metrics on its admin port since v12
+		if utils.Config.Api.Enabled && !isContainerExcluded(utils.Config.Api.Image, excluded) {
+			endpoints = append(endpoints, "http://"+utils.RestId+":3001/metrics")
+		}
+		if !isContainerExcluded(utils.EdgeRuntimeImage, excluded) {
+			endpoints = append(endpoints, "http://"+utils.EdgeRuntimeId+":8082/metrics")
+		}
+		var metricsConfigBuf bytes.Buffer
+		if err := metricsConfigTemplate.Execute(&metricsConfigBuf, metricsConfig{
+			DbUrl:     fmt.Sprintf("postgresql://postgres:%s@%s:%d/%s", dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database),
+			Endpoints: endpoints,
+		}); err != nil {
+			return err
+		}
+		if _, err := utils.DockerStart(
+			ctx,
+			container.Config{
+				Image: utils.VectorImage,
+				Env: []string{
+					"VECTOR_CONFIG=/etc/vector/vector.yaml",
+				},
+				Entrypoint: []string{"sh", "-c", `cat <<'EOF' > /etc/vector/vector.yaml && vector
+` + metricsConfigBuf.String() + `
+EOF
+`},
+				Healthcheck: &container.HealthConfig{
+					Test: []string{"CMD",
+						"wget",
+						"--no-verbose",
+						"--tries=1",
+						"--spider",
+						"http://127.0.0.1:9001/health"},
+					Interval: 10 * time.Second,
+					Timeout:  2 * time.Second,
+					Retries:  3,
+				},
+				ExposedPorts: nat.PortSet{"9598/tcp": {}},
+			},
+			container.HostConfig{
+				PortBindings:  nat.PortMap{"9598/tcp": []nat.PortBinding{{HostPort: strconv.FormatUint(uint64(utils.Config.Metrics.Port), 10)}}},
+				RestartPolicy: container.RestartPolicy{Name: "always"},
+			},
+			network.NetworkingConfig{
+				EndpointsConfig: map[string]*network.EndpointSettings{
+					utils.NetId: {
+						Aliases: utils.MetricsAliases,
+					},
+				},
+			},
+			utils.MetricsId,
+		); err != nil {
+			return err
+		}
+		started = append(started, utils.MetricsId)
+	}
+
 	p.Send(utils.StatusMsg("Waiting for health checks..."))
 	return reset.WaitForServiceReady(ctx, started)
 }
@@ -892,6 +976,49 @@ func isContainerExcluded(imageName string, excluded map[string]bool) bool {
 	return false
 }
 
+// Services that cannot run without their dependencies, keyed by short image name.
+var serviceDependencies = map[string][]string{
+	utils.ShortContainerImageName(utils.ImageProxyImage): {utils.ShortContainerImageName(utils.StorageImage)},
+	utils.ShortContainerImageName(utils.VectorImage):     {utils.ShortContainerImageName(utils.LogflareImage)},
+	utils.ShortContainerImageName(utils.StudioImage):     {utils.ShortContainerImageName(utils.PgmetaImage)},
+}
+
+// Resolves aliases to short image names and transitively excludes services
+// whose dependencies are excluded.
+func ResolveExcluded(names []string) ([]string, error) {
+	allowed := ExcludableContainers()
+	excluded := map[string]bool{}
+	var result []string
+	for _, name := range names {
+		short := utils.ResolveServiceName(name)
+		if !utils.SliceContains(allowed, short) {
+			return nil, errors.New("Unknown service to exclude: " + utils.Aqua(name) + ". Choose from: " + strings.Join(allowed, ", "))
+		}
+		if !excluded[short] {
+			excluded[short] = true
+			result = append(result, short)
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, name := range allowed {
+			if excluded[name] {
+				continue
+			}
+			for _, dep := range serviceDependencies[name] {
+				if excluded[dep] {
+					fmt.Fprintln(os.Stderr, "Excluding", utils.Aqua(name), "because it depends on", utils.Aqua(dep))
+					excluded[name] = true
+					result = append(result, name)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
 func ExcludableContainers() []string {
 	names := []string{}
 	for _, image := range utils.ServiceImages {
@@ -236,6 +236,23 @@ func TestDatabaseStart(t *testing.T) {
 	})
 }
 
+func TestResolveExcluded(t *testing.T) {
+	t.Run("excludes dependent services", func(t *testing.T) {
+		// Run test
+		excluded, err := ResolveExcluded([]string{"storage", "logflare", "storage-api"})
+		// Check error
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"storage-api", "logflare", "imgproxy", "vector"}, excluded)
+	})
+
+	t.Run("throws error on unknown service", func(t *testing.T) {
+		// Run test
+		_, err := ResolveExcluded([]string{"invalid"})
+		// Check error
+		assert.ErrorContains(t, err, "Unknown service to exclude: invalid")
+	})
+}
+
 func TestFormatMapForEnvConfig(t *testing.T) {
 	t.Run("It produces the correct format and removes the trailing comma", func(t *testing.T) {
 		output := bytes.Buffer{}
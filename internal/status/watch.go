@@ -0,0 +1,307 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Summarises client connections and cumulative transactions across all databases.
+const DB_STATS_QUERY = `
+SELECT
+  count(*) FILTER (WHERE state = 'active'),
+  count(*) FILTER (WHERE state LIKE 'idle%'),
+  count(*),
+  current_setting('max_connections')::int,
+  (SELECT sum(xact_commit + xact_rollback)::bigint FROM pg_stat_database)
+FROM pg_stat_activity
+WHERE backend_type = 'client backend'
+`
+
+type ServiceStats struct {
+	Name   string
+	Status string
+	// Percentage of a single CPU core, may exceed 100 on multi-core hosts
+	Cpu    float64
+	Memory uint64
+}
+
+type DbStats struct {
+	Active       int
+	Idle         int
+	Total        int
+	Max          int
+	Transactions int64
+}
+
+// Cumulative counters that the dashboard turns into rates between samples.
+type Sample struct {
+	Db DbStats
+	// Requests served by Edge Functions, only known when the metrics exporter is enabled
+	FunctionRequests float64
+	HasFunctions     bool
+}
+
+// Redraws a dashboard of container health, resource usage and database activity until interrupted.
+func Watch(ctx context.Context, interval time.Duration, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := AssertContainerHealthy(ctx, utils.DbId); err != nil {
+		return err
+	}
+	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{}, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	services := append([]string{utils.DbId}, listServices()...)
+	var prev Sample
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		stats := collectServiceStats(ctx, services)
+		db, err := GetDbStats(ctx, conn)
+		if err != nil {
+			return err
+		}
+		sample := Sample{Db: db}
+		if utils.Config.Metrics.Enabled {
+			// Exporter may still be starting, in which case the rate is shown as unknown
+			sample.FunctionRequests, err = GetFunctionRequests(ctx)
+			sample.HasFunctions = err == nil
+		}
+		elapsed := time.Since(start)
+		start = time.Now()
+		var buf bytes.Buffer
+		PrintDashboard(&buf, stats, sample, prev, elapsed)
+		// Clear screen before redrawing to avoid flicker from partial output
+		fmt.Fprint(os.Stdout, "\033[H\033[2J"+buf.String())
+		prev = sample
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func listServices() []string {
+	return []string{
+		utils.KongId,
+		utils.GotrueId,
+		utils.InbucketId,
+		utils.RealtimeId,
+		utils.RestId,
+		utils.StorageId,
+		utils.ImgProxyId,
+		utils.PgmetaId,
+		utils.StudioId,
+		utils.EdgeRuntimeId,
+		utils.LogflareId,
+		utils.VectorId,
+		utils.PoolerId,
+		utils.MetricsId,
+	}
+}
+
+func collectServiceStats(ctx context.Context, services []string) []ServiceStats {
+	result := make([]ServiceStats, len(services))
+	var wg sync.WaitGroup
+	for i, name := range services {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			result[i] = ServiceStats{Name: name, Status: getStatus(ctx, name)}
+			if result[i].Status == "stopped" {
+				return
+			}
+			if sample, err := getContainerStats(ctx, name); err == nil {
+				result[i].Cpu = cpuPercent(sample)
+				result[i].Memory = memoryUsage(sample)
+			}
+		}(i, name)
+	}
+	wg.Wait()
+	return result
+}
+
+func getStatus(ctx context.Context, name string) string {
+	resp, err := utils.Docker.ContainerInspect(ctx, name)
+	if client.IsErrNotFound(err) {
+		return "stopped"
+	} else if err != nil {
+		return "unknown"
+	}
+	if !resp.State.Running {
+		return resp.State.Status
+	}
+	// Services without native health checks are probed over http
+	if name == utils.RestId || name == utils.EdgeRuntimeId {
+		if IsServiceReady(ctx, name) {
+			return "healthy"
+		}
+		return "unhealthy"
+	}
+	if resp.State.Health != nil {
+		return resp.State.Health.Status
+	}
+	return resp.State.Status
+}
+
+func getContainerStats(ctx context.Context, name string) (types.StatsJSON, error) {
+	var stats types.StatsJSON
+	// Non-streaming stats include the previous sample for computing cpu usage
+	resp, err := utils.Docker.ContainerStats(ctx, name, false)
+	if err != nil {
+		return stats, err
+	}
+	defer resp.Body.Close()
+	err = json.NewDecoder(resp.Body).Decode(&stats)
+	return stats, err
+}
+
+// Ref: https://github.com/docker/cli/blob/master/cli/command/container/stats_helpers.go
+func cpuPercent(stats types.StatsJSON) float64 {
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	cpus := float64(stats.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+	return cpuDelta / systemDelta * cpus * 100
+}
+
+func memoryUsage(stats types.StatsJSON) uint64 {
+	// Page cache is reclaimable, so it's excluded like docker stats does
+	cache := stats.MemoryStats.Stats["inactive_file"]
+	if cache == 0 {
+		cache = stats.MemoryStats.Stats["cache"]
+	}
+	if cache > stats.MemoryStats.Usage {
+		return 0
+	}
+	return stats.MemoryStats.Usage - cache
+}
+
+func GetDbStats(ctx context.Context, conn *pgx.Conn) (DbStats, error) {
+	var stats DbStats
+	err := conn.QueryRow(ctx, DB_STATS_QUERY).Scan(
+		&stats.Active,
+		&stats.Idle,
+		&stats.Total,
+		&stats.Max,
+		&stats.Transactions,
+	)
+	if err != nil {
+		return stats, fmt.Errorf("failed to query database stats: %w", err)
+	}
+	return stats, nil
+}
+
+// Sums the request counters of all functions scraped by the metrics exporter. Auth and PostgREST
+// don't export request counters, so their metrics are only available from the exporter itself.
+func GetFunctionRequests(ctx context.Context) (float64, error) {
+	metricsUrl := fmt.Sprintf("http://127.0.0.1:%d/metrics", utils.Config.Metrics.Port)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsUrl, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to scrape metrics: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	return sumCounter(string(body), "edge_runtime_requests_total"), nil
+}
+
+// Adds up all samples of a counter in Prometheus text format, ignoring timestamps.
+func sumCounter(text, name string) float64 {
+	var total float64
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(line, name+"{") && !strings.HasPrefix(line, name+" ") {
+			continue
+		}
+		// Label values may contain spaces, so the value is read after the closing brace
+		if i := strings.LastIndex(line, "}"); i >= 0 {
+			line = line[i+1:]
+		} else {
+			line = line[len(name):]
+		}
+		if fields := strings.Fields(line); len(fields) > 0 {
+			if value, err := strconv.ParseFloat(fields[0], 64); err == nil {
+				total += value
+			}
+		}
+	}
+	return total
+}
+
+func PrintDashboard(w io.Writer, services []ServiceStats, cur, prev Sample, elapsed time.Duration) {
+	db := cur.Db
+	width := len("SERVICE")
+	for _, s := range services {
+		if len(s.Name) > width {
+			width = len(s.Name)
+		}
+	}
+	fmt.Fprintf(w, "%-*s  %-10s  %7s  %10s\n", width, "SERVICE", "STATUS", "CPU", "MEMORY")
+	for _, s := range services {
+		if s.Status == "stopped" {
+			continue
+		}
+		status := s.Status
+		switch status {
+		case "healthy", "running":
+			status = utils.Aqua(fmt.Sprintf("%-10s", status))
+		default:
+			status = utils.Red(fmt.Sprintf("%-10s", status))
+		}
+		fmt.Fprintf(w, "%-*s  %s  %6.1f%%  %10s\n", width, s.Name, status, s.Cpu, units.BytesSize(float64(s.Memory)))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s %d of %d (%d active, %d idle)\n", utils.Bold("Connections:"), db.Total, db.Max, db.Active, db.Idle)
+	// Rate is unknown until the second sample
+	rate := "-"
+	if prev.Db.Transactions > 0 && elapsed > 0 {
+		rate = fmt.Sprintf("%.1f", float64(db.Transactions-prev.Db.Transactions)/elapsed.Seconds())
+	}
+	fmt.Fprintf(w, "%s %s\n", utils.Bold("Transactions/s:"), rate)
+	if utils.Config.Metrics.Enabled {
+		rate = "-"
+		if cur.HasFunctions && prev.HasFunctions && elapsed > 0 {
+			rate = fmt.Sprintf("%.1f", (cur.FunctionRequests-prev.FunctionRequests)/elapsed.Seconds())
+		}
+		fmt.Fprintf(w, "%s %s\n", utils.Bold("Function requests/s:"), rate)
+		fmt.Fprintf(w, "%s http://127.0.0.1:%d/metrics\n", utils.Bold("Metrics:"), utils.Config.Metrics.Port)
+	}
+	fmt.Fprintln(w, strings.Repeat("-", width+35))
+	fmt.Fprintln(w, "Press Ctrl+C to exit.")
+}
@@ -0,0 +1,89 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestDbStats(t *testing.T) {
+	t.Run("queries connection stats", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(DB_STATS_QUERY).
+			Reply("SELECT 1", []interface{}{int64(2), int64(5), int64(8), int64(100), int64(1234)})
+		// Run test
+		db, err := utils.ConnectLocalPostgres(context.Background(), pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer db.Close(context.Background())
+		stats, err := GetDbStats(context.Background(), db)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, DbStats{Active: 2, Idle: 5, Total: 8, Max: 100, Transactions: 1234}, stats)
+	})
+}
+
+func TestPrintDashboard(t *testing.T) {
+	t.Run("prints service health and database activity", func(t *testing.T) {
+		services := []ServiceStats{
+			{Name: "supabase_db_test", Status: "healthy", Cpu: 12.5, Memory: 128 * 1024 * 1024},
+			{Name: "supabase_rest_test", Status: "unhealthy"},
+			{Name: "supabase_studio_test", Status: "stopped"},
+		}
+		prev := Sample{Db: DbStats{Transactions: 1000}}
+		cur := Sample{Db: DbStats{Active: 1, Idle: 2, Total: 3, Max: 100, Transactions: 1100}}
+		var out bytes.Buffer
+		// Run test
+		PrintDashboard(&out, services, cur, prev, 2*time.Second)
+		// Check output
+		assert.Contains(t, out.String(), "supabase_db_test")
+		assert.Contains(t, out.String(), "12.5%")
+		assert.Contains(t, out.String(), "128MiB")
+		assert.Contains(t, out.String(), "unhealthy")
+		assert.NotContains(t, out.String(), "supabase_studio_test")
+		assert.Contains(t, out.String(), "3 of 100 (1 active, 2 idle)")
+		assert.Contains(t, out.String(), "50.0")
+		assert.NotContains(t, out.String(), "Function requests/s:")
+	})
+
+	t.Run("prints function request rate", func(t *testing.T) {
+		utils.Config.Metrics.Enabled = true
+		defer func() { utils.Config.Metrics.Enabled = false }()
+		prev := Sample{FunctionRequests: 10, HasFunctions: true}
+		cur := Sample{FunctionRequests: 30, HasFunctions: true}
+		var out bytes.Buffer
+		// Run test
+		PrintDashboard(&out, nil, cur, prev, 4*time.Second)
+		// Check output
+		assert.Contains(t, out.String(), "Function requests/s: 5.0")
+	})
+}
+
+func TestSumCounter(t *testing.T) {
+	text := `# TYPE edge_runtime_requests_total counter
+edge_runtime_requests_total{function="hello world",status="200"} 3
+edge_runtime_requests_total{function="hello",status="500"} 2 1700000000000
+edge_runtime_requests_total_created 1700000000
+edge_runtime_request_seconds_total{function="hello"} 0.5
+`
+	assert.Equal(t, 5.0, sumCounter(text, "edge_runtime_requests_total"))
+}
+
+func TestCpuPercent(t *testing.T) {
+	var stats types.StatsJSON
+	stats.CPUStats.CPUUsage.TotalUsage = 300
+	stats.PreCPUStats.CPUUsage.TotalUsage = 100
+	stats.CPUStats.SystemUsage = 2000
+	stats.PreCPUStats.SystemUsage = 1000
+	stats.CPUStats.OnlineCPUs = 2
+	assert.Equal(t, 40.0, cpuPercent(stats))
+}
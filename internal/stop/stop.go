@@ -13,6 +13,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/errdefs"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/native"
 	"github.com/supabase/cli/internal/utils"
 )
 
@@ -21,6 +22,13 @@ func Run(ctx context.Context, backup bool, fsys afero.Fs) error {
 	if err := utils.LoadConfigFS(fsys); err != nil {
 		return err
 	}
+	if utils.IsNativeDbRunning() {
+		if err := native.Stop(ctx, fsys); err != nil {
+			return err
+		}
+		fmt.Println("Stopped " + utils.Aqua("supabase") + " native Postgres.")
+		return nil
+	}
 
 	// Stop all services
 	if err := utils.RunProgram(ctx, func(p utils.Program, ctx context.Context) error {
@@ -62,20 +62,18 @@ func ListStorageObjects(ctx context.Context, projectRef, bucket, prefix string,
 	return *data, nil
 }
 
-func UploadStorageObject(ctx context.Context, projectRef, remotePath, localPath string, fsys afero.Fs) error {
+// Overwrites existing objects instead of failing with a conflict.
+func WithUpsert(req *http.Request) {
+	req.Header.Set("x-upsert", "true")
+}
+
+func UploadStorageObject(ctx context.Context, projectRef, remotePath, localPath string, fsys afero.Fs, options ...func(*http.Request)) error {
 	f, err := fsys.Open(localPath)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	// Decode mimetype
-	header := io.LimitReader(f, 512)
-	buf, err := io.ReadAll(header)
-	if err != nil {
-		return err
-	}
-	mimetype := http.DetectContentType(buf)
-	_, err = f.Seek(0, io.SeekStart)
+	mimetype, err := detectMimeType(f)
 	if err != nil {
 		return err
 	}
@@ -94,6 +92,9 @@ func UploadStorageObject(ctx context.Context, projectRef, remotePath, localPath
 	req.Header.Add("Content-Type", mimetype)
 	// Use default value of storage-js: https://github.com/supabase/storage-js/blob/main/src/packages/StorageFileApi.ts#L22
 	req.Header.Add("Cache-Control", "max-age=3600")
+	for _, apply := range options {
+		apply(req)
+	}
 	// Sends request
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -110,6 +111,18 @@ func UploadStorageObject(ctx context.Context, projectRef, remotePath, localPath
 	return nil
 }
 
+// Decodes mimetype from file header and rewinds the file for upload.
+func detectMimeType(f afero.File) (string, error) {
+	header := io.LimitReader(f, 512)
+	buf, err := io.ReadAll(header)
+	if err != nil {
+		return "", err
+	}
+	mimetype := http.DetectContentType(buf)
+	_, err = f.Seek(0, io.SeekStart)
+	return mimetype, err
+}
+
 func DownloadStorageObject(ctx context.Context, projectRef, remotePath, localPath string, fsys afero.Fs) error {
 	apiKey, err := tenant.GetApiKeys(ctx, projectRef)
 	if err != nil {
@@ -0,0 +1,369 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+)
+
+const (
+	// Objects at least this large are uploaded using the resumable protocol
+	RESUMABLE_THRESHOLD = 50 * 1024 * 1024
+	// Ref: https://supabase.com/docs/guides/storage/uploads/resumable-uploads
+	tusVersion = "1.0.0"
+)
+
+var (
+	UploadStateDir = filepath.Join(utils.SupabaseDirPath, utils.TempDir, "uploads")
+
+	// Storage API requires all chunks except the last to be exactly 6MB
+	chunkSize     int64 = 6 * 1024 * 1024
+	maxRetries          = 3
+	retryInterval       = time.Second
+	errUploadGone       = errors.New("Resumable upload no longer exists")
+)
+
+// ProgressFunc is called with the number of bytes transferred so far and the total size.
+type ProgressFunc func(current, total int64)
+
+type uploadState struct {
+	UploadUrl string    `json:"upload_url"`
+	Offset    int64     `json:"offset"`
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"mod_time"`
+}
+
+func UploadStorageObjectResumable(ctx context.Context, projectRef, remotePath, localPath string, progress ProgressFunc, fsys afero.Fs) error {
+	f, err := fsys.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	mimetype, err := detectMimeType(f)
+	if err != nil {
+		return err
+	}
+	apiKey, err := tenant.GetApiKeys(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	remotePath = strings.TrimPrefix(remotePath, "/")
+	statePath := getUploadStatePath(projectRef, remotePath, localPath)
+	// Resume from saved offset if local file is unchanged
+	state := loadUploadState(statePath, info, fsys)
+	if state != nil {
+		if state.Offset, err = getUploadOffset(ctx, state.UploadUrl, apiKey.ServiceRole); errors.Is(err, errUploadGone) {
+			state = nil
+		} else if err != nil {
+			return err
+		}
+	}
+	if state == nil {
+		url, err := createUpload(ctx, projectRef, remotePath, mimetype, info.Size(), apiKey.ServiceRole)
+		if err != nil {
+			return err
+		}
+		state = &uploadState{UploadUrl: url, Size: info.Size(), ModTime: info.ModTime()}
+	}
+	if err := saveUploadState(statePath, *state, fsys); err != nil {
+		return err
+	}
+	if progress != nil {
+		progress(state.Offset, state.Size)
+	}
+	for state.Offset < state.Size {
+		if state.Offset, err = uploadChunkWithRetry(ctx, f, *state, apiKey.ServiceRole); err != nil {
+			return err
+		}
+		if err := saveUploadState(statePath, *state, fsys); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(state.Offset, state.Size)
+		}
+	}
+	return fsys.Remove(statePath)
+}
+
+func getUploadStatePath(projectRef, remotePath, localPath string) string {
+	if abs, err := filepath.Abs(localPath); err == nil {
+		localPath = abs
+	}
+	digest := sha256.Sum256([]byte(projectRef + "\n" + remotePath + "\n" + localPath))
+	return filepath.Join(UploadStateDir, hex.EncodeToString(digest[:8])+".json")
+}
+
+func loadUploadState(statePath string, info os.FileInfo, fsys afero.Fs) *uploadState {
+	data, err := afero.ReadFile(fsys, statePath)
+	if err != nil {
+		return nil
+	}
+	var state uploadState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	if state.Size != info.Size() || !state.ModTime.Equal(info.ModTime()) {
+		fmt.Fprintln(os.Stderr, "Local file has changed, restarting upload from the beginning.")
+		return nil
+	}
+	return &state
+}
+
+func saveUploadState(statePath string, state uploadState, fsys afero.Fs) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return utils.WriteFile(statePath, data, fsys)
+}
+
+func createUpload(ctx context.Context, projectRef, remotePath, mimetype string, size int64, token string) (string, error) {
+	bucket, objectName, _ := strings.Cut(remotePath, "/")
+	metadata := []string{
+		"bucketName " + base64.StdEncoding.EncodeToString([]byte(bucket)),
+		"objectName " + base64.StdEncoding.EncodeToString([]byte(objectName)),
+		"contentType " + base64.StdEncoding.EncodeToString([]byte(mimetype)),
+		"cacheControl " + base64.StdEncoding.EncodeToString([]byte("3600")),
+	}
+	url := fmt.Sprintf("https://%s/storage/v1/upload/resumable", utils.GetSupabaseHost(projectRef))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Tus-Resumable", tusVersion)
+	req.Header.Add("Upload-Length", strconv.FormatInt(size, 10))
+	req.Header.Add("Upload-Metadata", strings.Join(metadata, ","))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("Error status %d: %s", resp.StatusCode, body)
+	}
+	location := resp.Header.Get("Location")
+	if len(location) == 0 {
+		return "", errors.New("Missing Location header in resumable upload response")
+	}
+	return location, nil
+}
+
+func getUploadOffset(ctx context.Context, uploadUrl, token string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, uploadUrl, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Tus-Resumable", tusVersion)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return strconv.ParseInt(resp.Header.Get("Upload-Offset"), 10, 64)
+	case http.StatusNotFound, http.StatusGone:
+		return 0, errUploadGone
+	default:
+		return 0, fmt.Errorf("Error status %d: failed to get upload offset", resp.StatusCode)
+	}
+}
+
+func uploadChunkWithRetry(ctx context.Context, f afero.File, state uploadState, token string) (int64, error) {
+	var err error
+	for i := 0; i <= maxRetries; i++ {
+		if i > 0 {
+			fmt.Fprintf(os.Stderr, "Retrying chunk at offset %d: %v\n", state.Offset, err)
+			t := time.NewTimer(retryInterval * time.Duration(1<<(i-1)))
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return state.Offset, ctx.Err()
+			case <-t.C:
+			}
+			// Server may have persisted a partial chunk before failing
+			offset, headErr := getUploadOffset(ctx, state.UploadUrl, token)
+			if headErr != nil {
+				err = headErr
+				continue
+			}
+			state.Offset = offset
+		}
+		var offset int64
+		if offset, err = uploadChunk(ctx, f, state, token); err == nil {
+			return offset, nil
+		}
+	}
+	return state.Offset, err
+}
+
+func uploadChunk(ctx context.Context, f afero.File, state uploadState, token string) (int64, error) {
+	if _, err := f.Seek(state.Offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	size := chunkSize
+	if remaining := state.Size - state.Offset; remaining < size {
+		size = remaining
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, state.UploadUrl, io.LimitReader(f, size))
+	if err != nil {
+		return 0, err
+	}
+	req.ContentLength = size
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Tus-Resumable", tusVersion)
+	req.Header.Add("Upload-Offset", strconv.FormatInt(state.Offset, 10))
+	req.Header.Add("Content-Type", "application/offset+octet-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("Error status %d: %s", resp.StatusCode, body)
+	}
+	return strconv.ParseInt(resp.Header.Get("Upload-Offset"), 10, 64)
+}
+
+// Downloads to a partial file first so that interrupted transfers can be resumed with a ranged request.
+func DownloadStorageObjectResumable(ctx context.Context, projectRef, remotePath, localPath string, progress ProgressFunc, fsys afero.Fs) error {
+	apiKey, err := tenant.GetApiKeys(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	partPath := localPath + ".part"
+	// ETag of the partial download is needed to validate that the remote object is unchanged
+	etagPath := partPath + ".etag"
+	var offset int64
+	etag, err := afero.ReadFile(fsys, etagPath)
+	if err == nil && len(etag) > 0 {
+		if info, err := fsys.Stat(partPath); err == nil {
+			offset = info.Size()
+		}
+	}
+	remotePath = strings.TrimPrefix(remotePath, "/")
+	url := fmt.Sprintf("https://%s/storage/v1/object/%s", utils.GetSupabaseHost(projectRef), remotePath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Authorization", "Bearer "+apiKey.ServiceRole)
+	if offset > 0 {
+		req.Header.Add("Range", fmt.Sprintf("bytes=%d-", offset))
+		// Server responds with the full object instead if it no longer matches
+		req.Header.Add("If-Range", string(etag))
+	}
+	// Sends request
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	flag := os.O_WRONLY | os.O_CREATE
+	switch resp.StatusCode {
+	case http.StatusOK:
+		offset = 0
+		flag |= os.O_TRUNC
+		if err := saveETag(etagPath, resp.Header.Get("ETag"), fsys); err != nil {
+			return err
+		}
+	case http.StatusPartialContent:
+		flag |= os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Remote object has shrunk since the partial download, start over
+		if err := fsys.Remove(etagPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return DownloadStorageObjectResumable(ctx, projectRef, remotePath, localPath, progress, fsys)
+	default:
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("Error status %d: %s", resp.StatusCode, body)
+	}
+	// Streams to partial file
+	f, err := fsys.OpenFile(partPath, flag, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var w io.Writer = f
+	if progress != nil && resp.ContentLength > 0 {
+		w = io.MultiWriter(f, &progressWriter{
+			current:  offset,
+			total:    offset + resp.ContentLength,
+			progress: progress,
+		})
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := fsys.Rename(partPath, localPath); err != nil {
+		return err
+	}
+	if err := fsys.Remove(etagPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Partial downloads without a strong ETag cannot be validated, so they are never resumed.
+func saveETag(etagPath, etag string, fsys afero.Fs) error {
+	if len(etag) == 0 || strings.HasPrefix(etag, "W/") {
+		if err := fsys.Remove(etagPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return utils.WriteFile(etagPath, []byte(etag), fsys)
+}
+
+type progressWriter struct {
+	current  int64
+	total    int64
+	percent  int64
+	progress ProgressFunc
+}
+
+func (w *progressWriter) Write(b []byte) (int, error) {
+	w.current += int64(len(b))
+	// Only report progress on whole percentage increments
+	if percent := w.current * 100 / w.total; percent > w.percent {
+		w.percent = percent
+		w.progress(w.current, w.total)
+	}
+	return len(b), nil
+}
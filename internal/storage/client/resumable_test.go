@@ -0,0 +1,268 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestResumableUpload(t *testing.T) {
+	// Setup valid project ref
+	projectRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	// Use small chunks to simulate large objects
+	chunkSize, maxRetries, retryInterval = 4, 1, 0
+	storageHost := "https://" + utils.GetSupabaseHost(projectRef)
+	uploadUrl := storageHost + "/storage/v1/upload/resumable/test-upload"
+
+	t.Run("resumes interrupted upload", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/video.mp4", []byte("hello world!"), 0644))
+		statePath := getUploadStatePath(projectRef, "private/video.mp4", "/tmp/video.mp4")
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New(storageHost).
+			Post("/storage/v1/upload/resumable").
+			MatchHeader("Upload-Length", "12").
+			Reply(http.StatusCreated).
+			SetHeader("Location", uploadUrl)
+		gock.New(uploadUrl).
+			Patch("").
+			MatchHeader("Upload-Offset", "0").
+			Reply(http.StatusNoContent).
+			SetHeader("Upload-Offset", "4")
+		gock.New(uploadUrl).
+			Patch("").
+			ReplyError(errors.New("connection reset"))
+		gock.New(uploadUrl).
+			Head("").
+			Reply(http.StatusOK).
+			SetHeader("Upload-Offset", "4")
+		gock.New(uploadUrl).
+			Patch("").
+			ReplyError(errors.New("connection reset"))
+		// Run test
+		err := UploadStorageObjectResumable(context.Background(), projectRef, "/private/video.mp4", "/tmp/video.mp4", nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "connection reset")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Check saved state
+		state := loadUploadState(statePath, mockFileInfo(t, fsys, "/tmp/video.mp4"), fsys)
+		require.NotNil(t, state)
+		assert.Equal(t, uploadUrl, state.UploadUrl)
+		assert.Equal(t, int64(4), state.Offset)
+		// Resume upload
+		gock.New(uploadUrl).
+			Head("").
+			Reply(http.StatusOK).
+			SetHeader("Upload-Offset", "4")
+		gock.New(uploadUrl).
+			Patch("").
+			MatchHeader("Upload-Offset", "4").
+			Reply(http.StatusNoContent).
+			SetHeader("Upload-Offset", "8")
+		gock.New(uploadUrl).
+			Patch("").
+			MatchHeader("Upload-Offset", "8").
+			Reply(http.StatusNoContent).
+			SetHeader("Upload-Offset", "12")
+		var progress []int64
+		assert.NoError(t, UploadStorageObjectResumable(context.Background(), projectRef, "/private/video.mp4", "/tmp/video.mp4", func(current, total int64) {
+			progress = append(progress, current)
+		}, fsys))
+		assert.Equal(t, []int64{4, 8, 12}, progress)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Check state is cleaned up
+		exists, err := afero.Exists(fsys, statePath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("restarts upload on expired url", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/video.mp4", []byte("hi"), 0644))
+		statePath := getUploadStatePath(projectRef, "private/video.mp4", "/tmp/video.mp4")
+		state := uploadState{UploadUrl: uploadUrl, Offset: 1, Size: 2, ModTime: mockFileInfo(t, fsys, "/tmp/video.mp4").ModTime()}
+		require.NoError(t, saveUploadState(statePath, state, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(uploadUrl).
+			Head("").
+			Reply(http.StatusNotFound)
+		gock.New(storageHost).
+			Post("/storage/v1/upload/resumable").
+			Reply(http.StatusCreated).
+			SetHeader("Location", uploadUrl)
+		gock.New(uploadUrl).
+			Patch("").
+			MatchHeader("Upload-Offset", "0").
+			Reply(http.StatusNoContent).
+			SetHeader("Upload-Offset", "2")
+		// Run test
+		assert.NoError(t, UploadStorageObjectResumable(context.Background(), projectRef, "private/video.mp4", "/tmp/video.mp4", nil, fsys))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing bucket", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/video.mp4", []byte("hi"), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(storageHost).
+			Post("/storage/v1/upload/resumable").
+			Reply(http.StatusNotFound).
+			JSON(map[string]string{"error": "Bucket not found"})
+		// Run test
+		err := UploadStorageObjectResumable(context.Background(), projectRef, "private/video.mp4", "/tmp/video.mp4", nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, `"error":"Bucket not found"`)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestResumableDownload(t *testing.T) {
+	// Setup valid project ref
+	projectRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+	storageHost := "https://" + utils.GetSupabaseHost(projectRef)
+
+	t.Run("resumes partial download", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/video.mp4.part", []byte("hello"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/video.mp4.part.etag", []byte(`"v1"`), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		gock.New(storageHost).
+			Get("/storage/v1/object/private/video.mp4").
+			MatchHeader("Range", "bytes=5-").
+			MatchHeader("If-Range", `"v1"`).
+			Reply(http.StatusPartialContent).
+			SetHeader("ETag", `"v1"`).
+			BodyString(" world!")
+		// Run test
+		assert.NoError(t, DownloadStorageObjectResumable(context.Background(), projectRef, "/private/video.mp4", "/tmp/video.mp4", nil, fsys))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Check output
+		data, err := afero.ReadFile(fsys, "/tmp/video.mp4")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world!", string(data))
+		exists, err := afero.Exists(fsys, "/tmp/video.mp4.part")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = afero.Exists(fsys, "/tmp/video.mp4.part.etag")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("restarts download when object has changed", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/video.mp4.part", []byte("stale"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/video.mp4.part.etag", []byte(`"v1"`), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(storageHost).
+			Get("/storage/v1/object/private/video.mp4").
+			MatchHeader("Range", "bytes=5-").
+			MatchHeader("If-Range", `"v1"`).
+			Reply(http.StatusOK).
+			SetHeader("ETag", `"v2"`).
+			BodyString("hello world!")
+		// Run test
+		assert.NoError(t, DownloadStorageObjectResumable(context.Background(), projectRef, "private/video.mp4", "/tmp/video.mp4", nil, fsys))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Check output
+		data, err := afero.ReadFile(fsys, "/tmp/video.mp4")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world!", string(data))
+	})
+
+	t.Run("restarts download without saved etag", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/video.mp4.part", []byte("stale"), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(storageHost).
+			Get("/storage/v1/object/private/video.mp4").
+			AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+				return len(req.Header.Get("Range")) == 0, nil
+			}).
+			Reply(http.StatusOK).
+			BodyString("hello world!")
+		// Run test
+		assert.NoError(t, DownloadStorageObjectResumable(context.Background(), projectRef, "private/video.mp4", "/tmp/video.mp4", nil, fsys))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Check output
+		data, err := afero.ReadFile(fsys, "/tmp/video.mp4")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world!", string(data))
+	})
+
+	t.Run("keeps etag of incomplete download", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(storageHost).
+			Get("/storage/v1/object/private/video.mp4").
+			Reply(http.StatusOK).
+			SetHeader("ETag", `"v1"`).
+			BodyString("hello world!")
+		// Run test
+		err := DownloadStorageObjectResumable(context.Background(), projectRef, "private/video.mp4", "/tmp/video.mp4", nil, &renameErrorFs{Fs: fsys})
+		// Check error
+		assert.ErrorIs(t, err, os.ErrPermission)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Check saved etag
+		etag, err := afero.ReadFile(fsys, "/tmp/video.mp4.part.etag")
+		assert.NoError(t, err)
+		assert.Equal(t, `"v1"`, string(etag))
+	})
+}
+
+type renameErrorFs struct {
+	afero.Fs
+}
+
+func (fs *renameErrorFs) Rename(oldname, newname string) error {
+	return os.ErrPermission
+}
+
+func mockFileInfo(t *testing.T, fsys afero.Fs, path string) os.FileInfo {
+	info, err := fsys.Stat(path)
+	require.NoError(t, err)
+	return info
+}
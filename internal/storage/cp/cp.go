@@ -10,7 +10,9 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/docker/go-units"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/storage"
 	"github.com/supabase/cli/internal/storage/client"
@@ -20,7 +22,7 @@ import (
 
 var errUnsupportedOperation = errors.New("Unsupported operation")
 
-func Run(ctx context.Context, src, dst string, recursive bool, fsys afero.Fs) error {
+func Run(ctx context.Context, src, dst string, recursive, resume bool, fsys afero.Fs) error {
 	srcParsed, err := url.Parse(src)
 	if err != nil {
 		return err
@@ -35,14 +37,19 @@ func Run(ctx context.Context, src, dst string, recursive bool, fsys afero.Fs) er
 	}
 	if strings.ToLower(srcParsed.Scheme) == storage.STORAGE_SCHEME && dstParsed.Scheme == "" {
 		if recursive {
-			return DownloadStorageObjectAll(ctx, projectRef, srcParsed.Path, dst, fsys)
+			return DownloadStorageObjectAll(ctx, projectRef, srcParsed.Path, dst, resume, fsys)
 		}
-		return client.DownloadStorageObject(ctx, projectRef, srcParsed.Path, dst, fsys)
+		if !resume {
+			return client.DownloadStorageObject(ctx, projectRef, srcParsed.Path, dst, fsys)
+		}
+		return utils.RunProgram(ctx, func(p utils.Program, ctx context.Context) error {
+			return client.DownloadStorageObjectResumable(ctx, projectRef, srcParsed.Path, dst, newProgress(p, "Downloading"), fsys)
+		})
 	} else if srcParsed.Scheme == "" && strings.ToLower(dstParsed.Scheme) == storage.STORAGE_SCHEME {
 		if recursive {
-			return UploadStorageObjectAll(ctx, projectRef, dstParsed.Path, src, fsys)
+			return UploadStorageObjectAll(ctx, projectRef, dstParsed.Path, src, resume, fsys)
 		}
-		return client.UploadStorageObject(ctx, projectRef, dstParsed.Path, src, fsys)
+		return uploadStorageObject(ctx, projectRef, dstParsed.Path, src, resume, fsys)
 	} else if strings.ToLower(srcParsed.Scheme) == storage.STORAGE_SCHEME && strings.ToLower(dstParsed.Scheme) == storage.STORAGE_SCHEME {
 		return errors.New("Copying between buckets is not supported")
 	}
@@ -50,7 +57,7 @@ func Run(ctx context.Context, src, dst string, recursive bool, fsys afero.Fs) er
 	return errUnsupportedOperation
 }
 
-func DownloadStorageObjectAll(ctx context.Context, projectRef, remotePath, localPath string, fsys afero.Fs) error {
+func DownloadStorageObjectAll(ctx context.Context, projectRef, remotePath, localPath string, resume bool, fsys afero.Fs) error {
 	// Prepare local directory for download
 	if fi, err := fsys.Stat(localPath); err == nil && fi.IsDir() {
 		localPath = filepath.Join(localPath, path.Base(remotePath))
@@ -67,6 +74,9 @@ func DownloadStorageObjectAll(ctx context.Context, projectRef, remotePath, local
 		if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(dstPath)); err != nil {
 			return err
 		}
+		if resume {
+			return client.DownloadStorageObjectResumable(ctx, projectRef, objectPath, dstPath, nil, fsys)
+		}
 		return client.DownloadStorageObject(ctx, projectRef, objectPath, dstPath, fsys)
 	}); err != nil {
 		return err
@@ -77,7 +87,7 @@ func DownloadStorageObjectAll(ctx context.Context, projectRef, remotePath, local
 	return nil
 }
 
-func UploadStorageObjectAll(ctx context.Context, projectRef, remotePath, localPath string, fsys afero.Fs) error {
+func UploadStorageObjectAll(ctx context.Context, projectRef, remotePath, localPath string, resume bool, fsys afero.Fs) error {
 	noSlash := strings.TrimSuffix(remotePath, "/")
 	// Check if directory exists on remote
 	dirExists := false
@@ -119,14 +129,14 @@ func UploadStorageObjectAll(ctx context.Context, projectRef, remotePath, localPa
 			dstPath = path.Join(dstPath, relPath)
 		}
 		fmt.Fprintln(os.Stderr, "Uploading:", filePath, "=>", dstPath)
-		err = client.UploadStorageObject(ctx, projectRef, dstPath, filePath, fsys)
+		err = uploadStorageObject(ctx, projectRef, dstPath, filePath, resume, fsys)
 		if err != nil && strings.Contains(err.Error(), `"error":"Bucket not found"`) {
 			// Retry after creating bucket
 			if bucket, prefix := storage.SplitBucketPrefix(dstPath); len(prefix) > 0 {
 				if _, err := client.CreateStorageBucket(ctx, projectRef, bucket); err != nil {
 					return err
 				}
-				err = client.UploadStorageObject(ctx, projectRef, dstPath, filePath, fsys)
+				err = uploadStorageObject(ctx, projectRef, dstPath, filePath, resume, fsys)
 			}
 		}
 		return err
@@ -136,3 +146,36 @@ func UploadStorageObjectAll(ctx context.Context, projectRef, remotePath, localPa
 func IsDir(objectPrefix string) bool {
 	return len(objectPrefix) == 0 || strings.HasSuffix(objectPrefix, "/")
 }
+
+// Large objects are uploaded in chunks so that interrupted transfers can be resumed.
+func uploadStorageObject(ctx context.Context, projectRef, remotePath, localPath string, resume bool, fsys afero.Fs) error {
+	if resume {
+		if info, err := fsys.Stat(localPath); err == nil && info.Size() >= client.RESUMABLE_THRESHOLD {
+			return utils.RunProgram(ctx, func(p utils.Program, ctx context.Context) error {
+				return client.UploadStorageObjectResumable(ctx, projectRef, remotePath, localPath, newProgress(p, "Uploading"), fsys)
+			})
+		}
+	}
+	return client.UploadStorageObject(ctx, projectRef, remotePath, localPath, fsys)
+}
+
+func newProgress(p utils.Program, action string) client.ProgressFunc {
+	start := time.Now()
+	initial := int64(-1)
+	return func(current, total int64) {
+		// Resumed transfers should not count towards the transfer rate
+		if initial < 0 {
+			initial = current
+		}
+		status := fmt.Sprintf("%s %s / %s", action, units.HumanSize(float64(current)), units.HumanSize(float64(total)))
+		if done := current - initial; done > 0 && current < total {
+			eta := time.Duration(float64(time.Since(start)) * float64(total-current) / float64(done))
+			status += fmt.Sprintf(" (ETA %s)", eta.Round(time.Second))
+		}
+		p.Send(utils.StatusMsg(status))
+		if total > 0 {
+			percent := float64(current) / float64(total)
+			p.Send(utils.ProgressMsg(&percent))
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package storagesync
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/storage"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type Action string
+
+const (
+	ActionUpload   Action = "upload"
+	ActionDownload Action = "download"
+	ActionDelete   Action = "delete"
+)
+
+// Task is a single transfer or deletion required to make the destination match the source.
+type Task struct {
+	Action Action
+	// Path relative to both source and destination roots, using forward slashes
+	Path string
+}
+
+type SyncOptions struct {
+	Delete bool
+	DryRun bool
+	Jobs   uint
+}
+
+// Number of object keys to delete per request
+const deleteBatchSize = 100
+
+var errUnsupportedSync = errors.New("Sync requires one local directory and one storage path.")
+
+func Run(ctx context.Context, src, dst string, opts SyncOptions, fsys afero.Fs) error {
+	projectRef, err := utils.LoadProjectRef(fsys)
+	if err != nil {
+		return err
+	}
+	srcParsed, err := url.Parse(src)
+	if err != nil {
+		return err
+	}
+	dstParsed, err := url.Parse(dst)
+	if err != nil {
+		return err
+	}
+	var upload bool
+	var localDir, remotePath string
+	if srcParsed.Scheme == "" && strings.ToLower(dstParsed.Scheme) == storage.STORAGE_SCHEME {
+		upload, localDir, remotePath = true, src, dstParsed.Path
+	} else if strings.ToLower(srcParsed.Scheme) == storage.STORAGE_SCHEME && dstParsed.Scheme == "" {
+		localDir, remotePath = dst, srcParsed.Path
+	} else {
+		return errUnsupportedSync
+	}
+	bucket, prefix := storage.SplitBucketPrefix(remotePath)
+	if len(bucket) == 0 {
+		return storage.ErrInvalidURL
+	}
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	// 1. Compare local and remote trees
+	local, err := listLocalFiles(localDir, fsys)
+	if err != nil {
+		return err
+	}
+	remote, err := listRemoteObjects(ctx, projectRef, bucket, prefix)
+	if err != nil {
+		return err
+	}
+	tasks, err := planSync(upload, opts.Delete, localDir, local, remote, fsys)
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		fmt.Fprintln(os.Stderr, "Everything is up to date.")
+		return nil
+	}
+	// 2. Apply changes
+	if opts.DryRun {
+		for _, t := range tasks {
+			fmt.Printf("(dry run) %s: %s\n", t.Action, t.Path)
+		}
+		return nil
+	}
+	remoteRoot := path.Join(bucket, prefix)
+	return applyTasks(ctx, projectRef, upload, localDir, remoteRoot, tasks, opts.Jobs, fsys)
+}
+
+// Returns regular files keyed by slash separated path relative to root.
+func listLocalFiles(root string, fsys afero.Fs) (map[string]int64, error) {
+	result := map[string]int64{}
+	if err := afero.Walk(fsys, root, func(filePath string, info fs.FileInfo, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && filePath == root {
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		result[filepath.ToSlash(relPath)] = info.Size()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Returns object metadata keyed by path relative to prefix.
+func listRemoteObjects(ctx context.Context, projectRef, bucket, prefix string) (map[string]client.ObjectMetadata, error) {
+	result := map[string]client.ObjectMetadata{}
+	dirQueue := []string{prefix}
+	for len(dirQueue) > 0 {
+		dir := dirQueue[len(dirQueue)-1]
+		dirQueue = dirQueue[:len(dirQueue)-1]
+		for page := 0; ; page++ {
+			objects, err := client.ListStorageObjects(ctx, projectRef, bucket, dir, page)
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range objects {
+				if o.Id == nil {
+					dirQueue = append(dirQueue, dir+o.Name+"/")
+					continue
+				}
+				var metadata client.ObjectMetadata
+				if o.Metadata != nil {
+					metadata = *o.Metadata
+				}
+				result[strings.TrimPrefix(dir+o.Name, prefix)] = metadata
+			}
+			if len(objects) < client.PAGE_LIMIT {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func planSync(upload, delete bool, localDir string, local map[string]int64, remote map[string]client.ObjectMetadata, fsys afero.Fs) ([]Task, error) {
+	var tasks []Task
+	action, src, dst := ActionDownload, keys(remote), map[string]bool{}
+	for k := range local {
+		dst[k] = true
+	}
+	if upload {
+		action, src, dst = ActionUpload, keys(local), map[string]bool{}
+		for k := range remote {
+			dst[k] = true
+		}
+	}
+	for _, p := range src {
+		if dst[p] {
+			changed, err := isChanged(filepath.Join(localDir, filepath.FromSlash(p)), local[p], remote[p], fsys)
+			if err != nil {
+				return nil, err
+			} else if !changed {
+				continue
+			}
+		}
+		tasks = append(tasks, Task{Action: action, Path: p})
+	}
+	if delete {
+		sources := map[string]bool{}
+		for _, p := range src {
+			sources[p] = true
+		}
+		var orphans []string
+		for p := range dst {
+			if !sources[p] {
+				orphans = append(orphans, p)
+			}
+		}
+		sort.Strings(orphans)
+		for _, p := range orphans {
+			tasks = append(tasks, Task{Action: ActionDelete, Path: p})
+		}
+	}
+	return tasks, nil
+}
+
+// Compares size first, then content hash when the remote etag is a plain md5 digest.
+func isChanged(localPath string, size int64, remote client.ObjectMetadata, fsys afero.Fs) (bool, error) {
+	if size != int64(remote.Size) {
+		return true, nil
+	}
+	etag := strings.Trim(remote.ETag, `"`)
+	// Multipart uploads have etags suffixed by part count which cannot be compared
+	if len(etag) != md5.Size*2 {
+		return false, nil
+	}
+	f, err := fsys.Open(localPath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false, err
+	}
+	return hex.EncodeToString(h.Sum(nil)) != etag, nil
+}
+
+func applyTasks(ctx context.Context, projectRef string, upload bool, localDir, remoteRoot string, tasks []Task, jobs uint, fsys afero.Fs) error {
+	var transfers, localDeletes []Task
+	var remoteDeletes []string
+	for _, t := range tasks {
+		// Deletes apply to the destination, which is remote when uploading
+		if t.Action != ActionDelete {
+			transfers = append(transfers, t)
+		} else if upload {
+			remoteDeletes = append(remoteDeletes, t.Path)
+		} else {
+			localDeletes = append(localDeletes, t)
+		}
+	}
+	if jobs == 0 {
+		jobs = 1
+	}
+	taskCh := make(chan Task)
+	errCh := make(chan error, len(transfers))
+	for i := uint(0); i < jobs && int(i) < len(transfers); i++ {
+		go func() {
+			for t := range taskCh {
+				errCh <- transfer(ctx, projectRef, localDir, remoteRoot, t, fsys)
+			}
+		}()
+	}
+	for _, t := range transfers {
+		taskCh <- t
+	}
+	close(taskCh)
+	var errs []error
+	for range transfers {
+		if err := <-errCh; err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// Files are counted individually since a failed batch delete affects many objects
+	failed := len(errs)
+	if deletes := len(localDeletes) + len(remoteDeletes); deletes > 0 && failed > 0 {
+		// Deleting after a failed transfer could leave a file missing on both sides
+		fmt.Fprintf(os.Stderr, "Skipped deleting %d files because of failed transfers.\n", deletes)
+		failed += deletes
+		localDeletes, remoteDeletes = nil, nil
+	}
+	for _, t := range localDeletes {
+		fmt.Fprintln(os.Stderr, "Deleting:", t.Path)
+		if err := fsys.Remove(filepath.Join(localDir, filepath.FromSlash(t.Path))); err != nil {
+			errs = append(errs, err)
+			failed++
+		}
+	}
+	bucket, prefix := storage.SplitBucketPrefix(remoteRoot)
+	for i := 0; i < len(remoteDeletes); i += deleteBatchSize {
+		end := i + deleteBatchSize
+		if end > len(remoteDeletes) {
+			end = len(remoteDeletes)
+		}
+		var batch []string
+		for _, p := range remoteDeletes[i:end] {
+			fmt.Fprintln(os.Stderr, "Deleting:", path.Join(remoteRoot, p))
+			batch = append(batch, path.Join(prefix, p))
+		}
+		if _, err := client.DeleteStorageObjects(ctx, projectRef, bucket, batch); err != nil {
+			errs = append(errs, err)
+			failed += len(batch)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Synced %d of %d files.\n", len(tasks)-failed, len(tasks))
+	return errors.Join(errs...)
+}
+
+func transfer(ctx context.Context, projectRef, localDir, remoteRoot string, t Task, fsys afero.Fs) error {
+	localPath := filepath.Join(localDir, filepath.FromSlash(t.Path))
+	remotePath := path.Join(remoteRoot, t.Path)
+	if t.Action == ActionUpload {
+		fmt.Fprintln(os.Stderr, "Uploading:", localPath, "=>", remotePath)
+		return client.UploadStorageObject(ctx, projectRef, remotePath, localPath, fsys, client.WithUpsert)
+	}
+	fmt.Fprintln(os.Stderr, "Downloading:", remotePath, "=>", localPath)
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(localPath)); err != nil {
+		return err
+	}
+	return client.DownloadStorageObject(ctx, projectRef, remotePath, localPath, fsys)
+}
+
+func keys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,159 @@
+package storagesync
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/storage/client"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// md5 of "hello"
+const helloETag = `"5d41402abc4b2a76b9719d911017c592"`
+
+func TestStorageSync(t *testing.T) {
+	t.Run("uploads changed files and deletes orphans", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		projectRef := apitest.RandomProjectRef()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(projectRef), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "public/index.html", []byte("hello"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "public/css/main.css", []byte("body{}"), 0644))
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		storageHost := "https://" + utils.GetSupabaseHost(projectRef)
+		gock.New(storageHost).
+			Post("/storage/v1/object/list/assets").
+			JSON(client.ListObjectsQuery{Prefix: "", Limit: client.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]client.ObjectResponse{{
+				Name:     "index.html",
+				Id:       utils.Ptr("1"),
+				Metadata: &client.ObjectMetadata{ETag: helloETag, Size: 5},
+			}, {
+				Name:     "stale.txt",
+				Id:       utils.Ptr("2"),
+				Metadata: &client.ObjectMetadata{ETag: helloETag, Size: 5},
+			}})
+		gock.New(storageHost).
+			Post("/storage/v1/object/assets/css/main.css").
+			MatchHeader("x-upsert", "true").
+			Reply(http.StatusOK)
+		gock.New(storageHost).
+			Delete("/storage/v1/object/assets").
+			JSON(client.DeleteObjectsRequest{Prefixes: []string{"stale.txt"}}).
+			Reply(http.StatusOK).
+			JSON([]client.DeleteObjectsResponse{{Name: "stale.txt"}})
+		// Run test
+		err := Run(context.Background(), "public", "ss:///assets", SyncOptions{Delete: true, Jobs: 2}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips deletes on failed upload", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		projectRef := apitest.RandomProjectRef()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(projectRef), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "public/index.html", []byte("hello world"), 0644))
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{
+				Name:   "service_role",
+				ApiKey: "service-key",
+			}})
+		storageHost := "https://" + utils.GetSupabaseHost(projectRef)
+		gock.New(storageHost).
+			Post("/storage/v1/object/list/assets").
+			JSON(client.ListObjectsQuery{Prefix: "", Limit: client.PAGE_LIMIT}).
+			Reply(http.StatusOK).
+			JSON([]client.ObjectResponse{{
+				Name:     "stale.txt",
+				Id:       utils.Ptr("2"),
+				Metadata: &client.ObjectMetadata{ETag: helloETag, Size: 5},
+			}})
+		gock.New(storageHost).
+			Post("/storage/v1/object/assets/index.html").
+			Reply(http.StatusServiceUnavailable)
+		deleteMock := gock.New(storageHost).
+			Delete("/storage/v1/object/assets").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), "public", "ss:///assets", SyncOptions{Delete: true}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Error status 503")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.True(t, deleteMock.Mock.Request().Counter > 0)
+	})
+
+	t.Run("throws error on unsupported paths", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ProjectRefPath, []byte(apitest.RandomProjectRef()), 0644))
+		// Run test
+		err := Run(context.Background(), "ss:///a", "ss:///b", SyncOptions{}, fsys)
+		// Check error
+		assert.ErrorIs(t, err, errUnsupportedSync)
+	})
+}
+
+func TestPlanSync(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, filepath.Join("out", "same.txt"), []byte("hello"), 0644))
+	require.NoError(t, afero.WriteFile(fsys, filepath.Join("out", "edited.txt"), []byte("world"), 0644))
+	require.NoError(t, afero.WriteFile(fsys, filepath.Join("out", "local.txt"), []byte("local"), 0644))
+	local, err := listLocalFiles("out", fsys)
+	require.NoError(t, err)
+	remote := map[string]client.ObjectMetadata{
+		"same.txt":       {ETag: helloETag, Size: 5},
+		"edited.txt":     {ETag: helloETag, Size: 5},
+		"nested/new.txt": {ETag: helloETag, Size: 5},
+		"multipart.bin":  {ETag: `"abc-2"`, Size: 5},
+	}
+
+	t.Run("plans downloads", func(t *testing.T) {
+		tasks, err := planSync(false, true, "out", local, remote, fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, []Task{
+			{Action: ActionDownload, Path: "edited.txt"},
+			{Action: ActionDownload, Path: "multipart.bin"},
+			{Action: ActionDownload, Path: "nested/new.txt"},
+			{Action: ActionDelete, Path: "local.txt"},
+		}, tasks)
+	})
+
+	t.Run("plans uploads without delete", func(t *testing.T) {
+		tasks, err := planSync(true, false, "out", local, remote, fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, []Task{
+			{Action: ActionUpload, Path: "edited.txt"},
+			{Action: ActionUpload, Path: "local.txt"},
+		}, tasks)
+	})
+}
@@ -0,0 +1,251 @@
+package rls
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/render"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ExpectAllow = "allow"
+	ExpectDeny  = "deny"
+)
+
+// Fixture is a YAML file of RLS test cases that share the same setup SQL.
+type Fixture struct {
+	// Runs as the connecting user before each test case
+	Setup string     `yaml:"setup"`
+	Tests []TestCase `yaml:"tests"`
+}
+
+type TestCase struct {
+	Name   string         `yaml:"name"`
+	Role   string         `yaml:"role"`
+	Claims map[string]any `yaml:"claims"`
+	Query  string         `yaml:"query"`
+	Expect string         `yaml:"expect"`
+	// Expected number of rows returned or affected, only checked when allowed
+	Rows *int64 `yaml:"rows"`
+}
+
+type TestResult struct {
+	File   string `json:"file"`
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+	Expect string `json:"expect"`
+	Actual string `json:"actual"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+const (
+	SET_CLAIMS = "SELECT set_config('request.jwt.claims', $1, true), set_config('request.jwt.claim.sub', $2, true)"
+	SET_ROLE   = "SET LOCAL ROLE "
+)
+
+func Run(ctx context.Context, paths []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	files, err := findFixtures(paths, fsys)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("No RLS tests found in " + utils.Bold(utils.RlsTestsDir))
+	}
+	fixtures := make([]Fixture, len(files))
+	for i, path := range files {
+		if fixtures[i], err = loadFixture(path, fsys); err != nil {
+			return err
+		}
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	var results []TestResult
+	for i, path := range files {
+		fixture := fixtures[i]
+		fmt.Fprintln(os.Stderr, "Running RLS tests in", utils.Bold(path))
+		for _, tc := range fixture.Tests {
+			r, err := runTestCase(ctx, conn, fixture.Setup, tc)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			r.File = filepath.ToSlash(path)
+			results = append(results, r)
+		}
+	}
+	if err := printResults(results); err != nil {
+		return err
+	}
+	failed := 0
+	for _, r := range results {
+		if !r.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d RLS tests failed.", failed, len(results))
+	}
+	fmt.Fprintf(os.Stderr, "All %d RLS tests passed.\n", len(results))
+	return nil
+}
+
+func findFixtures(paths []string, fsys afero.Fs) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{utils.RlsTestsDir}
+	}
+	var files []string
+	for _, p := range paths {
+		if exists, err := afero.Exists(fsys, p); err != nil {
+			return nil, err
+		} else if !exists && p == utils.RlsTestsDir {
+			continue
+		}
+		if err := afero.Walk(fsys, p, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			ext := filepath.Ext(path)
+			if info.Mode().IsRegular() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, path)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func loadFixture(path string, fsys afero.Fs) (Fixture, error) {
+	var fixture Fixture
+	data, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return fixture, err
+	}
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&fixture); err != nil {
+		return fixture, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for i, tc := range fixture.Tests {
+		if len(tc.Name) == 0 {
+			fixture.Tests[i].Name = fmt.Sprintf("test %d", i+1)
+		}
+		if tc.Expect != ExpectAllow && tc.Expect != ExpectDeny {
+			return fixture, fmt.Errorf("%s: test %s must expect one of [ %s | %s ]", path, utils.Aqua(fixture.Tests[i].Name), ExpectAllow, ExpectDeny)
+		}
+		if len(tc.Role) == 0 {
+			fixture.Tests[i].Role = "authenticated"
+		}
+	}
+	return fixture, nil
+}
+
+// Each test case runs in its own transaction that is always rolled back.
+func runTestCase(ctx context.Context, conn *pgx.Conn, setup string, tc TestCase) (TestResult, error) {
+	result := TestResult{Name: tc.Name, Role: tc.Role, Expect: tc.Expect}
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		if err := tx.Rollback(context.Background()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
+	if len(strings.TrimSpace(setup)) > 0 {
+		if _, err := tx.Exec(ctx, setup); err != nil {
+			return result, fmt.Errorf("failed to run setup: %w", err)
+		}
+	}
+	claims := map[string]any{"role": tc.Role}
+	for k, v := range tc.Claims {
+		claims[k] = v
+	}
+	encoded, err := json.Marshal(claims)
+	if err != nil {
+		return result, err
+	}
+	sub := fmt.Sprint(claims["sub"])
+	if _, ok := claims["sub"]; !ok {
+		sub = ""
+	}
+	if _, err := tx.Exec(ctx, SET_CLAIMS, string(encoded), sub); err != nil {
+		return result, err
+	}
+	if _, err := tx.Exec(ctx, SET_ROLE+pgx.Identifier{tc.Role}.Sanitize()); err != nil {
+		return result, err
+	}
+	tag, err := tx.Exec(ctx, tc.Query)
+	result.Actual, result.Detail = evaluate(tag, err)
+	result.Passed = result.Actual == tc.Expect
+	if result.Passed && tc.Expect == ExpectAllow && tc.Rows != nil && tag.RowsAffected() != *tc.Rows {
+		result.Passed = false
+		result.Detail = fmt.Sprintf("expected %d rows, got %d", *tc.Rows, tag.RowsAffected())
+	}
+	return result, nil
+}
+
+// Queries are denied if they raise a permission error or RLS filters out every row.
+func evaluate(tag pgconn.CommandTag, err error) (string, string) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InsufficientPrivilege {
+		return ExpectDeny, pgErr.Message
+	} else if err != nil {
+		return "error", err.Error()
+	}
+	if tag.RowsAffected() == 0 {
+		return ExpectDeny, "no rows returned or affected"
+	}
+	return ExpectAllow, fmt.Sprintf("%d rows", tag.RowsAffected())
+}
+
+func printResults(results []TestResult) error {
+	if !render.IsPretty() {
+		return render.Encode(results)
+	}
+	table := `|FILE|TEST|ROLE|EXPECTED|ACTUAL|RESULT|
+|-|-|-|-|-|-|
+`
+	for _, r := range results {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+		}
+		actual := r.Actual
+		if len(r.Detail) > 0 {
+			actual += ": " + r.Detail
+		}
+		table += fmt.Sprintf("|`%s`|%s|`%s`|`%s`|%s|`%s`|\n",
+			r.File,
+			escape(r.Name),
+			r.Role,
+			r.Expect,
+			escape(actual),
+			status,
+		)
+	}
+	return list.RenderTable(table)
+}
+
+func escape(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
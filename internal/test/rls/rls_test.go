@@ -0,0 +1,120 @@
+package rls
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+var fixture = []byte(`setup: insert into todos (owner) values ('u1')
+tests:
+  - name: owner can read
+    claims:
+      sub: u1
+    query: select * from todos
+    expect: allow
+    rows: 1
+  - name: anon cannot delete
+    role: anon
+    query: delete from todos
+    expect: deny
+`)
+
+func TestRlsCommand(t *testing.T) {
+	path := filepath.Join(utils.RlsTestsDir, "todos.yaml")
+
+	t.Run("passes all tests", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, fixture, 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query("insert into todos (owner) values ('u1')").
+			Reply("INSERT 0 1").
+			Query(SET_CLAIMS, `{"role":"authenticated","sub":"u1"}`, "u1").
+			Reply("SELECT 1", []interface{}{"", ""}).
+			Query(SET_ROLE+`"authenticated"`).
+			Reply("SET").
+			Query("select * from todos").
+			Reply("SELECT 1", []interface{}{"u1"}).
+			Query("rollback").Reply("ROLLBACK").
+			Query("begin").Reply("BEGIN").
+			Query("insert into todos (owner) values ('u1')").
+			Reply("INSERT 0 1").
+			Query(SET_CLAIMS, `{"role":"anon"}`, "").
+			Reply("SELECT 1", []interface{}{"", ""}).
+			Query(SET_ROLE+`"anon"`).
+			Reply("SET").
+			Query("delete from todos").
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table todos").
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		err := Run(context.Background(), nil, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on failed assertion", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(`tests:
+  - query: select * from todos
+    expect: deny
+`), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(SET_CLAIMS, `{"role":"authenticated"}`, "").
+			Reply("SELECT 1", []interface{}{"", ""}).
+			Query(SET_ROLE+`"authenticated"`).
+			Reply("SET").
+			Query("select * from todos").
+			Reply("SELECT 1", []interface{}{"u1"}).
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		err := Run(context.Background(), nil, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "1 of 1 RLS tests failed.")
+	})
+
+	t.Run("throws error on invalid expectation", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(`tests:
+  - query: select 1
+    expect: maybe
+`), 0644))
+		// Run test
+		err := Run(context.Background(), nil, dbConfig, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "must expect one of")
+	})
+
+	t.Run("throws error on missing fixtures", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), nil, dbConfig, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "No RLS tests found")
+	})
+}
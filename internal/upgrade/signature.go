@@ -0,0 +1,67 @@
+package upgrade
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// SigningKey is the minisign public key of release checksums, assigned using `-ldflags` like utils.Version.
+var SigningKey string
+
+var ErrSignature = errors.New("invalid signature")
+
+const (
+	algLegacy    = "Ed"
+	algPrehashed = "ED"
+
+	trustedCommentPrefix = "trusted comment: "
+)
+
+// Verifies a minisign signature of message against a base64 encoded public key.
+// Both the legacy and prehashed signature algorithms are supported.
+func VerifySignature(message, signature []byte, publicKey string) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(key) != 2+8+ed25519.PublicKeySize || string(key[:2]) != algLegacy {
+		return fmt.Errorf("%w: malformed public key", ErrSignature)
+	}
+	keyId, pub := key[2:10], ed25519.PublicKey(key[10:])
+	// Signature files consist of an untrusted comment, the signature, a trusted comment and its signature
+	lines := strings.Split(strings.ReplaceAll(string(signature), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], trustedCommentPrefix) {
+		return fmt.Errorf("%w: malformed signature file", ErrSignature)
+	}
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed signature", ErrSignature)
+	}
+	if !bytes.Equal(sig[2:10], keyId) {
+		return fmt.Errorf("%w: signed by a different key", ErrSignature)
+	}
+	switch string(sig[:2]) {
+	case algLegacy:
+	case algPrehashed:
+		digest := blake2b.Sum512(message)
+		message = digest[:]
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %s", ErrSignature, sig[:2])
+	}
+	if !ed25519.Verify(pub, message, sig[10:]) {
+		return ErrSignature
+	}
+	// The trusted comment is signed together with the signature to prevent tampering
+	comment := strings.TrimPrefix(lines[2], trustedCommentPrefix)
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed trusted comment signature", ErrSignature)
+	}
+	if !ed25519.Verify(pub, append(sig[10:], comment...), global) {
+		return fmt.Errorf("%w: trusted comment", ErrSignature)
+	}
+	return nil
+}
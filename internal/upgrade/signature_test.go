@@ -0,0 +1,81 @@
+package upgrade
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+type signingKey struct {
+	publicKey string
+	keyId     []byte
+	private   ed25519.PrivateKey
+}
+
+func newSigningKey(t *testing.T) signingKey {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyId := []byte("abcdefgh")
+	key := append([]byte(algLegacy), keyId...)
+	return signingKey{
+		publicKey: base64.StdEncoding.EncodeToString(append(key, pub...)),
+		keyId:     keyId,
+		private:   priv,
+	}
+}
+
+// Produces a prehashed minisign signature file.
+func (k signingKey) sign(t *testing.T, message []byte) string {
+	digest := blake2b.Sum512(message)
+	sig := ed25519.Sign(k.private, digest[:])
+	comment := "timestamp:1697356800"
+	global := ed25519.Sign(k.private, append(append([]byte{}, sig...), comment...))
+	encoded := append(append([]byte(algPrehashed), k.keyId...), sig...)
+	return fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(encoded),
+		comment,
+		base64.StdEncoding.EncodeToString(global),
+	)
+}
+
+func TestVerifySignature(t *testing.T) {
+	key := newSigningKey(t)
+	message := []byte("checksums")
+
+	t.Run("verifies prehashed signature", func(t *testing.T) {
+		assert.NoError(t, VerifySignature(message, []byte(key.sign(t, message)), key.publicKey))
+	})
+
+	t.Run("throws error on different key", func(t *testing.T) {
+		other := newSigningKey(t)
+		// Run test
+		err := VerifySignature(message, []byte(other.sign(t, message)), key.publicKey)
+		// Check error
+		assert.ErrorIs(t, err, ErrSignature)
+	})
+
+	t.Run("throws error on tampered trusted comment", func(t *testing.T) {
+		tampered := replaceComment(key.sign(t, message))
+		// Run test
+		err := VerifySignature(message, []byte(tampered), key.publicKey)
+		// Check error
+		assert.ErrorContains(t, err, "invalid signature: trusted comment")
+	})
+
+	t.Run("throws error on malformed public key", func(t *testing.T) {
+		err := VerifySignature(message, []byte(key.sign(t, message)), "invalid")
+		// Check error
+		assert.ErrorContains(t, err, "malformed public key")
+	})
+}
+
+func replaceComment(sig string) string {
+	return strings.Replace(sig, "timestamp:1697356800", "timestamp:0", 1)
+}
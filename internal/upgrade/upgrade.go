@@ -0,0 +1,257 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/google/go-github/v53/github"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils"
+	"golang.org/x/mod/semver"
+	"golang.org/x/oauth2"
+)
+
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+
+	repoOwner = "supabase"
+	repoName  = "cli"
+)
+
+var (
+	Channels = []string{ChannelStable, ChannelBeta}
+
+	ErrDisabled = errors.New("Self-update is disabled by " + utils.Aqua("SUPABASE_DISABLE_UPGRADE") + ". Upgrade the CLI with your package manager instead.")
+	ErrChecksum = errors.New("checksum mismatch")
+	ErrUnsigned = errors.New("This build of Supabase CLI cannot verify release signatures. Upgrade the CLI with your package manager instead.")
+)
+
+// Replaces the running binary with the latest release on the selected channel.
+func Run(ctx context.Context, channel string, checkOnly bool, fsys afero.Fs) error {
+	// Managed installs, ie. Homebrew or npm, should be upgraded by their package manager
+	if viper.GetBool("DISABLE_UPGRADE") {
+		return ErrDisabled
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+	// Windows keeps the previous binary around until the next upgrade
+	_ = fsys.Remove(exe + ".old")
+	release, err := GetLatestRelease(ctx, newClient(ctx), channel)
+	if err != nil {
+		return err
+	}
+	latest := release.GetTagName()
+	if !IsNewer(latest, utils.Version) {
+		fmt.Fprintln(os.Stderr, "Supabase CLI is up to date:", utils.Aqua(utils.Version))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "A new version of Supabase CLI is available:", utils.Aqua(latest), "(currently installed "+utils.Version+")")
+	if checkOnly {
+		return nil
+	}
+	if !utils.PromptYesNo("Do you want to upgrade to "+utils.Aqua(latest)+"?", true, os.Stdin) {
+		return errors.New("Not upgrading Supabase CLI.")
+	}
+	binary, err := Download(ctx, release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	if err := ReplaceBinary(exe, binary, runtime.GOOS, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Upgraded Supabase CLI to", utils.Aqua(latest))
+	return nil
+}
+
+func newClient(ctx context.Context) *github.Client {
+	// Authenticated requests are subject to a higher rate limit
+	if token := os.Getenv("GITHUB_TOKEN"); len(token) > 0 {
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+		return github.NewClient(oauth2.NewClient(ctx, ts))
+	}
+	return github.NewClient(nil)
+}
+
+// Returns the latest published release, including pre-releases on the beta channel.
+func GetLatestRelease(ctx context.Context, client *github.Client, channel string) (*github.RepositoryRelease, error) {
+	switch channel {
+	case ChannelStable:
+		release, _, err := client.Repositories.GetLatestRelease(ctx, repoOwner, repoName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest release: %w", err)
+		}
+		return release, nil
+	case ChannelBeta:
+		releases, _, err := client.Repositories.ListReleases(ctx, repoOwner, repoName, &github.ListOptions{PerPage: 10})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list releases: %w", err)
+		}
+		for _, r := range releases {
+			if !r.GetDraft() {
+				return r, nil
+			}
+		}
+		return nil, errors.New("No releases found on channel: " + channel)
+	}
+	return nil, fmt.Errorf("Unknown channel %s, must be one of: %s", utils.Aqua(channel), strings.Join(Channels, ", "))
+}
+
+// Development builds are not tagged with a valid version, so they can always be upgraded.
+func IsNewer(latest, current string) bool {
+	current = "v" + strings.TrimPrefix(current, "v")
+	if !semver.IsValid(current) {
+		return true
+	}
+	return semver.Compare(latest, current) > 0
+}
+
+// Downloads the release archive for the target platform and returns the verified binary.
+func Download(ctx context.Context, release *github.RepositoryRelease, goos, goarch string) ([]byte, error) {
+	archiveName := fmt.Sprintf("supabase_%s_%s.tar.gz", goos, goarch)
+	if len(SigningKey) == 0 {
+		return nil, ErrUnsigned
+	}
+	var archiveUrl, checksumUrl, signatureUrl string
+	for _, a := range release.Assets {
+		if a.GetName() == archiveName {
+			archiveUrl = a.GetBrowserDownloadURL()
+		} else if strings.HasSuffix(a.GetName(), "checksums.txt") {
+			checksumUrl = a.GetBrowserDownloadURL()
+		} else if strings.HasSuffix(a.GetName(), "checksums.txt.minisig") {
+			signatureUrl = a.GetBrowserDownloadURL()
+		}
+	}
+	if len(archiveUrl) == 0 {
+		return nil, fmt.Errorf("Release %s has no binary for %s/%s", release.GetTagName(), goos, goarch)
+	}
+	if len(checksumUrl) == 0 {
+		return nil, fmt.Errorf("Release %s has no checksums file", release.GetTagName())
+	}
+	if len(signatureUrl) == 0 {
+		return nil, fmt.Errorf("Release %s has no checksums signature", release.GetTagName())
+	}
+	checksums, err := fetch(ctx, checksumUrl)
+	if err != nil {
+		return nil, err
+	}
+	// Checksums are only trusted once signed by the release key embedded in this binary
+	signature, err := fetch(ctx, signatureUrl)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifySignature(checksums, signature, SigningKey); err != nil {
+		return nil, fmt.Errorf("failed to verify checksums of release %s: %w", release.GetTagName(), err)
+	}
+	archive, err := fetch(ctx, archiveUrl)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyChecksum(archive, archiveName, checksums); err != nil {
+		return nil, err
+	}
+	binary := "supabase"
+	if goos == "windows" {
+		binary += ".exe"
+	}
+	return extract(archive, binary)
+}
+
+func fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Checks data against its entry in a sha256sum formatted checksums file.
+func VerifyChecksum(data []byte, name string, checksums []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if actual := hex.EncodeToString(sum[:]); actual != fields[0] {
+			return fmt.Errorf("%w for %s: expected %s, got %s", ErrChecksum, name, fields[0], actual)
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s not found in checksums file", ErrChecksum, name)
+}
+
+func extract(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+	return nil, errors.New("Binary not found in archive: " + name)
+}
+
+// Writes the new binary next to the current one so that the final rename is atomic.
+func ReplaceBinary(exe string, binary []byte, goos string, fsys afero.Fs) error {
+	tmp := exe + ".new"
+	if err := afero.WriteFile(fsys, tmp, binary, 0755); err != nil {
+		return fmt.Errorf("failed to write binary: %w", err)
+	}
+	// Windows locks running executables, but allows renaming them out of the way
+	if goos == "windows" {
+		if err := fsys.Rename(exe, exe+".old"); err != nil {
+			_ = fsys.Remove(tmp)
+			return fmt.Errorf("failed to move current binary: %w", err)
+		}
+	}
+	if err := fsys.Rename(tmp, exe); err != nil {
+		if goos == "windows" {
+			_ = fsys.Rename(exe+".old", exe)
+		}
+		_ = fsys.Remove(tmp)
+		return fmt.Errorf("failed to replace binary: %w", err)
+	}
+	return nil
+}
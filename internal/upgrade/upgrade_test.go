@@ -0,0 +1,258 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v53/github"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestIsNewer(t *testing.T) {
+	assert.True(t, IsNewer("v1.2.0", "1.1.9"))
+	assert.True(t, IsNewer("v1.2.0-beta.1", "1.1.0"))
+	assert.False(t, IsNewer("v1.2.0", "1.2.0"))
+	assert.False(t, IsNewer("v1.2.0-beta.1", "1.2.0"))
+	// Development builds can always be upgraded
+	assert.True(t, IsNewer("v1.2.0", ""))
+}
+
+func newArchive(t *testing.T, name string, data []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDownload(t *testing.T) {
+	binary := []byte("binary")
+	archive := newArchive(t, "supabase", binary)
+	release := &github.RepositoryRelease{
+		TagName: github.String("v1.2.0"),
+		Assets: []*github.ReleaseAsset{{
+			Name:               github.String("supabase_linux_amd64.tar.gz"),
+			BrowserDownloadURL: github.String("https://github.com/supabase/cli/releases/download/v1.2.0/supabase_linux_amd64.tar.gz"),
+		}, {
+			Name:               github.String("supabase_1.2.0_checksums.txt"),
+			BrowserDownloadURL: github.String("https://github.com/supabase/cli/releases/download/v1.2.0/supabase_1.2.0_checksums.txt"),
+		}, {
+			Name:               github.String("supabase_1.2.0_checksums.txt.minisig"),
+			BrowserDownloadURL: github.String("https://github.com/supabase/cli/releases/download/v1.2.0/supabase_1.2.0_checksums.txt.minisig"),
+		}},
+	}
+	key := newSigningKey(t)
+	SigningKey = key.publicKey
+	t.Cleanup(func() { SigningKey = "" })
+
+	t.Run("downloads verified binary", func(t *testing.T) {
+		checksums := checksum(archive) + "  supabase_linux_amd64.tar.gz\n"
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://github.com").
+			Get("/supabase/cli/releases/download/v1.2.0/supabase_1.2.0_checksums.txt").
+			Reply(http.StatusOK).
+			BodyString(checksums)
+		gock.New("https://github.com").
+			Get("/supabase/cli/releases/download/v1.2.0/supabase_1.2.0_checksums.txt.minisig").
+			Reply(http.StatusOK).
+			BodyString(key.sign(t, []byte(checksums)))
+		gock.New("https://github.com").
+			Get("/supabase/cli/releases/download/v1.2.0/supabase_linux_amd64.tar.gz").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(archive))
+		// Run test
+		data, err := Download(context.Background(), release, "linux", "amd64")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, binary, data)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on checksum mismatch", func(t *testing.T) {
+		checksums := checksum([]byte("other")) + "  supabase_linux_amd64.tar.gz\n"
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://github.com").
+			Get("/supabase/cli/releases/download/v1.2.0/supabase_1.2.0_checksums.txt").
+			Reply(http.StatusOK).
+			BodyString(checksums)
+		gock.New("https://github.com").
+			Get("/supabase/cli/releases/download/v1.2.0/supabase_1.2.0_checksums.txt.minisig").
+			Reply(http.StatusOK).
+			BodyString(key.sign(t, []byte(checksums)))
+		gock.New("https://github.com").
+			Get("/supabase/cli/releases/download/v1.2.0/supabase_linux_amd64.tar.gz").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(archive))
+		// Run test
+		_, err := Download(context.Background(), release, "linux", "amd64")
+		// Check error
+		assert.ErrorIs(t, err, ErrChecksum)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on tampered checksums", func(t *testing.T) {
+		checksums := checksum(archive) + "  supabase_linux_amd64.tar.gz\n"
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://github.com").
+			Get("/supabase/cli/releases/download/v1.2.0/supabase_1.2.0_checksums.txt").
+			Reply(http.StatusOK).
+			BodyString(checksums)
+		gock.New("https://github.com").
+			Get("/supabase/cli/releases/download/v1.2.0/supabase_1.2.0_checksums.txt.minisig").
+			Reply(http.StatusOK).
+			BodyString(key.sign(t, []byte("tampered")))
+		// Run test
+		_, err := Download(context.Background(), release, "linux", "amd64")
+		// Check error
+		assert.ErrorIs(t, err, ErrSignature)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on unsigned release", func(t *testing.T) {
+		unsigned := &github.RepositoryRelease{
+			TagName: release.TagName,
+			Assets:  release.Assets[:2],
+		}
+		// Run test
+		_, err := Download(context.Background(), unsigned, "linux", "amd64")
+		// Check error
+		assert.ErrorContains(t, err, "Release v1.2.0 has no checksums signature")
+	})
+
+	t.Run("throws error without signing key", func(t *testing.T) {
+		SigningKey = ""
+		t.Cleanup(func() { SigningKey = key.publicKey })
+		// Run test
+		_, err := Download(context.Background(), release, "linux", "amd64")
+		// Check error
+		assert.ErrorIs(t, err, ErrUnsigned)
+	})
+
+	t.Run("throws error on unsupported platform", func(t *testing.T) {
+		// Run test
+		_, err := Download(context.Background(), release, "plan9", "386")
+		// Check error
+		assert.ErrorContains(t, err, "Release v1.2.0 has no binary for plan9/386")
+	})
+}
+
+func TestGetLatestRelease(t *testing.T) {
+	client := github.NewClient(nil)
+
+	t.Run("gets latest stable release", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases/latest").
+			Reply(http.StatusOK).
+			JSON(github.RepositoryRelease{TagName: github.String("v1.2.0")})
+		// Run test
+		release, err := GetLatestRelease(context.Background(), client, ChannelStable)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.2.0", release.GetTagName())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("gets latest beta release", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.github.com").
+			Get("/repos/supabase/cli/releases").
+			Reply(http.StatusOK).
+			JSON([]github.RepositoryRelease{
+				{TagName: github.String("v1.3.0"), Draft: github.Bool(true)},
+				{TagName: github.String("v1.3.0-beta.1"), Prerelease: github.Bool(true)},
+			})
+		// Run test
+		release, err := GetLatestRelease(context.Background(), client, ChannelBeta)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.3.0-beta.1", release.GetTagName())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on unknown channel", func(t *testing.T) {
+		// Run test
+		_, err := GetLatestRelease(context.Background(), client, "nightly")
+		// Check error
+		assert.ErrorContains(t, err, "Unknown channel")
+	})
+}
+
+func TestReplaceBinary(t *testing.T) {
+	t.Run("replaces binary atomically", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/bin/supabase", []byte("old"), 0755))
+		// Run test
+		err := ReplaceBinary("/bin/supabase", []byte("new"), "linux", fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, "/bin/supabase")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("new"), data)
+		exists, err := afero.Exists(fsys, "/bin/supabase.new")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("moves locked binary on windows", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/bin/supabase.exe", []byte("old"), 0755))
+		// Run test
+		err := ReplaceBinary("/bin/supabase.exe", []byte("new"), "windows", fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, "/bin/supabase.exe")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("new"), data)
+		data, err = afero.ReadFile(fsys, "/bin/supabase.exe.old")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("old"), data)
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
+		// Run test
+		err := ReplaceBinary("/bin/supabase", []byte("new"), "linux", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to write binary")
+	})
+}
+
+func TestUpgradeCommand(t *testing.T) {
+	t.Run("throws error when disabled", func(t *testing.T) {
+		viper.Set("DISABLE_UPGRADE", true)
+		defer viper.Reset()
+		// Run test
+		err := Run(context.Background(), ChannelStable, false, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, ErrDisabled)
+	})
+}
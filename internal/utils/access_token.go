@@ -2,6 +2,7 @@ package utils
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -20,6 +21,13 @@ var (
 
 const AccessTokenKey = "access-token"
 
+const (
+	TokenStoreKeyring = "keyring"
+	TokenStoreFile    = "file"
+)
+
+var TokenStores = []string{TokenStoreKeyring, TokenStoreFile}
+
 func LoadAccessToken() (string, error) {
 	return LoadAccessTokenFS(afero.NewOsFs())
 }
@@ -40,16 +48,29 @@ func loadAccessToken(fsys afero.Fs) (string, error) {
 	if accessToken := os.Getenv("SUPABASE_ACCESS_TOKEN"); accessToken != "" {
 		return accessToken, nil
 	}
+	key := getAccessTokenKey(GetCurrentProfile(fsys))
+	store := GetTokenStore(fsys)
+	if store == TokenStoreFile {
+		return fallbackLoadToken(key, fsys)
+	}
 	// Load from native credentials store
-	if accessToken, err := credentials.Get(AccessTokenKey); err == nil {
+	if accessToken, err := credentials.Get(key); err == nil {
 		return accessToken, nil
+	} else if store == TokenStoreKeyring {
+		if errors.Is(err, keyring.ErrNotFound) {
+			return "", ErrMissingToken
+		}
+		return "", err
+	} else if !credentials.IsKeyring() && !errors.Is(err, keyring.ErrNotFound) {
+		// Encrypted store errors, such as wrong passphrase, should not fallback to plaintext file
+		return "", err
 	}
 	// Fallback to token file
-	return fallbackLoadToken(fsys)
+	return fallbackLoadToken(key, fsys)
 }
 
-func fallbackLoadToken(fsys afero.Fs) (string, error) {
-	path, err := getAccessTokenPath()
+func fallbackLoadToken(key string, fsys afero.Fs) (string, error) {
+	path, err := getAccessTokenPath(key)
 	if err != nil {
 		return "", err
 	}
@@ -67,16 +88,32 @@ func SaveAccessToken(accessToken string, fsys afero.Fs) error {
 	if !AccessTokenPattern.MatchString(accessToken) {
 		return ErrInvalidToken
 	}
-	// Save to native credentials store
-	if err := credentials.Set(AccessTokenKey, accessToken); err == nil {
-		return nil
+	profile := GetCurrentProfile(fsys)
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+	key := getAccessTokenKey(profile)
+	if store := GetTokenStore(fsys); store == TokenStoreFile {
+		if err := fallbackSaveToken(key, accessToken, fsys); err != nil {
+			return err
+		}
+	} else if err := credentials.Set(key, accessToken); err != nil {
+		// Pinned or non-keyring stores should not fallback to plaintext file
+		if store == TokenStoreKeyring || !credentials.IsKeyring() {
+			return err
+		}
+		// Fallback to token file
+		if err := fallbackSaveToken(key, accessToken, fsys); err != nil {
+			return err
+		}
 	}
-	// Fallback to token file
-	return fallbackSaveToken(accessToken, fsys)
+	// Refresh material belongs to the previous token
+	deleteTokenSession(profile, fsys)
+	return addProfile(profile, fsys)
 }
 
-func fallbackSaveToken(accessToken string, fsys afero.Fs) error {
-	path, err := getAccessTokenPath()
+func fallbackSaveToken(key, accessToken string, fsys afero.Fs) error {
+	path, err := getAccessTokenPath(key)
 	if err != nil {
 		return err
 	}
@@ -87,36 +124,99 @@ func fallbackSaveToken(accessToken string, fsys afero.Fs) error {
 }
 
 func DeleteAccessToken(fsys afero.Fs) error {
+	profile := GetCurrentProfile(fsys)
+	key := getAccessTokenKey(profile)
 	// Always delete the fallback token file to handle legacy CLI
-	if err := fallbackDeleteToken(fsys); err == nil {
+	if err := fallbackDeleteToken(key, fsys); err == nil {
 		// Typically user system should only have either token file or keyring.
 		// But we delete from both just in case.
-		_ = credentials.Delete(AccessTokenKey)
-		return nil
+		_ = credentials.Delete(key)
+		deleteTokenSession(profile, fsys)
+		return removeProfile(profile, fsys)
 	} else if !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
 	// Fallback not found, delete from native credentials store
-	err := credentials.Delete(AccessTokenKey)
+	err := credentials.Delete(key)
 	if errors.Is(err, credentials.ErrNotSupported) || errors.Is(err, keyring.ErrNotFound) {
 		return ErrNotLoggedIn
+	} else if err != nil {
+		return err
 	}
-	return err
+	deleteTokenSession(profile, fsys)
+	return removeProfile(profile, fsys)
 }
 
-func fallbackDeleteToken(fsys afero.Fs) error {
-	path, err := getAccessTokenPath()
+func fallbackDeleteToken(key string, fsys afero.Fs) error {
+	path, err := getAccessTokenPath(key)
 	if err != nil {
 		return err
 	}
 	return fsys.Remove(path)
 }
 
-func getAccessTokenPath() (string, error) {
+// Returns the store pinned by login, or empty string if tokens fall back from keyring to file.
+func GetTokenStore(fsys afero.Fs) string {
+	if config, err := LoadProfileConfig(fsys); err == nil {
+		return config.TokenStore
+	}
+	return ""
+}
+
+// Pins access tokens to the given store for subsequent commands.
+func SetTokenStore(name string, fsys afero.Fs) error {
+	if len(name) > 0 && !SliceContains(TokenStores, name) {
+		return fmt.Errorf("Unknown token store %q. Must be one of: %v", name, TokenStores)
+	}
+	config, err := LoadProfileConfig(fsys)
+	if err != nil {
+		return err
+	}
+	config.TokenStore = name
+	return saveProfileConfig(config, fsys)
+}
+
+// Reads the access token of the current profile from a single store, without falling back.
+func LoadTokenFromStore(store string, fsys afero.Fs) (string, error) {
+	key := getAccessTokenKey(GetCurrentProfile(fsys))
+	if store == TokenStoreFile {
+		return fallbackLoadToken(key, fsys)
+	}
+	accessToken, err := credentials.Get(key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrMissingToken
+	}
+	return accessToken, err
+}
+
+// Writes the access token of the current profile to a single store, without falling back.
+func SaveTokenToStore(store, accessToken string, fsys afero.Fs) error {
+	key := getAccessTokenKey(GetCurrentProfile(fsys))
+	if store == TokenStoreFile {
+		return fallbackSaveToken(key, accessToken, fsys)
+	}
+	return credentials.Set(key, accessToken)
+}
+
+// Removes the access token of the current profile from a single store.
+func DeleteTokenFromStore(store string, fsys afero.Fs) error {
+	key := getAccessTokenKey(GetCurrentProfile(fsys))
+	if store == TokenStoreFile {
+		return fallbackDeleteToken(key, fsys)
+	}
+	return credentials.Delete(key)
+}
+
+// Returns the path of the plaintext token file of the current profile.
+func GetAccessTokenPath(fsys afero.Fs) (string, error) {
+	return getAccessTokenPath(getAccessTokenKey(GetCurrentProfile(fsys)))
+}
+
+func getAccessTokenPath(key string) (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
 	// TODO: fallback to workdir
-	return filepath.Join(home, ".supabase", AccessTokenKey), nil
+	return filepath.Join(home, ".supabase", key), nil
 }
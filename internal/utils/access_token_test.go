@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/testing/apitest"
@@ -52,13 +53,13 @@ func TestLoadToken(t *testing.T) {
 
 func TestLoadTokenFallback(t *testing.T) {
 	t.Run("fallback loads from file", func(t *testing.T) {
-		path, err := getAccessTokenPath()
+		path, err := getAccessTokenPath(AccessTokenKey)
 		assert.NoError(t, err)
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0600))
 		// Run test
-		token, err := fallbackLoadToken(fsys)
+		token, err := fallbackLoadToken(AccessTokenKey, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, token)
@@ -70,19 +71,19 @@ func TestLoadTokenFallback(t *testing.T) {
 		// Setup empty home directory
 		t.Setenv("HOME", "")
 		// Run test
-		token, err := fallbackLoadToken(fsys)
+		token, err := fallbackLoadToken(AccessTokenKey, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "$HOME is not defined")
 		assert.Empty(t, token)
 	})
 
 	t.Run("throws error on read failure", func(t *testing.T) {
-		path, err := getAccessTokenPath()
+		path, err := getAccessTokenPath(AccessTokenKey)
 		assert.NoError(t, err)
 		// Setup in-memory fs
 		fsys := &fstest.OpenErrorFs{DenyPath: path}
 		// Run test
-		token, err := fallbackLoadToken(fsys)
+		token, err := fallbackLoadToken(AccessTokenKey, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "permission denied")
 		assert.Empty(t, token)
@@ -112,6 +113,17 @@ func TestSaveToken(t *testing.T) {
 		// Check error
 		assert.ErrorIs(t, err, ErrInvalidToken)
 	})
+
+	t.Run("throws error on invalid profile", func(t *testing.T) {
+		viper.Set("PROFILE", "../work")
+		defer viper.Set("PROFILE", "")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := SaveAccessToken(token, fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrInvalidProfile)
+	})
 }
 
 func TestSaveTokenFallback(t *testing.T) {
@@ -121,9 +133,9 @@ func TestSaveTokenFallback(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		assert.NoError(t, fallbackSaveToken(token, fsys))
+		assert.NoError(t, fallbackSaveToken(AccessTokenKey, token, fsys))
 		// Validate saved token
-		path, err := getAccessTokenPath()
+		path, err := getAccessTokenPath(AccessTokenKey)
 		assert.NoError(t, err)
 		contents, err := afero.ReadFile(fsys, path)
 		assert.NoError(t, err)
@@ -136,7 +148,7 @@ func TestSaveTokenFallback(t *testing.T) {
 		// Setup empty home directory
 		t.Setenv("HOME", "")
 		// Run test
-		err := fallbackSaveToken(token, fsys)
+		err := fallbackSaveToken(AccessTokenKey, token, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "$HOME is not defined")
 	})
@@ -145,7 +157,7 @@ func TestSaveTokenFallback(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
 		// Run test
-		err := fallbackSaveToken(token, fsys)
+		err := fallbackSaveToken(AccessTokenKey, token, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "operation not permitted")
 	})
@@ -156,7 +168,7 @@ func TestSaveTokenFallback(t *testing.T) {
 		// Setup in-memory fs
 		fsys := &fstest.OpenErrorFs{DenyPath: home}
 		// Run test
-		err = fallbackSaveToken(token, fsys)
+		err = fallbackSaveToken(AccessTokenKey, token, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "permission denied")
 	})
@@ -168,14 +180,14 @@ func TestDeleteToken(t *testing.T) {
 		require.NoError(t, credentials.Set(AccessTokenKey, token))
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, fallbackSaveToken(token, fsys))
+		require.NoError(t, fallbackSaveToken(AccessTokenKey, token, fsys))
 		// Run test
 		err := DeleteAccessToken(fsys)
 		// Check error
 		assert.NoError(t, err)
 		_, err = credentials.Get(AccessTokenKey)
 		assert.ErrorIs(t, err, keyring.ErrNotFound)
-		path, err := getAccessTokenPath()
+		path, err := getAccessTokenPath(AccessTokenKey)
 		assert.NoError(t, err)
 		exists, err := afero.Exists(fsys, path)
 		assert.NoError(t, err)
@@ -202,3 +214,44 @@ func TestDeleteToken(t *testing.T) {
 		assert.ErrorContains(t, err, "$HOME is not defined")
 	})
 }
+
+func TestTokenStore(t *testing.T) {
+	token := string(apitest.RandomAccessToken(t))
+
+	t.Run("pinned file store skips keyring", func(t *testing.T) {
+		keyring.MockInit()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SetTokenStore(TokenStoreFile, fsys))
+		// Run test
+		assert.NoError(t, SaveAccessToken(token, fsys))
+		// Validate saved token
+		_, err := LoadTokenFromStore(TokenStoreKeyring, fsys)
+		assert.ErrorIs(t, err, ErrMissingToken)
+		loaded, err := LoadAccessTokenFS(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, token, loaded)
+	})
+
+	t.Run("pinned keyring store skips file", func(t *testing.T) {
+		keyring.MockInit()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SaveTokenToStore(TokenStoreFile, token, fsys))
+		require.NoError(t, SetTokenStore(TokenStoreKeyring, fsys))
+		// Run test
+		_, err := LoadAccessTokenFS(fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingToken)
+	})
+
+	t.Run("throws error on unknown store", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := SetTokenStore("vault", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "vault")
+		assert.Empty(t, GetTokenStore(fsys))
+	})
+}
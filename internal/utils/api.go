@@ -10,8 +10,10 @@ import (
 	"net/http"
 	"net/http/httptrace"
 	"net/textproto"
+	"strings"
 	"sync"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/viper"
 	supabase "github.com/supabase/cli/pkg/api"
 )
@@ -131,7 +133,7 @@ func WithTraceContext(ctx context.Context) context.Context {
 			}
 		},
 		WroteHeaderField: func(key string, value []string) {
-			log.Println("Sent Header:", key, value)
+			log.Println("Sent Header:", RedactTrace(key+": "+strings.Join(value, ", ")))
 		},
 		WroteRequest: func(wr httptrace.WroteRequestInfo) {
 			if wr.Err != nil {
@@ -193,8 +195,10 @@ func GetSupabase() *supabase.ClientWithResponses {
 		}
 		apiClient, err = supabase.NewClientWithResponses(
 			GetSupabaseAPIHost(),
+			supabase.WithHTTPClient(&http.Client{
+				Transport: newRefreshTransport(token, afero.NewOsFs()),
+			}),
 			supabase.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-				req.Header.Set("Authorization", "Bearer "+token)
 				req.Header.Set("User-Agent", "SupabaseCLI/"+Version)
 				return nil
 			}),
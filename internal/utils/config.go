@@ -36,6 +36,7 @@ var (
 	LogflareId    string
 	VectorId      string
 	PoolerId      string
+	MetricsId     string
 
 	DbAliases          = []string{"db", "db.supabase.internal"}
 	KongAliases        = []string{"kong", "api.supabase.internal"}
@@ -51,6 +52,7 @@ var (
 	LogflareAliases    = []string{"analytics"}
 	VectorAliases      = []string{"vector"}
 	PoolerAliases      = []string{"pooler"}
+	MetricsAliases     = []string{"metrics"}
 
 	InitialSchemaSql string
 	//go:embed templates/initial_schemas/13.sql
@@ -105,6 +107,9 @@ const (
 	AddressIPv4 AddressFamily = "IPv4"
 )
 
+// Root key used by databases started before keys were provisioned per project.
+const DefaultRootKey = "d4dc5b6d4a1d6a10b2c1e76112c994d65db7cec380572cc1839624d4be3fa275"
+
 var Config = config{
 	Api: api{
 		Image: PostgrestImage,
@@ -112,7 +117,7 @@ var Config = config{
 	Db: db{
 		Image:    Pg15Image,
 		Password: "postgres",
-		RootKey:  "d4dc5b6d4a1d6a10b2c1e76112c994d65db7cec380572cc1839624d4be3fa275",
+		RootKey:  DefaultRootKey,
 	},
 	Realtime: realtime{
 		IpVersion: AddressIPv6,
@@ -198,11 +203,37 @@ type (
 		Auth         auth                `toml:"auth" mapstructure:"auth"`
 		Functions    map[string]function `toml:"functions"`
 		Analytics    analytics           `toml:"analytics"`
+		Metrics      metrics             `toml:"metrics"`
+		Services     services            `toml:"services" mapstructure:"-"`
 		Experimental experimental        `toml:"experimental" mapstructure:"-"`
+		Credentials  credentialStore     `toml:"credentials" mapstructure:"-"`
+		// Named groups of project refs for commands that fan out to multiple projects
+		ProjectGroups map[string][]string `toml:"project_groups" mapstructure:"-"`
 		// TODO
 		// Scripts   scripts
 	}
 
+	services struct {
+		// Service names of containers to skip when running supabase start
+		Exclude []string `toml:"exclude"`
+		// Maps service names to container resource limits
+		Resources map[string]resourceLimit `toml:"resources"`
+	}
+
+	resourceLimit struct {
+		Memory sizeInBytes `toml:"memory"`
+		Cpus   float64     `toml:"cpus"`
+	}
+
+	credentialStore struct {
+		Store string `toml:"store"`
+		// Executable implementing docker credential helper protocol, used by exec store. Only read
+		// from env or the profile saved by login, never from a project's config.toml.
+		Helper string `toml:"helper"`
+		// Entry prefix for pass, secret path for vault, or vault name for 1password
+		Path string `toml:"path"`
+	}
+
 	api struct {
 		Enabled         bool     `toml:"enabled"`
 		Image           string   `toml:"-"`
@@ -213,13 +244,31 @@ type (
 	}
 
 	db struct {
-		Image        string `toml:"-"`
-		Port         uint   `toml:"port"`
-		ShadowPort   uint   `toml:"shadow_port"`
-		MajorVersion uint   `toml:"major_version"`
-		Password     string `toml:"-"`
-		RootKey      string `toml:"-" mapstructure:"root_key"`
-		Pooler       pooler `toml:"pooler"`
+		Image        string     `toml:"-"`
+		Port         uint       `toml:"port"`
+		ShadowPort   uint       `toml:"shadow_port"`
+		MajorVersion uint       `toml:"major_version"`
+		Password     string     `toml:"-"`
+		RootKey      string     `toml:"-" mapstructure:"root_key"`
+		Pooler       pooler     `toml:"pooler"`
+		Lint         lint       `toml:"lint"`
+		Snapshots    snapshots  `toml:"snapshots"`
+		Dump         dumpConfig `toml:"dump"`
+	}
+
+	dumpConfig struct {
+		// Maps fully qualified column names, ie. schema.table.column, to a masker applied to dumped data
+		Anonymize map[string]string `toml:"anonymize"`
+	}
+
+	snapshots struct {
+		// Maximum number of local snapshots to keep, oldest are pruned first
+		MaxCount uint `toml:"max_count"`
+	}
+
+	lint struct {
+		// Maps rule names to whether they are enabled, defaults to all enabled
+		Rules map[string]bool `toml:"rules"`
 	}
 
 	pooler struct {
@@ -280,6 +329,17 @@ type (
 		DoubleConfirmChanges bool                     `toml:"double_confirm_changes"`
 		EnableConfirmations  bool                     `toml:"enable_confirmations"`
 		Template             map[string]emailTemplate `toml:"template"`
+		Smtp                 smtp                     `toml:"smtp"`
+	}
+
+	smtp struct {
+		Enabled    bool   `toml:"enabled"`
+		Host       string `toml:"host"`
+		Port       uint16 `toml:"port"`
+		User       string `toml:"user"`
+		Pass       string `toml:"pass"`
+		AdminEmail string `toml:"admin_email"`
+		SenderName string `toml:"sender_name"`
 	}
 
 	emailTemplate struct {
@@ -338,6 +398,11 @@ type (
 		ImportMap string `toml:"import_map"`
 	}
 
+	metrics struct {
+		Enabled bool   `toml:"enabled"`
+		Port    uint16 `toml:"port"`
+	}
+
 	analytics struct {
 		Enabled          bool            `toml:"enabled"`
 		Port             uint16          `toml:"port"`
@@ -371,13 +436,18 @@ func LoadConfigFS(fsys afero.Fs) error {
 		return err
 	}
 	// Load user defined config
-	if metadata, err := toml.DecodeFS(afero.NewIOFS(fsys), ConfigPath, &Config); err != nil {
-		CmdSuggestion = fmt.Sprintf("Have you set up the project with %s?", Aqua("supabase init"))
-		cwd, osErr := os.Getwd()
-		if osErr != nil {
-			cwd = "current directory"
-		}
-		return fmt.Errorf("cannot read config in %s: %w", cwd, err)
+	data, err := afero.ReadFile(fsys, ConfigPath)
+	if err != nil {
+		return readConfigError(err)
+	}
+	// Deprecated keys are upgraded in memory so that existing config continues to work
+	upgraded, changes, err := UpgradeConfig(string(data))
+	if err != nil {
+		return readConfigError(err)
+	}
+	warnDeprecatedConfig(changes)
+	if metadata, err := toml.Decode(upgraded, &Config); err != nil {
+		return readConfigError(err)
 	} else if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
 		fmt.Fprintf(os.Stderr, "Unknown config fields: %+v\n", undecoded)
 	}
@@ -411,6 +481,21 @@ func LoadConfigFS(fsys afero.Fs) error {
 			LogflareId = GetId(LogflareAliases[0])
 			VectorId = GetId(VectorAliases[0])
 			PoolerId = GetId(PoolerAliases[0])
+			MetricsId = GetId(MetricsAliases[0])
+		}
+		// Validate services config
+		for _, name := range Config.Services.Exclude {
+			if !IsKnownService(name) {
+				return errors.New("Unknown service in config services.exclude: " + name)
+			}
+		}
+		for name, limit := range Config.Services.Resources {
+			if !IsKnownService(name) {
+				return errors.New("Unknown service in config services.resources: " + name)
+			}
+			if limit.Memory < 0 || limit.Cpus < 0 {
+				return errors.New("Invalid resource limit for service: " + name)
+			}
 		}
 		// Validate api config
 		if Config.Api.Port == 0 {
@@ -500,8 +585,17 @@ func LoadConfigFS(fsys afero.Fs) error {
 					}
 				}
 			}
-			// Validate sms config
+			// Validate smtp config
 			var err error
+			if Config.Auth.Email.Smtp.Enabled {
+				if len(Config.Auth.Email.Smtp.Host) == 0 {
+					return errors.New("Missing required field in config: auth.email.smtp.host")
+				}
+				if Config.Auth.Email.Smtp.Pass, err = maybeLoadEnv(Config.Auth.Email.Smtp.Pass); err != nil {
+					return err
+				}
+			}
+			// Validate sms config
 			if Config.Auth.Sms.Twilio.Enabled {
 				if len(Config.Auth.Sms.Twilio.AccountSid) == 0 {
 					return errors.New("Missing required field in config: auth.sms.twilio.account_sid")
@@ -624,9 +718,63 @@ func LoadConfigFS(fsys afero.Fs) error {
 			return fmt.Errorf("Invalid config for analytics.backend. Must be one of: %v", allowed)
 		}
 	}
+	// Validate metrics config
+	if Config.Metrics.Enabled {
+		if Config.Metrics.Port == 0 {
+			return errors.New("Missing required field in config: metrics.port")
+		}
+		for _, p := range hostPorts() {
+			if p.port == uint(Config.Metrics.Port) {
+				return fmt.Errorf("Invalid config for metrics.port: %d is already used by %s", Config.Metrics.Port, p.name)
+			}
+		}
+	}
 	return nil
 }
 
+type hostPort struct {
+	name string
+	port uint
+}
+
+// Returns the host ports bound by other enabled services.
+func hostPorts() []hostPort {
+	ports := []hostPort{
+		{"api.port", Config.Api.Port},
+		{"db.port", Config.Db.Port},
+		{"db.shadow_port", Config.Db.ShadowPort},
+	}
+	if Config.Db.Pooler.Enabled {
+		ports = append(ports, hostPort{"db.pooler.port", uint(Config.Db.Pooler.Port)})
+	}
+	if Config.Studio.Enabled {
+		ports = append(ports, hostPort{"studio.port", Config.Studio.Port})
+	}
+	if Config.Inbucket.Enabled {
+		ports = append(ports,
+			hostPort{"inbucket.port", Config.Inbucket.Port},
+			hostPort{"inbucket.smtp_port", Config.Inbucket.SmtpPort},
+			hostPort{"inbucket.pop3_port", Config.Inbucket.Pop3Port},
+		)
+	}
+	if Config.Analytics.Enabled {
+		ports = append(ports,
+			hostPort{"analytics.port", uint(Config.Analytics.Port)},
+			hostPort{"analytics.vector_port", uint(Config.Analytics.VectorPort)},
+		)
+	}
+	return ports
+}
+
+func readConfigError(err error) error {
+	CmdSuggestion = fmt.Sprintf("Have you set up the project with %s?", Aqua("supabase init"))
+	cwd, osErr := os.Getwd()
+	if osErr != nil {
+		cwd = "current directory"
+	}
+	return fmt.Errorf("cannot read config in %s: %w", cwd, err)
+}
+
 func maybeLoadEnv(s string) (string, error) {
 	matches := envPattern.FindStringSubmatch(s)
 	if len(matches) == 0 {
@@ -641,7 +789,7 @@ func maybeLoadEnv(s string) (string, error) {
 	return "", fmt.Errorf(`Error evaluating "%s": environment variable %s is unset.`, s, envName)
 }
 
-func sanitizeProjectId(src string) string {
+func SanitizeProjectId(src string) string {
 	// A valid project ID must only contain alphanumeric and special characters _.-
 	sanitized := invalidProjectId.ReplaceAllString(src, "_")
 	// It must also start with an alphanumeric character
@@ -662,7 +810,7 @@ func InitConfig(params InitParams, fsys afero.Fs) error {
 		}
 		params.ProjectId = filepath.Base(cwd)
 	}
-	params.ProjectId = sanitizeProjectId(params.ProjectId)
+	params.ProjectId = SanitizeProjectId(params.ProjectId)
 	// Create config file
 	if err := MkdirIfNotExistFS(fsys, filepath.Dir(ConfigPath)); err != nil {
 		return err
@@ -1,13 +1,16 @@
 package utils
 
 import (
+	"bytes"
 	_ "embed"
 	"testing"
 	"text/template"
 
 	"github.com/BurntSushi/toml"
+	"github.com/docker/docker/api/types/container"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -16,6 +19,50 @@ var (
 	testInitConfigTemplate = template.Must(template.New("initConfig.test").Parse(testInitConfigEmbed))
 )
 
+func TestMetricsConfigParsing(t *testing.T) {
+	setup := func(t *testing.T, port string) afero.Fs {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, WriteConfig(fsys, false))
+		data, err := afero.ReadFile(fsys, ConfigPath)
+		require.NoError(t, err)
+		data = bytes.Replace(data, []byte("enabled = false\nport = 54330"), []byte("enabled = true\nport = "+port), 1)
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, data, 0644))
+		return fsys
+	}
+
+	t.Run("loads metrics port", func(t *testing.T) {
+		fsys := setup(t, "54330")
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check output
+		assert.Equal(t, uint16(54330), Config.Metrics.Port)
+	})
+
+	t.Run("throws error on port collision", func(t *testing.T) {
+		fsys := setup(t, "54322")
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for metrics.port: 54322 is already used by db.port")
+	})
+
+	t.Run("throws error on missing port", func(t *testing.T) {
+		fsys := setup(t, "0")
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing required field in config: metrics.port")
+	})
+
+	t.Run("throws error on port out of range", func(t *testing.T) {
+		fsys := setup(t, "70000")
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.Error(t, err)
+	})
+}
+
 func TestConfigParsing(t *testing.T) {
 	// Reset global variable
 	copy := initConfigTemplate
@@ -124,13 +171,47 @@ func TestFileSizeLimitConfigParsing(t *testing.T) {
 	})
 }
 
+func TestServicesConfigParsing(t *testing.T) {
+	t.Run("applies resource limits by alias", func(t *testing.T) {
+		var testConfig config
+		_, err := toml.Decode(`
+		[services.resources.db]
+		memory = "1GB"
+		cpus = 1.5
+		`, &testConfig)
+		require.NoError(t, err)
+		Config.Services = testConfig.Services
+		defer func() { Config.Services = services{} }()
+		// Run test
+		var resources container.Resources
+		applyResourceLimits(Pg15Image, &resources)
+		// Check output
+		assert.Equal(t, int64(1073741824), resources.Memory)
+		assert.Equal(t, int64(1500000000), resources.NanoCPUs)
+	})
+
+	t.Run("throws error on unknown service", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, WriteConfig(fsys, false))
+		data, err := afero.ReadFile(fsys, ConfigPath)
+		require.NoError(t, err)
+		data = bytes.Replace(data, []byte(`# exclude = ["imgproxy", "realtime"]`), []byte(`exclude = ["mongo"]`), 1)
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, data, 0644))
+		defer func() { Config.Services = services{} }()
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unknown service in config services.exclude: mongo")
+	})
+}
+
 func TestSanitizeProjectI(t *testing.T) {
 	// Preserves valid consecutive characters
-	assert.Equal(t, "abc", sanitizeProjectId("abc"))
-	assert.Equal(t, "a..b_c", sanitizeProjectId("a..b_c"))
+	assert.Equal(t, "abc", SanitizeProjectId("abc"))
+	assert.Equal(t, "a..b_c", SanitizeProjectId("a..b_c"))
 	// Removes leading special characters
-	assert.Equal(t, "abc", sanitizeProjectId("_abc"))
-	assert.Equal(t, "abc", sanitizeProjectId("_@abc"))
+	assert.Equal(t, "abc", SanitizeProjectId("_abc"))
+	assert.Equal(t, "abc", SanitizeProjectId("_@abc"))
 	// Replaces consecutive invalid characters with a single _
-	assert.Equal(t, "a_bc-", sanitizeProjectId("a@@bc-"))
+	assert.Equal(t, "a_bc-", SanitizeProjectId("a@@bc-"))
 }
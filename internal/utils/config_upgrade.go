@@ -0,0 +1,300 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ConfigMigration describes a config key that has been renamed, moved to another table, or had
+// its value format changed between CLI releases.
+type ConfigMigration struct {
+	OldPath string
+	NewPath string
+	// Optionally converts the decoded value to the new format
+	Transform func(value any) (any, error)
+}
+
+// ConfigChange records a single migration applied to config.toml.
+type ConfigChange struct {
+	OldPath  string
+	NewPath  string
+	OldValue string
+	NewValue string
+	// Set when the new key already exists, in which case the old key is dropped
+	Conflict bool
+}
+
+// When renaming a config key, append to this list so that existing config.toml can be upgraded
+// with `supabase config upgrade`. Migrations are applied in order.
+var ConfigMigrations = []ConfigMigration{{
+	OldPath: "auth.double_confirm_changes",
+	NewPath: "auth.email.double_confirm_changes",
+}, {
+	OldPath: "auth.enable_confirmations",
+	NewPath: "auth.email.enable_confirmations",
+}, {
+	OldPath: "db.pooler_enabled",
+	NewPath: "db.pooler.enabled",
+}, {
+	OldPath: "db.pool_mode",
+	NewPath: "db.pooler.pool_mode",
+}, {
+	OldPath:   "realtime.ip_version",
+	NewPath:   "realtime.ip_version",
+	Transform: normaliseAddressFamily,
+}}
+
+func normaliseAddressFamily(value any) (any, error) {
+	family, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected string but got %T", value)
+	}
+	for _, allowed := range []AddressFamily{AddressIPv4, AddressIPv6} {
+		if strings.EqualFold(family, string(allowed)) {
+			return string(allowed), nil
+		}
+	}
+	return family, nil
+}
+
+var (
+	tableHeaderPattern = regexp.MustCompile(`^\s*\[\s*([A-Za-z0-9_.-]+)\s*\]\s*(#.*)?$`)
+	arrayHeaderPattern = regexp.MustCompile(`^\s*\[\[`)
+	keyValuePattern    = regexp.MustCompile(`^(\s*)([A-Za-z0-9_.-]+)(\s*=\s*)(.*)$`)
+)
+
+// A key value pair spanning lines [start, end] of the config file.
+type tomlEntry struct {
+	table string
+	key   string
+	value string
+	start int
+	end   int
+}
+
+func (e tomlEntry) path() string {
+	if len(e.table) == 0 {
+		return e.key
+	}
+	return e.table + "." + e.key
+}
+
+// Rewrites deprecated keys in config.toml text, preserving comments and ordering of all other lines.
+func UpgradeConfig(data string) (string, []ConfigChange, error) {
+	lines := strings.Split(data, "\n")
+	var changes []ConfigChange
+	for _, m := range ConfigMigrations {
+		entries, headers := parseTomlLines(lines)
+		var entry *tomlEntry
+		for i := range entries {
+			if entries[i].path() == m.OldPath {
+				entry = &entries[i]
+				break
+			}
+		}
+		if entry == nil {
+			continue
+		}
+		change := ConfigChange{OldPath: m.OldPath, NewPath: m.NewPath, OldValue: entry.value}
+		replacement := lines[entry.start : entry.end+1]
+		newTable, newKey := splitTomlPath(m.NewPath)
+		if m.Transform != nil {
+			var decoded map[string]any
+			if _, err := toml.Decode("v = "+entry.value, &decoded); err != nil {
+				return data, nil, fmt.Errorf("failed to parse %s: %w", m.OldPath, err)
+			}
+			value, err := m.Transform(decoded["v"])
+			if err != nil {
+				return data, nil, fmt.Errorf("failed to upgrade %s: %w", m.OldPath, err)
+			}
+			if change.NewValue, err = encodeTomlValue(value); err != nil {
+				return data, nil, err
+			}
+			if m.OldPath == m.NewPath && change.NewValue == stripTomlComment(entry.value) {
+				continue
+			}
+			indent := keyValuePattern.FindStringSubmatch(lines[entry.start])[1]
+			replacement = []string{indent + newKey + " = " + change.NewValue}
+		} else {
+			change.NewValue = entry.value
+			matches := keyValuePattern.FindStringSubmatch(lines[entry.start])
+			replacement = append([]string{matches[1] + newKey + matches[3] + matches[4]}, replacement[1:]...)
+		}
+		if m.OldPath == m.NewPath {
+			lines = spliceLines(lines, entry.start, entry.end+1, replacement)
+			changes = append(changes, change)
+			continue
+		}
+		// Comments directly above the old key are moved along with it
+		start := entry.start
+		for start > 0 && strings.HasPrefix(strings.TrimSpace(lines[start-1]), "#") {
+			start--
+		}
+		replacement = append(append([]string{}, lines[start:entry.start]...), replacement...)
+		conflict := false
+		for _, e := range entries {
+			if e.path() == m.NewPath {
+				conflict = true
+				break
+			}
+		}
+		lines = spliceLines(lines, start, entry.end+1, nil)
+		if conflict {
+			change.Conflict = true
+			changes = append(changes, change)
+			continue
+		}
+		// Insert after the last entry of the new table, creating the table if necessary
+		entries, headers = parseTomlLines(lines)
+		if index, ok := headers[newTable]; ok || len(newTable) == 0 {
+			insertAt := index + 1
+			if len(newTable) == 0 {
+				insertAt = 0
+			}
+			for _, e := range entries {
+				if e.table == newTable && e.end+1 > insertAt {
+					insertAt = e.end + 1
+				}
+			}
+			lines = spliceLines(lines, insertAt, insertAt, replacement)
+		} else {
+			// Trailing newline is kept as the last empty line
+			insertAt := len(lines)
+			if insertAt > 0 && len(lines[insertAt-1]) == 0 {
+				insertAt--
+			}
+			block := append([]string{"", "[" + newTable + "]"}, replacement...)
+			lines = spliceLines(lines, insertAt, insertAt, block)
+		}
+		changes = append(changes, change)
+	}
+	return strings.Join(lines, "\n"), changes, nil
+}
+
+// Returns the key value entries and line index of each table header.
+func parseTomlLines(lines []string) ([]tomlEntry, map[string]int) {
+	var entries []tomlEntry
+	headers := map[string]int{}
+	table := ""
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if arrayHeaderPattern.MatchString(line) {
+			// Keys in array of tables are never migrated
+			table = "[]"
+			continue
+		}
+		if matches := tableHeaderPattern.FindStringSubmatch(line); len(matches) > 1 {
+			table = matches[1]
+			headers[table] = i
+			continue
+		}
+		matches := keyValuePattern.FindStringSubmatch(line)
+		if len(matches) == 0 {
+			continue
+		}
+		entry := tomlEntry{table: table, key: matches[2], value: matches[4], start: i, end: i}
+		// Multiline arrays and strings continue until brackets or quotes are balanced
+		for depth := tomlNesting(entry.value); depth > 0 && entry.end+1 < len(lines); {
+			entry.end++
+			entry.value += "\n" + lines[entry.end]
+			depth = tomlNesting(entry.value)
+		}
+		entries = append(entries, entry)
+		i = entry.end
+	}
+	return entries, headers
+}
+
+// Counts unclosed brackets or multiline strings, ignoring those in quoted strings and comments.
+func tomlNesting(value string) int {
+	for _, delim := range []string{`"""`, `'''`} {
+		if strings.HasPrefix(strings.TrimSpace(value), delim) && strings.Count(value, delim) < 2 {
+			return 1
+		}
+	}
+	depth := 0
+	var quote rune
+	for _, r := range value {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			// Skip to end of line
+			quote = '\n'
+		case r == '[' || r == '{':
+			depth++
+		case r == ']' || r == '}':
+			depth--
+		}
+	}
+	return depth
+}
+
+func stripTomlComment(value string) string {
+	var quote rune
+	for i, r := range value {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return strings.TrimSpace(value[:i])
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+func encodeTomlValue(value any) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]any{"v": value}); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimPrefix(buf.String(), "v = ")), nil
+}
+
+func splitTomlPath(path string) (string, string) {
+	index := strings.LastIndexByte(path, '.')
+	if index < 0 {
+		return "", path
+	}
+	return path[:index], path[index+1:]
+}
+
+func spliceLines(lines []string, start, end int, replacement []string) []string {
+	result := make([]string, 0, len(lines)-(end-start)+len(replacement))
+	result = append(result, lines[:start]...)
+	result = append(result, replacement...)
+	return append(result, lines[end:]...)
+}
+
+var warnedDeprecatedKeys = map[string]bool{}
+
+// Prints each deprecated key once per process, suggesting the upgrade command.
+func warnDeprecatedConfig(changes []ConfigChange) {
+	var keys []string
+	for _, c := range changes {
+		if !warnedDeprecatedKeys[c.OldPath] {
+			warnedDeprecatedKeys[c.OldPath] = true
+			keys = append(keys, c.OldPath)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(os.Stderr, "Deprecated config fields:", strings.Join(keys, ", "))
+	fmt.Fprintf(os.Stderr, "Run %s to migrate your config file.\n", Aqua("supabase config upgrade"))
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeConfig(t *testing.T) {
+	fixtures := map[string][]ConfigChange{
+		"auth_email": {{
+			OldPath:  "auth.double_confirm_changes",
+			NewPath:  "auth.email.double_confirm_changes",
+			OldValue: "true",
+			NewValue: "true",
+		}, {
+			OldPath:  "auth.enable_confirmations",
+			NewPath:  "auth.email.enable_confirmations",
+			OldValue: "false",
+			NewValue: "false",
+		}},
+		"db_pooler": {{
+			OldPath:  "db.pooler_enabled",
+			NewPath:  "db.pooler.enabled",
+			OldValue: "true",
+			NewValue: "true",
+		}, {
+			OldPath:  "db.pool_mode",
+			NewPath:  "db.pooler.pool_mode",
+			OldValue: `"session" # one of transaction, session`,
+			NewValue: `"session" # one of transaction, session`,
+		}},
+		"realtime_ip_version": {{
+			OldPath:  "realtime.ip_version",
+			NewPath:  "realtime.ip_version",
+			OldValue: `"ipv4"`,
+			NewValue: `"IPv4"`,
+		}},
+	}
+
+	for name, expected := range fixtures {
+		t.Run("upgrades "+name, func(t *testing.T) {
+			input, err := os.ReadFile(filepath.Join("testdata", "upgrade", name+".toml"))
+			require.NoError(t, err)
+			golden, err := os.ReadFile(filepath.Join("testdata", "upgrade", name+".expected.toml"))
+			require.NoError(t, err)
+			// Run test
+			upgraded, changes, err := UpgradeConfig(string(input))
+			// Check error
+			assert.NoError(t, err)
+			assert.Equal(t, expected, changes)
+			assert.Equal(t, string(golden), upgraded)
+			// Check output is valid toml
+			var decoded map[string]any
+			_, err = toml.Decode(upgraded, &decoded)
+			assert.NoError(t, err)
+		})
+
+		t.Run("is idempotent for "+name, func(t *testing.T) {
+			input, err := os.ReadFile(filepath.Join("testdata", "upgrade", name+".toml"))
+			require.NoError(t, err)
+			first, _, err := UpgradeConfig(string(input))
+			require.NoError(t, err)
+			// Run test
+			second, changes, err := UpgradeConfig(first)
+			// Check error
+			assert.NoError(t, err)
+			assert.Empty(t, changes)
+			assert.Equal(t, first, second)
+		})
+	}
+
+	t.Run("drops old key when new key exists", func(t *testing.T) {
+		input := strings.Join([]string{
+			"[auth]",
+			"enable_confirmations = true",
+			"",
+			"[auth.email]",
+			"enable_confirmations = false",
+			"",
+		}, "\n")
+		// Run test
+		upgraded, changes, err := UpgradeConfig(input)
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, changes, 1)
+		assert.True(t, changes[0].Conflict)
+		assert.Equal(t, "[auth]\n\n[auth.email]\nenable_confirmations = false\n", upgraded)
+	})
+
+	t.Run("ignores multiline values", func(t *testing.T) {
+		input := strings.Join([]string{
+			"[auth]",
+			"additional_redirect_urls = [",
+			`  "https://127.0.0.1:3000", # enable_confirmations = true`,
+			"]",
+			"",
+		}, "\n")
+		// Run test
+		upgraded, changes, err := UpgradeConfig(input)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Equal(t, input, upgraded)
+	})
+}
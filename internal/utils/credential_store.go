@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"golang.org/x/term"
+)
+
+const (
+	CredentialStoreKeyring       = "keyring"
+	CredentialStoreEncryptedFile = "encrypted-file"
+	CredentialStorePass          = "pass"
+	CredentialStoreOnePassword   = "1password"
+	CredentialStoreVault         = "vault"
+	CredentialStoreExec          = "exec"
+)
+
+var CredentialStores = []string{
+	CredentialStoreKeyring,
+	CredentialStoreEncryptedFile,
+	CredentialStorePass,
+	CredentialStoreOnePassword,
+	CredentialStoreVault,
+	CredentialStoreExec,
+}
+
+// Configures the credentials backend from env, the store saved by login, or the [credentials]
+// section of config.toml, in that order of precedence. Unset fields fall through to the next source.
+func InitCredentialStore(fsys afero.Fs) error {
+	config := credentialStore{
+		Store:  viper.GetString("CREDENTIALS_STORE"),
+		Helper: viper.GetString("CREDENTIALS_HELPER"),
+		Path:   viper.GetString("CREDENTIALS_PATH"),
+	}
+	// Choice made by the user takes precedence over the project they happen to run in
+	if profiles, err := LoadProfileConfig(fsys); err == nil {
+		config.merge(credentialStore{
+			Store:  profiles.CredentialStore,
+			Helper: profiles.CredentialHelper,
+			Path:   profiles.CredentialPath,
+		})
+	}
+	var local struct {
+		Credentials credentialStore `toml:"credentials"`
+	}
+	if data, err := afero.ReadFile(fsys, ConfigPath); err == nil {
+		if _, err := toml.Decode(string(data), &local); err == nil {
+			// Running a command in a cloned repository must never execute a binary chosen by that repository
+			if len(local.Credentials.Helper) > 0 {
+				fmt.Fprintln(os.Stderr, "Ignoring credentials.helper in config.toml. Set "+Aqua("SUPABASE_CREDENTIALS_HELPER")+" instead.")
+				local.Credentials.Helper = ""
+			}
+			config.merge(local.Credentials)
+		}
+	}
+	store, err := newCredentialStore(config, fsys)
+	if err != nil {
+		return err
+	}
+	credentials.UseStore(store)
+	return nil
+}
+
+// Fills in unset fields from a lower precedence source, unless that source selects a different store.
+func (c *credentialStore) merge(other credentialStore) {
+	if len(c.Store) == 0 {
+		c.Store = other.Store
+	} else if len(other.Store) > 0 && other.Store != c.Store {
+		return
+	}
+	if len(c.Helper) == 0 {
+		c.Helper = other.Helper
+	}
+	if len(c.Path) == 0 {
+		c.Path = other.Path
+	}
+}
+
+// Persists the choice of credentials backend, along with its helper and path, for subsequent commands.
+func SetCredentialStore(name string, fsys afero.Fs) error {
+	selected := credentialStore{
+		Store:  name,
+		Helper: viper.GetString("CREDENTIALS_HELPER"),
+		Path:   viper.GetString("CREDENTIALS_PATH"),
+	}
+	store, err := newCredentialStore(selected, fsys)
+	if err != nil {
+		return err
+	}
+	config, err := LoadProfileConfig(fsys)
+	if err != nil {
+		return err
+	}
+	config.CredentialStore = selected.Store
+	config.CredentialHelper = selected.Helper
+	config.CredentialPath = selected.Path
+	if err := saveProfileConfig(config, fsys); err != nil {
+		return err
+	}
+	credentials.UseStore(store)
+	return nil
+}
+
+func newCredentialStore(config credentialStore, fsys afero.Fs) (credentials.Store, error) {
+	switch config.Store {
+	case "", CredentialStoreKeyring:
+		return credentials.NewKeyringStore(), nil
+	case CredentialStoreEncryptedFile:
+		path, err := getEncryptedStorePath()
+		if err != nil {
+			return nil, err
+		}
+		return credentials.NewEncryptedFileStore(path, promptPassphrase, fsys), nil
+	case CredentialStorePass:
+		return credentials.NewPassStore(config.Path), nil
+	case CredentialStoreOnePassword:
+		return credentials.NewOnePasswordStore(config.Path), nil
+	case CredentialStoreVault:
+		return credentials.NewVaultStore(config.Path), nil
+	case CredentialStoreExec:
+		if len(config.Helper) == 0 {
+			CmdSuggestion = "Set the " + Aqua("SUPABASE_CREDENTIALS_HELPER") + " environment variable to the credential helper executable."
+			return nil, errors.New("Missing credentials helper for exec store.")
+		}
+		return credentials.NewExecStore(config.Helper), nil
+	}
+	return nil, fmt.Errorf("Unknown credentials store %q. Must be one of: %v", config.Store, CredentialStores)
+}
+
+func promptPassphrase() (string, error) {
+	if passphrase := os.Getenv("SUPABASE_CREDENTIALS_PASSPHRASE"); len(passphrase) > 0 {
+		return passphrase, nil
+	}
+	suggestion := "Set the " + Aqua("SUPABASE_CREDENTIALS_PASSPHRASE") + " environment variable to unlock the encrypted credentials file."
+	if err := AssertInteractive("Enter passphrase for encrypted credentials", suggestion); err != nil {
+		return "", err
+	}
+	// Read a single line if passphrase is piped
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if len(line) == 0 && err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+	fmt.Fprint(os.Stderr, "Enter passphrase for encrypted credentials: ")
+	return credentials.PromptMasked(os.Stdin), nil
+}
+
+func getEncryptedStorePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".supabase", "credentials.enc"), nil
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils/credentials"
+)
+
+func TestInitCredentialStore(t *testing.T) {
+	t.Cleanup(func() { credentials.UseStore(credentials.NewKeyringStore()) })
+
+	t.Run("loads store from config", func(t *testing.T) {
+		viper.Set("CREDENTIALS_HELPER", "docker-credential-pass")
+		defer viper.Set("CREDENTIALS_HELPER", "")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte("[credentials]\nstore = \"exec\"\n"), 0644))
+		// Run test
+		assert.NoError(t, InitCredentialStore(fsys))
+		// Check store
+		assert.False(t, credentials.IsKeyring())
+	})
+
+	t.Run("ignores helper from config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`[credentials]
+store = "exec"
+helper = "./malicious.sh"
+`), 0644))
+		// Run test
+		err := InitCredentialStore(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing credentials helper")
+	})
+
+	t.Run("loads helper saved by login", func(t *testing.T) {
+		viper.Set("CREDENTIALS_HELPER", "docker-credential-pass")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SetCredentialStore(CredentialStoreExec, fsys))
+		viper.Set("CREDENTIALS_HELPER", "")
+		// Run test
+		assert.NoError(t, InitCredentialStore(fsys))
+		// Check store
+		assert.False(t, credentials.IsKeyring())
+		config, err := LoadProfileConfig(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, "docker-credential-pass", config.CredentialHelper)
+	})
+
+	t.Run("prefers store saved by login over config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SetCredentialStore(CredentialStoreKeyring, fsys))
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte("[credentials]\nstore = \"exec\"\n"), 0644))
+		// Run test
+		assert.NoError(t, InitCredentialStore(fsys))
+		// Check store
+		assert.True(t, credentials.IsKeyring())
+	})
+
+	t.Run("defaults to keyring", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, InitCredentialStore(fsys))
+		// Check store
+		assert.True(t, credentials.IsKeyring())
+	})
+
+	t.Run("throws error on missing helper", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte("[credentials]\nstore = \"exec\"\n"), 0644))
+		// Run test
+		err := InitCredentialStore(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing credentials helper")
+	})
+
+	t.Run("throws error on unknown store", func(t *testing.T) {
+		viper.Set("CREDENTIALS_STORE", "lastpass")
+		defer viper.Set("CREDENTIALS_STORE", "")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := InitCredentialStore(fsys)
+		// Check error
+		assert.ErrorContains(t, err, `Unknown credentials store "lastpass"`)
+	})
+}
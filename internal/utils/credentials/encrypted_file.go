@@ -0,0 +1,180 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/afero"
+	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	saltSize = 16
+	// Ref: https://pkg.go.dev/golang.org/x/crypto/scrypt#Key
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var ErrWrongPassphrase = errors.New("Failed to decrypt credentials file. Is the passphrase correct?")
+
+// PassphraseFunc is called at most once per process to unlock the encrypted file.
+type PassphraseFunc func() (string, error)
+
+// encryptedFileStore keeps all credentials in a single file encrypted with AES-256-GCM, using a key
+// derived from a user passphrase with scrypt. This is intended for headless servers without a keyring.
+type encryptedFileStore struct {
+	path       string
+	passphrase PassphraseFunc
+	fsys       afero.Fs
+
+	once   sync.Once
+	secret string
+	err    error
+}
+
+func NewEncryptedFileStore(path string, passphrase PassphraseFunc, fsys afero.Fs) Store {
+	return &encryptedFileStore{path: path, passphrase: passphrase, fsys: fsys}
+}
+
+func (s *encryptedFileStore) Get(project string) (string, error) {
+	entries, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	if value, ok := entries[project]; ok {
+		return value, nil
+	}
+	return "", keyring.ErrNotFound
+}
+
+func (s *encryptedFileStore) Set(project, password string) error {
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	entries[project] = password
+	return s.save(entries)
+}
+
+func (s *encryptedFileStore) Delete(project string) error {
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[project]; !ok {
+		return keyring.ErrNotFound
+	}
+	delete(entries, project)
+	return s.save(entries)
+}
+
+func (s *encryptedFileStore) getPassphrase() (string, error) {
+	s.once.Do(func() {
+		s.secret, s.err = s.passphrase()
+		if s.err == nil && len(s.secret) == 0 {
+			s.err = errors.New("Passphrase must not be empty.")
+		}
+	})
+	return s.secret, s.err
+}
+
+func (s *encryptedFileStore) load() (map[string]string, error) {
+	entries := map[string]string{}
+	data, err := afero.ReadFile(s.fsys, s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	} else if err != nil {
+		return nil, err
+	}
+	passphrase, err := s.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := decrypt(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *encryptedFileStore) save(entries map[string]string) error {
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	passphrase, err := s.getPassphrase()
+	if err != nil {
+		return err
+	}
+	data, err := encrypt(plaintext, passphrase)
+	if err != nil {
+		return err
+	}
+	if err := s.fsys.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	return afero.WriteFile(s.fsys, s.path, data, 0600)
+}
+
+// Output is laid out as salt | nonce | ciphertext.
+func encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	aead, err := newCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out := append(salt, nonce...)
+	return aead.Seal(out, nonce, plaintext, salt), nil
+}
+
+func decrypt(data []byte, passphrase string) ([]byte, error) {
+	if len(data) < saltSize {
+		return nil, ErrWrongPassphrase
+	}
+	salt := data[:saltSize]
+	aead, err := newCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < saltSize+aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	nonce := data[saltSize : saltSize+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, data[saltSize+aead.NonceSize():], salt)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+func newCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
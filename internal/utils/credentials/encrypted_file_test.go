@@ -0,0 +1,77 @@
+package credentials
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func TestEncryptedFileStore(t *testing.T) {
+	passphrase := func() (string, error) {
+		return "correct horse battery staple", nil
+	}
+
+	t.Run("round trips credentials", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		store := NewEncryptedFileStore("/home/.supabase/credentials.enc", passphrase, fsys)
+		// Run test
+		require.NoError(t, store.Set("access-token", "sbp_test"))
+		require.NoError(t, store.Set("project-ref", "db-password"))
+		// Check file is encrypted
+		data, err := afero.ReadFile(fsys, "/home/.supabase/credentials.enc")
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "sbp_test")
+		// Check values with a new store instance
+		store = NewEncryptedFileStore("/home/.supabase/credentials.enc", passphrase, fsys)
+		value, err := store.Get("access-token")
+		assert.NoError(t, err)
+		assert.Equal(t, "sbp_test", value)
+		assert.NoError(t, store.Delete("access-token"))
+		_, err = store.Get("access-token")
+		assert.ErrorIs(t, err, keyring.ErrNotFound)
+		value, err = store.Get("project-ref")
+		assert.NoError(t, err)
+		assert.Equal(t, "db-password", value)
+	})
+
+	t.Run("throws error on wrong passphrase", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, NewEncryptedFileStore("/credentials.enc", passphrase, fsys).Set("access-token", "sbp_test"))
+		store := NewEncryptedFileStore("/credentials.enc", func() (string, error) {
+			return "wrong", nil
+		}, fsys)
+		// Run test
+		_, err := store.Get("access-token")
+		// Check error
+		assert.ErrorIs(t, err, ErrWrongPassphrase)
+	})
+
+	t.Run("throws error on missing passphrase", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		errMissing := errors.New("missing passphrase")
+		store := NewEncryptedFileStore("/credentials.enc", func() (string, error) {
+			return "", errMissing
+		}, fsys)
+		// Run test
+		err := store.Set("access-token", "sbp_test")
+		// Check error
+		assert.ErrorIs(t, err, errMissing)
+	})
+
+	t.Run("returns not found without file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		store := NewEncryptedFileStore("/credentials.enc", passphrase, fsys)
+		// Run test
+		_, err := store.Get("access-token")
+		// Check error
+		assert.ErrorIs(t, err, keyring.ErrNotFound)
+	})
+}
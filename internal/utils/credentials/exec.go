@@ -0,0 +1,212 @@
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Runs an external command with stdin, returning its stdout. Overridden in tests.
+var runCommand = func(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var execErr *exec.Error
+		if errors.As(err, &execErr) {
+			return "", fmt.Errorf("failed to run %s: %w", name, err)
+		}
+		return stdout.String(), fmt.Errorf("%s %s: %w\n%s", name, args[0], err, strings.TrimSpace(stdout.String()+stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// Maps command failures to keyring.ErrNotFound if output contains the marker.
+func notFound(err error, marker string) error {
+	if err != nil && strings.Contains(err.Error(), marker) {
+		return keyring.ErrNotFound
+	}
+	return err
+}
+
+// passStore saves each credential as an entry in the standard unix password manager.
+// Ref: https://www.passwordstore.org
+type passStore struct {
+	prefix string
+}
+
+func NewPassStore(prefix string) Store {
+	if len(prefix) == 0 {
+		prefix = "supabase"
+	}
+	return passStore{prefix: prefix}
+}
+
+func (s passStore) Get(project string) (string, error) {
+	out, err := runCommand("", "pass", "show", path.Join(s.prefix, project))
+	if err != nil {
+		return "", notFound(err, "is not in the password store")
+	}
+	// First line holds the password by convention
+	password, _, _ := strings.Cut(out, "\n")
+	return password, nil
+}
+
+func (s passStore) Set(project, password string) error {
+	_, err := runCommand(password+"\n", "pass", "insert", "--multiline", "--force", path.Join(s.prefix, project))
+	return err
+}
+
+func (s passStore) Delete(project string) error {
+	_, err := runCommand("", "pass", "rm", "--force", path.Join(s.prefix, project))
+	return notFound(err, "is not in the password store")
+}
+
+// onePasswordStore saves each credential as a password item using the 1Password CLI.
+// Ref: https://developer.1password.com/docs/cli
+type onePasswordStore struct {
+	vault string
+}
+
+func NewOnePasswordStore(vault string) Store {
+	if len(vault) == 0 {
+		vault = "Private"
+	}
+	return onePasswordStore{vault: vault}
+}
+
+func (s onePasswordStore) title(project string) string {
+	return namespace + " " + project
+}
+
+func (s onePasswordStore) Get(project string) (string, error) {
+	out, err := runCommand("", "op", "item", "get", s.title(project), "--vault", s.vault, "--fields", "label=password", "--reveal")
+	if err != nil {
+		return "", notFound(err, "isn't an item")
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (s onePasswordStore) Set(project, password string) error {
+	// Replaces existing item to avoid duplicate titles
+	if err := s.Delete(project); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return err
+	}
+	// Item template is piped via stdin so that secrets are not exposed in process list
+	template, err := json.Marshal(map[string]any{
+		"title":    s.title(project),
+		"category": "PASSWORD",
+		"fields": []map[string]string{{
+			"id":      "password",
+			"type":    "CONCEALED",
+			"purpose": "PASSWORD",
+			"label":   "password",
+			"value":   password,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = runCommand(string(template), "op", "item", "create", "--vault", s.vault, "-")
+	return err
+}
+
+func (s onePasswordStore) Delete(project string) error {
+	_, err := runCommand("", "op", "item", "delete", s.title(project), "--vault", s.vault)
+	return notFound(err, "isn't an item")
+}
+
+// vaultStore saves each credential as a KV secret using the HashiCorp Vault CLI, which reads
+// VAULT_ADDR and VAULT_TOKEN from env.
+// Ref: https://developer.hashicorp.com/vault/docs/commands/kv
+type vaultStore struct {
+	path string
+}
+
+func NewVaultStore(path string) Store {
+	if len(path) == 0 {
+		path = "secret/supabase"
+	}
+	return vaultStore{path: path}
+}
+
+func (s vaultStore) Get(project string) (string, error) {
+	out, err := runCommand("", "vault", "kv", "get", "-field=value", path.Join(s.path, project))
+	if err != nil {
+		return "", notFound(err, "No value found at")
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (s vaultStore) Set(project, password string) error {
+	// Reads value from stdin so that secrets are not exposed in process list
+	_, err := runCommand(password, "vault", "kv", "put", path.Join(s.path, project), "value=-")
+	return err
+}
+
+func (s vaultStore) Delete(project string) error {
+	// Deleting metadata succeeds even if the secret does not exist
+	if _, err := runCommand("", "vault", "kv", "metadata", "get", path.Join(s.path, project)); err != nil {
+		return notFound(err, "No value found at")
+	}
+	_, err := runCommand("", "vault", "kv", "metadata", "delete", path.Join(s.path, project))
+	return err
+}
+
+// execStore delegates to a helper program implementing Docker's credential helper protocol.
+// Ref: https://github.com/docker/docker-credential-helpers
+type execStore struct {
+	helper string
+}
+
+type helperCredentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+func NewExecStore(helper string) Store {
+	return execStore{helper: helper}
+}
+
+func (s execStore) serverURL(project string) string {
+	return "supabase-cli://" + project
+}
+
+func (s execStore) Get(project string) (string, error) {
+	out, err := runCommand(s.serverURL(project), s.helper, "get")
+	if err != nil {
+		return "", notFound(err, "credentials not found")
+	}
+	var creds helperCredentials
+	if err := json.Unmarshal([]byte(out), &creds); err != nil {
+		return "", fmt.Errorf("failed to parse %s output: %w", s.helper, err)
+	}
+	return creds.Secret, nil
+}
+
+func (s execStore) Set(project, password string) error {
+	input, err := json.Marshal(helperCredentials{
+		ServerURL: s.serverURL(project),
+		Username:  namespace,
+		Secret:    password,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = runCommand(string(input), s.helper, "store")
+	return err
+}
+
+func (s execStore) Delete(project string) error {
+	_, err := runCommand(s.serverURL(project), s.helper, "erase")
+	return notFound(err, "credentials not found")
+}
@@ -0,0 +1,131 @@
+package credentials
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
+)
+
+type mockCommand struct {
+	stdin  string
+	args   []string
+	stdout string
+	err    error
+}
+
+// Replaces runCommand with a mock that records calls and returns canned output in order.
+func mockRunCommand(t *testing.T, replies ...mockCommand) *[]mockCommand {
+	original := runCommand
+	t.Cleanup(func() { runCommand = original })
+	var calls []mockCommand
+	runCommand = func(stdin string, name string, args ...string) (string, error) {
+		call := mockCommand{stdin: stdin, args: append([]string{name}, args...)}
+		calls = append(calls, call)
+		if len(replies) == 0 {
+			t.Fatalf("unexpected command: %v", call.args)
+		}
+		reply := replies[0]
+		replies = replies[1:]
+		return reply.stdout, reply.err
+	}
+	return &calls
+}
+
+func TestPassStore(t *testing.T) {
+	t.Run("reads first line of entry", func(t *testing.T) {
+		calls := mockRunCommand(t, mockCommand{stdout: "sbp_test\nusername: cli\n"})
+		// Run test
+		value, err := NewPassStore("").Get("access-token")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "sbp_test", value)
+		assert.Equal(t, []string{"pass", "show", "supabase/access-token"}, (*calls)[0].args)
+	})
+
+	t.Run("pipes password to insert", func(t *testing.T) {
+		calls := mockRunCommand(t, mockCommand{})
+		// Run test
+		assert.NoError(t, NewPassStore("work").Set("access-token", "sbp_test"))
+		// Check command
+		assert.Equal(t, "sbp_test\n", (*calls)[0].stdin)
+		assert.NotContains(t, strings.Join((*calls)[0].args, " "), "sbp_test")
+	})
+
+	t.Run("maps missing entry to not found", func(t *testing.T) {
+		mockRunCommand(t, mockCommand{err: errors.New("Error: supabase/access-token is not in the password store.")})
+		// Run test
+		_, err := NewPassStore("").Get("access-token")
+		// Check error
+		assert.ErrorIs(t, err, keyring.ErrNotFound)
+	})
+}
+
+func TestOnePasswordStore(t *testing.T) {
+	t.Run("replaces existing item", func(t *testing.T) {
+		calls := mockRunCommand(t, mockCommand{
+			err: errors.New(`"Supabase CLI access-token" isn't an item in the "Private" vault`),
+		}, mockCommand{})
+		// Run test
+		assert.NoError(t, NewOnePasswordStore("").Set("access-token", "sbp_test"))
+		// Check command
+		assert.Equal(t, []string{"op", "item", "create", "--vault", "Private", "-"}, (*calls)[1].args)
+		assert.Contains(t, (*calls)[1].stdin, `"value":"sbp_test"`)
+	})
+}
+
+func TestVaultStore(t *testing.T) {
+	t.Run("reads value field", func(t *testing.T) {
+		calls := mockRunCommand(t, mockCommand{stdout: "sbp_test\n"})
+		// Run test
+		value, err := NewVaultStore("kv/cli").Get("access-token")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "sbp_test", value)
+		assert.Equal(t, []string{"vault", "kv", "get", "-field=value", "kv/cli/access-token"}, (*calls)[0].args)
+	})
+
+	t.Run("deletes existing secret", func(t *testing.T) {
+		calls := mockRunCommand(t, mockCommand{stdout: "======= Metadata Path =======\n"}, mockCommand{})
+		// Run test
+		assert.NoError(t, NewVaultStore("").Delete("access-token"))
+		// Check command
+		assert.Equal(t, []string{"vault", "kv", "metadata", "delete", "secret/supabase/access-token"}, (*calls)[1].args)
+	})
+
+	t.Run("maps missing secret to not found", func(t *testing.T) {
+		mockRunCommand(t, mockCommand{err: errors.New("No value found at secret/metadata/supabase/access-token")})
+		// Run test
+		err := NewVaultStore("").Delete("access-token")
+		// Check error
+		assert.ErrorIs(t, err, keyring.ErrNotFound)
+	})
+}
+
+func TestExecStore(t *testing.T) {
+	t.Run("speaks credential helper protocol", func(t *testing.T) {
+		calls := mockRunCommand(t, mockCommand{}, mockCommand{
+			stdout: `{"ServerURL":"supabase-cli://access-token","Username":"Supabase CLI","Secret":"sbp_test"}`,
+		})
+		store := NewExecStore("docker-credential-pass")
+		// Run test
+		assert.NoError(t, store.Set("access-token", "sbp_test"))
+		value, err := store.Get("access-token")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "sbp_test", value)
+		assert.Equal(t, []string{"docker-credential-pass", "store"}, (*calls)[0].args)
+		assert.JSONEq(t, `{"ServerURL":"supabase-cli://access-token","Username":"Supabase CLI","Secret":"sbp_test"}`, (*calls)[0].stdin)
+		assert.Equal(t, "supabase-cli://access-token", (*calls)[1].stdin)
+	})
+
+	t.Run("maps missing credentials to not found", func(t *testing.T) {
+		mockRunCommand(t, mockCommand{err: errors.New("credentials not found in native keychain")})
+		// Run test
+		err := NewExecStore("docker-credential-pass").Delete("access-token")
+		// Check error
+		assert.ErrorIs(t, err, keyring.ErrNotFound)
+	})
+}
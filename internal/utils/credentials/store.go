@@ -12,24 +12,63 @@ const namespace = "Supabase CLI"
 
 var ErrNotSupported = errors.New("Keyring is not supported on WSL")
 
+// Store persists secrets, such as access tokens and database passwords, keyed by project.
+type Store interface {
+	Get(project string) (string, error)
+	Set(project, password string) error
+	Delete(project string) error
+}
+
+// Backend used by the package level helpers, defaults to native keyring.
+var backend Store = keyringStore{}
+
+// Replaces the backend used for storing credentials.
+func UseStore(store Store) {
+	backend = store
+}
+
+// Returns true if credentials are stored in the native keyring.
+func IsKeyring() bool {
+	_, ok := backend.(keyringStore)
+	return ok
+}
+
 // Retrieves the stored password of a project and username
 func Get(project string) (string, error) {
+	return backend.Get(project)
+}
+
+// Stores the password of a project and username
+func Set(project, password string) error {
+	return backend.Set(project, password)
+}
+
+// Erases the stored password of a project and username
+func Delete(project string) error {
+	return backend.Delete(project)
+}
+
+type keyringStore struct{}
+
+func NewKeyringStore() Store {
+	return keyringStore{}
+}
+
+func (keyringStore) Get(project string) (string, error) {
 	if err := assertKeyringSupported(); err != nil {
 		return "", err
 	}
 	return keyring.Get(namespace, project)
 }
 
-// Stores the password of a project and username
-func Set(project, password string) error {
+func (keyringStore) Set(project, password string) error {
 	if err := assertKeyringSupported(); err != nil {
 		return err
 	}
 	return keyring.Set(namespace, project, password)
 }
 
-// Erases the stored password of a project and username
-func Delete(project string) error {
+func (keyringStore) Delete(project string) error {
 	if err := assertKeyringSupported(); err != nil {
 		return err
 	}
@@ -317,6 +317,10 @@ func AbsImportMapPath(importMapPath, slug string, fsys afero.Fs) (string, error)
 			if !filepath.IsAbs(importMapPath) {
 				importMapPath = filepath.Join(SupabaseDirPath, importMapPath)
 			}
+		} else if local, err := FunctionImportMapPath(slug, fsys); err != nil {
+			return "", err
+		} else if len(local) > 0 {
+			importMapPath = local
 		} else if exists, _ := afero.Exists(fsys, FallbackImportMapPath); exists {
 			importMapPath = FallbackImportMapPath
 		} else {
@@ -334,3 +338,34 @@ func AbsImportMapPath(importMapPath, slug string, fsys afero.Fs) (string, error)
 	}
 	return resolved, nil
 }
+
+// Returns the deno.json or import_map.json colocated with a Function, or empty string if there is none.
+func FunctionImportMapPath(slug string, fsys afero.Fs) (string, error) {
+	if len(slug) == 0 {
+		return "", nil
+	}
+	functionDir := filepath.Join(FunctionsDir, slug)
+	denoJsonPath := filepath.Join(functionDir, "deno.json")
+	if contents, err := afero.ReadFile(fsys, denoJsonPath); err == nil {
+		// Deno config either embeds the import map or points to a separate file
+		var denoJson struct {
+			ImportMap string `json:"importMap"`
+		}
+		if err := json.Unmarshal(contents, &denoJson); err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", denoJsonPath, err)
+		}
+		if len(denoJson.ImportMap) > 0 {
+			return filepath.Join(functionDir, denoJson.ImportMap), nil
+		}
+		return denoJsonPath, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	importMapPath := filepath.Join(functionDir, "import_map.json")
+	if exists, err := afero.Exists(fsys, importMapPath); err != nil {
+		return "", err
+	} else if exists {
+		return importMapPath, nil
+	}
+	return "", nil
+}
@@ -88,6 +88,36 @@ func TestImportMapPath(t *testing.T) {
 		assert.Equal(t, absPath, resolved)
 	})
 
+	t.Run("colocated deno.json takes precedence over fallback", func(t *testing.T) {
+		slug := "colocated"
+		denoJsonPath := filepath.Join(FunctionsDir, slug, "deno.json")
+		absPath, err := filepath.Abs(denoJsonPath)
+		require.NoError(t, err)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, FallbackImportMapPath, []byte("{}"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, denoJsonPath, []byte(`{"imports":{}}`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, absPath, []byte(`{"imports":{}}`), 0644))
+		// Run test
+		resolved, err := AbsImportMapPath("", slug, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, absPath, resolved)
+	})
+
+	t.Run("follows import map declared in deno.json", func(t *testing.T) {
+		slug := "colocated"
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		denoJsonPath := filepath.Join(FunctionsDir, slug, "deno.json")
+		require.NoError(t, afero.WriteFile(fsys, denoJsonPath, []byte(`{"importMap":"../_shared/import_map.json"}`), 0644))
+		// Run test
+		resolved, err := FunctionImportMapPath(slug, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(FunctionsDir, "_shared", "import_map.json"), resolved)
+	})
+
 	t.Run("returns empty string if no fallback", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
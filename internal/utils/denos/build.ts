@@ -139,6 +139,10 @@ async function load(
       }
       case "http:":
       case "https:": {
+        const cached = await readCache(specifier);
+        if (cached) {
+          return cached;
+        }
         const requestHeaders: { Authorization?: string } = {};
         {
           const denoAuthTokens = parseDenoAuthTokens(
@@ -166,12 +170,14 @@ async function load(
         for (const [key, value] of response.headers) {
           headers[key.toLowerCase()] = value;
         }
-        return {
+        const module: LoadResponseModule = {
           kind: "module",
           specifier: response.url,
           headers,
           content,
         };
+        await writeCache(specifier, module);
+        return module;
       }
       default:
         return undefined;
@@ -180,3 +186,45 @@ async function load(
     return undefined;
   }
 }
+
+// Remote modules shared between Functions are cached on disk for the duration of a deploy.
+const cacheDir = Deno.env.get("SUPABASE_BUNDLE_CACHE_DIR");
+
+async function cachePath(specifier: string): Promise<string> {
+  const digest = await crypto.subtle.digest(
+    "SHA-256",
+    new TextEncoder().encode(specifier),
+  );
+  const hex = Array.from(new Uint8Array(digest))
+    .map((b) => b.toString(16).padStart(2, "0"))
+    .join("");
+  return path.join(cacheDir!, `${hex}.json`);
+}
+
+async function readCache(
+  specifier: string,
+): Promise<LoadResponseModule | undefined> {
+  if (!cacheDir) {
+    return undefined;
+  }
+  try {
+    return JSON.parse(await Deno.readTextFile(await cachePath(specifier)));
+  } catch {
+    return undefined;
+  }
+}
+
+async function writeCache(specifier: string, module: LoadResponseModule) {
+  if (!cacheDir) {
+    return;
+  }
+  // Write to a temporary file first so concurrent bundles never read partial entries
+  try {
+    const dst = await cachePath(specifier);
+    const tmp = await Deno.makeTempFile({ dir: cacheDir });
+    await Deno.writeTextFile(tmp, JSON.stringify(module));
+    await Deno.rename(tmp, dst);
+  } catch {
+    // Caching is best effort
+  }
+}
@@ -248,11 +248,28 @@ func DockerPullImageIfNotCached(ctx context.Context, imageName string) error {
 	return DockerImagePullWithRetry(ctx, imageUrl, 2)
 }
 
+func applyResourceLimits(image string, resources *container.Resources) {
+	short := ShortContainerImageName(image)
+	for name, limit := range Config.Services.Resources {
+		if ResolveServiceName(name) != short {
+			continue
+		}
+		if limit.Memory > 0 {
+			resources.Memory = int64(limit.Memory)
+		}
+		if limit.Cpus > 0 {
+			resources.NanoCPUs = int64(limit.Cpus * 1e9)
+		}
+	}
+}
+
 func DockerStart(ctx context.Context, config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig, containerName string) (string, error) {
 	// Pull container image
 	if err := DockerPullImageIfNotCached(ctx, config.Image); err != nil {
 		return "", err
 	}
+	// Apply resource limits from config
+	applyResourceLimits(config.Image, &hostConfig.Resources)
 	// Setup default config
 	config.Image = GetRegistryImageUrl(config.Image)
 	if config.Labels == nil {
@@ -0,0 +1,127 @@
+package fanout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Limits concurrent projects so that bundling and database connections don't exhaust local resources.
+const maxJobs = 4
+
+type outputKey struct{}
+
+type output struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// Returns the writer for command output of the project being run, defaulting to os.Stdout.
+func Stdout(ctx context.Context) io.Writer {
+	if out, ok := ctx.Value(outputKey{}).(output); ok {
+		return out.stdout
+	}
+	return os.Stdout
+}
+
+// Returns the writer for progress messages of the project being run, defaulting to os.Stderr.
+func Stderr(ctx context.Context) io.Writer {
+	if out, ok := ctx.Value(outputKey{}).(output); ok {
+		return out.stderr
+	}
+	return os.Stderr
+}
+
+// Runs fn against each project concurrently, reporting the status of each project as it completes.
+// Returns an error if any project failed, after all projects have finished.
+func Run(ctx context.Context, projectRefs []string, fn func(context.Context, string) error) error {
+	return run(ctx, projectRefs, fn, os.Stdout, os.Stderr)
+}
+
+func run(ctx context.Context, projectRefs []string, fn func(context.Context, string) error, stdout, stderr io.Writer) error {
+	// Single project keeps the original output
+	if len(projectRefs) == 1 {
+		return fn(ctx, projectRefs[0])
+	}
+	errs := make([]error, len(projectRefs))
+	jobs := make(chan struct{}, maxJobs)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for i, ref := range projectRefs {
+		wg.Add(1)
+		go func(i int, ref string) {
+			defer wg.Done()
+			jobs <- struct{}{}
+			defer func() { <-jobs }()
+			// Label every line so that concurrent output can be told apart
+			prefix := utils.Aqua(ref) + " | "
+			out := output{
+				stdout: &prefixWriter{prefix: prefix, w: stdout, mu: &mu},
+				stderr: &prefixWriter{prefix: prefix, w: stderr, mu: &mu},
+			}
+			err := fn(context.WithValue(ctx, outputKey{}, out), ref)
+			mu.Lock()
+			defer mu.Unlock()
+			out.stdout.(*prefixWriter).flush()
+			out.stderr.(*prefixWriter).flush()
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", ref, err)
+				fmt.Fprintln(stderr, utils.Red("Failed"), utils.Aqua(ref)+":", err)
+			} else {
+				fmt.Fprintln(stderr, "Finished", utils.Aqua(ref)+".")
+			}
+		}(i, ref)
+	}
+	wg.Wait()
+	var failed int
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d projects failed:\n%w", failed, len(projectRefs), errors.Join(errs...))
+	}
+	fmt.Fprintf(stderr, "Finished %d projects.\n", len(projectRefs))
+	return nil
+}
+
+// Writes complete lines with a prefix. The mutex is shared by all projects so lines never interleave.
+type prefixWriter struct {
+	prefix string
+	w      io.Writer
+	mu     *sync.Mutex
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := p.buf[:i+1]
+		p.buf = p.buf[i+1:]
+		if _, err := io.WriteString(p.w, p.prefix+string(line)); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Writes any trailing partial line. Caller must hold the mutex.
+func (p *prefixWriter) flush() {
+	if len(p.buf) > 0 {
+		fmt.Fprintln(p.w, p.prefix+string(p.buf))
+		p.buf = nil
+	}
+}
@@ -0,0 +1,73 @@
+package fanout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestFanout(t *testing.T) {
+	t.Run("runs all projects", func(t *testing.T) {
+		var count int32
+		var out bytes.Buffer
+		// Run test
+		err := run(context.Background(), []string{"a", "b", "c", "d", "e"}, func(ctx context.Context, ref string) error {
+			atomic.AddInt32(&count, 1)
+			return nil
+		}, &out, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, int32(5), count)
+		assert.Contains(t, out.String(), "Finished 5 projects.")
+	})
+
+	t.Run("aggregates errors after all projects finish", func(t *testing.T) {
+		var count int32
+		var out bytes.Buffer
+		// Run test
+		err := run(context.Background(), []string{"a", "b", "c"}, func(ctx context.Context, ref string) error {
+			atomic.AddInt32(&count, 1)
+			if ref == "b" {
+				return errors.New("network error")
+			}
+			return nil
+		}, &out, &out)
+		// Check error
+		assert.ErrorContains(t, err, "1 of 3 projects failed")
+		assert.ErrorContains(t, err, "b: network error")
+		assert.Equal(t, int32(3), count)
+		assert.Contains(t, out.String(), "Finished a.")
+	})
+
+	t.Run("prefixes output of each project", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		// Run test
+		err := run(context.Background(), []string{"a", "b"}, func(ctx context.Context, ref string) error {
+			fmt.Fprint(Stdout(ctx), "Deploying ")
+			fmt.Fprintln(Stdout(ctx), ref)
+			fmt.Fprint(Stderr(ctx), "partial")
+			return nil
+		}, &stdout, &stderr)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, stdout.String(), utils.Aqua("a")+" | Deploying a\n")
+		assert.Contains(t, stdout.String(), utils.Aqua("b")+" | Deploying b\n")
+		assert.Contains(t, stderr.String(), utils.Aqua("a")+" | partial\n")
+	})
+
+	t.Run("returns error of single project as is", func(t *testing.T) {
+		errNetwork := errors.New("network error")
+		// Run test
+		err := Run(context.Background(), []string{"a"}, func(ctx context.Context, ref string) error {
+			return errNetwork
+		})
+		// Check error
+		assert.Equal(t, errNetwork, err)
+	})
+}
@@ -61,15 +61,17 @@ func ParseDatabaseConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
 		DbConfig.Password = utils.Config.Db.Password
 		DbConfig.Database = "postgres"
 	case linked:
+		// Connections to multiple projects are configured by the caller
+		if len(ProjectRefs) > 0 {
+			return resolveProjectRefs(fsys)
+		}
 		projectRef, err := utils.LoadProjectRef(fsys)
 		if err != nil {
 			return err
 		}
-		DbConfig.Host = utils.GetSupabaseDbHost(projectRef)
-		DbConfig.Port = 6543
-		DbConfig.User = "postgres"
-		DbConfig.Password = getPassword(projectRef)
-		DbConfig.Database = "postgres"
+		if DbConfig, err = NewLinkedConfig(projectRef); err != nil {
+			return err
+		}
 	case proxy:
 		token, err := utils.LoadAccessTokenFS(fsys)
 		if err != nil {
@@ -88,12 +90,27 @@ func ParseDatabaseConfig(flagSet *pflag.FlagSet, fsys afero.Fs) error {
 	return nil
 }
 
-func getPassword(projectRef string) string {
+func NewLinkedConfig(projectRef string) (pgconn.Config, error) {
+	password, err := GetPassword(projectRef)
+	if err != nil {
+		return pgconn.Config{}, err
+	}
+	return pgconn.Config{
+		Host:     utils.GetSupabaseDbHost(projectRef),
+		Port:     6543,
+		User:     "postgres",
+		Password: password,
+		Database: "postgres",
+	}, nil
+}
+
+// Resolves the database password from env, credential store, or an interactive prompt.
+func GetPassword(projectRef string) (string, error) {
 	if password := viper.GetString("DB_PASSWORD"); len(password) > 0 {
-		return password
+		return password, nil
 	}
 	if password, err := credentials.Get(projectRef); err == nil {
-		return password
+		return password, nil
 	}
 	return link.PromptPassword(os.Stdin)
 }
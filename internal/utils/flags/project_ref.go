@@ -15,9 +15,16 @@ import (
 	"golang.org/x/term"
 )
 
-var ProjectRef string
+var (
+	ProjectRef string
+	// Refs or group names passed to commands that fan out to multiple projects
+	ProjectRefs []string
+)
 
 func ParseProjectRef(fsys afero.Fs) error {
+	if len(ProjectRefs) > 0 {
+		return resolveProjectRefs(fsys)
+	}
 	// Flag takes highest precedence
 	if len(ProjectRef) == 0 {
 		ProjectRef = viper.GetString("PROJECT_ID")
@@ -51,3 +58,43 @@ Enter your project ref: `, utils.GetSupabaseDashboardURL())
 	ProjectRef = strings.TrimSpace(scanner.Text())
 	return utils.AssertProjectRefIsValid(ProjectRef)
 }
+
+// Expands groups defined in config.toml, keeping ProjectRef as the first project for single project
+// code paths.
+func resolveProjectRefs(fsys afero.Fs) error {
+	var refs []string
+	for _, name := range ProjectRefs {
+		group := []string{name}
+		if !utils.ProjectRefPattern.MatchString(name) {
+			if err := utils.LoadConfigFS(fsys); err != nil {
+				return err
+			}
+			var ok bool
+			if group, ok = utils.Config.ProjectGroups[name]; !ok {
+				return errors.New("Invalid project ref or group: " + utils.Aqua(name))
+			}
+		}
+		for _, ref := range group {
+			if err := utils.AssertProjectRefIsValid(ref); err != nil {
+				return fmt.Errorf("%w: %s", err, ref)
+			}
+			if !utils.SliceContains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	if len(refs) == 0 {
+		return errors.New("No projects found in " + utils.Aqua(strings.Join(ProjectRefs, ",")))
+	}
+	ProjectRefs = refs
+	ProjectRef = refs[0]
+	return nil
+}
+
+// Returns all projects to run against, falling back to the single project ref.
+func GetProjectRefs() []string {
+	if len(ProjectRefs) > 0 {
+		return ProjectRefs
+	}
+	return []string{ProjectRef}
+}
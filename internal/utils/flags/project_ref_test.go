@@ -3,6 +3,7 @@ package flags
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -83,3 +84,34 @@ func TestProjectPrompt(t *testing.T) {
 		assert.ErrorIs(t, err, bufio.ErrTooLong)
 	})
 }
+
+func TestProjectRefs(t *testing.T) {
+	first := apitest.RandomProjectRef()
+	second := apitest.RandomProjectRef()
+	defer func() { ProjectRefs = nil }()
+
+	t.Run("expands groups from config", func(t *testing.T) {
+		ProjectRefs = []string{first, "production"}
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		config := fmt.Sprintf("[project_groups]\nproduction = [%q, %q]\n", first, second)
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(config), 0644))
+		// Run test
+		err := ParseProjectRef(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{first, second}, ProjectRefs)
+		assert.Equal(t, first, ProjectRef)
+	})
+
+	t.Run("throws error on unknown group", func(t *testing.T) {
+		ProjectRefs = []string{"staging"}
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(""), 0644))
+		// Run test
+		err := ParseProjectRef(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid project ref or group:")
+	})
+}
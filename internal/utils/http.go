@@ -12,7 +12,10 @@ import (
 	openapi "github.com/supabase/cli/pkg/api"
 )
 
-var httpClient = http.Client{Timeout: 10 * time.Second}
+var httpClient = http.Client{
+	Transport: traceTransport{},
+	Timeout:   10 * time.Second,
+}
 
 func JsonResponse[T any](ctx context.Context, method, url string, reqBody any, reqEditors ...openapi.RequestEditorFn) (*T, error) {
 	var body bytes.Buffer
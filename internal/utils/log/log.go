@@ -0,0 +1,121 @@
+// Package log writes leveled, logfmt style messages to stderr.
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelError {
+		return strconv.Itoa(int(l))
+	}
+	return levelNames[l]
+}
+
+// Levels returns the names accepted by ParseLevel, from most to least verbose.
+func Levels() []string {
+	return append([]string{}, levelNames...)
+}
+
+func ParseLevel(name string) (Level, error) {
+	for i, n := range levelNames {
+		if strings.EqualFold(name, n) {
+			return Level(i), nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("invalid log level: %s", name)
+}
+
+var (
+	mu     sync.Mutex
+	level            = LevelInfo
+	output io.Writer = os.Stderr
+	now              = time.Now
+)
+
+func SetLevel(l Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+}
+
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	output = w
+}
+
+func Enabled(l Level) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return l >= level
+}
+
+// Debug logs a message with optional key value pairs, ie. Debug("request", "method", "GET").
+func Debug(msg string, kv ...any) {
+	write(LevelDebug, msg, kv)
+}
+
+func Info(msg string, kv ...any) {
+	write(LevelInfo, msg, kv)
+}
+
+func Warn(msg string, kv ...any) {
+	write(LevelWarn, msg, kv)
+}
+
+func Error(msg string, kv ...any) {
+	write(LevelError, msg, kv)
+}
+
+func write(l Level, msg string, kv []any) {
+	mu.Lock()
+	defer mu.Unlock()
+	if l < level {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString("time=")
+	sb.WriteString(now().UTC().Format(time.RFC3339))
+	sb.WriteString(" level=")
+	sb.WriteString(l.String())
+	sb.WriteString(" msg=")
+	sb.WriteString(quote(msg))
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprint(kv[i])
+		var value any = "!MISSING"
+		if i+1 < len(kv) {
+			value = kv[i+1]
+		}
+		sb.WriteString(" ")
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(quote(fmt.Sprint(value)))
+	}
+	sb.WriteString("\n")
+	fmt.Fprint(output, sb.String())
+}
+
+func quote(s string) string {
+	if len(s) == 0 || strings.ContainsAny(s, " =\"\t\r\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
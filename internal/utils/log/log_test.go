@@ -0,0 +1,39 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevels(t *testing.T) {
+	var out bytes.Buffer
+	SetOutput(&out)
+	now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() {
+		SetOutput(os.Stderr)
+		SetLevel(LevelInfo)
+		now = time.Now
+	})
+
+	t.Run("filters messages below level", func(t *testing.T) {
+		out.Reset()
+		SetLevel(LevelWarn)
+		// Run test
+		Info("hidden")
+		Warn("shown", "status", 503, "body", "service unavailable")
+		// Check output
+		assert.Equal(t, `time=2024-01-01T00:00:00Z level=warn msg=shown status=503 body="service unavailable"`+"\n", out.String())
+	})
+
+	t.Run("parses level names", func(t *testing.T) {
+		level, err := ParseLevel("DEBUG")
+		assert.NoError(t, err)
+		assert.Equal(t, LevelDebug, level)
+		_, err = ParseLevel("verbose")
+		assert.ErrorContains(t, err, "invalid log level: verbose")
+	})
+}
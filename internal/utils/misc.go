@@ -6,11 +6,14 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -62,6 +65,39 @@ var ServiceImages = []string{
 	PgbouncerImage,
 }
 
+// Friendly names accepted in place of short image names.
+var serviceAliases = map[string]string{
+	"db":        ShortContainerImageName(Pg15Image),
+	"auth":      ShortContainerImageName(GotrueImage),
+	"rest":      ShortContainerImageName(PostgrestImage),
+	"storage":   ShortContainerImageName(StorageImage),
+	"meta":      ShortContainerImageName(PgmetaImage),
+	"functions": ShortContainerImageName(EdgeRuntimeImage),
+	"analytics": ShortContainerImageName(LogflareImage),
+	"pooler":    ShortContainerImageName(PgbouncerImage),
+}
+
+// Resolves an alias, such as storage, to the short image name of its container.
+func ResolveServiceName(name string) string {
+	if short, ok := serviceAliases[name]; ok {
+		return short
+	}
+	return name
+}
+
+func IsKnownService(name string) bool {
+	short := ResolveServiceName(name)
+	if short == ShortContainerImageName(Pg15Image) {
+		return true
+	}
+	for _, image := range ServiceImages {
+		if ShortContainerImageName(image) == short {
+			return true
+		}
+	}
+	return false
+}
+
 func ShortContainerImageName(imageName string) string {
 	matches := ImageNamePattern.FindStringSubmatch(imageName)
 	if len(matches) < 2 {
@@ -166,18 +202,24 @@ var (
 	TempDir               = ".temp"
 	ImportMapsDir         = filepath.Join(SupabaseDirPath, TempDir, "import_maps")
 	ProjectRefPath        = filepath.Join(SupabaseDirPath, TempDir, "project-ref")
+	NativeDbDir           = filepath.Join(SupabaseDirPath, TempDir, "postgres")
+	RootKeyPath           = filepath.Join(SupabaseDirPath, TempDir, "pgsodium-root-key")
 	RemoteDbPath          = filepath.Join(SupabaseDirPath, TempDir, "remote-db-url")
 	PostgresVersionPath   = filepath.Join(SupabaseDirPath, TempDir, "postgres-version")
 	GotrueVersionPath     = filepath.Join(SupabaseDirPath, TempDir, "gotrue-version")
 	RestVersionPath       = filepath.Join(SupabaseDirPath, TempDir, "rest-version")
 	StorageVersionPath    = filepath.Join(SupabaseDirPath, TempDir, "storage-version")
 	CurrBranchPath        = filepath.Join(SupabaseDirPath, ".branches", "_current_branch")
+	SnapshotsDir          = filepath.Join(SupabaseDirPath, ".snapshots")
 	MigrationsDir         = filepath.Join(SupabaseDirPath, "migrations")
+	SchemasDir            = filepath.Join(SupabaseDirPath, "schemas")
 	FunctionsDir          = filepath.Join(SupabaseDirPath, "functions")
 	FallbackImportMapPath = filepath.Join(FunctionsDir, "import_map.json")
 	FallbackEnvFilePath   = filepath.Join(FunctionsDir, ".env")
 	DbTestsDir            = filepath.Join(SupabaseDirPath, "tests")
+	RlsTestsDir           = filepath.Join(DbTestsDir, "rls")
 	SeedDataPath          = filepath.Join(SupabaseDirPath, "seed.sql")
+	SeedsDir              = filepath.Join(SupabaseDirPath, "seeds")
 	CustomRolesPath       = filepath.Join(SupabaseDirPath, "roles.sql")
 
 	ErrNotLinked  = errors.New("Cannot find project ref. Have you run " + Aqua("supabase link") + "?")
@@ -230,6 +272,9 @@ func NewError(s string) error {
 }
 
 func AssertSupabaseDbIsRunning() error {
+	if IsNativeDbRunning() {
+		return nil
+	}
 	if _, err := Docker.ContainerInspect(context.Background(), DbId); err != nil {
 		return ErrNotRunning
 	}
@@ -237,6 +282,43 @@ func AssertSupabaseDbIsRunning() error {
 	return nil
 }
 
+// Native databases are started without Docker by supabase start --native.
+func IsNativeDbRunning() bool {
+	data, err := os.ReadFile(filepath.Join(NativeDbDir, "data", "postmaster.pid"))
+	if err != nil {
+		return false
+	}
+	// The first and fourth lines of postmaster.pid are the server pid and port
+	lines := strings.Split(string(data), "\n")
+	if len(lines) < 4 {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+	if err != nil || !isProcessAlive(pid) {
+		return false
+	}
+	// A stale pid file may point to a recycled pid, so also check the port
+	port := strings.TrimSpace(lines[3])
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", port), time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+func isProcessAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// Windows fails to find exited processes, but does not support signals
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
 func IsGitRepo() bool {
 	opts := &git.PlainOpenOptions{DetectDotGit: true}
 	_, err := git.PlainOpenWithOptions(".", opts)
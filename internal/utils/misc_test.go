@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"fmt"
 	"io/fs"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -61,3 +63,42 @@ func TestProjectRoot(t *testing.T) {
 		assert.Equal(t, cwd, path)
 	})
 }
+
+func TestIsNativeDbRunning(t *testing.T) {
+	NativeDbDir = t.TempDir()
+	t.Cleanup(func() { NativeDbDir = filepath.Join(SupabaseDirPath, TempDir, "postgres") })
+	pidPath := filepath.Join(NativeDbDir, "data", "postmaster.pid")
+	require.NoError(t, os.MkdirAll(filepath.Dir(pidPath), 0755))
+	writePid := func(t *testing.T, pid int, port string) {
+		data := fmt.Sprintf("%d\n%s\n1697356800\n%s\n\n127.0.0.1\n", pid, filepath.Dir(pidPath), port)
+		require.NoError(t, os.WriteFile(pidPath, []byte(data), 0600))
+	}
+
+	t.Run("detects running server", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+		_, port, err := net.SplitHostPort(l.Addr().String())
+		require.NoError(t, err)
+		writePid(t, os.Getpid(), port)
+		// Run test
+		assert.True(t, IsNativeDbRunning())
+	})
+
+	t.Run("ignores stale pid file", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		_, port, err := net.SplitHostPort(l.Addr().String())
+		require.NoError(t, err)
+		require.NoError(t, l.Close())
+		writePid(t, os.Getpid(), port)
+		// Run test
+		assert.False(t, IsNativeDbRunning())
+	})
+
+	t.Run("ignores malformed pid file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(pidPath, []byte("invalid"), 0600))
+		// Run test
+		assert.False(t, IsNativeDbRunning())
+	})
+}
@@ -80,18 +80,7 @@ func (t *tokenizer) ScanToken(data []byte, atEOF bool) (advance int, token []byt
 //
 // Each statement is split as it is, without removing comments or white spaces.
 func Split(sql io.Reader, transform ...func(string) string) (stats []string, err error) {
-	t := tokenizer{state: &ReadyState{}}
-	scanner := bufio.NewScanner(sql)
-
-	// Increase scanner capacity to support very long lines containing e.g. geodata
-	buf := make([]byte, startBufSize)
-	maxbuf := int(viper.GetSizeInBytes("SCANNER_BUFFER_SIZE"))
-	if maxbuf == 0 {
-		maxbuf = MaxScannerCapacity
-	}
-	scanner.Buffer(buf, maxbuf)
-	scanner.Split(t.ScanToken)
-
+	scanner, maxbuf := newScanner(sql)
 	var token string
 	for scanner.Scan() {
 		token = scanner.Text()
@@ -113,6 +102,27 @@ func Split(sql io.Reader, transform ...func(string) string) (stats []string, err
 	return stats, err
 }
 
+// Returns a scanner that emits one statement at a time, for streaming large inputs.
+func NewScanner(sql io.Reader) *bufio.Scanner {
+	scanner, _ := newScanner(sql)
+	return scanner
+}
+
+func newScanner(sql io.Reader) (*bufio.Scanner, int) {
+	t := tokenizer{state: &ReadyState{}}
+	scanner := bufio.NewScanner(sql)
+
+	// Increase scanner capacity to support very long lines containing e.g. geodata
+	buf := make([]byte, startBufSize)
+	maxbuf := int(viper.GetSizeInBytes("SCANNER_BUFFER_SIZE"))
+	if maxbuf == 0 {
+		maxbuf = MaxScannerCapacity
+	}
+	scanner.Buffer(buf, maxbuf)
+	scanner.Split(t.ScanToken)
+	return scanner, maxbuf
+}
+
 func SplitAndTrim(sql io.Reader) (stats []string, err error) {
 	return Split(sql, func(token string) string {
 		return strings.TrimRight(token, ";")
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+const DefaultProfile = "default"
+
+var (
+	ProfileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+	ErrInvalidProfile  = errors.New("Invalid profile name. Must only contain letters, digits, hyphens, and underscores.")
+)
+
+// ProfileConfig tracks the named profiles that have been logged in, along with the active one.
+type ProfileConfig struct {
+	Current  string   `json:"current"`
+	Profiles []string `json:"profiles"`
+	// Backend for storing access tokens, defaults to native keyring
+	CredentialStore string `json:"credential_store,omitempty"`
+	// Helper executable and path of the credentials store, saved alongside its name
+	CredentialHelper string `json:"credential_helper,omitempty"`
+	CredentialPath   string `json:"credential_path,omitempty"`
+	// Pins access tokens to either the credentials store or plaintext file, disabling fallback
+	TokenStore string `json:"token_store,omitempty"`
+}
+
+// Profile names are used in keyring keys and token file paths, so they must be checked before use.
+func ValidateProfileName(name string) error {
+	if !ProfileNamePattern.MatchString(name) {
+		return ErrInvalidProfile
+	}
+	return nil
+}
+
+// Resolves the active profile from --profile flag, SUPABASE_PROFILE env, or the saved profile config.
+func GetCurrentProfile(fsys afero.Fs) string {
+	if profile := viper.GetString("PROFILE"); len(profile) > 0 {
+		return profile
+	}
+	if config, err := LoadProfileConfig(fsys); err == nil && len(config.Current) > 0 {
+		return config.Current
+	}
+	return DefaultProfile
+}
+
+func LoadProfileConfig(fsys afero.Fs) (ProfileConfig, error) {
+	var config ProfileConfig
+	path, err := getProfileConfigPath()
+	if err != nil {
+		return config, err
+	}
+	data, err := afero.ReadFile(fsys, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	} else if err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return config, nil
+}
+
+func saveProfileConfig(config ProfileConfig, fsys afero.Fs) error {
+	path, err := getProfileConfigPath()
+	if err != nil {
+		return err
+	}
+	sort.Strings(config.Profiles)
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return err
+	}
+	return afero.WriteFile(fsys, path, data, 0600)
+}
+
+// Sets the active profile used by subsequent commands. The profile must have been logged in.
+func SwitchProfile(name string, fsys afero.Fs) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	config, err := LoadProfileConfig(fsys)
+	if err != nil {
+		return err
+	}
+	if name != DefaultProfile && !SliceContains(config.Profiles, name) {
+		CmdSuggestion = fmt.Sprintf("Run %s first.", Aqua("supabase login --profile "+name))
+		return errors.New("Profile not found: " + name)
+	}
+	config.Current = name
+	return saveProfileConfig(config, fsys)
+}
+
+func addProfile(name string, fsys afero.Fs) error {
+	config, err := LoadProfileConfig(fsys)
+	if err != nil {
+		return err
+	}
+	if SliceContains(config.Profiles, name) && len(config.Current) > 0 {
+		return nil
+	}
+	if !SliceContains(config.Profiles, name) {
+		config.Profiles = append(config.Profiles, name)
+	}
+	// The first profile to log in becomes active
+	if len(config.Current) == 0 {
+		config.Current = name
+	}
+	return saveProfileConfig(config, fsys)
+}
+
+func removeProfile(name string, fsys afero.Fs) error {
+	config, err := LoadProfileConfig(fsys)
+	if err != nil {
+		return err
+	}
+	if !SliceContains(config.Profiles, name) {
+		return nil
+	}
+	for i, p := range config.Profiles {
+		if p == name {
+			config.Profiles = append(config.Profiles[:i], config.Profiles[i+1:]...)
+			break
+		}
+	}
+	if config.Current == name {
+		config.Current = ""
+	}
+	return saveProfileConfig(config, fsys)
+}
+
+// The default profile uses the legacy key so that existing logins continue to work.
+func getAccessTokenKey(profile string) string {
+	if len(profile) == 0 || profile == DefaultProfile {
+		return AccessTokenKey
+	}
+	return AccessTokenKey + "." + profile
+}
+
+func getProfileConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".supabase", "profiles.json"), nil
+}
@@ -12,9 +12,29 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
 	"golang.org/x/term"
 )
 
+var ErrNonInteractive = errors.New("Cannot prompt for input in non-interactive mode")
+
+// IsNonInteractive returns true when running with --yes flag or SUPABASE_CI=true, in which case
+// prompts fail fast. Only the --yes flag answers yes to confirmation prompts.
+func IsNonInteractive() bool {
+	return viper.GetBool("YES") || viper.GetBool("CI")
+}
+
+// AssertInteractive fails with a descriptive error if the prompt cannot be answered by a user.
+func AssertInteractive(prompt, suggestion string) error {
+	if !IsNonInteractive() {
+		return nil
+	}
+	if len(suggestion) > 0 {
+		CmdSuggestion = suggestion
+	}
+	return fmt.Errorf("%w: %s", ErrNonInteractive, strings.TrimSpace(prompt))
+}
+
 var (
 	titleStyle        = lipgloss.NewStyle().MarginLeft(2)
 	itemStyle         = lipgloss.NewStyle().PaddingLeft(4)
@@ -107,6 +127,9 @@ func (m model) View() string {
 
 // Prompt user to choose from a list of items, returns the chosen index.
 func PromptChoice(ctx context.Context, title string, items []PromptItem) (PromptItem, error) {
+	if err := AssertInteractive(title, "Provide this value with a command line flag instead. Run with "+Aqua("--help")+" to see all flags."); err != nil {
+		return PromptItem{}, err
+	}
 	// Create list items
 	var listItems []list.Item
 	for _, v := range items {
@@ -146,8 +169,17 @@ func PromptChoice(ctx context.Context, title string, items []PromptItem) (Prompt
 	return initial.choice, err
 }
 
-// PromptYesNo asks yes/no questions using the label.
+// PromptYesNo asks yes/no questions using the label, always confirming with --yes flag. Other
+// non-interactive runs, such as in CI, decline the prompt instead of guessing an answer.
 func PromptYesNo(label string, def bool, stdin *os.File) bool {
+	if viper.GetBool("YES") {
+		fmt.Fprintln(os.Stderr, label, "[y/n] y")
+		return true
+	}
+	if err := AssertInteractive(label, "Pass "+Aqua("--yes")+" to confirm all prompts."); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
 	if !term.IsTerminal(int(stdin.Fd())) {
 		return def
 	}
@@ -175,3 +207,26 @@ func PromptYesNo(label string, def bool, stdin *os.File) bool {
 		}
 	}
 }
+
+// PromptConfirmToken guards irreversible actions by requiring the user to type back a token, such as
+// a project ref. Unlike yes/no prompts, the --yes flag does not skip confirmation; non-interactive
+// callers must pass the token with the --confirm flag instead.
+func PromptConfirmToken(label, token, confirm string, stdin *os.File) error {
+	if len(confirm) > 0 {
+		if confirm != token {
+			return fmt.Errorf("Confirmation %s does not match %s.", Aqua(confirm), Aqua(token))
+		}
+		return nil
+	}
+	suggestion := "Pass " + Aqua("--confirm "+token) + " to confirm this action."
+	if IsNonInteractive() || !term.IsTerminal(int(stdin.Fd())) {
+		CmdSuggestion = suggestion
+		return fmt.Errorf("%w: %s", ErrNonInteractive, label)
+	}
+	fmt.Fprintf(os.Stderr, "%s\nType %s to confirm: ", label, Aqua(token))
+	s, _ := bufio.NewReader(stdin).ReadString('\n')
+	if strings.TrimSpace(s) != token {
+		return errors.New("Confirmation does not match " + Aqua(token) + ".")
+	}
+	return nil
+}
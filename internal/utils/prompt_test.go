@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonInteractivePrompt(t *testing.T) {
+	t.Run("confirms yes no prompt with --yes", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Run test
+		assert.True(t, PromptYesNo("Confirm resetting the remote database?", false, os.Stdin))
+	})
+
+	t.Run("declines yes no prompt in CI", func(t *testing.T) {
+		viper.Set("CI", true)
+		defer viper.Set("CI", false)
+		// Run test
+		assert.False(t, PromptYesNo("Confirm resetting the remote database?", true, os.Stdin))
+		assert.Contains(t, CmdSuggestion, "--yes")
+	})
+
+	t.Run("fails fast on choice prompt in CI", func(t *testing.T) {
+		viper.Set("CI", true)
+		defer viper.Set("CI", false)
+		// Run test
+		_, err := PromptChoice(context.Background(), "Which project do you want to link?", []PromptItem{{Summary: "test"}})
+		// Check error
+		assert.ErrorIs(t, err, ErrNonInteractive)
+		assert.ErrorContains(t, err, "Which project do you want to link?")
+	})
+
+	t.Run("allows prompts by default", func(t *testing.T) {
+		assert.NoError(t, AssertInteractive("Enter your database password", ""))
+	})
+}
+
+func TestConfirmTokenPrompt(t *testing.T) {
+	t.Run("accepts matching confirm flag", func(t *testing.T) {
+		assert.NoError(t, PromptConfirmToken("Delete project?", "test-ref", "test-ref", os.Stdin))
+	})
+
+	t.Run("throws error on mismatched confirm flag", func(t *testing.T) {
+		err := PromptConfirmToken("Delete project?", "test-ref", "other-ref", os.Stdin)
+		assert.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("does not skip confirmation with --yes", func(t *testing.T) {
+		viper.Set("YES", true)
+		defer viper.Set("YES", false)
+		// Run test
+		err := PromptConfirmToken("Delete project?", "test-ref", "", os.Stdin)
+		// Check error
+		assert.ErrorIs(t, err, ErrNonInteractive)
+		assert.Contains(t, CmdSuggestion, "--confirm test-ref")
+	})
+}
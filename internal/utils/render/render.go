@@ -0,0 +1,46 @@
+// Package render writes command results in the output format selected by the global --output flag.
+package render
+
+import (
+	"io"
+	"os"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Format is bound to the global --output flag.
+var Format = utils.EnumFlag{
+	Allowed: utils.OutputDefaultAllowed,
+	Value:   utils.OutputPretty,
+}
+
+func IsPretty() bool {
+	return Format.Value == utils.OutputPretty
+}
+
+// Encode writes value to stdout in the selected machine readable format.
+func Encode(value any) error {
+	return utils.EncodeOutput(Format.Value, os.Stdout, value)
+}
+
+// ErrorEnvelope is written in place of command results when a command fails.
+type ErrorEnvelope struct {
+	Error ErrorDetail `json:"error" yaml:"error" toml:"error"`
+}
+
+type ErrorDetail struct {
+	Message    string `json:"message" yaml:"message" toml:"message"`
+	Suggestion string `json:"suggestion,omitempty" yaml:"suggestion,omitempty" toml:"suggestion,omitempty"`
+}
+
+// Error encodes err in the selected format, returning false if output is human readable.
+func Error(w io.Writer, err error, suggestion string) bool {
+	if IsPretty() {
+		return false
+	}
+	envelope := ErrorEnvelope{Error: ErrorDetail{
+		Message:    err.Error(),
+		Suggestion: suggestion,
+	}}
+	return utils.EncodeOutput(Format.Value, w, envelope) == nil
+}
@@ -0,0 +1,40 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestRenderError(t *testing.T) {
+	t.Run("encodes error envelope as json", func(t *testing.T) {
+		Format.Value = utils.OutputJson
+		defer func() { Format.Value = utils.OutputPretty }()
+		var out bytes.Buffer
+		// Run test
+		assert.True(t, Error(&out, errors.New("not found"), "Try again."))
+		// Check output
+		assert.JSONEq(t, `{"error":{"message":"not found","suggestion":"Try again."}}`, out.String())
+	})
+
+	t.Run("encodes error envelope as yaml", func(t *testing.T) {
+		Format.Value = utils.OutputYaml
+		defer func() { Format.Value = utils.OutputPretty }()
+		var out bytes.Buffer
+		// Run test
+		assert.True(t, Error(&out, errors.New("not found"), ""))
+		// Check output
+		assert.Equal(t, "error:\n  message: not found\n", out.String())
+	})
+
+	t.Run("skips pretty output", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.False(t, Error(&out, errors.New("not found"), ""))
+		// Check output
+		assert.Empty(t, out.String())
+	})
+}
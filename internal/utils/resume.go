@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+var ResumeDir = filepath.Join(SupabaseDirPath, TempDir, "resume")
+
+// ResumeState records completed steps of a multi-step operation in a local state file, so that
+// re-running the operation after a failure skips steps that already succeeded.
+type ResumeState struct {
+	// Maps each completed step to a digest of its input, ie. the bundled function
+	Completed map[string]string `json:"completed"`
+
+	mu   sync.Mutex
+	path string
+	fsys afero.Fs
+}
+
+func LoadResumeState(operation string, fsys afero.Fs) (*ResumeState, error) {
+	state := ResumeState{
+		Completed: map[string]string{},
+		path:      filepath.Join(ResumeDir, operation+".json"),
+		fsys:      fsys,
+	}
+	data, err := afero.ReadFile(fsys, state.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &state, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Done checks whether a step has completed with the same input digest.
+func (s *ResumeState) Done(step, digest string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.Completed[step]
+	return ok && prev == digest
+}
+
+// MarkDone persists a completed step immediately so progress survives interruptions.
+func (s *ResumeState) MarkDone(step, digest string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Completed[step] = digest
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return WriteFile(s.path, data, s.fsys)
+}
+
+// Clear removes the state file once the whole operation has succeeded.
+func (s *ResumeState) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Completed = map[string]string{}
+	if err := s.fsys.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/utils/log"
+)
+
+// Initial delay between retries, doubled after every attempt.
+var retryBackoff = 500 * time.Millisecond
+
+const maxBackoff = 10 * time.Second
+
+// retryTransport retries management API requests that fail due to network errors or transient
+// server errors. Since the API does not deduplicate requests by idempotency key, non-idempotent
+// requests are only retried when they could not have reached the server.
+type retryTransport struct {
+	base http.RoundTripper
+}
+
+func (t retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Max retries is configurable via --api-retries flag or SUPABASE_API_RETRIES env var
+	retries := viper.GetInt("API-RETRIES")
+	if req.Method != http.MethodGet && req.Method != http.MethodHead && len(req.Header.Get("Idempotency-Key")) == 0 {
+		req = req.Clone(req.Context())
+		req.Header.Set("Idempotency-Key", uuid.NewString())
+	}
+	backoff := retryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= retries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+		// Request body must be replayable to retry
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		delay := backoff
+		if resp != nil {
+			if after, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && after > 0 {
+				delay = time.Duration(after) * time.Second
+			}
+			resp.Body.Close()
+		}
+		log.Warn("Retrying request", "url", req.URL.Redacted(), "attempt", attempt+1, "delay", delay)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		return isIdempotent(req.Method) || notSent(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// Rate limited requests are rejected before being processed
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// Connection failures happen before any part of the request is written.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
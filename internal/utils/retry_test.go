@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestRetryTransport(t *testing.T) {
+	retryBackoff = time.Millisecond
+	viper.Set("API-RETRIES", 2)
+	t.Cleanup(func() {
+		retryBackoff = 500 * time.Millisecond
+		viper.Set("API-RETRIES", nil)
+	})
+	client := http.Client{Transport: retryTransport{base: traceTransport{}}}
+
+	t.Run("retries rate limited post with the same idempotency key", func(t *testing.T) {
+		var keys []string
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Post("/v1/projects").
+			AddMatcher(recordKey(&keys)).
+			Reply(http.StatusTooManyRequests)
+		gock.New(DefaultApiHost).
+			Post("/v1/projects").
+			BodyString("{}").
+			AddMatcher(recordKey(&keys)).
+			Reply(http.StatusCreated)
+		// Run test
+		req, err := http.NewRequest(http.MethodPost, DefaultApiHost+"/v1/projects", bytes.NewBufferString("{}"))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Len(t, keys, 2)
+		assert.NotEmpty(t, keys[0])
+		assert.Equal(t, keys[0], keys[1])
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("retries post on dial error", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Post("/v1/projects").
+			ReplyError(&net.OpError{Op: "dial", Err: errors.New("connection refused")})
+		gock.New(DefaultApiHost).
+			Post("/v1/projects").
+			Reply(http.StatusCreated)
+		// Run test
+		resp, err := client.Post(DefaultApiHost+"/v1/projects", "application/json", bytes.NewBufferString("{}"))
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("does not retry post after it is sent", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Post("/v1/projects").
+			Reply(http.StatusServiceUnavailable)
+		gock.New(DefaultApiHost).
+			Post("/v1/projects").
+			ReplyError(errors.New("connection reset by peer"))
+		// Run test
+		resp, err := client.Post(DefaultApiHost+"/v1/projects", "application/json", bytes.NewBufferString("{}"))
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		_, err = client.Post(DefaultApiHost+"/v1/projects", "application/json", bytes.NewBufferString("{}"))
+		assert.ErrorContains(t, err, "connection reset by peer")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Get("/v1/projects").
+			Times(3).
+			Reply(http.StatusBadGateway)
+		// Run test
+		resp, err := client.Get(DefaultApiHost + "/v1/projects")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusBadRequest)
+		// Run test
+		resp, err := client.Get(DefaultApiHost + "/v1/projects")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func recordKey(keys *[]string) gock.MatchFunc {
+	return func(req *http.Request, _ *gock.Request) (bool, error) {
+		*keys = append(*keys, req.Header.Get("Idempotency-Key"))
+		return true, nil
+	}
+}
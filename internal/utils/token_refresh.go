@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils/credentials"
+)
+
+// Access tokens are refreshed this long before they expire to account for clock skew.
+const tokenExpiryLeeway = 30 * time.Second
+
+var ErrNoRefreshToken = errors.New("No refresh token found. Please run " + Aqua("supabase login") + " again.")
+
+// TokenSession is the refresh material stored alongside a short-lived access token.
+type TokenSession struct {
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+}
+
+func (s TokenSession) Expired() bool {
+	return !s.ExpiresAt.IsZero() && time.Now().Add(tokenExpiryLeeway).After(s.ExpiresAt)
+}
+
+// Saves an access token with its refresh material through the same credentials fallback chain.
+func SaveTokenSession(accessToken string, session TokenSession, fsys afero.Fs) error {
+	if err := SaveAccessToken(accessToken, fsys); err != nil {
+		return err
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	key := getRefreshTokenKey(GetCurrentProfile(fsys))
+	if err := credentials.Set(key, string(data)); err == nil {
+		return nil
+	} else if !credentials.IsKeyring() {
+		return err
+	}
+	return fallbackSaveToken(key, string(data), fsys)
+}
+
+func LoadTokenSession(fsys afero.Fs) (TokenSession, error) {
+	var session TokenSession
+	key := getRefreshTokenKey(GetCurrentProfile(fsys))
+	data, err := credentials.Get(key)
+	if err != nil {
+		if data, err = fallbackLoadToken(key, fsys); errors.Is(err, ErrMissingToken) {
+			return session, ErrNoRefreshToken
+		} else if err != nil {
+			return session, err
+		}
+	}
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return session, fmt.Errorf("failed to parse refresh token: %w", err)
+	}
+	if len(session.RefreshToken) == 0 {
+		return session, ErrNoRefreshToken
+	}
+	return session, nil
+}
+
+func deleteTokenSession(profile string, fsys afero.Fs) {
+	key := getRefreshTokenKey(profile)
+	_ = credentials.Delete(key)
+	_ = fallbackDeleteToken(key, fsys)
+}
+
+func getRefreshTokenKey(profile string) string {
+	return getAccessTokenKey(profile) + ".refresh"
+}
+
+// Ref: https://datatracker.ietf.org/doc/html/rfc6749#section-6
+type refreshTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+// Exchanges the stored refresh token for a new access token, persisting both on success.
+func RefreshAccessToken(ctx context.Context, fsys afero.Fs) (string, error) {
+	session, err := LoadTokenSession(fsys)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {session.RefreshToken},
+	}
+	endpoint := GetSupabaseAPIHost() + "/platform/cli/login/device/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh access token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read refresh token response: %w", err)
+	}
+	var token refreshTokenResponse
+	if err := json.Unmarshal(body, &token); err != nil || resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to refresh access token: %s", body)
+	}
+	// Refresh tokens may be rotated on every use
+	if len(token.RefreshToken) > 0 {
+		session.RefreshToken = token.RefreshToken
+	}
+	session.ExpiresAt = time.Time{}
+	if token.ExpiresIn > 0 {
+		session.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if err := SaveTokenSession(token.AccessToken, session, fsys); err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// refreshTransport authenticates management API requests, transparently refreshing expired
+// access tokens and retrying once on 401 responses.
+type refreshTransport struct {
+	mu      sync.Mutex
+	token   string
+	session *TokenSession
+	fsys    afero.Fs
+	base    http.RoundTripper
+}
+
+func newRefreshTransport(token string, fsys afero.Fs) *refreshTransport {
+	t := refreshTransport{token: token, fsys: fsys, base: retryTransport{base: traceTransport{}}}
+	// Tokens supplied via env var are never refreshed
+	if len(os.Getenv("SUPABASE_ACCESS_TOKEN")) == 0 {
+		if session, err := LoadTokenSession(fsys); err == nil {
+			t.session = &session
+		}
+	}
+	return &t
+}
+
+func (t *refreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := t.getToken(req.Context())
+	resp, err := t.base.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || t.session == nil {
+		return resp, err
+	}
+	// Request body must be replayable to retry
+	if req.Body != nil && req.GetBody == nil {
+		return resp, err
+	}
+	refreshed, refreshErr := t.refresh(req.Context(), token)
+	if refreshErr != nil {
+		fmt.Fprintln(os.Stderr, refreshErr)
+		return resp, err
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+	return t.base.RoundTrip(withBearerToken(retry, refreshed))
+}
+
+func (t *refreshTransport) getToken(ctx context.Context) string {
+	t.mu.Lock()
+	expired := t.session != nil && t.session.Expired()
+	token := t.token
+	t.mu.Unlock()
+	if expired {
+		if refreshed, err := t.refresh(ctx, token); err == nil {
+			return refreshed
+		}
+	}
+	return token
+}
+
+func (t *refreshTransport) refresh(ctx context.Context, stale string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// Another request may have refreshed the token already
+	if t.token != stale {
+		return t.token, nil
+	}
+	token, err := RefreshAccessToken(ctx, t.fsys)
+	if err != nil {
+		return "", err
+	}
+	t.token = token
+	if session, err := LoadTokenSession(t.fsys); err == nil {
+		t.session = &session
+	}
+	return token, nil
+}
+
+func withBearerToken(req *http.Request, token string) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", "Bearer "+token)
+	return clone
+}
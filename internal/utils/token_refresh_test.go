@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/zalando/go-keyring"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestRefreshAccessToken(t *testing.T) {
+	keyring.MockInit()
+	oldToken := string(apitest.RandomAccessToken(t))
+	newToken := string(apitest.RandomAccessToken(t))
+
+	t.Run("refreshes and persists token", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SaveTokenSession(oldToken, TokenSession{RefreshToken: "old-refresh"}, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Post("/platform/cli/login/device/token").
+			BodyString("grant_type=refresh_token&refresh_token=old-refresh").
+			Reply(http.StatusOK).
+			JSON(refreshTokenResponse{AccessToken: newToken, RefreshToken: "new-refresh", ExpiresIn: 3600})
+		// Run test
+		token, err := RefreshAccessToken(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, newToken, token)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Check persisted credentials
+		saved, err := LoadAccessTokenFS(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, newToken, saved)
+		session, err := LoadTokenSession(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, "new-refresh", session.RefreshToken)
+		assert.False(t, session.Expired())
+	})
+
+	t.Run("throws error on missing refresh token", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SaveAccessToken(oldToken, fsys))
+		// Run test
+		_, err := RefreshAccessToken(context.Background(), fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrNoRefreshToken)
+	})
+
+	t.Run("throws error on revoked refresh token", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SaveTokenSession(oldToken, TokenSession{RefreshToken: "old-refresh"}, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Post("/platform/cli/login/device/token").
+			Reply(http.StatusBadRequest).
+			JSON(refreshTokenResponse{Error: "invalid_grant"})
+		// Run test
+		_, err := RefreshAccessToken(context.Background(), fsys)
+		// Check error
+		assert.ErrorContains(t, err, "invalid_grant")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestRefreshTransport(t *testing.T) {
+	keyring.MockInit()
+	oldToken := string(apitest.RandomAccessToken(t))
+	newToken := string(apitest.RandomAccessToken(t))
+
+	t.Run("retries request after refreshing on 401", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SaveTokenSession(oldToken, TokenSession{RefreshToken: "refresh"}, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Get("/v1/projects").
+			MatchHeader("Authorization", "Bearer "+oldToken).
+			Reply(http.StatusUnauthorized)
+		gock.New(DefaultApiHost).
+			Post("/platform/cli/login/device/token").
+			Reply(http.StatusOK).
+			JSON(refreshTokenResponse{AccessToken: newToken})
+		gock.New(DefaultApiHost).
+			Get("/v1/projects").
+			MatchHeader("Authorization", "Bearer "+newToken).
+			Reply(http.StatusOK)
+		// Run test
+		client := http.Client{Transport: newRefreshTransport(oldToken, fsys)}
+		resp, err := client.Get(DefaultApiHost + "/v1/projects")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("refreshes expired token before request", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		session := TokenSession{RefreshToken: "refresh", ExpiresAt: time.Now().Add(-time.Minute)}
+		require.NoError(t, SaveTokenSession(oldToken, session, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Post("/platform/cli/login/device/token").
+			Reply(http.StatusOK).
+			JSON(refreshTokenResponse{AccessToken: newToken, ExpiresIn: 3600})
+		gock.New(DefaultApiHost).
+			Get("/v1/projects").
+			MatchHeader("Authorization", "Bearer "+newToken).
+			Reply(http.StatusOK)
+		// Run test
+		client := http.Client{Transport: newRefreshTransport(oldToken, fsys)}
+		resp, err := client.Get(DefaultApiHost + "/v1/projects")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("passes through 401 without refresh token", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SaveAccessToken(oldToken, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusUnauthorized)
+		// Run test
+		client := http.Client{Transport: newRefreshTransport(oldToken, fsys)}
+		resp, err := client.Get(DefaultApiHost + "/v1/projects")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"strings"
+
+	"github.com/supabase/cli/internal/utils/log"
+)
+
+var (
+	// Matches access tokens anywhere in a trace, not only as the whole string
+	tokenInTrace = regexp.MustCompile(strings.Trim(AccessTokenPattern.String(), "^$"))
+	// Credential headers are always redacted, regardless of token format
+	secretHeader = regexp.MustCompile(`(?mi)^((?:Authorization|Apikey|Cookie|Set-Cookie): )[^\r\n]*`)
+	// JSON string fields holding credentials, ie. access tokens, api keys, database passwords and secret values
+	secretJsonField = regexp.MustCompile(`(?i)("(?:[a-z_]*(?:token|secret|password|pass|key)|value)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// Form encoded credentials, ie. when refreshing tokens
+	secretFormField = regexp.MustCompile(`(?i)((?:^|[?&\n])[a-z_]*(?:token|secret|password)=)[^&\s]*`)
+	// Service role and anon keys are JWTs
+	jwtInTrace = regexp.MustCompile(`eyJ[\w-]+\.eyJ[\w-]+\.[\w-]*`)
+	// Passwords embedded in database connection strings
+	dbUrlPassword = regexp.MustCompile(`(postgres(?:ql)?://[^:/@\s]+:)\S*@`)
+)
+
+const redacted = "[REDACTED]"
+
+// traceTransport dumps sanitized HTTP requests and responses at debug log level.
+type traceTransport struct {
+	base http.RoundTripper
+}
+
+func (t traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if !log.Enabled(log.LevelDebug) {
+		return base.RoundTrip(req)
+	}
+	if dump, err := httputil.DumpRequestOut(req, req.GetBody != nil); err == nil {
+		log.Debug("HTTP request", "dump", RedactTrace(string(dump)))
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		log.Debug("HTTP error", "url", req.URL.Redacted(), "error", err)
+		return resp, err
+	}
+	if dump, err := httputil.DumpResponse(resp, true); err == nil {
+		log.Debug("HTTP response", "dump", RedactTrace(string(dump)))
+	}
+	return resp, nil
+}
+
+// RedactTrace removes credentials from a HTTP dump before it is logged.
+func RedactTrace(dump string) string {
+	dump = secretHeader.ReplaceAllString(dump, "${1}"+redacted)
+	dump = secretJsonField.ReplaceAllString(dump, `${1}"`+redacted+`"`)
+	dump = secretFormField.ReplaceAllString(dump, "${1}"+redacted)
+	dump = jwtInTrace.ReplaceAllString(dump, redacted)
+	dump = dbUrlPassword.ReplaceAllString(dump, "${1}"+redacted+"@")
+	return tokenInTrace.ReplaceAllString(dump, redacted)
+}
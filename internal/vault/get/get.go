@@ -0,0 +1,34 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const SELECT_SECRET = "SELECT decrypted_secret FROM vault.decrypted_secrets WHERE name = $1"
+
+func Run(ctx context.Context, name string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	var value *string
+	if err := conn.QueryRow(ctx, SELECT_SECRET, name).Scan(&value); errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("Vault secret not found: " + utils.Aqua(name))
+	} else if err != nil {
+		return fmt.Errorf("failed to get vault secret: %w", err)
+	}
+	// Decryption returns null when the secret was encrypted with a different root key
+	if value == nil {
+		return errors.New("Failed to decrypt vault secret: " + utils.Aqua(name))
+	}
+	fmt.Println(*value)
+	return nil
+}
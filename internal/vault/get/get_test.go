@@ -0,0 +1,45 @@
+package get
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestGetSecret(t *testing.T) {
+	t.Run("prints decrypted secret", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_SECRET, "stripe_key").
+			Reply("SELECT 1", []interface{}{"sk_test"})
+		// Run test
+		err := Run(context.Background(), "stripe_key", dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing secret", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_SECRET, "stripe_key").
+			Reply("SELECT 0")
+		// Run test
+		err := Run(context.Background(), "stripe_key", dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Vault secret not found:")
+	})
+}
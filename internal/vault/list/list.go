@@ -0,0 +1,55 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/pgxv5"
+	"github.com/supabase/cli/internal/utils/render"
+)
+
+const LIST_SECRETS = "SELECT id::text, name, coalesce(description, '') AS description, updated_at FROM vault.secrets ORDER BY name"
+
+// Secret values are never listed, use get to print a single value.
+type Secret struct {
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	rows, err := conn.Query(ctx, LIST_SECRETS)
+	if err != nil {
+		return fmt.Errorf("failed to list vault secrets: %w", err)
+	}
+	secrets, err := pgxv5.CollectRows[Secret](rows)
+	if err != nil {
+		return err
+	}
+	if !render.IsPretty() {
+		return render.Encode(secrets)
+	}
+	if len(secrets) == 0 {
+		fmt.Fprintln(os.Stderr, "No vault secrets found.")
+		return nil
+	}
+	table := "|NAME|DESCRIPTION|UPDATED AT (UTC)|\n|-|-|-|\n"
+	for _, s := range secrets {
+		table += fmt.Sprintf("|`%s`|%s|`%s`|\n", s.Name, strings.ReplaceAll(s.Description, "|", "\\|"), s.UpdatedAt.UTC().Format("2006-01-02 15:04:05"))
+	}
+	return list.RenderTable(table)
+}
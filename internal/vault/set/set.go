@@ -0,0 +1,94 @@
+package set
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/new"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	SELECT_SECRET_ID = "SELECT id::text FROM vault.secrets WHERE name = $1"
+	CREATE_SECRET    = "SELECT vault.create_secret($1, $2, coalesce($3, ''))"
+	// Null arguments leave the existing values unchanged
+	UPDATE_SECRET = "SELECT vault.update_secret($1, $2, NULL, $3)"
+)
+
+var (
+	namePattern    = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	ErrInvalidName = errors.New("Invalid secret name. Must contain only alphanumeric, hyphen, or underscore.")
+	ErrEmptyValue  = errors.New("Secret value must not be empty.")
+)
+
+// Creates or updates a vault secret. When migration is true, a migration referencing the
+// secret by name is also created so that the value itself is never committed.
+func Run(ctx context.Context, name, value, description string, migration bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if !namePattern.MatchString(name) {
+		return ErrInvalidName
+	}
+	if len(value) == 0 {
+		return ErrEmptyValue
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := upsertSecret(ctx, name, value, description, conn); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Finished setting vault secret:", utils.Aqua(name))
+	if migration {
+		path, err := writeMigration(name, description, fsys)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Created new migration at " + utils.Bold(path))
+	}
+	return nil
+}
+
+func upsertSecret(ctx context.Context, name, value, description string, conn *pgx.Conn) error {
+	var desc *string
+	if len(description) > 0 {
+		desc = &description
+	}
+	var id string
+	err := conn.QueryRow(ctx, SELECT_SECRET_ID, name).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		_, err = conn.Exec(ctx, CREATE_SECRET, value, name, desc)
+	} else if err == nil {
+		_, err = conn.Exec(ctx, UPDATE_SECRET, id, value, desc)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set vault secret: %w", err)
+	}
+	return nil
+}
+
+func writeMigration(name, description string, fsys afero.Fs) (string, error) {
+	path := new.GetMigrationPath(utils.GetCurrentTimestamp(), "vault_"+name)
+	if err := utils.MkdirIfNotExistFS(fsys, utils.MigrationsDir); err != nil {
+		return "", err
+	}
+	return path, utils.WriteFile(path, []byte(ReferenceSql(name, description)), fsys)
+}
+
+// Declares a secret by name with an empty value that is provisioned separately in each environment.
+func ReferenceSql(name, description string) string {
+	quote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return fmt.Sprintf(`-- The secret value is not committed. Set it in each environment with: supabase vault set %[1]s
+SELECT vault.create_secret('', %[2]s, %[3]s)
+WHERE NOT EXISTS (SELECT 1 FROM vault.secrets WHERE name = %[2]s);
+`, name, quote(name), quote(description))
+}
@@ -0,0 +1,72 @@
+package set
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestSetSecret(t *testing.T) {
+	t.Run("creates new secret with migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SELECT_SECRET_ID, "stripe_key").
+			Reply("SELECT 0").
+			Query(CREATE_SECRET, "sk_test", "stripe_key", "Stripe API key").
+			Reply("SELECT 1", []interface{}{"8c4b9d4e-0f3a-4f36-9c36-7a2f5c1d2e3f"})
+		// Run test
+		err := Run(context.Background(), "stripe_key", "sk_test", "Stripe API key", true, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		migrations, err := afero.Glob(fsys, filepath.Join(utils.MigrationsDir, "*_vault_stripe_key.sql"))
+		require.NoError(t, err)
+		require.Len(t, migrations, 1)
+		contents, err := afero.ReadFile(fsys, migrations[0])
+		require.NoError(t, err)
+		assert.Equal(t, ReferenceSql("stripe_key", "Stripe API key"), string(contents))
+		assert.NotContains(t, string(contents), "sk_test")
+	})
+
+	t.Run("updates existing secret", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		id := "8c4b9d4e-0f3a-4f36-9c36-7a2f5c1d2e3f"
+		conn.Query(SELECT_SECRET_ID, "stripe_key").
+			Reply("SELECT 1", []interface{}{id}).
+			Query(UPDATE_SECRET, id, "sk_live", "Stripe API key").
+			Reply("SELECT 1", []interface{}{""})
+		// Run test
+		err := Run(context.Background(), "stripe_key", "sk_live", "Stripe API key", false, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		err := Run(context.Background(), "stripe key", "sk_test", "", false, dbConfig, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, ErrInvalidName)
+	})
+
+	t.Run("throws error on empty value", func(t *testing.T) {
+		err := Run(context.Background(), "stripe_key", "", "", false, dbConfig, afero.NewMemMapFs())
+		assert.ErrorIs(t, err, ErrEmptyValue)
+	})
+}
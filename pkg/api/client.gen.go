@@ -119,6 +119,14 @@ type ClientInterface interface {
 	// V1ListOrganizationMembers request
 	V1ListOrganizationMembers(ctx context.Context, slug string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// V1InviteOrganizationMemberWithBody request with any body
+	V1InviteOrganizationMemberWithBody(ctx context.Context, slug string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V1InviteOrganizationMember(ctx context.Context, slug string, body V1InviteOrganizationMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V1RemoveOrganizationMember request
+	V1RemoveOrganizationMember(ctx context.Context, slug string, userId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetProjects request
 	GetProjects(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -130,6 +138,9 @@ type ClientInterface interface {
 	// DeleteProject request
 	DeleteProject(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetLogs request
+	GetLogs(ctx context.Context, ref string, params *GetLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetProjectApiKeys request
 	GetProjectApiKeys(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -182,6 +193,14 @@ type ClientInterface interface {
 
 	UpdateConfig(ctx context.Context, ref string, body UpdateConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetStorageConfig request
+	GetStorageConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateStorageConfigWithBody request with any body
+	UpdateStorageConfigWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateStorageConfig(ctx context.Context, ref string, body UpdateStorageConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// RemoveCustomHostnameConfig request
 	RemoveCustomHostnameConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -199,11 +218,22 @@ type ClientInterface interface {
 	// Reverify request
 	Reverify(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// V1ListAllBackups request
+	V1ListAllBackups(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// V1RestorePitrWithBody request with any body
 	V1RestorePitrWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	V1RestorePitr(ctx context.Context, ref string, body V1RestorePitrJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// V1DownloadBackup request
+	V1DownloadBackup(ctx context.Context, ref string, backupId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V1UpdateDatabasePasswordWithBody request with any body
+	V1UpdateDatabasePasswordWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V1UpdateDatabasePassword(ctx context.Context, ref string, body V1UpdateDatabasePasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// V1RunQueryWithBody request with any body
 	V1RunQueryWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -253,6 +283,9 @@ type ClientInterface interface {
 
 	ApplyNetworkRestrictions(ctx context.Context, ref string, body ApplyNetworkRestrictionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// V1PauseProject request
+	V1PauseProject(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetPgsodiumConfig request
 	GetPgsodiumConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -275,6 +308,9 @@ type ClientInterface interface {
 	// TemporarilyDisableReadonlyMode request
 	TemporarilyDisableReadonlyMode(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// V1RestoreProject request
+	V1RestoreProject(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteSecretsWithBody request with any body
 	DeleteSecretsWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -296,6 +332,11 @@ type ClientInterface interface {
 
 	UpdateSslEnforcementConfig(ctx context.Context, ref string, body UpdateSslEnforcementConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// V1TransferProjectWithBody request with any body
+	V1TransferProjectWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V1TransferProject(ctx context.Context, ref string, body V1TransferProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetTypescriptTypes request
 	GetTypescriptTypes(ctx context.Context, ref string, params *GetTypescriptTypesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -465,6 +506,42 @@ func (c *Client) V1ListOrganizationMembers(ctx context.Context, slug string, req
 	return c.Client.Do(req)
 }
 
+func (c *Client) V1InviteOrganizationMemberWithBody(ctx context.Context, slug string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1InviteOrganizationMemberRequestWithBody(c.Server, slug, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V1InviteOrganizationMember(ctx context.Context, slug string, body V1InviteOrganizationMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1InviteOrganizationMemberRequest(c.Server, slug, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V1RemoveOrganizationMember(ctx context.Context, slug string, userId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1RemoveOrganizationMemberRequest(c.Server, slug, userId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetProjects(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetProjectsRequest(c.Server)
 	if err != nil {
@@ -513,6 +590,18 @@ func (c *Client) DeleteProject(ctx context.Context, ref string, reqEditors ...Re
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetLogs(ctx context.Context, ref string, params *GetLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLogsRequest(c.Server, ref, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetProjectApiKeys(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetProjectApiKeysRequest(c.Server, ref)
 	if err != nil {
@@ -741,6 +830,42 @@ func (c *Client) UpdateConfig(ctx context.Context, ref string, body UpdateConfig
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetStorageConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStorageConfigRequest(c.Server, ref)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateStorageConfigWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateStorageConfigRequestWithBody(c.Server, ref, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateStorageConfig(ctx context.Context, ref string, body UpdateStorageConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateStorageConfigRequest(c.Server, ref, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) RemoveCustomHostnameConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewRemoveCustomHostnameConfigRequest(c.Server, ref)
 	if err != nil {
@@ -813,6 +938,18 @@ func (c *Client) Reverify(ctx context.Context, ref string, reqEditors ...Request
 	return c.Client.Do(req)
 }
 
+func (c *Client) V1ListAllBackups(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1ListAllBackupsRequest(c.Server, ref)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) V1RestorePitrWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewV1RestorePitrRequestWithBody(c.Server, ref, contentType, body)
 	if err != nil {
@@ -837,6 +974,42 @@ func (c *Client) V1RestorePitr(ctx context.Context, ref string, body V1RestorePi
 	return c.Client.Do(req)
 }
 
+func (c *Client) V1DownloadBackup(ctx context.Context, ref string, backupId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1DownloadBackupRequest(c.Server, ref, backupId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V1UpdateDatabasePasswordWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1UpdateDatabasePasswordRequestWithBody(c.Server, ref, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V1UpdateDatabasePassword(ctx context.Context, ref string, body V1UpdateDatabasePasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1UpdateDatabasePasswordRequest(c.Server, ref, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) V1RunQueryWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewV1RunQueryRequestWithBody(c.Server, ref, contentType, body)
 	if err != nil {
@@ -1053,6 +1226,18 @@ func (c *Client) ApplyNetworkRestrictions(ctx context.Context, ref string, body
 	return c.Client.Do(req)
 }
 
+func (c *Client) V1PauseProject(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1PauseProjectRequest(c.Server, ref)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetPgsodiumConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetPgsodiumConfigRequest(c.Server, ref)
 	if err != nil {
@@ -1149,6 +1334,18 @@ func (c *Client) TemporarilyDisableReadonlyMode(ctx context.Context, ref string,
 	return c.Client.Do(req)
 }
 
+func (c *Client) V1RestoreProject(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1RestoreProjectRequest(c.Server, ref)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) DeleteSecretsWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewDeleteSecretsRequestWithBody(c.Server, ref, contentType, body)
 	if err != nil {
@@ -1245,6 +1442,30 @@ func (c *Client) UpdateSslEnforcementConfig(ctx context.Context, ref string, bod
 	return c.Client.Do(req)
 }
 
+func (c *Client) V1TransferProjectWithBody(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1TransferProjectRequestWithBody(c.Server, ref, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V1TransferProject(ctx context.Context, ref string, body V1TransferProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV1TransferProjectRequest(c.Server, ref, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetTypescriptTypes(ctx context.Context, ref string, params *GetTypescriptTypesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetTypescriptTypesRequest(c.Server, ref, params)
 	if err != nil {
@@ -1806,6 +2027,94 @@ func NewV1ListOrganizationMembersRequest(server string, slug string) (*http.Requ
 	return req, nil
 }
 
+// NewV1InviteOrganizationMemberRequest calls the generic V1InviteOrganizationMember builder with application/json body
+func NewV1InviteOrganizationMemberRequest(server string, slug string, body V1InviteOrganizationMemberJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV1InviteOrganizationMemberRequestWithBody(server, slug, "application/json", bodyReader)
+}
+
+// NewV1InviteOrganizationMemberRequestWithBody generates requests for V1InviteOrganizationMember with any type of body
+func NewV1InviteOrganizationMemberRequestWithBody(server string, slug string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "slug", runtime.ParamLocationPath, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/organizations/%s/members", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewV1RemoveOrganizationMemberRequest generates requests for V1RemoveOrganizationMember
+func NewV1RemoveOrganizationMemberRequest(server string, slug string, userId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "slug", runtime.ParamLocationPath, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/organizations/%s/members/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetProjectsRequest generates requests for GetProjects
 func NewGetProjectsRequest(server string) (*http.Request, error) {
 	var err error
@@ -1907,8 +2216,8 @@ func NewDeleteProjectRequest(server string, ref string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetProjectApiKeysRequest generates requests for GetProjectApiKeys
-func NewGetProjectApiKeysRequest(server string, ref string) (*http.Request, error) {
+// NewGetLogsRequest generates requests for GetLogs
+func NewGetLogsRequest(server string, ref string, params *GetLogsParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -1923,7 +2232,7 @@ func NewGetProjectApiKeysRequest(server string, ref string) (*http.Request, erro
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/v1/projects/%s/api-keys", pathParam0)
+	operationPath := fmt.Sprintf("/v1/projects/%s/analytics/endpoints/logs.all", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1933,15 +2242,103 @@ func NewGetProjectApiKeysRequest(server string, ref string) (*http.Request, erro
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	return req, nil
-}
+		if params.Sql != nil {
 
-// NewDisableBranchRequest generates requests for DisableBranch
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sql", runtime.ParamLocationQuery, *params.Sql); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IsoTimestampStart != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "iso_timestamp_start", runtime.ParamLocationQuery, *params.IsoTimestampStart); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IsoTimestampEnd != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "iso_timestamp_end", runtime.ParamLocationQuery, *params.IsoTimestampEnd); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetProjectApiKeysRequest generates requests for GetProjectApiKeys
+func NewGetProjectApiKeysRequest(server string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/api-keys", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDisableBranchRequest generates requests for DisableBranch
 func NewDisableBranchRequest(server string, ref string) (*http.Request, error) {
 	var err error
 
@@ -2469,6 +2866,87 @@ func NewUpdateConfigRequestWithBody(server string, ref string, contentType strin
 	return req, nil
 }
 
+// NewGetStorageConfigRequest generates requests for GetStorageConfig
+func NewGetStorageConfigRequest(server string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/config/storage", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateStorageConfigRequest calls the generic UpdateStorageConfig builder with application/json body
+func NewUpdateStorageConfigRequest(server string, ref string, body UpdateStorageConfigJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateStorageConfigRequestWithBody(server, ref, "application/json", bodyReader)
+}
+
+// NewUpdateStorageConfigRequestWithBody generates requests for UpdateStorageConfig with any type of body
+func NewUpdateStorageConfigRequestWithBody(server string, ref string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/config/storage", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewRemoveCustomHostnameConfigRequest generates requests for RemoveCustomHostnameConfig
 func NewRemoveCustomHostnameConfigRequest(server string, ref string) (*http.Request, error) {
 	var err error
@@ -2652,6 +3130,40 @@ func NewReverifyRequest(server string, ref string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewV1ListAllBackupsRequest generates requests for V1ListAllBackups
+func NewV1ListAllBackupsRequest(server string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/database/backups", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewV1RestorePitrRequest calls the generic V1RestorePitr builder with application/json body
 func NewV1RestorePitrRequest(server string, ref string, body V1RestorePitrJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -2699,6 +3211,94 @@ func NewV1RestorePitrRequestWithBody(server string, ref string, contentType stri
 	return req, nil
 }
 
+// NewV1DownloadBackupRequest generates requests for V1DownloadBackup
+func NewV1DownloadBackupRequest(server string, ref string, backupId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "backup_id", runtime.ParamLocationPath, backupId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/database/backups/%s/download", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV1UpdateDatabasePasswordRequest calls the generic V1UpdateDatabasePassword builder with application/json body
+func NewV1UpdateDatabasePasswordRequest(server string, ref string, body V1UpdateDatabasePasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV1UpdateDatabasePasswordRequestWithBody(server, ref, "application/json", bodyReader)
+}
+
+// NewV1UpdateDatabasePasswordRequestWithBody generates requests for V1UpdateDatabasePassword with any type of body
+func NewV1UpdateDatabasePasswordRequestWithBody(server string, ref string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/database/password", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewV1RunQueryRequest calls the generic V1RunQuery builder with application/json body
 func NewV1RunQueryRequest(server string, ref string, body V1RunQueryJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -3472,6 +4072,40 @@ func NewApplyNetworkRestrictionsRequestWithBody(server string, ref string, conte
 	return req, nil
 }
 
+// NewV1PauseProjectRequest generates requests for V1PauseProject
+func NewV1PauseProjectRequest(server string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/pause", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetPgsodiumConfigRequest generates requests for GetPgsodiumConfig
 func NewGetPgsodiumConfigRequest(server string, ref string) (*http.Request, error) {
 	var err error
@@ -3702,6 +4336,40 @@ func NewTemporarilyDisableReadonlyModeRequest(server string, ref string) (*http.
 	return req, nil
 }
 
+// NewV1RestoreProjectRequest generates requests for V1RestoreProject
+func NewV1RestoreProjectRequest(server string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewDeleteSecretsRequest calls the generic DeleteSecrets builder with application/json body
 func NewDeleteSecretsRequest(server string, ref string, body DeleteSecretsJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -3911,6 +4579,53 @@ func NewUpdateSslEnforcementConfigRequestWithBody(server string, ref string, con
 	return req, nil
 }
 
+// NewV1TransferProjectRequest calls the generic V1TransferProject builder with application/json body
+func NewV1TransferProjectRequest(server string, ref string, body V1TransferProjectJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV1TransferProjectRequestWithBody(server, ref, "application/json", bodyReader)
+}
+
+// NewV1TransferProjectRequestWithBody generates requests for V1TransferProject with any type of body
+func NewV1TransferProjectRequestWithBody(server string, ref string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/transfer", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewGetTypescriptTypesRequest generates requests for GetTypescriptTypes
 func NewGetTypescriptTypesRequest(server string, ref string, params *GetTypescriptTypesParams) (*http.Request, error) {
 	var err error
@@ -4400,6 +5115,14 @@ type ClientWithResponsesInterface interface {
 	// V1ListOrganizationMembersWithResponse request
 	V1ListOrganizationMembersWithResponse(ctx context.Context, slug string, reqEditors ...RequestEditorFn) (*V1ListOrganizationMembersResponse, error)
 
+	// V1InviteOrganizationMemberWithBodyWithResponse request with any body
+	V1InviteOrganizationMemberWithBodyWithResponse(ctx context.Context, slug string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1InviteOrganizationMemberResponse, error)
+
+	V1InviteOrganizationMemberWithResponse(ctx context.Context, slug string, body V1InviteOrganizationMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*V1InviteOrganizationMemberResponse, error)
+
+	// V1RemoveOrganizationMemberWithResponse request
+	V1RemoveOrganizationMemberWithResponse(ctx context.Context, slug string, userId string, reqEditors ...RequestEditorFn) (*V1RemoveOrganizationMemberResponse, error)
+
 	// GetProjectsWithResponse request
 	GetProjectsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetProjectsResponse, error)
 
@@ -4411,6 +5134,9 @@ type ClientWithResponsesInterface interface {
 	// DeleteProjectWithResponse request
 	DeleteProjectWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*DeleteProjectResponse, error)
 
+	// GetLogsWithResponse request
+	GetLogsWithResponse(ctx context.Context, ref string, params *GetLogsParams, reqEditors ...RequestEditorFn) (*GetLogsResponse, error)
+
 	// GetProjectApiKeysWithResponse request
 	GetProjectApiKeysWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetProjectApiKeysResponse, error)
 
@@ -4463,6 +5189,14 @@ type ClientWithResponsesInterface interface {
 
 	UpdateConfigWithResponse(ctx context.Context, ref string, body UpdateConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateConfigResponse, error)
 
+	// GetStorageConfigWithResponse request
+	GetStorageConfigWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetStorageConfigResponse, error)
+
+	// UpdateStorageConfigWithBodyWithResponse request with any body
+	UpdateStorageConfigWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateStorageConfigResponse, error)
+
+	UpdateStorageConfigWithResponse(ctx context.Context, ref string, body UpdateStorageConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateStorageConfigResponse, error)
+
 	// RemoveCustomHostnameConfigWithResponse request
 	RemoveCustomHostnameConfigWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*RemoveCustomHostnameConfigResponse, error)
 
@@ -4480,11 +5214,22 @@ type ClientWithResponsesInterface interface {
 	// ReverifyWithResponse request
 	ReverifyWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*ReverifyResponse, error)
 
+	// V1ListAllBackupsWithResponse request
+	V1ListAllBackupsWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*V1ListAllBackupsResponse, error)
+
 	// V1RestorePitrWithBodyWithResponse request with any body
 	V1RestorePitrWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1RestorePitrResponse, error)
 
 	V1RestorePitrWithResponse(ctx context.Context, ref string, body V1RestorePitrJSONRequestBody, reqEditors ...RequestEditorFn) (*V1RestorePitrResponse, error)
 
+	// V1DownloadBackupWithResponse request
+	V1DownloadBackupWithResponse(ctx context.Context, ref string, backupId string, reqEditors ...RequestEditorFn) (*V1DownloadBackupResponse, error)
+
+	// V1UpdateDatabasePasswordWithBodyWithResponse request with any body
+	V1UpdateDatabasePasswordWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1UpdateDatabasePasswordResponse, error)
+
+	V1UpdateDatabasePasswordWithResponse(ctx context.Context, ref string, body V1UpdateDatabasePasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*V1UpdateDatabasePasswordResponse, error)
+
 	// V1RunQueryWithBodyWithResponse request with any body
 	V1RunQueryWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1RunQueryResponse, error)
 
@@ -4534,6 +5279,9 @@ type ClientWithResponsesInterface interface {
 
 	ApplyNetworkRestrictionsWithResponse(ctx context.Context, ref string, body ApplyNetworkRestrictionsJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyNetworkRestrictionsResponse, error)
 
+	// V1PauseProjectWithResponse request
+	V1PauseProjectWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*V1PauseProjectResponse, error)
+
 	// GetPgsodiumConfigWithResponse request
 	GetPgsodiumConfigWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetPgsodiumConfigResponse, error)
 
@@ -4556,6 +5304,9 @@ type ClientWithResponsesInterface interface {
 	// TemporarilyDisableReadonlyModeWithResponse request
 	TemporarilyDisableReadonlyModeWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*TemporarilyDisableReadonlyModeResponse, error)
 
+	// V1RestoreProjectWithResponse request
+	V1RestoreProjectWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*V1RestoreProjectResponse, error)
+
 	// DeleteSecretsWithBodyWithResponse request with any body
 	DeleteSecretsWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteSecretsResponse, error)
 
@@ -4577,6 +5328,11 @@ type ClientWithResponsesInterface interface {
 
 	UpdateSslEnforcementConfigWithResponse(ctx context.Context, ref string, body UpdateSslEnforcementConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSslEnforcementConfigResponse, error)
 
+	// V1TransferProjectWithBodyWithResponse request with any body
+	V1TransferProjectWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1TransferProjectResponse, error)
+
+	V1TransferProjectWithResponse(ctx context.Context, ref string, body V1TransferProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*V1TransferProjectResponse, error)
+
 	// GetTypescriptTypesWithResponse request
 	GetTypescriptTypesWithResponse(ctx context.Context, ref string, params *GetTypescriptTypesParams, reqEditors ...RequestEditorFn) (*GetTypescriptTypesResponse, error)
 
@@ -4788,6 +5544,49 @@ func (r V1ListOrganizationMembersResponse) StatusCode() int {
 	return 0
 }
 
+type V1InviteOrganizationMemberResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *V1InviteMemberResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V1InviteOrganizationMemberResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V1InviteOrganizationMemberResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V1RemoveOrganizationMemberResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r V1RemoveOrganizationMemberResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V1RemoveOrganizationMemberResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetProjectsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -4855,6 +5654,28 @@ func (r DeleteProjectResponse) StatusCode() int {
 	return 0
 }
 
+type GetLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AnalyticsResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetProjectApiKeysResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5162,6 +5983,49 @@ func (r UpdateConfigResponse) StatusCode() int {
 	return 0
 }
 
+type GetStorageConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StorageConfigResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStorageConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStorageConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateStorageConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateStorageConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateStorageConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type RemoveCustomHostnameConfigResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5271,6 +6135,28 @@ func (r ReverifyResponse) StatusCode() int {
 	return 0
 }
 
+type V1ListAllBackupsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *V1BackupsResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V1ListAllBackupsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V1ListAllBackupsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type V1RestorePitrResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5292,6 +6178,50 @@ func (r V1RestorePitrResponse) StatusCode() int {
 	return 0
 }
 
+type V1DownloadBackupResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *V1BackupDownloadResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V1DownloadBackupResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V1DownloadBackupResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V1UpdateDatabasePasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UpdatePasswordResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V1UpdateDatabasePasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V1UpdateDatabasePasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type V1RunQueryResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5574,6 +6504,27 @@ func (r ApplyNetworkRestrictionsResponse) StatusCode() int {
 	return 0
 }
 
+type V1PauseProjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r V1PauseProjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V1PauseProjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetPgsodiumConfigResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5705,6 +6656,27 @@ func (r TemporarilyDisableReadonlyModeResponse) StatusCode() int {
 	return 0
 }
 
+type V1RestoreProjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r V1RestoreProjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V1RestoreProjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type DeleteSecretsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5814,6 +6786,28 @@ func (r UpdateSslEnforcementConfigResponse) StatusCode() int {
 	return 0
 }
 
+type V1TransferProjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ProjectResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V1TransferProjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V1TransferProjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetTypescriptTypesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -6128,6 +7122,32 @@ func (c *ClientWithResponses) V1ListOrganizationMembersWithResponse(ctx context.
 	return ParseV1ListOrganizationMembersResponse(rsp)
 }
 
+// V1InviteOrganizationMemberWithBodyWithResponse request with arbitrary body returning *V1InviteOrganizationMemberResponse
+func (c *ClientWithResponses) V1InviteOrganizationMemberWithBodyWithResponse(ctx context.Context, slug string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1InviteOrganizationMemberResponse, error) {
+	rsp, err := c.V1InviteOrganizationMemberWithBody(ctx, slug, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1InviteOrganizationMemberResponse(rsp)
+}
+
+func (c *ClientWithResponses) V1InviteOrganizationMemberWithResponse(ctx context.Context, slug string, body V1InviteOrganizationMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*V1InviteOrganizationMemberResponse, error) {
+	rsp, err := c.V1InviteOrganizationMember(ctx, slug, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1InviteOrganizationMemberResponse(rsp)
+}
+
+// V1RemoveOrganizationMemberWithResponse request returning *V1RemoveOrganizationMemberResponse
+func (c *ClientWithResponses) V1RemoveOrganizationMemberWithResponse(ctx context.Context, slug string, userId string, reqEditors ...RequestEditorFn) (*V1RemoveOrganizationMemberResponse, error) {
+	rsp, err := c.V1RemoveOrganizationMember(ctx, slug, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1RemoveOrganizationMemberResponse(rsp)
+}
+
 // GetProjectsWithResponse request returning *GetProjectsResponse
 func (c *ClientWithResponses) GetProjectsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetProjectsResponse, error) {
 	rsp, err := c.GetProjects(ctx, reqEditors...)
@@ -6163,6 +7183,15 @@ func (c *ClientWithResponses) DeleteProjectWithResponse(ctx context.Context, ref
 	return ParseDeleteProjectResponse(rsp)
 }
 
+// GetLogsWithResponse request returning *GetLogsResponse
+func (c *ClientWithResponses) GetLogsWithResponse(ctx context.Context, ref string, params *GetLogsParams, reqEditors ...RequestEditorFn) (*GetLogsResponse, error) {
+	rsp, err := c.GetLogs(ctx, ref, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLogsResponse(rsp)
+}
+
 // GetProjectApiKeysWithResponse request returning *GetProjectApiKeysResponse
 func (c *ClientWithResponses) GetProjectApiKeysWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetProjectApiKeysResponse, error) {
 	rsp, err := c.GetProjectApiKeys(ctx, ref, reqEditors...)
@@ -6309,24 +7338,50 @@ func (c *ClientWithResponses) GetConfigWithResponse(ctx context.Context, ref str
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetConfigResponse(rsp)
+	return ParseGetConfigResponse(rsp)
+}
+
+// UpdateConfigWithBodyWithResponse request with arbitrary body returning *UpdateConfigResponse
+func (c *ClientWithResponses) UpdateConfigWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateConfigResponse, error) {
+	rsp, err := c.UpdateConfigWithBody(ctx, ref, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateConfigResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateConfigWithResponse(ctx context.Context, ref string, body UpdateConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateConfigResponse, error) {
+	rsp, err := c.UpdateConfig(ctx, ref, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateConfigResponse(rsp)
+}
+
+// GetStorageConfigWithResponse request returning *GetStorageConfigResponse
+func (c *ClientWithResponses) GetStorageConfigWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetStorageConfigResponse, error) {
+	rsp, err := c.GetStorageConfig(ctx, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStorageConfigResponse(rsp)
 }
 
-// UpdateConfigWithBodyWithResponse request with arbitrary body returning *UpdateConfigResponse
-func (c *ClientWithResponses) UpdateConfigWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateConfigResponse, error) {
-	rsp, err := c.UpdateConfigWithBody(ctx, ref, contentType, body, reqEditors...)
+// UpdateStorageConfigWithBodyWithResponse request with arbitrary body returning *UpdateStorageConfigResponse
+func (c *ClientWithResponses) UpdateStorageConfigWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateStorageConfigResponse, error) {
+	rsp, err := c.UpdateStorageConfigWithBody(ctx, ref, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseUpdateConfigResponse(rsp)
+	return ParseUpdateStorageConfigResponse(rsp)
 }
 
-func (c *ClientWithResponses) UpdateConfigWithResponse(ctx context.Context, ref string, body UpdateConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateConfigResponse, error) {
-	rsp, err := c.UpdateConfig(ctx, ref, body, reqEditors...)
+func (c *ClientWithResponses) UpdateStorageConfigWithResponse(ctx context.Context, ref string, body UpdateStorageConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateStorageConfigResponse, error) {
+	rsp, err := c.UpdateStorageConfig(ctx, ref, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseUpdateConfigResponse(rsp)
+	return ParseUpdateStorageConfigResponse(rsp)
 }
 
 // RemoveCustomHostnameConfigWithResponse request returning *RemoveCustomHostnameConfigResponse
@@ -6382,6 +7437,15 @@ func (c *ClientWithResponses) ReverifyWithResponse(ctx context.Context, ref stri
 	return ParseReverifyResponse(rsp)
 }
 
+// V1ListAllBackupsWithResponse request returning *V1ListAllBackupsResponse
+func (c *ClientWithResponses) V1ListAllBackupsWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*V1ListAllBackupsResponse, error) {
+	rsp, err := c.V1ListAllBackups(ctx, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1ListAllBackupsResponse(rsp)
+}
+
 // V1RestorePitrWithBodyWithResponse request with arbitrary body returning *V1RestorePitrResponse
 func (c *ClientWithResponses) V1RestorePitrWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1RestorePitrResponse, error) {
 	rsp, err := c.V1RestorePitrWithBody(ctx, ref, contentType, body, reqEditors...)
@@ -6399,6 +7463,32 @@ func (c *ClientWithResponses) V1RestorePitrWithResponse(ctx context.Context, ref
 	return ParseV1RestorePitrResponse(rsp)
 }
 
+// V1DownloadBackupWithResponse request returning *V1DownloadBackupResponse
+func (c *ClientWithResponses) V1DownloadBackupWithResponse(ctx context.Context, ref string, backupId string, reqEditors ...RequestEditorFn) (*V1DownloadBackupResponse, error) {
+	rsp, err := c.V1DownloadBackup(ctx, ref, backupId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1DownloadBackupResponse(rsp)
+}
+
+// V1UpdateDatabasePasswordWithBodyWithResponse request with arbitrary body returning *V1UpdateDatabasePasswordResponse
+func (c *ClientWithResponses) V1UpdateDatabasePasswordWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1UpdateDatabasePasswordResponse, error) {
+	rsp, err := c.V1UpdateDatabasePasswordWithBody(ctx, ref, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1UpdateDatabasePasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) V1UpdateDatabasePasswordWithResponse(ctx context.Context, ref string, body V1UpdateDatabasePasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*V1UpdateDatabasePasswordResponse, error) {
+	rsp, err := c.V1UpdateDatabasePassword(ctx, ref, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1UpdateDatabasePasswordResponse(rsp)
+}
+
 // V1RunQueryWithBodyWithResponse request with arbitrary body returning *V1RunQueryResponse
 func (c *ClientWithResponses) V1RunQueryWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1RunQueryResponse, error) {
 	rsp, err := c.V1RunQueryWithBody(ctx, ref, contentType, body, reqEditors...)
@@ -6556,6 +7646,15 @@ func (c *ClientWithResponses) ApplyNetworkRestrictionsWithResponse(ctx context.C
 	return ParseApplyNetworkRestrictionsResponse(rsp)
 }
 
+// V1PauseProjectWithResponse request returning *V1PauseProjectResponse
+func (c *ClientWithResponses) V1PauseProjectWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*V1PauseProjectResponse, error) {
+	rsp, err := c.V1PauseProject(ctx, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1PauseProjectResponse(rsp)
+}
+
 // GetPgsodiumConfigWithResponse request returning *GetPgsodiumConfigResponse
 func (c *ClientWithResponses) GetPgsodiumConfigWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetPgsodiumConfigResponse, error) {
 	rsp, err := c.GetPgsodiumConfig(ctx, ref, reqEditors...)
@@ -6626,6 +7725,15 @@ func (c *ClientWithResponses) TemporarilyDisableReadonlyModeWithResponse(ctx con
 	return ParseTemporarilyDisableReadonlyModeResponse(rsp)
 }
 
+// V1RestoreProjectWithResponse request returning *V1RestoreProjectResponse
+func (c *ClientWithResponses) V1RestoreProjectWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*V1RestoreProjectResponse, error) {
+	rsp, err := c.V1RestoreProject(ctx, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1RestoreProjectResponse(rsp)
+}
+
 // DeleteSecretsWithBodyWithResponse request with arbitrary body returning *DeleteSecretsResponse
 func (c *ClientWithResponses) DeleteSecretsWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteSecretsResponse, error) {
 	rsp, err := c.DeleteSecretsWithBody(ctx, ref, contentType, body, reqEditors...)
@@ -6695,6 +7803,23 @@ func (c *ClientWithResponses) UpdateSslEnforcementConfigWithResponse(ctx context
 	return ParseUpdateSslEnforcementConfigResponse(rsp)
 }
 
+// V1TransferProjectWithBodyWithResponse request with arbitrary body returning *V1TransferProjectResponse
+func (c *ClientWithResponses) V1TransferProjectWithBodyWithResponse(ctx context.Context, ref string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V1TransferProjectResponse, error) {
+	rsp, err := c.V1TransferProjectWithBody(ctx, ref, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1TransferProjectResponse(rsp)
+}
+
+func (c *ClientWithResponses) V1TransferProjectWithResponse(ctx context.Context, ref string, body V1TransferProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*V1TransferProjectResponse, error) {
+	rsp, err := c.V1TransferProject(ctx, ref, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV1TransferProjectResponse(rsp)
+}
+
 // GetTypescriptTypesWithResponse request returning *GetTypescriptTypesResponse
 func (c *ClientWithResponses) GetTypescriptTypesWithResponse(ctx context.Context, ref string, params *GetTypescriptTypesParams, reqEditors ...RequestEditorFn) (*GetTypescriptTypesResponse, error) {
 	rsp, err := c.GetTypescriptTypes(ctx, ref, params, reqEditors...)
@@ -6997,6 +8122,48 @@ func ParseV1ListOrganizationMembersResponse(rsp *http.Response) (*V1ListOrganiza
 	return response, nil
 }
 
+// ParseV1InviteOrganizationMemberResponse parses an HTTP response from a V1InviteOrganizationMemberWithResponse call
+func ParseV1InviteOrganizationMemberResponse(rsp *http.Response) (*V1InviteOrganizationMemberResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V1InviteOrganizationMemberResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest V1InviteMemberResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV1RemoveOrganizationMemberResponse parses an HTTP response from a V1RemoveOrganizationMemberWithResponse call
+func ParseV1RemoveOrganizationMemberResponse(rsp *http.Response) (*V1RemoveOrganizationMemberResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V1RemoveOrganizationMemberResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
 // ParseGetProjectsResponse parses an HTTP response from a GetProjectsWithResponse call
 func ParseGetProjectsResponse(rsp *http.Response) (*GetProjectsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -7082,6 +8249,32 @@ func ParseDeleteProjectResponse(rsp *http.Response) (*DeleteProjectResponse, err
 	return response, nil
 }
 
+// ParseGetLogsResponse parses an HTTP response from a GetLogsWithResponse call
+func ParseGetLogsResponse(rsp *http.Response) (*GetLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AnalyticsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetProjectApiKeysResponse parses an HTTP response from a GetProjectApiKeysWithResponse call
 func ParseGetProjectApiKeysResponse(rsp *http.Response) (*GetProjectApiKeysResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -7436,6 +8629,48 @@ func ParseUpdateConfigResponse(rsp *http.Response) (*UpdateConfigResponse, error
 	return response, nil
 }
 
+// ParseGetStorageConfigResponse parses an HTTP response from a GetStorageConfigWithResponse call
+func ParseGetStorageConfigResponse(rsp *http.Response) (*GetStorageConfigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStorageConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StorageConfigResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateStorageConfigResponse parses an HTTP response from a UpdateStorageConfigWithResponse call
+func ParseUpdateStorageConfigResponse(rsp *http.Response) (*UpdateStorageConfigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateStorageConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
 // ParseRemoveCustomHostnameConfigResponse parses an HTTP response from a RemoveCustomHostnameConfigWithResponse call
 func ParseRemoveCustomHostnameConfigResponse(rsp *http.Response) (*RemoveCustomHostnameConfigResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -7556,6 +8791,32 @@ func ParseReverifyResponse(rsp *http.Response) (*ReverifyResponse, error) {
 	return response, nil
 }
 
+// ParseV1ListAllBackupsResponse parses an HTTP response from a V1ListAllBackupsWithResponse call
+func ParseV1ListAllBackupsResponse(rsp *http.Response) (*V1ListAllBackupsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V1ListAllBackupsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest V1BackupsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseV1RestorePitrResponse parses an HTTP response from a V1RestorePitrWithResponse call
 func ParseV1RestorePitrResponse(rsp *http.Response) (*V1RestorePitrResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -7572,6 +8833,58 @@ func ParseV1RestorePitrResponse(rsp *http.Response) (*V1RestorePitrResponse, err
 	return response, nil
 }
 
+// ParseV1DownloadBackupResponse parses an HTTP response from a V1DownloadBackupWithResponse call
+func ParseV1DownloadBackupResponse(rsp *http.Response) (*V1DownloadBackupResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V1DownloadBackupResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest V1BackupDownloadResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV1UpdateDatabasePasswordResponse parses an HTTP response from a V1UpdateDatabasePasswordWithResponse call
+func ParseV1UpdateDatabasePasswordResponse(rsp *http.Response) (*V1UpdateDatabasePasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V1UpdateDatabasePasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UpdatePasswordResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseV1RunQueryResponse parses an HTTP response from a V1RunQueryWithResponse call
 func ParseV1RunQueryResponse(rsp *http.Response) (*V1RunQueryResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -7870,6 +9183,22 @@ func ParseApplyNetworkRestrictionsResponse(rsp *http.Response) (*ApplyNetworkRes
 	return response, nil
 }
 
+// ParseV1PauseProjectResponse parses an HTTP response from a V1PauseProjectWithResponse call
+func ParseV1PauseProjectResponse(rsp *http.Response) (*V1PauseProjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V1PauseProjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
 // ParseGetPgsodiumConfigResponse parses an HTTP response from a GetPgsodiumConfigWithResponse call
 func ParseGetPgsodiumConfigResponse(rsp *http.Response) (*GetPgsodiumConfigResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -8016,6 +9345,22 @@ func ParseTemporarilyDisableReadonlyModeResponse(rsp *http.Response) (*Temporari
 	return response, nil
 }
 
+// ParseV1RestoreProjectResponse parses an HTTP response from a V1RestoreProjectWithResponse call
+func ParseV1RestoreProjectResponse(rsp *http.Response) (*V1RestoreProjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V1RestoreProjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
 // ParseDeleteSecretsResponse parses an HTTP response from a DeleteSecretsWithResponse call
 func ParseDeleteSecretsResponse(rsp *http.Response) (*DeleteSecretsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -8136,6 +9481,32 @@ func ParseUpdateSslEnforcementConfigResponse(rsp *http.Response) (*UpdateSslEnfo
 	return response, nil
 }
 
+// ParseV1TransferProjectResponse parses an HTTP response from a V1TransferProjectWithResponse call
+func ParseV1TransferProjectResponse(rsp *http.Response) (*V1TransferProjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V1TransferProjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ProjectResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetTypescriptTypesResponse parses an HTTP response from a GetTypescriptTypesWithResponse call
 func ParseGetTypescriptTypesResponse(rsp *http.Response) (*GetTypescriptTypesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
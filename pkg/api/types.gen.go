@@ -224,6 +224,14 @@ type ActivateVanitySubdomainResponse struct {
 	CustomDomain string `json:"custom_domain"`
 }
 
+// AnalyticsResponse defines model for AnalyticsResponse.
+type AnalyticsResponse struct {
+	Error *struct {
+		Message *string `json:"message,omitempty"`
+	} `json:"error,omitempty"`
+	Result *[]map[string]interface{} `json:"result,omitempty"`
+}
+
 // ApiKeyResponse defines model for ApiKeyResponse.
 type ApiKeyResponse struct {
 	ApiKey string `json:"api_key"`
@@ -261,14 +269,25 @@ type AttributeValue_Default struct {
 
 // AuthConfigResponse defines model for AuthConfigResponse.
 type AuthConfigResponse struct {
-	RateLimitEmailSent *float32 `json:"rate_limit_email_sent,omitempty"`
-	SmtpAdminEmail     *string  `json:"smtp_admin_email,omitempty"`
-	SmtpHost           *string  `json:"smtp_host,omitempty"`
-	SmtpMaxFrequency   *float32 `json:"smtp_max_frequency,omitempty"`
-	SmtpPass           *string  `json:"smtp_pass,omitempty"`
-	SmtpPort           *string  `json:"smtp_port,omitempty"`
-	SmtpSenderName     *string  `json:"smtp_sender_name,omitempty"`
-	SmtpUser           *string  `json:"smtp_user,omitempty"`
+	DisableSignup                     *bool    `json:"disable_signup,omitempty"`
+	ExternalEmailEnabled              *bool    `json:"external_email_enabled,omitempty"`
+	ExternalPhoneEnabled              *bool    `json:"external_phone_enabled,omitempty"`
+	JwtExp                            *int     `json:"jwt_exp,omitempty"`
+	MailerAutoconfirm                 *bool    `json:"mailer_autoconfirm,omitempty"`
+	MailerSecureEmailChangeEnabled    *bool    `json:"mailer_secure_email_change_enabled,omitempty"`
+	RateLimitEmailSent                *float32 `json:"rate_limit_email_sent,omitempty"`
+	RefreshTokenRotationEnabled       *bool    `json:"refresh_token_rotation_enabled,omitempty"`
+	SecurityRefreshTokenReuseInterval *int     `json:"security_refresh_token_reuse_interval,omitempty"`
+	SiteUrl                           *string  `json:"site_url,omitempty"`
+	SmsAutoconfirm                    *bool    `json:"sms_autoconfirm,omitempty"`
+	SmtpAdminEmail                    *string  `json:"smtp_admin_email,omitempty"`
+	SmtpHost                          *string  `json:"smtp_host,omitempty"`
+	SmtpMaxFrequency                  *float32 `json:"smtp_max_frequency,omitempty"`
+	SmtpPass                          *string  `json:"smtp_pass,omitempty"`
+	SmtpPort                          *string  `json:"smtp_port,omitempty"`
+	SmtpSenderName                    *string  `json:"smtp_sender_name,omitempty"`
+	SmtpUser                          *string  `json:"smtp_user,omitempty"`
+	UriAllowList                      *string  `json:"uri_allow_list,omitempty"`
 }
 
 // AuthHealthResponse defines model for AuthHealthResponse.
@@ -760,6 +779,11 @@ type SslEnforcements struct {
 	Database bool `json:"database"`
 }
 
+// StorageConfigResponse defines model for StorageConfigResponse.
+type StorageConfigResponse struct {
+	FileSizeLimit int64 `json:"fileSizeLimit"`
+}
+
 // SubdomainAvailabilityResponse defines model for SubdomainAvailabilityResponse.
 type SubdomainAvailabilityResponse struct {
 	Available bool `json:"available"`
@@ -772,14 +796,25 @@ type TypescriptResponse struct {
 
 // UpdateAuthConfigBody defines model for UpdateAuthConfigBody.
 type UpdateAuthConfigBody struct {
-	RateLimitEmailSent *float32 `json:"rate_limit_email_sent,omitempty"`
-	SmtpAdminEmail     *string  `json:"smtp_admin_email,omitempty"`
-	SmtpHost           *string  `json:"smtp_host,omitempty"`
-	SmtpMaxFrequency   *float32 `json:"smtp_max_frequency,omitempty"`
-	SmtpPass           *string  `json:"smtp_pass,omitempty"`
-	SmtpPort           *string  `json:"smtp_port,omitempty"`
-	SmtpSenderName     *string  `json:"smtp_sender_name,omitempty"`
-	SmtpUser           *string  `json:"smtp_user,omitempty"`
+	DisableSignup                     *bool    `json:"disable_signup,omitempty"`
+	ExternalEmailEnabled              *bool    `json:"external_email_enabled,omitempty"`
+	ExternalPhoneEnabled              *bool    `json:"external_phone_enabled,omitempty"`
+	JwtExp                            *int     `json:"jwt_exp,omitempty"`
+	MailerAutoconfirm                 *bool    `json:"mailer_autoconfirm,omitempty"`
+	MailerSecureEmailChangeEnabled    *bool    `json:"mailer_secure_email_change_enabled,omitempty"`
+	RateLimitEmailSent                *float32 `json:"rate_limit_email_sent,omitempty"`
+	RefreshTokenRotationEnabled       *bool    `json:"refresh_token_rotation_enabled,omitempty"`
+	SecurityRefreshTokenReuseInterval *int     `json:"security_refresh_token_reuse_interval,omitempty"`
+	SiteUrl                           *string  `json:"site_url,omitempty"`
+	SmsAutoconfirm                    *bool    `json:"sms_autoconfirm,omitempty"`
+	SmtpAdminEmail                    *string  `json:"smtp_admin_email,omitempty"`
+	SmtpHost                          *string  `json:"smtp_host,omitempty"`
+	SmtpMaxFrequency                  *float32 `json:"smtp_max_frequency,omitempty"`
+	SmtpPass                          *string  `json:"smtp_pass,omitempty"`
+	SmtpPort                          *string  `json:"smtp_port,omitempty"`
+	SmtpSenderName                    *string  `json:"smtp_sender_name,omitempty"`
+	SmtpUser                          *string  `json:"smtp_user,omitempty"`
+	UriAllowList                      *string  `json:"uri_allow_list,omitempty"`
 }
 
 // UpdateBranchBody defines model for UpdateBranchBody.
@@ -810,6 +845,16 @@ type UpdateFunctionBody struct {
 	VerifyJwt *bool   `json:"verify_jwt,omitempty"`
 }
 
+// UpdatePasswordBody defines model for UpdatePasswordBody.
+type UpdatePasswordBody struct {
+	Password string `json:"password"`
+}
+
+// UpdatePasswordResponse defines model for UpdatePasswordResponse.
+type UpdatePasswordResponse struct {
+	Message string `json:"message"`
+}
+
 // UpdatePgsodiumConfigBody defines model for UpdatePgsodiumConfigBody.
 type UpdatePgsodiumConfigBody struct {
 	RootKey string `json:"root_key"`
@@ -857,11 +902,54 @@ type UpdateProviderResponse struct {
 	UpdatedAt *string         `json:"updated_at,omitempty"`
 }
 
+// UpdateStorageConfigBody defines model for UpdateStorageConfigBody.
+type UpdateStorageConfigBody struct {
+	FileSizeLimit *int64 `json:"fileSizeLimit,omitempty"`
+}
+
 // UpgradeDatabaseBody defines model for UpgradeDatabaseBody.
 type UpgradeDatabaseBody struct {
 	TargetVersion float32 `json:"target_version"`
 }
 
+// V1BackupDownloadResponse defines model for V1BackupDownloadResponse.
+type V1BackupDownloadResponse struct {
+	ChecksumSha256 *string `json:"checksum_sha256,omitempty"`
+	SizeBytes      *int64  `json:"size_bytes,omitempty"`
+	Url            string  `json:"url"`
+}
+
+// V1BackupResponse defines model for V1BackupResponse.
+type V1BackupResponse struct {
+	Id               string `json:"id"`
+	InsertedAt       string `json:"inserted_at"`
+	IsPhysicalBackup bool   `json:"is_physical_backup"`
+	SizeBytes        *int64 `json:"size_bytes,omitempty"`
+	Status           string `json:"status"`
+}
+
+// V1BackupsResponse defines model for V1BackupsResponse.
+type V1BackupsResponse struct {
+	Backups            []V1BackupResponse   `json:"backups"`
+	PhysicalBackupData V1PhysicalBackupData `json:"physical_backup_data"`
+	PitrEnabled        bool                 `json:"pitr_enabled"`
+	Region             string               `json:"region"`
+	WalgEnabled        bool                 `json:"walg_enabled"`
+}
+
+// V1InviteMemberBody defines model for V1InviteMemberBody.
+type V1InviteMemberBody struct {
+	Email    string  `json:"email"`
+	RoleName *string `json:"role_name,omitempty"`
+}
+
+// V1InviteMemberResponse defines model for V1InviteMemberResponse.
+type V1InviteMemberResponse struct {
+	Email    string `json:"email"`
+	Id       string `json:"id"`
+	RoleName string `json:"role_name"`
+}
+
 // V1OrganizationMemberResponse defines model for V1OrganizationMemberResponse.
 type V1OrganizationMemberResponse struct {
 	Email    *string `json:"email,omitempty"`
@@ -881,9 +969,20 @@ type V1PgbouncerConfigResponse struct {
 // V1PgbouncerConfigResponsePoolMode defines model for V1PgbouncerConfigResponse.PoolMode.
 type V1PgbouncerConfigResponsePoolMode string
 
+// V1PhysicalBackupData defines model for V1PhysicalBackupData.
+type V1PhysicalBackupData struct {
+	EarliestPhysicalBackupDateUnix *int64 `json:"earliest_physical_backup_date_unix,omitempty"`
+	LatestPhysicalBackupDateUnix   *int64 `json:"latest_physical_backup_date_unix,omitempty"`
+}
+
 // V1RestorePitrBody defines model for V1RestorePitrBody.
 type V1RestorePitrBody struct {
-	RecoveryTimeTargetUnix float32 `json:"recovery_time_target_unix"`
+	RecoveryTimeTargetUnix int64 `json:"recovery_time_target_unix"`
+}
+
+// V1TransferProjectBody defines model for V1TransferProjectBody.
+type V1TransferProjectBody struct {
+	TargetOrganizationSlug string `json:"target_organization_slug"`
 }
 
 // VanitySubdomainBody defines model for VanitySubdomainBody.
@@ -918,6 +1017,13 @@ type AuthorizeParamsResponseType string
 // AuthorizeParamsCodeChallengeMethod defines parameters for Authorize.
 type AuthorizeParamsCodeChallengeMethod string
 
+// GetLogsParams defines parameters for GetLogs.
+type GetLogsParams struct {
+	Sql               *string `form:"sql,omitempty" json:"sql,omitempty"`
+	IsoTimestampStart *string `form:"iso_timestamp_start,omitempty" json:"iso_timestamp_start,omitempty"`
+	IsoTimestampEnd   *string `form:"iso_timestamp_end,omitempty" json:"iso_timestamp_end,omitempty"`
+}
+
 // CreateFunctionParams defines parameters for CreateFunction.
 type CreateFunctionParams struct {
 	Slug           *string `form:"slug,omitempty" json:"slug,omitempty"`
@@ -972,6 +1078,9 @@ type TokenFormdataRequestBody = OAuthTokenBody
 // CreateOrganizationJSONRequestBody defines body for CreateOrganization for application/json ContentType.
 type CreateOrganizationJSONRequestBody = CreateOrganizationBody
 
+// V1InviteOrganizationMemberJSONRequestBody defines body for V1InviteOrganizationMember for application/json ContentType.
+type V1InviteOrganizationMemberJSONRequestBody = V1InviteMemberBody
+
 // CreateProjectJSONRequestBody defines body for CreateProject for application/json ContentType.
 type CreateProjectJSONRequestBody = CreateProjectBody
 
@@ -990,12 +1099,18 @@ type UpdateProviderByIdJSONRequestBody = UpdateProviderBody
 // UpdateConfigJSONRequestBody defines body for UpdateConfig for application/json ContentType.
 type UpdateConfigJSONRequestBody = UpdatePostgresConfigBody
 
+// UpdateStorageConfigJSONRequestBody defines body for UpdateStorageConfig for application/json ContentType.
+type UpdateStorageConfigJSONRequestBody = UpdateStorageConfigBody
+
 // CreateCustomHostnameConfigJSONRequestBody defines body for CreateCustomHostnameConfig for application/json ContentType.
 type CreateCustomHostnameConfigJSONRequestBody = UpdateCustomHostnameBody
 
 // V1RestorePitrJSONRequestBody defines body for V1RestorePitr for application/json ContentType.
 type V1RestorePitrJSONRequestBody = V1RestorePitrBody
 
+// V1UpdateDatabasePasswordJSONRequestBody defines body for V1UpdateDatabasePassword for application/json ContentType.
+type V1UpdateDatabasePasswordJSONRequestBody = UpdatePasswordBody
+
 // V1RunQueryJSONRequestBody defines body for V1RunQuery for application/json ContentType.
 type V1RunQueryJSONRequestBody = RunQueryBody
 
@@ -1026,6 +1141,9 @@ type CreateSecretsJSONRequestBody = CreateSecretsJSONBody
 // UpdateSslEnforcementConfigJSONRequestBody defines body for UpdateSslEnforcementConfig for application/json ContentType.
 type UpdateSslEnforcementConfigJSONRequestBody = SslEnforcementRequest
 
+// V1TransferProjectJSONRequestBody defines body for V1TransferProject for application/json ContentType.
+type V1TransferProjectJSONRequestBody = V1TransferProjectBody
+
 // UpgradeProjectJSONRequestBody defines body for UpgradeProject for application/json ContentType.
 type UpgradeProjectJSONRequestBody = UpgradeDatabaseBody
 